@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/anazcodes/blogapp/pkg/jwt"
+)
+
+// keygen prints a new key to add to a key set file.
+func keygen(args []string) error {
+	fs := flag.NewFlagSet("keygen", flag.ExitOnError)
+	id := fs.String("kid", time.Now().UTC().Format("2006-01-02"), "Key ID")
+	alg := fs.String("alg", jwt.EdDSA, "Key algorithm: EdDSA or HS256")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	b, err := jwt.GenerateKey(*id, *alg)
+	if err != nil {
+		return fmt.Errorf("jwt.generatekey: %w", err)
+	}
+
+	fmt.Println(string(b))
+
+	return nil
+}
+
+// newKeySet loads the key set from path, or returns a set of one key of its own when path is empty.
+func newKeySet(path string) (*jwt.KeySet, error) {
+	if path == "" {
+		log.Println("No -jwt-keys, signing tokens with an ephemeral key: they do not survive a restart")
+
+		secret := make([]byte, jwt.MinSecretLength)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, err
+		}
+
+		key, err := jwt.NewHMACKey("ephemeral", secret)
+		if err != nil {
+			return nil, err
+		}
+
+		return jwt.NewKeySet(key.ID, key)
+	}
+
+	active, keys, err := readKeySet(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return jwt.NewKeySet(active, keys...)
+}
+
+func readKeySet(path string) (string, []jwt.Key, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", nil, fmt.Errorf("read: %w", err)
+	}
+
+	active, keys, err := jwt.ParseKeySet(b)
+	if err != nil {
+		return "", nil, fmt.Errorf("jwt.parsekeyset: %w", err)
+	}
+
+	return active, keys, nil
+}
+
+// reloadKeySet replaces the keys of ks with the keys of path on every SIGHUP until ctx is done, which
+// rotates the keys without a restart. A key set that fails to load leaves the keys as they were.
+func reloadKeySet(ctx context.Context, ks *jwt.KeySet, path string) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+		}
+
+		active, keys, err := readKeySet(path)
+		if err == nil {
+			err = ks.Replace(active, keys...)
+		}
+
+		if err != nil {
+			log.Printf("Reloading the key set failed, keeping the current keys: %v", err)
+			continue
+		}
+
+		log.Printf("Reloaded the key set, signing with %q", active)
+	}
+}
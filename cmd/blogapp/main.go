@@ -3,29 +3,195 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
 	"log"
+	"math"
+	"os"
 	"os/signal"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
 	"syscall"
+	"time"
 
 	"github.com/anazcodes/blogapp/internal/api/http/blogapp"
+	"github.com/anazcodes/blogapp/internal/business/authbus"
 	"github.com/anazcodes/blogapp/internal/business/blogbus"
+	"github.com/anazcodes/blogapp/internal/business/commentbus"
 	"github.com/anazcodes/blogapp/internal/repository/blogrepo"
+	"github.com/anazcodes/blogapp/internal/repository/blogrepo/cache"
+	"github.com/anazcodes/blogapp/internal/repository/blogrepo/filestore"
+	"github.com/anazcodes/blogapp/internal/repository/blogrepo/sqlitestore"
+	"github.com/anazcodes/blogapp/internal/repository/blogrepo/wal"
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		if cmd, ok := commands[os.Args[1]]; ok {
+			if err := cmd(os.Args[2:]); err != nil {
+				log.Fatalln(err)
+			}
+			return
+		}
+	}
+
 	port := flag.String("port", "3000", "Server Port")
 	capacity := flag.Int("cache-capacity", 30, "Cache Capacity")
+	storage := flag.String("storage", "memory", "Storage engine: memory, file or sqlite")
+	dataDir := flag.String("data-dir", "data", "Data directory used by the file and sqlite storages")
+	compactEvery := flag.Duration("compact-interval", 5*time.Minute, "Interval between file storage log compactions, 0 disables it")
+	walPath := flag.String("wal", "", "Write-ahead log recording the memory storage, empty disables it")
+	walSync := flag.String("wal-sync", "always", "Write-ahead log fsync policy: always, interval or never")
+	walSyncEvery := flag.Duration("wal-sync-interval", time.Second, "Fsync interval of the interval policy")
+	eviction := flag.String("cache-eviction", "reject", "Memory storage policy for a full cache: reject, lru, lfu or oldest")
+	spill := flag.String("cache-spill", "", "Storage evicted posts are moved to: file or sqlite in data-dir, empty drops them")
+	shards := flag.Int("cache-shards", 1, "Independently locked shards of the memory storage cache without a write-ahead log, 1 disables sharding")
+	scheduleEvery := flag.Duration("schedule-interval", time.Minute, "Interval between scans for scheduled posts that are due, 0 disables publishing them")
+	purgeEvery := flag.Duration("trash-purge-interval", time.Hour, "Interval between purges of the posts kept in the trash past the retention, 0 disables purging")
+	retention := flag.Duration("trash-retention", 30*24*time.Hour, "Time deleted posts are kept in the trash before they are purged")
+	editWindow := flag.Duration("comment-edit-window", commentbus.DefaultEditWindow, "Time the user who posted a comment can edit it for, 0 disables their edits")
+	maxLinks := flag.Int("spam-max-links", 3, "Most links a comment may hold before it is rejected as spam, negative disables the check")
+	blocklist := flag.String("spam-blocklist", "", "Comma-separated words a comment is rejected as spam for using")
+	spamThreshold := flag.Float64("spam-threshold", commentbus.DefaultSpamThreshold, "Probability of spam, learned from the moderators, over which a comment is rejected, 1 disables it")
+
+	jwtKeys := flag.String("jwt-keys", "", "Key set file tokens are signed and verified with, reloaded on SIGHUP, empty signs with an ephemeral key")
+	tokenTTL := flag.Duration("jwt-ttl", authbus.DefaultTokenTTL, "Time a token authenticates its user for")
+	maxFailures := flag.Int("login-max-failures", authbus.DefaultLockout.MaxFailures, "Failed logins in a row that lock an account, 0 disables the lockout")
+	lockout := flag.Duration("login-lockout", authbus.DefaultLockout.Duration, "Time an account is locked for after too many failed logins")
+	maxHashes := flag.Int("max-password-hashes", authbus.DefaultMaxHashes, "Most passwords hashed or checked at once by each blog, registers and logins past it are refused with 503")
+	grantAdmin := flag.String("grant-admin", "", "Username of a user made an admin on start, the first admin of a blog or one recovering from losing every admin, tenant:username with -tenants")
+	operatorToken := flag.String("operator-token", os.Getenv("BLOGAPP_OPERATOR_TOKEN"), "Token the snapshot and restore routes accept in X-Operator-Token from outside the host, empty serves them on the host only, defaults to $BLOGAPP_OPERATOR_TOKEN")
+	tenantsPath := flag.String("tenants", "", "JSON file of the blogs served as tenants, each with its files in a directory of its own, empty serves one blog")
 
 	flag.Parse()
 
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
 	defer stop()
 
-	log.Printf("Starting server on port: %s with cache-capacity: %d", *port, *capacity)
+	log.Printf("Starting server on port: %s with cache-capacity: %d storage: %s", *port, *capacity, *storage)
+
+	policy, err := wal.ParseSyncPolicy(*walSync)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	evictionPolicy, err := cache.ParsePolicy(*eviction)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	tenants := []tenantConfig{{}}
+	if *tenantsPath != "" {
+		if tenants, err = readTenants(*tenantsPath); err != nil {
+			log.Fatalln(err)
+		}
+	}
+
+	cfg := config{
+		storage:      *storage,
+		dataDir:      *dataDir,
+		walPath:      *walPath,
+		capacity:     *capacity,
+		compactEvery: *compactEvery,
+		wal:          wal.Options{Sync: policy, SyncEvery: *walSyncEvery},
+		eviction:     evictionPolicy,
+		spill:        *spill,
+		shards:       *shards,
+	}
+
+	// The rules run first, their reasons tell moderators more than a probability.
+	var rules []commentbus.Classifier
+	if *maxLinks >= 0 {
+		rules = append(rules, commentbus.LinkLimit(*maxLinks))
+	}
+	if *blocklist != "" {
+		rules = append(rules, commentbus.NewBlocklist(strings.Split(*blocklist, ",")...))
+	}
+
+	keys, err := newKeySet(*jwtKeys)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	// Every tenant has a repository of its own, its posts, users and keys are never reached from another.
+	var (
+		repos   []repository
+		blogs   []blogapp.Tenant
+		granted bool
+	)
+
+	for _, tc := range tenants {
+		repo, err := newRepository(ctx, cfg.forTenant(tc.Name))
+		if err != nil {
+			log.Fatalln(err)
+		}
+		repo.SetQuota(tc.Quota)
+		repos = append(repos, repo)
+
+		// The classifier trained by the moderators learns their past decisions again.
+		bayes := commentbus.NewBayes(*spamThreshold)
+		if err := commentbus.Train(ctx, repo, bayes); err != nil {
+			log.Fatalln(err)
+		}
+		classifiers := append(slices.Clone(rules), bayes)
+
+		if username, ok := adminOf(*grantAdmin, tc.Name); ok {
+			username = authbus.NormalizeUsername(username)
+			if _, err := repo.UpdateUser(ctx, username, authbus.RoleChange{Role: authbus.RoleAdmin}); err != nil {
+				log.Fatalln(err)
+			}
+			log.Printf("Made %q an admin", username)
+			granted = true
+		}
+
+		auth := authbus.NewBusiness(keys, repo, authbus.Config{
+			TokenTTL:  *tokenTTL,
+			Lockout:   authbus.Lockout{MaxFailures: *maxFailures, Duration: *lockout},
+			Argon2:    authbus.DefaultArgon2Params,
+			MaxHashes: *maxHashes,
+		})
+
+		blogs = append(blogs, di(tc, repo, *editWindow, classifiers, auth))
+	}
+
+	if *grantAdmin != "" && !granted {
+		log.Fatalf("No tenant for -grant-admin %q, want tenant:username", *grantAdmin)
+	}
 
-	app := di(*port, *capacity)
+	var app blogapp.App
+	if *tenantsPath != "" {
+		app = blogapp.NewTenantApp(*port, blogs, *operatorToken)
+	} else {
+		app = blogapp.NewApp(*port, blogs[0].Business, blogs[0].Comments, blogs[0].Auth, *operatorToken)
+	}
 	go app.Serve()
 
+	if *jwtKeys != "" {
+		go reloadKeySet(ctx, keys, *jwtKeys)
+	}
+
+	// The background jobs stop with ctx, they must finish their pass before the repositories are closed.
+	var jobs sync.WaitGroup
+
+	for _, repo := range repos {
+		if *scheduleEvery > 0 {
+			jobs.Add(1)
+			go func() {
+				defer jobs.Done()
+				blogbus.NewScheduler(repo, blogbus.SystemClock, *scheduleEvery).Run(ctx)
+			}()
+		}
+
+		if *purgeEvery > 0 {
+			jobs.Add(1)
+			go func() {
+				defer jobs.Done()
+				blogbus.NewPurger(repo, blogbus.SystemClock, *purgeEvery, *retention).Run(ctx)
+			}()
+		}
+	}
+
 	<-ctx.Done()
 
 	log.Println("Graceful shutdown triggered")
@@ -33,13 +199,124 @@ func main() {
 	if err := app.Shutdown(context.Background()); err != nil {
 		log.Fatalln(err)
 	}
+
+	jobs.Wait()
+
+	for _, repo := range repos {
+		if err := repo.Close(); err != nil {
+			log.Fatalln(err)
+		}
+	}
 }
 
-// di injects dependencies and initializes the application.
-func di(port string, capacity int) blogapp.App {
-	repo := blogrepo.NewRepository(capacity)
-	bus := blogbus.NewBusiness(repo)
-	app := blogapp.NewApp(port, bus)
+// repository is a blogbus.Repo, commentbus.Repo and authbus.Repo with a quota of posts, whose storage
+// must be released on shutdown.
+type repository interface {
+	blogbus.Repo
+	commentbus.Repo
+	authbus.Repo
+	SetQuota(quota int)
+	Close() error
+}
+
+// config selects and configures the storage behind the repository.
+type config struct {
+	storage      string
+	dataDir      string
+	walPath      string
+	capacity     int
+	compactEvery time.Duration
+	wal          wal.Options
+	eviction     cache.Policy
+	spill        string
+	shards       int
+}
 
-	return app
+// newRepository creates the repository for the selected storage engine.
+func newRepository(ctx context.Context, cfg config) (repository, error) {
+	if cfg.eviction != cache.Reject && (cfg.storage == "file" || cfg.walPath != "") {
+		return nil, fmt.Errorf("cache eviction requires the sqlite storage or the memory storage without a write-ahead log")
+	}
+
+	if cfg.spill != "" && cfg.eviction == cache.Reject {
+		return nil, fmt.Errorf("cache spill requires an eviction policy other than reject")
+	}
+
+	if cfg.shards != 1 && (cfg.storage != "memory" || cfg.walPath != "") {
+		return nil, fmt.Errorf("cache shards require the memory storage without a write-ahead log")
+	}
+
+	switch cfg.storage {
+	case "memory":
+		if cfg.walPath != "" {
+			if err := os.MkdirAll(filepath.Dir(cfg.walPath), 0o755); err != nil {
+				return nil, fmt.Errorf("mkdir: %w", err)
+			}
+			return blogrepo.NewWALRepository(cfg.walPath, cfg.capacity, cfg.wal)
+		}
+		spill, err := newSpill(ctx, cfg)
+		if err != nil {
+			return nil, err
+		}
+		return blogrepo.NewMemoryRepository(ctx, cache.Config{
+			Capacity: cfg.capacity,
+			Eviction: cfg.eviction,
+			Shards:   cfg.shards,
+		}, spill)
+	case "file":
+		return blogrepo.NewFileRepository(cfg.dataDir, cfg.capacity, cfg.compactEvery, cfg.wal)
+	case "sqlite":
+		if err := os.MkdirAll(cfg.dataDir, 0o755); err != nil {
+			return nil, fmt.Errorf("mkdir: %w", err)
+		}
+		store, err := sqlitestore.Open(ctx, filepath.Join(cfg.dataDir, "blogapp.db"))
+		if err != nil {
+			return nil, fmt.Errorf("sqlitestore.open: %w", err)
+		}
+		// The database is authoritative, its hot cache always makes room for the recently read posts.
+		policy := cfg.eviction
+		if policy == cache.Reject {
+			policy = cache.LRU
+		}
+		return blogrepo.NewTieredRepository(ctx, store, cfg.capacity, policy)
+	default:
+		return nil, fmt.Errorf("unknown storage %q", cfg.storage)
+	}
+}
+
+// newSpill opens the storage posts evicted from the memory storage are moved to, nil when they are dropped.
+func newSpill(ctx context.Context, cfg config) (blogrepo.Store, error) {
+	switch cfg.spill {
+	case "":
+		return nil, nil
+	case "file":
+		// The spill only receives evicted posts, bounding it would fail evictions.
+		store, err := filestore.Open(cfg.dataDir, math.MaxInt, cfg.compactEvery, cfg.wal)
+		if err != nil {
+			return nil, fmt.Errorf("filestore.open: %w", err)
+		}
+		return store, nil
+	case "sqlite":
+		if err := os.MkdirAll(cfg.dataDir, 0o755); err != nil {
+			return nil, fmt.Errorf("mkdir: %w", err)
+		}
+		store, err := sqlitestore.Open(ctx, filepath.Join(cfg.dataDir, "blogapp.db"))
+		if err != nil {
+			return nil, fmt.Errorf("sqlitestore.open: %w", err)
+		}
+		return store, nil
+	default:
+		return nil, fmt.Errorf("unknown cache spill %q", cfg.spill)
+	}
+}
+
+// di injects dependencies and initializes the businesses of a blog.
+func di(tc tenantConfig, repo repository, editWindow time.Duration, classifiers []commentbus.Classifier, auth authbus.Business) blogapp.Tenant {
+	return blogapp.Tenant{
+		Name:     tc.Name,
+		Hosts:    tc.Hosts,
+		Business: blogbus.NewBusiness(repo),
+		Comments: commentbus.NewBusiness(repo, editWindow, classifiers...),
+		Auth:     auth,
+	}
 }
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/anazcodes/blogapp/pkg/request"
+)
+
+// commands are the subcommands run against a live server instead of starting one.
+var commands = map[string]func(args []string) error{
+	"snapshot": snapshot,
+	"restore":  restore,
+	"keygen":   keygen,
+}
+
+// snapshot downloads a snapshot of the server's blog store to a file.
+func snapshot(args []string) error {
+	fs := flag.NewFlagSet("snapshot", flag.ExitOnError)
+	addr := fs.String("addr", "http://localhost:3000", "Server address")
+	token := fs.String("token", os.Getenv("BLOGAPP_TOKEN"), "Token from /api/auth/login, defaults to $BLOGAPP_TOKEN")
+	operator := fs.String("operator-token", os.Getenv("BLOGAPP_OPERATOR_TOKEN"), "Operator token of the server, needed outside its host, defaults to $BLOGAPP_OPERATOR_TOKEN")
+	out := fs.String("out", fmt.Sprintf("blogapp-%s.snap", time.Now().UTC().Format("20060102T150405Z")), "Snapshot file to write")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, *addr+"/api/admin/snapshot", nil)
+	if err != nil {
+		return fmt.Errorf("new request: %w", err)
+	}
+
+	res, err := send(req, *token, *operator)
+	if err != nil {
+		return fmt.Errorf("request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return responseErr(res)
+	}
+
+	f, err := os.Create(*out)
+	if err != nil {
+		return fmt.Errorf("create: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(f, res.Body); err != nil {
+		return fmt.Errorf("write: %w", err)
+	}
+
+	if err := f.Sync(); err != nil {
+		return fmt.Errorf("sync: %w", err)
+	}
+
+	log.Printf("Snapshot written to %s", *out)
+
+	return nil
+}
+
+// restore uploads a snapshot file, replacing every post on the server.
+func restore(args []string) error {
+	fs := flag.NewFlagSet("restore", flag.ExitOnError)
+	addr := fs.String("addr", "http://localhost:3000", "Server address")
+	token := fs.String("token", os.Getenv("BLOGAPP_TOKEN"), "Token from /api/auth/login, defaults to $BLOGAPP_TOKEN")
+	operator := fs.String("operator-token", os.Getenv("BLOGAPP_OPERATOR_TOKEN"), "Operator token of the server, needed outside its host, defaults to $BLOGAPP_OPERATOR_TOKEN")
+	in := fs.String("in", "", "Snapshot file to restore")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *in == "" {
+		return fmt.Errorf("restore: -in is required")
+	}
+
+	f, err := os.Open(*in)
+	if err != nil {
+		return fmt.Errorf("open: %w", err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("stat: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, *addr+"/api/admin/restore", f)
+	if err != nil {
+		return fmt.Errorf("new request: %w", err)
+	}
+	req.ContentLength = info.Size()
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	res, err := send(req, *token, *operator)
+	if err != nil {
+		return fmt.Errorf("request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return responseErr(res)
+	}
+
+	log.Printf("Restored %s", *in)
+
+	return nil
+}
+
+// send sends req to the server, authenticated with token, as its operator with operator.
+func send(req *http.Request, token, operator string) (*http.Response, error) {
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	if operator != "" {
+		req.Header.Set("X-Operator-Token", operator)
+	}
+
+	return http.DefaultClient.Do(req)
+}
+
+// responseErr builds an error from a failed request.Response.
+func responseErr(res *http.Response) error {
+	var body request.Response
+
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return fmt.Errorf("server responded with %s", res.Status)
+	}
+
+	return fmt.Errorf("server responded with %s: %s: %v", res.Status, body.Message, body.Error)
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// tenantConfig is a blog of the tenants file. The blog without a name is the one served without
+// tenants.
+type tenantConfig struct {
+	Name  string   `json:"name"`
+	Hosts []string `json:"hosts"`
+	Quota int      `json:"quota"` // Most posts the blog holds, the trash included, 0 for no bound.
+}
+
+// tenantName is what a name of a tenant is made of, it is a path segment and a directory.
+var tenantName = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,31}$`)
+
+// readTenants reads the tenants of the JSON array at path. Names and hosts are unique, hosts are
+// lowercased.
+func readTenants(path string) ([]tenantConfig, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read: %w", err)
+	}
+
+	var tenants []tenantConfig
+	if err := json.Unmarshal(b, &tenants); err != nil {
+		return nil, fmt.Errorf("unmarshal: %w", err)
+	}
+
+	if len(tenants) == 0 {
+		return nil, fmt.Errorf("no tenants in %s", path)
+	}
+
+	names := make(map[string]bool)
+	hosts := make(map[string]string)
+
+	for i, t := range tenants {
+		if !tenantName.MatchString(t.Name) {
+			return nil, fmt.Errorf("tenant %q: want a name of 1 to 32 lowercase letters, digits and dashes", t.Name)
+		}
+
+		if names[t.Name] {
+			return nil, fmt.Errorf("tenant %q: listed twice", t.Name)
+		}
+		names[t.Name] = true
+
+		if t.Quota < 0 {
+			return nil, fmt.Errorf("tenant %q: negative quota %d", t.Name, t.Quota)
+		}
+
+		for j, h := range t.Hosts {
+			h = strings.ToLower(h)
+			if other, ok := hosts[h]; ok {
+				return nil, fmt.Errorf("tenant %q: host %q is a host of %q", t.Name, h, other)
+			}
+			hosts[h] = t.Name
+			tenants[i].Hosts[j] = h
+		}
+	}
+
+	return tenants, nil
+}
+
+// forTenant returns cfg with the files of the tenant named name in a directory of their own, the
+// same cfg for the blog without a name.
+func (cfg config) forTenant(name string) config {
+	if name == "" {
+		return cfg
+	}
+
+	cfg.dataDir = filepath.Join(cfg.dataDir, name)
+	if cfg.walPath != "" {
+		cfg.walPath = filepath.Join(filepath.Dir(cfg.walPath), name, filepath.Base(cfg.walPath))
+	}
+
+	return cfg
+}
+
+// adminOf returns the username grant makes an admin of the tenant named name. Without tenants grant is
+// the username, with them it is tenant:username.
+func adminOf(grant, name string) (string, bool) {
+	if name == "" {
+		return grant, grant != ""
+	}
+
+	tenant, username, ok := strings.Cut(grant, ":")
+
+	return username, ok && tenant == name
+}
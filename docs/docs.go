@@ -23,19 +23,21 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/blog-post": {
+        "/api/admin/cache/stats": {
             "get": {
-                "description": "Retrieves all the available Blog Posts.",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Returns the hit, miss and eviction counters of the in-memory cache.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Blog Post"
+                    "Admin"
                 ],
-                "summary": "Blog Posts",
+                "summary": "Cache Stats",
                 "responses": {
                     "200": {
                         "description": "Success",
@@ -48,30 +50,21 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/blogapp.BlogPost"
-                                            }
+                                            "$ref": "#/definitions/blogapp.CacheStats"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Failed to bind path param",
-                        "schema": {
-                            "$ref": "#/definitions/request.Response"
-                        }
-                    },
-                    "404": {
-                        "description": "Referenced resource does not found in the system",
+                    "401": {
+                        "description": "Failed to authenticate, the token is missing, invalid or expired",
                         "schema": {
                             "$ref": "#/definitions/request.Response"
                         }
                     },
-                    "422": {
-                        "description": "Failed to save, blog storage capacity reached",
+                    "403": {
+                        "description": "Forbidden, the role of the user does not allow this",
                         "schema": {
                             "$ref": "#/definitions/request.Response"
                         }
@@ -83,32 +76,48 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api/admin/restore": {
             "post": {
-                "description": "Creates a new Blog Post entry to the system and returns the Blog Post's ID.",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replaces every Blog Post with the contents of an uploaded snapshot file, of any size.",
                 "consumes": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Blog Post"
+                    "Admin"
                 ],
-                "summary": "Add Blog Post",
+                "summary": "Restore",
                 "parameters": [
                     {
-                        "description": "Payload",
+                        "description": "Snapshot file",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/blogapp.AddBlogPost"
+                            "type": "array",
+                            "items": {
+                                "type": "integer"
+                            }
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Operator token, required from outside the host of the server",
+                        "name": "X-Operator-Token",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "201": {
+                    "200": {
                         "description": "Success",
                         "schema": {
                             "allOf": [
@@ -119,7 +128,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/blogapp.BlogPostID"
+                                            "$ref": "#/definitions/blogapp.SnapshotInfo"
                                         }
                                     }
                                 }
@@ -127,13 +136,25 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Failed to bind path param",
+                        "description": "Failed to restore, the snapshot is corrupted",
                         "schema": {
                             "$ref": "#/definitions/request.Response"
                         }
                     },
-                    "404": {
-                        "description": "Referenced resource does not found in the system",
+                    "401": {
+                        "description": "Failed to authenticate, the token is missing, invalid or expired",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden, the role of the user does not allow this, or the request is not from the operator of the server",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Failed to save, resource conflicts with an existing one",
                         "schema": {
                             "$ref": "#/definitions/request.Response"
                         }
@@ -153,28 +174,72 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/blog-post/{id}": {
+        "/api/admin/snapshot": {
             "get": {
-                "description": "Retrieves single Blog Post belongs to the provided ID.",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Downloads a point-in-time snapshot file of every Blog Post.",
                 "produces": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "tags": [
-                    "Blog Post"
+                    "Admin"
                 ],
-                "summary": "Blog Post",
+                "summary": "Snapshot",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Blog Post ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "description": "Operator token, required from outside the host of the server",
+                        "name": "X-Operator-Token",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Snapshot file",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to authenticate, the token is missing, invalid or expired",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden, the role of the user does not allow this, or the request is not from the operator of the server",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
                     }
                 ],
+                "description": "Lists every user with their role, ordered by username.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Users",
                 "responses": {
                     "200": {
                         "description": "Success",
@@ -187,27 +252,24 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/blogapp.BlogPost"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/blogapp.Account"
+                                            }
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Failed to bind path param",
-                        "schema": {
-                            "$ref": "#/definitions/request.Response"
-                        }
-                    },
-                    "404": {
-                        "description": "Referenced resource does not found in the system",
+                    "401": {
+                        "description": "Failed to authenticate, the token is missing, invalid or expired",
                         "schema": {
                             "$ref": "#/definitions/request.Response"
                         }
                     },
-                    "422": {
-                        "description": "Failed to save, blog storage capacity reached",
+                    "403": {
+                        "description": "Forbidden, the role of the user does not allow this",
                         "schema": {
                             "$ref": "#/definitions/request.Response"
                         }
@@ -219,9 +281,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Deletes Blog Post in the given ID.",
+            }
+        },
+        "/api/admin/users/{username}": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Gives the user with the given username a role: reader, author, editor or admin. Authors write posts and change their own, editors change and publish anyone's and run the taxonomy and comments, admins manage users and the server. The new role applies to the sessions the user has. Admins cannot change their own role, so there is always one.",
                 "consumes": [
                     "application/json"
                 ],
@@ -229,16 +298,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Blog Post"
+                    "Admin"
                 ],
-                "summary": "Delete Blog Post",
+                "summary": "Set Role",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Blog Post ID",
-                        "name": "id",
+                        "type": "string",
+                        "description": "Username",
+                        "name": "username",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Payload",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/blogapp.SetRole"
+                        }
                     }
                 ],
                 "responses": {
@@ -253,7 +331,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/blogapp.BlogPostID"
+                                            "$ref": "#/definitions/blogapp.Account"
                                         }
                                     }
                                 }
@@ -261,19 +339,83 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Failed to bind path param",
+                        "description": "Failed to bind JSON",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to authenticate, the token is missing, invalid or expired",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden, admins cannot change their own role",
                         "schema": {
                             "$ref": "#/definitions/request.Response"
                         }
                     },
                     "404": {
-                        "description": "Referenced resource does not found in the system",
+                        "description": "Referenced user does not exist",
                         "schema": {
                             "$ref": "#/definitions/request.Response"
                         }
                     },
-                    "422": {
-                        "description": "Failed to save, blog storage capacity reached",
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/keys": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the API keys of the authenticated user, or of every user to an admin, oldest first. Revoked keys are listed too.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "API Keys",
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/blogapp.APIKey"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to authenticate, the token is missing, invalid or expired",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden, the API key is not scoped for this",
                         "schema": {
                             "$ref": "#/definitions/request.Response"
                         }
@@ -286,8 +428,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "patch": {
-                "description": "Updates Blog Post with the given data.",
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates an API key that acts as the authenticated user within its scopes, for machine clients like publishing pipelines. Send it as \"Authorization: ApiKey \u003ckey\u003e\". The scopes are posts:read, posts:write, posts:publish, taxonomy:write, comments:write, reactions:write, comments:moderate and admin, a key may never do more than the role of its user. The key is only in this response, only its hash is stored. API keys cannot manage API keys.",
                 "consumes": [
                     "application/json"
                 ],
@@ -295,29 +442,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Blog Post"
+                    "Auth"
                 ],
-                "summary": "Update Blog Post",
+                "summary": "Create API Key",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Blog Post ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
                     {
                         "description": "Payload",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/blogapp.UpdateBlogPost"
+                            "$ref": "#/definitions/blogapp.CreateAPIKey"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
+                    "201": {
                         "description": "Success",
                         "schema": {
                             "allOf": [
@@ -328,7 +468,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/blogapp.BlogPostID"
+                                            "$ref": "#/definitions/blogapp.IssuedAPIKey"
                                         }
                                     }
                                 }
@@ -336,19 +476,19 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Failed to bind path param",
+                        "description": "Failed to bind JSON",
                         "schema": {
                             "$ref": "#/definitions/request.Response"
                         }
                     },
-                    "404": {
-                        "description": "Referenced resource does not found in the system",
+                    "401": {
+                        "description": "Failed to authenticate, the token is missing, invalid or expired",
                         "schema": {
                             "$ref": "#/definitions/request.Response"
                         }
                     },
-                    "422": {
-                        "description": "Failed to save, blog storage capacity reached",
+                    "403": {
+                        "description": "Forbidden, the API key is not scoped for this",
                         "schema": {
                             "$ref": "#/definitions/request.Response"
                         }
@@ -361,68 +501,4868 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "blogapp.AddBlogPost": {
-            "type": "object",
-            "properties": {
-                "body": {
-                    "type": "string"
-                },
-                "description": {
-                    "type": "string"
-                },
-                "title": {
-                    "type": "string"
-                }
-            }
-        },
-        "blogapp.BlogPost": {
-            "type": "object",
-            "properties": {
-                "body": {
-                    "type": "string"
-                },
-                "created_at": {
-                    "type": "string"
-                },
-                "description": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "title": {
-                    "type": "string"
-                },
-                "updated_at": {
-                    "type": "string"
-                }
-            }
-        },
-        "blogapp.BlogPostID": {
-            "type": "object",
-            "properties": {
-                "id": {
-                    "type": "integer"
-                }
-            }
         },
-        "blogapp.UpdateBlogPost": {
-            "type": "object",
-            "properties": {
+        "/api/auth/keys/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revokes an API key of the authenticated user, or of any user by an admin. The key no longer authenticates.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Revoke API Key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/blogapp.APIKey"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to bind path param",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to authenticate, the token is missing, invalid or expired",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden, the API key is not scoped for this",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Referenced API key does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "The API key is revoked",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/keys/{id}/rotate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replaces the secret of an API key, keeping its ID, name and scopes. The old key no longer authenticates, the new one is only in this response.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Rotate API Key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API Key ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/blogapp.IssuedAPIKey"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to bind path param",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to authenticate, the token is missing, invalid or expired",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden, the API key is not scoped for this",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Referenced API key does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "The API key is revoked",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/login": {
+            "post": {
+                "description": "Logs a user in with a username and password and starts a session, whose signed token authenticates the user until it expires or the user logs out. Send it as \"Authorization: Bearer \u003ctoken\u003e\" with the requests that create, change or delete resources. The account is locked for a while after repeated failed logins.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Login",
+                "parameters": [
+                    {
+                        "description": "Payload",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/blogapp.Login"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/blogapp.Token"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to bind JSON",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to log in, the username or password is wrong",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "423": {
+                        "description": "Failed to log in, the account is locked after repeated failed logins",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Failed to process your request, too many passwords are being checked, try again later",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/logout": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ends the session of the token the request is authenticated with, the token no longer authenticates anyone.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Logout",
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to authenticate, the token is missing, invalid or expired",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/password": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Changes the password of the authenticated user once the current password is confirmed, and ends every other session of the user. Wrong current passwords count towards the lockout like failed logins.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Change Password",
+                "parameters": [
+                    {
+                        "description": "Payload",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/blogapp.ChangePassword"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to bind JSON",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to authenticate, the token is missing, invalid or expired",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "423": {
+                        "description": "Failed to log in, the account is locked after repeated failed logins",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Failed to process your request, too many passwords are being checked, try again later",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/register": {
+            "post": {
+                "description": "Creates an account that logs in with the username and password of the payload. Usernames are lowercased, 3 to 32 letters, digits, dots, dashes and underscores. Passwords are 8 to 128 characters and are stored as argon2id hashes.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Register",
+                "parameters": [
+                    {
+                        "description": "Payload",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/blogapp.Register"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/blogapp.Account"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to bind JSON",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Failed to register, the username is taken",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Failed to process your request, too many passwords are being checked, try again later",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/authors": {
+            "get": {
+                "description": "Retrieves every author, ordered by ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Author"
+                ],
+                "summary": "Authors",
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/blogapp.Author"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates the profile of an author Blog Posts can be linked to and returns it with the ID it was issued.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Author"
+                ],
+                "summary": "Add Author",
+                "parameters": [
+                    {
+                        "description": "Payload",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/blogapp.AddAuthor"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/blogapp.Author"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to bind JSON",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to authenticate, the token is missing, invalid or expired",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden, the role of the user does not allow this",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/authors/{id}": {
+            "get": {
+                "description": "Retrieves the author with the given ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Author"
+                ],
+                "summary": "Author",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Author ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/blogapp.Author"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to bind path param",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Referenced author does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes the author with the given ID. An author with Blog Posts, the posts in the trash included, is only deleted when its posts are reassigned to another author with reassign_to.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Author"
+                ],
+                "summary": "Delete Author",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Author ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID of the author the Blog Posts move to",
+                        "name": "reassign_to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/blogapp.AuthorID"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to bind path param",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to authenticate, the token is missing, invalid or expired",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden, the role of the user does not allow this",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Referenced author does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Failed to delete, the author still has Blog Posts",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Edits the profile of the author with the given ID, the fields left out keep their value.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Author"
+                ],
+                "summary": "Update Author",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Author ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payload",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/blogapp.UpdateAuthor"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/blogapp.Author"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to bind path param",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to authenticate, the token is missing, invalid or expired",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden, the role of the user does not allow this",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Referenced author does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/authors/{id}/posts": {
+            "get": {
+                "description": "Retrieves a page of the Blog Posts of the author with the given ID, ordered by ID. Pass the next_cursor of a page as cursor to retrieve the following one.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Author"
+                ],
+                "summary": "Author Blog Posts",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Author ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "draft",
+                            "published",
+                            "archived"
+                        ],
+                        "type": "string",
+                        "description": "Status, published by default",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, the first page when empty",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/blogapp.BlogPost"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to bind path param",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Referenced author does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/blog-post": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves a page of the Blog Posts matching the filters, ordered by ID unless sorted otherwise. Pass the next_cursor of a page as cursor to retrieve the following one with the same filters and order.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Blog Post"
+                ],
+                "summary": "Blog Posts",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page size, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, the first page when empty",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created at or after, RFC 3339",
+                        "name": "created_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created before, RFC 3339",
+                        "name": "created_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Updated at or after, RFC 3339",
+                        "name": "updated_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Updated before, RFC 3339",
+                        "name": "updated_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Title starts with, case-sensitive",
+                        "name": "title_prefix",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Title contains, case-sensitive",
+                        "name": "title_contains",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "draft",
+                            "published",
+                            "archived"
+                        ],
+                        "type": "string",
+                        "description": "Status, published by default, the others to their authors and editors",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tag slug",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Category slug, subcategories included",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Author ID",
+                        "name": "author",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "created_at",
+                            "updated_at",
+                            "title"
+                        ],
+                        "type": "string",
+                        "description": "Sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/blogapp.BlogPost"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to bind query",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to authenticate, the token is missing, invalid or expired",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden, the role of the user does not allow this",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a new Blog Post entry to the system and returns the Blog Post's ID. The post is named by the slug of the payload, or else by a unique slug generated from its title.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Blog Post"
+                ],
+                "summary": "Add Blog Post",
+                "parameters": [
+                    {
+                        "description": "Payload",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/blogapp.AddBlogPost"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/blogapp.BlogPostID"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to bind path param",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to authenticate, the token is missing, invalid or expired",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden, the role of the user does not allow this",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Referenced resource does not found in the system",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Failed to save, the slug is taken by another post",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Failed to save, blog storage capacity or the quota of the blog reached",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/blog-post/by-slug/{slug}": {
+            "get": {
+                "description": "Retrieves the Blog Post named by the slug. An old slug of the post redirects to its current slug with 301 Moved Permanently, the post is sent along.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Blog Post"
+                ],
+                "summary": "Blog Post by Slug",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Blog Post slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/blogapp.BlogPost"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "301": {
+                        "description": "Moved to the current slug of the post",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/blogapp.BlogPost"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to bind path param",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Referenced resource is in the trash",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/blog-post/search": {
+            "get": {
+                "description": "Retrieves a page of the published Blog Posts matching any word of the search in their title, description or body, the most relevant first. Words are matched regardless of case and inflection, and common words like \"the\" are ignored. Each result carries snippets of its matching fields with the matched words wrapped in \u003cmark\u003e tags. Pass the next_cursor of a page as cursor to retrieve the following one of the same search.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Blog Post"
+                ],
+                "summary": "Search Blog Posts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, the first page when empty",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/blogapp.SearchHit"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to bind query",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/blog-post/trash": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves a page of the Blog Posts in the trash, ordered by ID. Pass the next_cursor of a page as cursor to retrieve the following one.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "Trashed Blog Posts",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page size, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, the first page when empty",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/blogapp.BlogPost"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to bind query",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to authenticate, the token is missing, invalid or expired",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden, the role of the user does not allow this",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/blog-post/trash/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Permanently deletes a Blog Post in the trash.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "Purge Blog Post",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Blog Post ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/blogapp.BlogPostID"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to bind path param",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to authenticate, the token is missing, invalid or expired",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden, the role of the user does not allow this",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Referenced resource is not in the trash",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/blog-post/trash/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Moves a Blog Post out of the trash.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "Restore Blog Post",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Blog Post ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/blogapp.BlogPostID"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to bind path param",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to authenticate, the token is missing, invalid or expired",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden, the role of the user does not allow this",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Referenced resource is not in the trash",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/blog-post/{id}": {
+            "get": {
+                "description": "Retrieves single Blog Post belongs to the provided ID, with the number of readers who reacted with each reaction.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Blog Post"
+                ],
+                "summary": "Blog Post",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Blog Post ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/blogapp.BlogPost"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to bind path param",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Referenced resource does not found in the system",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Failed to save, blog storage capacity reached",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Moves Blog Post in the given ID to the trash, which hides it until it is restored or purged.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Blog Post"
+                ],
+                "summary": "Delete Blog Post",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Blog Post ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/blogapp.BlogPostID"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to bind path param",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to authenticate, the token is missing, invalid or expired",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden, authors can only change their own posts",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Referenced resource does not found in the system",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Failed to save, blog storage capacity reached",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates Blog Post with the given data. The post moves to the slug of the payload, and a slug generated from its title follows a new title. The slugs the post had before keep leading to it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Blog Post"
+                ],
+                "summary": "Update Blog Post",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Blog Post ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payload",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/blogapp.UpdateBlogPost"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/blogapp.BlogPostID"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to bind path param",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to authenticate, the token is missing, invalid or expired",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden, authors can only change their own posts",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Referenced resource does not found in the system",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Failed to save, the slug is taken by another post",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Failed to save, blog storage capacity reached",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/blog-post/{id}/archive": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Archives a published Blog Post, removing it from the published listing.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Blog Post"
+                ],
+                "summary": "Archive Blog Post",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Blog Post ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/blogapp.BlogPostID"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to bind path param",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to authenticate, the token is missing, invalid or expired",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden, the role of the user does not allow this",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Referenced resource does not found in the system",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Failed to change the status, the post is not in a status it can change from",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/blog-post/{id}/comments": {
+            "get": {
+                "description": "Retrieves a page of the approved comments on a Blog Post, or of the approved replies to one of its comments with parent, oldest first. Every comment carries the number of its approved replies, which are retrieved with its ID as parent. Pass the next_cursor of a page as cursor to retrieve the following one.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comment"
+                ],
+                "summary": "Comments",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Blog Post ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID of the comment whose replies are retrieved",
+                        "name": "parent",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, the first page when empty",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/blogapp.Comment"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to bind path param",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Referenced resource is in the trash",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Adds a comment to a Blog Post, or a reply to one of its approved comments with the parent_id of the payload. The comment is held pending until a moderator approves it, or rejected when a spam classifier takes it for spam.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comment"
+                ],
+                "summary": "Add Comment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Blog Post ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payload",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/blogapp.AddComment"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/blogapp.Comment"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to bind path param",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to authenticate, the token is missing, invalid or expired",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Referenced resource is in the trash",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/blog-post/{id}/comments/{comment}": {
+            "get": {
+                "description": "Retrieves an approved comment on a Blog Post.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comment"
+                ],
+                "summary": "Comment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Blog Post ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Comment ID",
+                        "name": "comment",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/blogapp.Comment"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to bind path param",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Referenced resource is in the trash",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes a comment with every reply in its thread.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comment"
+                ],
+                "summary": "Delete Comment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Blog Post ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Comment ID",
+                        "name": "comment",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/blogapp.CommentID"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to bind path param",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to authenticate, the token is missing, invalid or expired",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden, the role of the user does not allow this",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Referenced resource is in the trash",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Edits the body of a comment the user posted, which is only possible for a while after it was posted. Moderators edit any comment at any time. The edited comment is moderated again.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comment"
+                ],
+                "summary": "Update Comment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Blog Post ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Comment ID",
+                        "name": "comment",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payload",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/blogapp.UpdateComment"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/blogapp.Comment"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to bind path param",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to authenticate, the token is missing, invalid or expired",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden, the comment was posted by another user or its edit window has passed",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Referenced resource is in the trash",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/blog-post/{id}/publish": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Publishes a draft Blog Post, making it visible to readers.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Blog Post"
+                ],
+                "summary": "Publish Blog Post",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Blog Post ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/blogapp.BlogPostID"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to bind path param",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to authenticate, the token is missing, invalid or expired",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden, the role of the user does not allow this",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Referenced resource does not found in the system",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Failed to change the status, the post is not in a status it can change from",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/blog-post/{id}/reactions": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Adds the reaction of the user to a Blog Post, replacing the one the user had. Reacting again with the same reaction changes nothing. Responds with the number of readers who reacted with each reaction.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reaction"
+                ],
+                "summary": "React",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Blog Post ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payload",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/blogapp.AddReaction"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "additionalProperties": {
+                                                "type": "integer"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to bind path param",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to authenticate, the token is missing, invalid or expired",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Referenced resource is in the trash",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Removes the reaction of the user to a Blog Post, removing a reaction the user does not have changes nothing. Responds with the number of readers who reacted with each reaction.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reaction"
+                ],
+                "summary": "Unreact",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Blog Post ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "additionalProperties": {
+                                                "type": "integer"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to bind path param",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to authenticate, the token is missing, invalid or expired",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Referenced resource is in the trash",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/blog-post/{id}/revisions": {
+            "get": {
+                "description": "Retrieves the revisions of a Blog Post, oldest first. Revision 1 is the content the post was created with and every update records the next one.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Blog Post"
+                ],
+                "summary": "Blog Post Revisions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Blog Post ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/blogapp.RevisionSummary"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to bind path param",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Referenced resource does not found in the system",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/blog-post/{id}/revisions/diff": {
+            "get": {
+                "description": "Compares two revisions of a Blog Post line by line, for every field that differs between them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Blog Post"
+                ],
+                "summary": "Diff Blog Post Revisions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Blog Post ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision compared from",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision compared to",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/blogapp.FieldDiff"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to bind path param",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Referenced revision does not exist for the Blog Post",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/blog-post/{id}/revisions/{number}": {
+            "get": {
+                "description": "Retrieves the content of a Blog Post at one of its revisions.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Blog Post"
+                ],
+                "summary": "Blog Post Revision",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Blog Post ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision number",
+                        "name": "number",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/blogapp.Revision"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to bind path param",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Referenced revision does not exist for the Blog Post",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/blog-post/{id}/revisions/{number}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates a Blog Post back to the content of one of its revisions. The restore is recorded as a new revision, the revisions after the restored one are kept.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Blog Post"
+                ],
+                "summary": "Restore Blog Post Revision",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Blog Post ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision number",
+                        "name": "number",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/blogapp.BlogPostID"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to bind path param",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to authenticate, the token is missing, invalid or expired",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden, authors can only change their own posts",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Referenced revision does not exist for the Blog Post",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/blog-post/{id}/schedule": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Schedules a draft Blog Post to be published at a future time, replacing its previous schedule.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Blog Post"
+                ],
+                "summary": "Schedule Blog Post",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Blog Post ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payload",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/blogapp.ScheduleBlogPost"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/blogapp.BlogPostID"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to bind path param",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to authenticate, the token is missing, invalid or expired",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden, the role of the user does not allow this",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Referenced resource does not found in the system",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Failed to change the status, the post is not in a status it can change from",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/blog-post/{id}/unpublish": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Turns a published Blog Post back into a draft.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Blog Post"
+                ],
+                "summary": "Unpublish Blog Post",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Blog Post ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/blogapp.BlogPostID"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to bind path param",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to authenticate, the token is missing, invalid or expired",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden, the role of the user does not allow this",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Referenced resource does not found in the system",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Failed to change the status, the post is not in a status it can change from",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/blog-post/{id}/unschedule": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Clears the schedule of a draft Blog Post so it is not published.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Blog Post"
+                ],
+                "summary": "Unschedule Blog Post",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Blog Post ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/blogapp.BlogPostID"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to bind path param",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to authenticate, the token is missing, invalid or expired",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden, the role of the user does not allow this",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Referenced resource does not found in the system",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Failed to change the status, the post is not in a status it can change from",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/categories": {
+            "get": {
+                "description": "Retrieves every category depth first, each after its parent.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Category"
+                ],
+                "summary": "Categories",
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/blogapp.Category"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a category Blog Posts can be filed under, at the top level or under a parent category. The category is named by the slug of the payload, or else by the slug of its name.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Category"
+                ],
+                "summary": "Add Category",
+                "parameters": [
+                    {
+                        "description": "Payload",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/blogapp.AddCategory"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/blogapp.Category"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to bind JSON",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to authenticate, the token is missing, invalid or expired",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden, the role of the user does not allow this",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Failed to save, the category already exists",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/categories/{slug}": {
+            "get": {
+                "description": "Retrieves the category with the given slug.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Category"
+                ],
+                "summary": "Category",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Category slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/blogapp.Category"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to bind path param",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Referenced category does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes the category with the given slug. A category is only deleted once it has no subcategories and no Blog Post is filed under it, the posts in the trash included.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Category"
+                ],
+                "summary": "Delete Category",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Category slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/blogapp.TermSlug"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to bind path param",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to authenticate, the token is missing, invalid or expired",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden, the role of the user does not allow this",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Referenced category does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Failed to delete, Blog Posts or subcategories still refer to it",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Renames the category with the given slug or moves it under another parent, its slug never changes.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Category"
+                ],
+                "summary": "Update Category",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Category slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payload",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/blogapp.UpdateCategory"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/blogapp.Category"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to bind path param",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to authenticate, the token is missing, invalid or expired",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden, the role of the user does not allow this",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Referenced category does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/moderation/comments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves a page of the comments awaiting moderators across the Blog Posts that are not in the trash, oldest first. Comments are pending by default, status selects the rejected ones to review the spam classifiers, or the approved ones. Pass the next_cursor of a page as cursor to retrieve the following one.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Moderation"
+                ],
+                "summary": "Comment Queue",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Status of the comments: pending by default, approved or rejected",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, the first page when empty",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/blogapp.Comment"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to bind query",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to authenticate, the token is missing, invalid or expired",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden, the role of the user does not allow this",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Approves or rejects up to 100 comments at once. None is moderated when one of them does not exist.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Moderation"
+                ],
+                "summary": "Moderate Comments",
+                "parameters": [
+                    {
+                        "description": "Payload",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/blogapp.ModerateComments"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/blogapp.Comment"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to bind JSON",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to authenticate, the token is missing, invalid or expired",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden, the role of the user does not allow this",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Referenced comment does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/moderation/comments/{id}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Approves a comment, which makes it public. The decision is learned by the spam classifier.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Moderation"
+                ],
+                "summary": "Approve Comment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Comment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/blogapp.Comment"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to bind path param",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to authenticate, the token is missing, invalid or expired",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden, the role of the user does not allow this",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Referenced comment does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/moderation/comments/{id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Rejects a comment, which hides it. The decision is learned by the spam classifier.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Moderation"
+                ],
+                "summary": "Reject Comment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Comment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/blogapp.Comment"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to bind path param",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to authenticate, the token is missing, invalid or expired",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden, the role of the user does not allow this",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Referenced comment does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tags": {
+            "get": {
+                "description": "Retrieves every tag, ordered by slug.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tag"
+                ],
+                "summary": "Tags",
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/blogapp.Tag"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a tag posts can be labeled with. The tag is named by the slug of the payload, or else by the slug of its name.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tag"
+                ],
+                "summary": "Add Tag",
+                "parameters": [
+                    {
+                        "description": "Payload",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/blogapp.AddTag"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/blogapp.Tag"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to bind JSON",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to authenticate, the token is missing, invalid or expired",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden, the role of the user does not allow this",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Failed to save, the tag already exists",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tags/cloud": {
+            "get": {
+                "description": "Retrieves every tag with the number of published Blog Posts that have it, the most used first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tag"
+                ],
+                "summary": "Tag Cloud",
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/blogapp.TagCount"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tags/{slug}": {
+            "get": {
+                "description": "Retrieves the tag with the given slug.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tag"
+                ],
+                "summary": "Tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/blogapp.Tag"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to bind path param",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Referenced tag does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes the tag with the given slug. A tag is only deleted once no Blog Post has it, the posts in the trash included.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tag"
+                ],
+                "summary": "Delete Tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/blogapp.TermSlug"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to bind path param",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to authenticate, the token is missing, invalid or expired",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden, the role of the user does not allow this",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Referenced tag does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Failed to delete, Blog Posts or subcategories still refer to it",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Renames the tag with the given slug, its slug never changes.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tag"
+                ],
+                "summary": "Update Tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payload",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/blogapp.UpdateTag"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/request.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/blogapp.Tag"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to bind path param",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Failed to authenticate, the token is missing, invalid or expired",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden, the role of the user does not allow this",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Referenced tag does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process your request",
+                        "schema": {
+                            "$ref": "#/definitions/request.Response"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "blogapp.APIKey": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "owner": {
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "rotated_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "blogapp.Account": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "locked_until": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "blogapp.AddAuthor": {
+            "type": "object",
+            "properties": {
+                "avatar_url": {
+                    "type": "string"
+                },
+                "bio": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "blogapp.AddBlogPost": {
+            "type": "object",
+            "properties": {
+                "author_id": {
+                    "type": "integer"
+                },
+                "body": {
+                    "type": "string"
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "description": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "blogapp.AddCategory": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "parent": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                }
+            }
+        },
+        "blogapp.AddComment": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string"
+                },
+                "body": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "description": "ParentID is the comment replied to, leaving it out comments on the post itself.",
+                    "type": "integer"
+                }
+            }
+        },
+        "blogapp.AddReaction": {
+            "type": "object",
+            "properties": {
+                "reaction": {
+                    "description": "Reaction is one of like, love, laugh, wow, sad and angry.",
+                    "type": "string"
+                }
+            }
+        },
+        "blogapp.AddTag": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                }
+            }
+        },
+        "blogapp.Author": {
+            "type": "object",
+            "properties": {
+                "avatar_url": {
+                    "type": "string"
+                },
+                "bio": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "blogapp.AuthorID": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "blogapp.BlogPost": {
+            "type": "object",
+            "properties": {
+                "author_id": {
+                    "type": "integer"
+                },
+                "body": {
+                    "type": "string"
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "publish_at": {
+                    "type": "string"
+                },
+                "published_at": {
+                    "type": "string"
+                },
+                "reactions": {
+                    "description": "Reactions is the number of readers who reacted with each reaction.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "blogapp.BlogPostID": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "blogapp.CacheStats": {
+            "type": "object",
+            "properties": {
+                "capacity": {
+                    "type": "integer"
+                },
+                "evictions": {
+                    "type": "integer"
+                },
+                "hits": {
+                    "type": "integer"
+                },
+                "misses": {
+                    "type": "integer"
+                },
+                "posts": {
+                    "type": "integer"
+                }
+            }
+        },
+        "blogapp.Category": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                }
+            }
+        },
+        "blogapp.ChangePassword": {
+            "type": "object",
+            "properties": {
+                "current_password": {
+                    "type": "string"
+                },
+                "new_password": {
+                    "type": "string"
+                }
+            }
+        },
+        "blogapp.Comment": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string"
+                },
+                "body": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "flag": {
+                    "description": "Flag is the reason a spam classifier rejected the comment.",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "moderated_at": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "integer"
+                },
+                "post_id": {
+                    "type": "integer"
+                },
+                "replies": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "blogapp.CommentID": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "post_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "blogapp.CreateAPIKey": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "blogapp.DiffLine": {
+            "type": "object",
+            "properties": {
+                "op": {
+                    "type": "string",
+                    "enum": [
+                        "equal",
+                        "delete",
+                        "insert"
+                    ]
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "blogapp.FieldDiff": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/blogapp.DiffLine"
+                    }
+                }
+            }
+        },
+        "blogapp.IssuedAPIKey": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "owner": {
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "rotated_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "blogapp.Login": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "blogapp.ModerateComments": {
+            "type": "object",
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "status": {
+                    "description": "Status is the decision on the comments, approved or rejected.",
+                    "type": "string"
+                }
+            }
+        },
+        "blogapp.Register": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "blogapp.Revision": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "string"
+                },
+                "changed": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "editor": {
+                    "type": "string"
+                },
+                "number": {
+                    "type": "integer"
+                },
+                "restored_from": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "blogapp.RevisionSummary": {
+            "type": "object",
+            "properties": {
+                "changed": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "editor": {
+                    "type": "string"
+                },
+                "number": {
+                    "type": "integer"
+                },
+                "restored_from": {
+                    "type": "integer"
+                }
+            }
+        },
+        "blogapp.ScheduleBlogPost": {
+            "type": "object",
+            "properties": {
+                "publish_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "blogapp.SearchHit": {
+            "type": "object",
+            "properties": {
+                "author_id": {
+                    "type": "integer"
+                },
+                "body": {
+                    "type": "string"
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "publish_at": {
+                    "type": "string"
+                },
+                "published_at": {
+                    "type": "string"
+                },
+                "reactions": {
+                    "description": "Reactions is the number of readers who reacted with each reaction.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "score": {
+                    "type": "number"
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "snippets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/blogapp.Snippet"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "blogapp.SetRole": {
+            "type": "object",
+            "properties": {
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "blogapp.SnapshotInfo": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "posts": {
+                    "type": "integer"
+                },
+                "serial": {
+                    "type": "integer"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "blogapp.Snippet": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "blogapp.Tag": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                }
+            }
+        },
+        "blogapp.TagCount": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "posts": {
+                    "type": "integer"
+                },
+                "slug": {
+                    "type": "string"
+                }
+            }
+        },
+        "blogapp.TermSlug": {
+            "type": "object",
+            "properties": {
+                "slug": {
+                    "type": "string"
+                }
+            }
+        },
+        "blogapp.Token": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "blogapp.UpdateAuthor": {
+            "type": "object",
+            "properties": {
+                "avatar_url": {
+                    "type": "string"
+                },
+                "bio": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "blogapp.UpdateBlogPost": {
+            "type": "object",
+            "properties": {
+                "author_id": {
+                    "description": "AuthorID moves the post to another author, leaving it out keeps its author.",
+                    "type": "integer"
+                },
                 "body": {
                     "type": "string"
                 },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "description": {
                     "type": "string"
                 },
+                "slug": {
+                    "type": "string"
+                },
+                "tags": {
+                    "description": "Tags and Categories replace those of the post, leaving them out keeps them and an empty array\nremoves them.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "title": {
                     "type": "string"
                 }
             }
         },
+        "blogapp.UpdateCategory": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "parent": {
+                    "description": "Parent moves the category, leaving it out keeps its parent and an empty string moves it to the\ntop level.",
+                    "type": "string"
+                }
+            }
+        },
+        "blogapp.UpdateComment": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "string"
+                }
+            }
+        },
+        "blogapp.UpdateTag": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.Pagination": {
+            "type": "object",
+            "properties": {
+                "has_more": {
+                    "type": "boolean"
+                },
+                "next_cursor": {
+                    "type": "string"
+                }
+            }
+        },
         "request.Response": {
             "type": "object",
             "properties": {
@@ -431,11 +5371,22 @@ const docTemplate = `{
                 "message": {
                     "type": "string"
                 },
+                "pagination": {
+                    "$ref": "#/definitions/request.Pagination"
+                },
                 "status": {
                     "type": "integer"
                 }
             }
         }
+    },
+    "securityDefinitions": {
+        "BearerAuth": {
+            "description": "A token from /api/auth/login, as \"Bearer \u003ctoken\u003e\", or an API key from /api/auth/keys, as \"ApiKey \u003ckey\u003e\".",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        }
     }
 }`
 
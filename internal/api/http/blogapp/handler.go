@@ -1,10 +1,14 @@
 package blogapp
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	"time"
 
+	"github.com/anazcodes/blogapp/internal/business/authbus"
 	"github.com/anazcodes/blogapp/internal/business/blogbus"
+	"github.com/anazcodes/blogapp/internal/business/commentbus"
 	errs "github.com/anazcodes/blogapp/internal/errs/blogapperr"
 
 	"github.com/anazcodes/blogapp/pkg/request"
@@ -14,7 +18,13 @@ import (
 type app struct {
 	port     string
 	business blogbus.Business
+	comments commentbus.Business
+	auth     authbus.Business
 	fbr      *fiber.App
+
+	// operatorToken admits the operator to the routes that copy or replace the whole blog from outside
+	// the host of the server, none is admitted that way when it is empty.
+	operatorToken string
 }
 
 type App interface {
@@ -25,11 +35,18 @@ type App interface {
 	Fiber() *fiber.App
 }
 
-func NewApp(port string, bus blogbus.Business) App {
+func NewApp(port string, bus blogbus.Business, comments commentbus.Business, auth authbus.Business, operatorToken string) App {
+	return newApp(port, bus, comments, auth, operatorToken)
+}
+
+func newApp(port string, bus blogbus.Business, comments commentbus.Business, auth authbus.Business, operatorToken string) *app {
 	app := &app{
-		port:     port,
-		business: bus,
-		fbr:      fiber.New(),
+		port:          port,
+		business:      bus,
+		comments:      comments,
+		auth:          auth,
+		fbr:           fiber.New(config),
+		operatorToken: operatorToken,
 	}
 	app.register(app.fbr)
 
@@ -44,19 +61,212 @@ func (a *app) Shutdown(ctx context.Context) error {
 	return a.fbr.ShutdownWithContext(ctx)
 }
 
+// @Summary		Register
+// @Description	Creates an account that logs in with the username and password of the payload. Usernames are lowercased, 3 to 32 letters, digits, dots, dashes and underscores. Passwords are 8 to 128 characters and are stored as argon2id hashes.
+// @Tags			Auth
+// @Accept			json
+// @Produce		json
+// @Param			body	body		Register						true	"Payload"
+// @Success		201		{object}	request.Response{data=Account}	"Success"
+// @Failure		400		{object}	request.Response				"Failed to register, the username is invalid"
+// @Failure		400		{object}	request.Response				"Failed to register, the password is too short or too long"
+// @Failure		409		{object}	request.Response				"Failed to register, the username is taken"
+// @Failure		400		{object}	request.Response				"Failed to bind JSON"
+// @Failure		500		{object}	request.Response				"Failed to process your request"
+// @Failure		503		{object}	request.Response				"Failed to process your request, too many passwords are being checked, try again later"
+// @Router			/api/auth/register [post]
+func (a *app) Register(c *fiber.Ctx) error {
+	body := new(Register)
+	return request.Handle(c, body, 15*time.Second, errs.Response,
+		func(ctx context.Context, req any) (any, error) {
+			body := req.(*Register)
+			u, err := a.auth.Register(ctx, authbus.Register{Username: body.Username, Password: body.Password})
+			c.Status(fiber.StatusCreated)
+			return toAccount(u), err
+		})
+}
+
+// @Summary		Login
+// @Description	Logs a user in with a username and password and starts a session, whose signed token authenticates the user until it expires or the user logs out. Send it as "Authorization: Bearer <token>" with the requests that create, change or delete resources. The account is locked for a while after repeated failed logins.
+// @Tags			Auth
+// @Accept			json
+// @Produce		json
+// @Param			body	body		Login							true	"Payload"
+// @Success		200		{object}	request.Response{data=Token}	"Success"
+// @Failure		401		{object}	request.Response				"Failed to log in, the username or password is wrong"
+// @Failure		423		{object}	request.Response				"Failed to log in, the account is locked after repeated failed logins"
+// @Failure		400		{object}	request.Response				"Failed to bind JSON"
+// @Failure		500		{object}	request.Response				"Failed to process your request"
+// @Failure		503		{object}	request.Response				"Failed to process your request, too many passwords are being checked, try again later"
+// @Router			/api/auth/login [post]
+func (a *app) Login(c *fiber.Ctx) error {
+	body := new(Login)
+	return request.Handle(c, body, 15*time.Second, errs.Response,
+		func(ctx context.Context, req any) (any, error) {
+			body := req.(*Login)
+			t, err := a.auth.Login(ctx, authbus.Login{Username: body.Username, Password: body.Password})
+			return toToken(t), err
+		})
+}
+
+// @Summary		Logout
+// @Description	Ends the session of the token the request is authenticated with, the token no longer authenticates anyone.
+// @Tags			Auth
+// @Produce		json
+// @Success		200	{object}	request.Response	"Success"
+// @Failure		500	{object}	request.Response	"Failed to process your request"
+// @Failure		401	{object}	request.Response	"Failed to authenticate, the token is missing, invalid or expired"
+// @Security		BearerAuth
+// @Router			/api/auth/logout [post]
+func (a *app) Logout(c *fiber.Ctx) error {
+	return request.Handle(c, nil, 15*time.Second, errs.Response,
+		func(ctx context.Context, req any) (any, error) {
+			return nil, a.auth.Logout(ctx)
+		})
+}
+
+// @Summary		Change Password
+// @Description	Changes the password of the authenticated user once the current password is confirmed, and ends every other session of the user. Wrong current passwords count towards the lockout like failed logins.
+// @Tags			Auth
+// @Accept			json
+// @Produce		json
+// @Param			body	body		ChangePassword		true	"Payload"
+// @Success		200		{object}	request.Response	"Success"
+// @Failure		401		{object}	request.Response	"Failed to log in, the username or password is wrong"
+// @Failure		423		{object}	request.Response	"Failed to log in, the account is locked after repeated failed logins"
+// @Failure		400		{object}	request.Response	"Failed to register, the password is too short or too long"
+// @Failure		400		{object}	request.Response	"Failed to bind JSON"
+// @Failure		500		{object}	request.Response	"Failed to process your request"
+// @Failure		503		{object}	request.Response	"Failed to process your request, too many passwords are being checked, try again later"
+// @Failure		401		{object}	request.Response	"Failed to authenticate, the token is missing, invalid or expired"
+// @Security		BearerAuth
+// @Router			/api/auth/password [post]
+func (a *app) ChangePassword(c *fiber.Ctx) error {
+	body := new(ChangePassword)
+	return request.Handle(c, body, 15*time.Second, errs.Response,
+		func(ctx context.Context, req any) (any, error) {
+			body := req.(*ChangePassword)
+			return nil, a.auth.ChangePassword(ctx, authbus.ChangePassword{
+				CurrentPassword: body.CurrentPassword,
+				NewPassword:     body.NewPassword,
+			})
+		})
+}
+
+// @Summary		Create API Key
+// @Description	Creates an API key that acts as the authenticated user within its scopes, for machine clients like publishing pipelines. Send it as "Authorization: ApiKey <key>". The scopes are posts:read, posts:write, posts:publish, taxonomy:write, comments:write, reactions:write, comments:moderate and admin, a key may never do more than the role of its user. The key is only in this response, only its hash is stored. API keys cannot manage API keys.
+// @Tags			Auth
+// @Accept			json
+// @Produce		json
+// @Param			body	body		CreateAPIKey						true	"Payload"
+// @Success		201		{object}	request.Response{data=IssuedAPIKey}	"Success"
+// @Failure		400		{object}	request.Response					"Failed to create the API key, want a name of 1 to 64 characters and known scopes"
+// @Failure		400		{object}	request.Response					"Failed to bind JSON"
+// @Failure		500		{object}	request.Response					"Failed to process your request"
+// @Failure		401		{object}	request.Response					"Failed to authenticate, the token is missing, invalid or expired"
+// @Failure		403		{object}	request.Response					"Forbidden, the API key is not scoped for this"
+// @Security		BearerAuth
+// @Router			/api/auth/keys [post]
+func (a *app) CreateAPIKey(c *fiber.Ctx) error {
+	body := new(CreateAPIKey)
+	return request.Handle(c, body, 15*time.Second, errs.Response,
+		func(ctx context.Context, req any) (any, error) {
+			body := req.(*CreateAPIKey)
+			scopes := make([]authbus.Scope, len(body.Scopes))
+			for i, s := range body.Scopes {
+				scopes[i] = authbus.Scope(s)
+			}
+
+			k, err := a.auth.CreateAPIKey(ctx, authbus.CreateAPIKey{Name: body.Name, Scopes: scopes})
+			c.Status(fiber.StatusCreated)
+			return toIssuedAPIKey(k), err
+		})
+}
+
+// @Summary		API Keys
+// @Description	Lists the API keys of the authenticated user, or of every user to an admin, oldest first. Revoked keys are listed too.
+// @Tags			Auth
+// @Produce		json
+// @Success		200	{object}	request.Response{data=[]APIKey}	"Success"
+// @Failure		500	{object}	request.Response				"Failed to process your request"
+// @Failure		401	{object}	request.Response				"Failed to authenticate, the token is missing, invalid or expired"
+// @Failure		403	{object}	request.Response				"Forbidden, the API key is not scoped for this"
+// @Security		BearerAuth
+// @Router			/api/auth/keys [get]
+func (a *app) APIKeys(c *fiber.Ctx) error {
+	return request.Handle(c, nil, 15*time.Second, errs.Response,
+		func(ctx context.Context, req any) (any, error) {
+			ks, err := a.auth.APIKeys(ctx)
+			return toAPIKeys(ks), err
+		})
+}
+
+// @Summary		Revoke API Key
+// @Description	Revokes an API key of the authenticated user, or of any user by an admin. The key no longer authenticates.
+// @Tags			Auth
+// @Produce		json
+// @Param			id	path		string							true	"API Key ID"
+// @Success		200	{object}	request.Response{data=APIKey}	"Success"
+// @Failure		404	{object}	request.Response				"Referenced API key does not exist"
+// @Failure		409	{object}	request.Response				"The API key is revoked"
+// @Failure		400	{object}	request.Response				"Failed to bind path param"
+// @Failure		500	{object}	request.Response				"Failed to process your request"
+// @Failure		401	{object}	request.Response				"Failed to authenticate, the token is missing, invalid or expired"
+// @Failure		403	{object}	request.Response				"Forbidden, the API key is not scoped for this"
+// @Security		BearerAuth
+// @Router			/api/auth/keys/{id} [delete]
+func (a *app) RevokeAPIKey(c *fiber.Ctx) error {
+	body := new(APIKeyID)
+	return request.Handle(c, body, 15*time.Second, errs.Response,
+		func(ctx context.Context, req any) (any, error) {
+			body := req.(*APIKeyID)
+			k, err := a.auth.RevokeAPIKey(ctx, body.ID)
+			return toAPIKey(k), err
+		})
+}
+
+// @Summary		Rotate API Key
+// @Description	Replaces the secret of an API key, keeping its ID, name and scopes. The old key no longer authenticates, the new one is only in this response.
+// @Tags			Auth
+// @Produce		json
+// @Param			id	path		string								true	"API Key ID"
+// @Success		200	{object}	request.Response{data=IssuedAPIKey}	"Success"
+// @Failure		404	{object}	request.Response					"Referenced API key does not exist"
+// @Failure		409	{object}	request.Response					"The API key is revoked"
+// @Failure		400	{object}	request.Response					"Failed to bind path param"
+// @Failure		500	{object}	request.Response					"Failed to process your request"
+// @Failure		401	{object}	request.Response					"Failed to authenticate, the token is missing, invalid or expired"
+// @Failure		403	{object}	request.Response					"Forbidden, the API key is not scoped for this"
+// @Security		BearerAuth
+// @Router			/api/auth/keys/{id}/rotate [post]
+func (a *app) RotateAPIKey(c *fiber.Ctx) error {
+	body := new(APIKeyID)
+	return request.Handle(c, body, 15*time.Second, errs.Response,
+		func(ctx context.Context, req any) (any, error) {
+			body := req.(*APIKeyID)
+			k, err := a.auth.RotateAPIKey(ctx, body.ID)
+			return toIssuedAPIKey(k), err
+		})
+}
+
 // @Summary		Add Blog Post
-// @Description	Creates a new Blog Post entry to the system and returns the Blog Post's ID.
+// @Description	Creates a new Blog Post entry to the system and returns the Blog Post's ID. The post is named by the slug of the payload, or else by a unique slug generated from its title.
 // @Tags			Blog Post
 // @Accept			json
 // @Produce		json
 // @Param			body	body		AddBlogPost							true	"Payload"
 // @Success		201		{object}	request.Response{data=BlogPostID}	"Success"
-// @Failure		422		{object}	request.Response					"Failed to save, blog storage capacity reached"
+// @Failure		400		{object}	request.Response					"Failed to save, the slug must be lower-case letters and digits in words joined by hyphens"
+// @Failure		409		{object}	request.Response					"Failed to save, the slug is taken by another post"
+// @Failure		422		{object}	request.Response					"Failed to save, blog storage capacity or the quota of the blog reached"
 // @Failure		404		{object}	request.Response					"Referenced resource does not found in the system"
 // @Failure		400		{object}	request.Response					"Failed to bind JSON"
 // @Failure		400		{object}	request.Response					"Failed to bind query"
 // @Failure		400		{object}	request.Response					"Failed to bind path param"
 // @Failure		500		{object}	request.Response					"Failed to process your request"
+// @Failure		401		{object}	request.Response					"Failed to authenticate, the token is missing, invalid or expired"
+// @Failure		403		{object}	request.Response					"Forbidden, the role of the user does not allow this"
+// @Security		BearerAuth
 // @Router			/api/blog-post [post]
 func (a *app) AddBlogPost(c *fiber.Ctx) error {
 	body := new(AddBlogPost)
@@ -69,28 +279,77 @@ func (a *app) AddBlogPost(c *fiber.Ctx) error {
 }
 
 // @Summary		Blog Posts
-// @Description	Retrieves all the available Blog Posts.
+// @Description	Retrieves a page of the Blog Posts matching the filters, ordered by ID unless sorted otherwise. Pass the next_cursor of a page as cursor to retrieve the following one with the same filters and order.
 // @Tags			Blog Post
 // @Accept			json
 // @Produce		json
-// @Success		200	{object}	request.Response{data=[]BlogPost}	"Success"
-// @Failure		422	{object}	request.Response					"Failed to save, blog storage capacity reached"
-// @Failure		404	{object}	request.Response					"Referenced resource does not found in the system"
-// @Failure		400	{object}	request.Response					"Failed to bind JSON"
-// @Failure		400	{object}	request.Response					"Failed to bind query"
-// @Failure		400	{object}	request.Response					"Failed to bind path param"
-// @Failure		500	{object}	request.Response					"Failed to process your request"
+// @Param			limit			query		int									false	"Page size, 20 by default and at most 100"
+// @Param			cursor			query		string								false	"Cursor of the page, the first page when empty"
+// @Param			created_from	query		string								false	"Created at or after, RFC 3339"
+// @Param			created_to		query		string								false	"Created before, RFC 3339"
+// @Param			updated_from	query		string								false	"Updated at or after, RFC 3339"
+// @Param			updated_to		query		string								false	"Updated before, RFC 3339"
+// @Param			title_prefix	query		string								false	"Title starts with, case-sensitive"
+// @Param			title_contains	query		string								false	"Title contains, case-sensitive"
+// @Param			status			query		string								false	"Status, published by default, the others to their authors and editors"	Enums(draft, published, archived)
+// @Param			tag				query		string								false	"Tag slug"
+// @Param			category		query		string								false	"Category slug, subcategories included"
+// @Param			author			query		int									false	"Author ID"
+// @Param			sort			query		string								false	"Sort field"	Enums(id, created_at, updated_at, title)
+// @Param			order			query		string								false	"Sort order"	Enums(asc, desc)
+// @Success		200				{object}	request.Response{data=[]BlogPost}	"Success"
+// @Failure		400				{object}	request.Response					"Failed to list, the limit is out of range"
+// @Failure		400				{object}	request.Response					"Failed to list, the cursor is invalid"
+// @Failure		400				{object}	request.Response					"Failed to list, the query is invalid"
+// @Failure		400				{object}	request.Response					"Failed to bind query"
+// @Failure		401				{object}	request.Response					"Failed to authenticate, the token is missing, invalid or expired"
+// @Failure		403				{object}	request.Response					"Forbidden, the role of the user does not allow this"
+// @Failure		500				{object}	request.Response					"Failed to process your request"
+// @Security		BearerAuth
 // @Router			/api/blog-post [get]
 func (a *app) BlogPosts(c *fiber.Ctx) error {
-	return request.Handle(c, nil, 15*time.Second, errs.Response,
+	query := new(BlogPostsQuery)
+	return request.Handle(c, query, 15*time.Second, errs.Response,
 		func(ctx context.Context, req any) (any, error) {
-			bps := a.business.BlogPosts(ctx)
-			return toBlogPosts(bps), nil
+			query := req.(*BlogPostsQuery)
+
+			q, err := toBusQuery(query)
+			if err != nil {
+				return nil, err
+			}
+
+			bpp, err := a.business.BlogPosts(ctx, q, toBusPage(query))
+			return toPaginated(bpp), err
+		})
+}
+
+// @Summary		Search Blog Posts
+// @Description	Retrieves a page of the published Blog Posts matching any word of the search in their title, description or body, the most relevant first. Words are matched regardless of case and inflection, and common words like "the" are ignored. Each result carries snippets of its matching fields with the matched words wrapped in <mark> tags. Pass the next_cursor of a page as cursor to retrieve the following one of the same search.
+// @Tags			Blog Post
+// @Accept			json
+// @Produce		json
+// @Param			q		query		string								true	"Search"
+// @Param			limit	query		int									false	"Page size, 20 by default and at most 100"
+// @Param			cursor	query		string								false	"Cursor of the page, the first page when empty"
+// @Success		200		{object}	request.Response{data=[]SearchHit}	"Success"
+// @Failure		400		{object}	request.Response					"Failed to search, the query is empty"
+// @Failure		400		{object}	request.Response					"Failed to list, the limit is out of range"
+// @Failure		400		{object}	request.Response					"Failed to list, the cursor is invalid"
+// @Failure		400		{object}	request.Response					"Failed to bind query"
+// @Failure		500		{object}	request.Response					"Failed to process your request"
+// @Router			/api/blog-post/search [get]
+func (a *app) SearchBlogPosts(c *fiber.Ctx) error {
+	query := new(SearchQuery)
+	return request.Handle(c, query, 15*time.Second, errs.Response,
+		func(ctx context.Context, req any) (any, error) {
+			query := req.(*SearchQuery)
+			sp, err := a.business.SearchBlogPosts(ctx, query.Q, blogbus.Page{Limit: query.Limit, Cursor: query.Cursor})
+			return toSearchPaginated(sp), err
 		})
 }
 
 // @Summary		Blog Post
-// @Description	Retrieves single Blog Post belongs to the provided ID.
+// @Description	Retrieves single Blog Post belongs to the provided ID, with the number of readers who reacted with each reaction.
 // @Tags			Blog Post
 // @Accept			json
 // @Produce		json
@@ -113,8 +372,35 @@ func (a *app) BlogPost(c *fiber.Ctx) error {
 		})
 }
 
+// @Summary		Blog Post by Slug
+// @Description	Retrieves the Blog Post named by the slug. An old slug of the post redirects to its current slug with 301 Moved Permanently, the post is sent along.
+// @Tags			Blog Post
+// @Accept			json
+// @Produce		json
+// @Param			slug	path		string							true	"Blog Post slug"
+// @Success		200		{object}	request.Response{data=BlogPost}	"Success"
+// @Success		301		{object}	request.Response{data=BlogPost}	"Moved to the current slug of the post"
+// @Failure		404		{object}	request.Response				"Referenced resource does not found in the system"
+// @Failure		404		{object}	request.Response				"Referenced resource is in the trash"
+// @Failure		400		{object}	request.Response				"Failed to bind path param"
+// @Failure		500		{object}	request.Response				"Failed to process your request"
+// @Router			/api/blog-post/by-slug/{slug} [get]
+func (a *app) BlogPostBySlug(c *fiber.Ctx) error {
+	body := new(BlogPostSlug)
+	return request.Handle(c, body, 15*time.Second, errs.Response,
+		func(ctx context.Context, req any) (any, error) {
+			body := req.(*BlogPostSlug)
+			bp, err := a.business.BlogPostBySlug(ctx, body.Slug)
+			if err == nil && bp.Slug != body.Slug {
+				c.Location(a.base(c) + slugPath + bp.Slug)
+				c.Status(fiber.StatusMovedPermanently)
+			}
+			return toBlogPost(bp), err
+		})
+}
+
 // @Summary		Delete Blog Post
-// @Description	Deletes Blog Post in the given ID.
+// @Description	Moves Blog Post in the given ID to the trash, which hides it until it is restored or purged.
 // @Tags			Blog Post
 // @Accept			json
 // @Produce		json
@@ -126,6 +412,10 @@ func (a *app) BlogPost(c *fiber.Ctx) error {
 // @Failure		400	{object}	request.Response					"Failed to bind query"
 // @Failure		400	{object}	request.Response					"Failed to bind path param"
 // @Failure		500	{object}	request.Response					"Failed to process your request"
+// @Failure		401	{object}	request.Response					"Failed to authenticate, the token is missing, invalid or expired"
+// @Failure		403	{object}	request.Response					"Forbidden, the role of the user does not allow this"
+// @Failure		403	{object}	request.Response					"Forbidden, authors can only change their own posts"
+// @Security		BearerAuth
 // @Router			/api/blog-post/{id} [delete]
 func (a *app) DeleteBlogPost(c *fiber.Ctx) error {
 	body := new(BlogPostID)
@@ -136,20 +426,101 @@ func (a *app) DeleteBlogPost(c *fiber.Ctx) error {
 		})
 }
 
+// @Summary		Trashed Blog Posts
+// @Description	Retrieves a page of the Blog Posts in the trash, ordered by ID. Pass the next_cursor of a page as cursor to retrieve the following one.
+// @Tags			Trash
+// @Accept			json
+// @Produce		json
+// @Param			limit	query		int									false	"Page size, 20 by default and at most 100"
+// @Param			cursor	query		string								false	"Cursor of the page, the first page when empty"
+// @Success		200		{object}	request.Response{data=[]BlogPost}	"Success"
+// @Failure		400		{object}	request.Response					"Failed to list, the limit is out of range"
+// @Failure		400		{object}	request.Response					"Failed to list, the cursor is invalid"
+// @Failure		400		{object}	request.Response					"Failed to bind query"
+// @Failure		500		{object}	request.Response					"Failed to process your request"
+// @Failure		401		{object}	request.Response					"Failed to authenticate, the token is missing, invalid or expired"
+// @Failure		403		{object}	request.Response					"Forbidden, the role of the user does not allow this"
+// @Security		BearerAuth
+// @Router			/api/blog-post/trash [get]
+func (a *app) TrashedBlogPosts(c *fiber.Ctx) error {
+	query := new(PageQuery)
+	return request.Handle(c, query, 15*time.Second, errs.Response,
+		func(ctx context.Context, req any) (any, error) {
+			query := req.(*PageQuery)
+			page := blogbus.Page{Limit: query.Limit, Cursor: query.Cursor}
+			bpp, err := a.business.BlogPosts(ctx, blogbus.Query{Trashed: true}, page)
+			return toPaginated(bpp), err
+		})
+}
+
+// @Summary		Restore Blog Post
+// @Description	Moves a Blog Post out of the trash.
+// @Tags			Trash
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int									true	"Blog Post ID"
+// @Success		200	{object}	request.Response{data=BlogPostID}	"Success"
+// @Failure		404	{object}	request.Response					"Referenced resource does not found in the system"
+// @Failure		404	{object}	request.Response					"Referenced resource is not in the trash"
+// @Failure		400	{object}	request.Response					"Failed to bind path param"
+// @Failure		500	{object}	request.Response					"Failed to process your request"
+// @Failure		401	{object}	request.Response					"Failed to authenticate, the token is missing, invalid or expired"
+// @Failure		403	{object}	request.Response					"Forbidden, the role of the user does not allow this"
+// @Security		BearerAuth
+// @Router			/api/blog-post/trash/{id}/restore [post]
+func (a *app) RestoreBlogPost(c *fiber.Ctx) error {
+	body := new(BlogPostID)
+	return request.Handle(c, body, 15*time.Second, errs.Response,
+		func(ctx context.Context, req any) (any, error) {
+			body := req.(*BlogPostID)
+			return a.business.RestoreBlogPost(ctx, body.ID)
+		})
+}
+
+// @Summary		Purge Blog Post
+// @Description	Permanently deletes a Blog Post in the trash.
+// @Tags			Trash
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int									true	"Blog Post ID"
+// @Success		200	{object}	request.Response{data=BlogPostID}	"Success"
+// @Failure		404	{object}	request.Response					"Referenced resource does not found in the system"
+// @Failure		404	{object}	request.Response					"Referenced resource is not in the trash"
+// @Failure		400	{object}	request.Response					"Failed to bind path param"
+// @Failure		500	{object}	request.Response					"Failed to process your request"
+// @Failure		401	{object}	request.Response					"Failed to authenticate, the token is missing, invalid or expired"
+// @Failure		403	{object}	request.Response					"Forbidden, the role of the user does not allow this"
+// @Security		BearerAuth
+// @Router			/api/blog-post/trash/{id} [delete]
+func (a *app) PurgeBlogPost(c *fiber.Ctx) error {
+	body := new(BlogPostID)
+	return request.Handle(c, body, 15*time.Second, errs.Response,
+		func(ctx context.Context, req any) (any, error) {
+			body := req.(*BlogPostID)
+			return a.business.PurgeBlogPost(ctx, body.ID)
+		})
+}
+
 // @Summary		Update Blog Post
-// @Description	Updates Blog Post with the given data.
+// @Description	Updates Blog Post with the given data. The post moves to the slug of the payload, and a slug generated from its title follows a new title. The slugs the post had before keep leading to it.
 // @Tags			Blog Post
 // @Accept			json
 // @Produce		json
 // @Param			id		path		int									true	"Blog Post ID"
 // @Param			body	body		UpdateBlogPost						true	"Payload"
 // @Success		200		{object}	request.Response{data=BlogPostID}	"Success"
+// @Failure		400		{object}	request.Response					"Failed to save, the slug must be lower-case letters and digits in words joined by hyphens"
+// @Failure		409		{object}	request.Response					"Failed to save, the slug is taken by another post"
 // @Failure		422		{object}	request.Response					"Failed to save, blog storage capacity reached"
 // @Failure		404		{object}	request.Response					"Referenced resource does not found in the system"
 // @Failure		400		{object}	request.Response					"Failed to bind JSON"
 // @Failure		400		{object}	request.Response					"Failed to bind query"
 // @Failure		400		{object}	request.Response					"Failed to bind path param"
 // @Failure		500		{object}	request.Response					"Failed to process your request"
+// @Failure		401		{object}	request.Response					"Failed to authenticate, the token is missing, invalid or expired"
+// @Failure		403		{object}	request.Response					"Forbidden, the role of the user does not allow this"
+// @Failure		403		{object}	request.Response					"Forbidden, authors can only change their own posts"
+// @Security		BearerAuth
 // @Router			/api/blog-post/{id} [patch]
 func (a *app) UpdateBlogPost(c *fiber.Ctx) error {
 	body := new(UpdateBlogPost)
@@ -159,3 +530,1034 @@ func (a *app) UpdateBlogPost(c *fiber.Ctx) error {
 			return a.business.UpdateBlogPost(ctx, body.ID, toBusUpdateBlogPost(body))
 		})
 }
+
+// @Summary		Blog Post Revisions
+// @Description	Retrieves the revisions of a Blog Post, oldest first. Revision 1 is the content the post was created with and every update records the next one.
+// @Tags			Blog Post
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int											true	"Blog Post ID"
+// @Success		200	{object}	request.Response{data=[]RevisionSummary}	"Success"
+// @Failure		404	{object}	request.Response							"Referenced resource does not found in the system"
+// @Failure		400	{object}	request.Response							"Failed to bind path param"
+// @Failure		500	{object}	request.Response							"Failed to process your request"
+// @Router			/api/blog-post/{id}/revisions [get]
+func (a *app) BlogPostRevisions(c *fiber.Ctx) error {
+	body := new(BlogPostID)
+	return request.Handle(c, body, 15*time.Second, errs.Response,
+		func(ctx context.Context, req any) (any, error) {
+			body := req.(*BlogPostID)
+			revs, err := a.business.BlogPostRevisions(ctx, body.ID)
+			return toRevisionSummaries(revs), err
+		})
+}
+
+// @Summary		Blog Post Revision
+// @Description	Retrieves the content of a Blog Post at one of its revisions.
+// @Tags			Blog Post
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int								true	"Blog Post ID"
+// @Param			number	path		int								true	"Revision number"
+// @Success		200		{object}	request.Response{data=Revision}	"Success"
+// @Failure		404		{object}	request.Response				"Referenced resource does not found in the system"
+// @Failure		404		{object}	request.Response				"Referenced revision does not exist for the Blog Post"
+// @Failure		400		{object}	request.Response				"Failed to bind path param"
+// @Failure		500		{object}	request.Response				"Failed to process your request"
+// @Router			/api/blog-post/{id}/revisions/{number} [get]
+func (a *app) BlogPostRevision(c *fiber.Ctx) error {
+	body := new(RevisionID)
+	return request.Handle(c, body, 15*time.Second, errs.Response,
+		func(ctx context.Context, req any) (any, error) {
+			body := req.(*RevisionID)
+			rev, err := a.business.BlogPostRevision(ctx, body.ID, body.Number)
+			return toRevision(rev), err
+		})
+}
+
+// @Summary		Diff Blog Post Revisions
+// @Description	Compares two revisions of a Blog Post line by line, for every field that differs between them.
+// @Tags			Blog Post
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int									true	"Blog Post ID"
+// @Param			from	query		int									true	"Revision compared from"
+// @Param			to		query		int									true	"Revision compared to"
+// @Success		200		{object}	request.Response{data=[]FieldDiff}	"Success"
+// @Failure		404		{object}	request.Response					"Referenced resource does not found in the system"
+// @Failure		404		{object}	request.Response					"Referenced revision does not exist for the Blog Post"
+// @Failure		400		{object}	request.Response					"Failed to bind query"
+// @Failure		400		{object}	request.Response					"Failed to bind path param"
+// @Failure		500		{object}	request.Response					"Failed to process your request"
+// @Router			/api/blog-post/{id}/revisions/diff [get]
+func (a *app) DiffBlogPostRevisions(c *fiber.Ctx) error {
+	query := new(DiffQuery)
+	return request.Handle(c, query, 15*time.Second, errs.Response,
+		func(ctx context.Context, req any) (any, error) {
+			query := req.(*DiffQuery)
+			fds, err := a.business.DiffBlogPostRevisions(ctx, query.ID, query.From, query.To)
+			return toFieldDiffs(fds), err
+		})
+}
+
+// @Summary		Restore Blog Post Revision
+// @Description	Updates a Blog Post back to the content of one of its revisions. The restore is recorded as a new revision, the revisions after the restored one are kept.
+// @Tags			Blog Post
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int									true	"Blog Post ID"
+// @Param			number	path		int									true	"Revision number"
+// @Success		200		{object}	request.Response{data=BlogPostID}	"Success"
+// @Failure		404		{object}	request.Response					"Referenced resource does not found in the system"
+// @Failure		404		{object}	request.Response					"Referenced revision does not exist for the Blog Post"
+// @Failure		400		{object}	request.Response					"Failed to bind path param"
+// @Failure		500		{object}	request.Response					"Failed to process your request"
+// @Failure		401		{object}	request.Response					"Failed to authenticate, the token is missing, invalid or expired"
+// @Failure		403		{object}	request.Response					"Forbidden, the role of the user does not allow this"
+// @Failure		403		{object}	request.Response					"Forbidden, authors can only change their own posts"
+// @Security		BearerAuth
+// @Router			/api/blog-post/{id}/revisions/{number}/restore [post]
+func (a *app) RestoreBlogPostRevision(c *fiber.Ctx) error {
+	body := new(RevisionID)
+	return request.Handle(c, body, 15*time.Second, errs.Response,
+		func(ctx context.Context, req any) (any, error) {
+			body := req.(*RevisionID)
+			return a.business.RestoreBlogPostRevision(ctx, body.ID, body.Number)
+		})
+}
+
+// @Summary		Publish Blog Post
+// @Description	Publishes a draft Blog Post, making it visible to readers.
+// @Tags			Blog Post
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int									true	"Blog Post ID"
+// @Success		200	{object}	request.Response{data=BlogPostID}	"Success"
+// @Failure		409	{object}	request.Response					"Failed to change the status, the post is not in a status it can change from"
+// @Failure		404	{object}	request.Response					"Referenced resource does not found in the system"
+// @Failure		400	{object}	request.Response					"Failed to bind path param"
+// @Failure		500	{object}	request.Response					"Failed to process your request"
+// @Failure		401	{object}	request.Response					"Failed to authenticate, the token is missing, invalid or expired"
+// @Failure		403	{object}	request.Response					"Forbidden, the role of the user does not allow this"
+// @Security		BearerAuth
+// @Router			/api/blog-post/{id}/publish [post]
+func (a *app) PublishBlogPost(c *fiber.Ctx) error {
+	body := new(BlogPostID)
+	return request.Handle(c, body, 15*time.Second, errs.Response,
+		func(ctx context.Context, req any) (any, error) {
+			body := req.(*BlogPostID)
+			return a.business.PublishBlogPost(ctx, body.ID)
+		})
+}
+
+// @Summary		Unpublish Blog Post
+// @Description	Turns a published Blog Post back into a draft.
+// @Tags			Blog Post
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int									true	"Blog Post ID"
+// @Success		200	{object}	request.Response{data=BlogPostID}	"Success"
+// @Failure		409	{object}	request.Response					"Failed to change the status, the post is not in a status it can change from"
+// @Failure		404	{object}	request.Response					"Referenced resource does not found in the system"
+// @Failure		400	{object}	request.Response					"Failed to bind path param"
+// @Failure		500	{object}	request.Response					"Failed to process your request"
+// @Failure		401	{object}	request.Response					"Failed to authenticate, the token is missing, invalid or expired"
+// @Failure		403	{object}	request.Response					"Forbidden, the role of the user does not allow this"
+// @Security		BearerAuth
+// @Router			/api/blog-post/{id}/unpublish [post]
+func (a *app) UnpublishBlogPost(c *fiber.Ctx) error {
+	body := new(BlogPostID)
+	return request.Handle(c, body, 15*time.Second, errs.Response,
+		func(ctx context.Context, req any) (any, error) {
+			body := req.(*BlogPostID)
+			return a.business.UnpublishBlogPost(ctx, body.ID)
+		})
+}
+
+// @Summary		Archive Blog Post
+// @Description	Archives a published Blog Post, removing it from the published listing.
+// @Tags			Blog Post
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int									true	"Blog Post ID"
+// @Success		200	{object}	request.Response{data=BlogPostID}	"Success"
+// @Failure		409	{object}	request.Response					"Failed to change the status, the post is not in a status it can change from"
+// @Failure		404	{object}	request.Response					"Referenced resource does not found in the system"
+// @Failure		400	{object}	request.Response					"Failed to bind path param"
+// @Failure		500	{object}	request.Response					"Failed to process your request"
+// @Failure		401	{object}	request.Response					"Failed to authenticate, the token is missing, invalid or expired"
+// @Failure		403	{object}	request.Response					"Forbidden, the role of the user does not allow this"
+// @Security		BearerAuth
+// @Router			/api/blog-post/{id}/archive [post]
+func (a *app) ArchiveBlogPost(c *fiber.Ctx) error {
+	body := new(BlogPostID)
+	return request.Handle(c, body, 15*time.Second, errs.Response,
+		func(ctx context.Context, req any) (any, error) {
+			body := req.(*BlogPostID)
+			return a.business.ArchiveBlogPost(ctx, body.ID)
+		})
+}
+
+// @Summary		Schedule Blog Post
+// @Description	Schedules a draft Blog Post to be published at a future time, replacing its previous schedule.
+// @Tags			Blog Post
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int									true	"Blog Post ID"
+// @Param			body	body		ScheduleBlogPost					true	"Payload"
+// @Success		200		{object}	request.Response{data=BlogPostID}	"Success"
+// @Failure		409		{object}	request.Response					"Failed to change the status, the post is not in a status it can change from"
+// @Failure		404		{object}	request.Response					"Referenced resource does not found in the system"
+// @Failure		400		{object}	request.Response					"Failed to schedule, the publish time is not in the future"
+// @Failure		400		{object}	request.Response					"Failed to bind JSON"
+// @Failure		400		{object}	request.Response					"Failed to bind path param"
+// @Failure		500		{object}	request.Response					"Failed to process your request"
+// @Failure		401		{object}	request.Response					"Failed to authenticate, the token is missing, invalid or expired"
+// @Failure		403		{object}	request.Response					"Forbidden, the role of the user does not allow this"
+// @Security		BearerAuth
+// @Router			/api/blog-post/{id}/schedule [post]
+func (a *app) ScheduleBlogPost(c *fiber.Ctx) error {
+	body := new(ScheduleBlogPost)
+	return request.Handle(c, body, 15*time.Second, errs.Response,
+		func(ctx context.Context, req any) (any, error) {
+			body := req.(*ScheduleBlogPost)
+			return a.business.ScheduleBlogPost(ctx, body.ID, body.PublishAt)
+		})
+}
+
+// @Summary		Unschedule Blog Post
+// @Description	Clears the schedule of a draft Blog Post so it is not published.
+// @Tags			Blog Post
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int									true	"Blog Post ID"
+// @Success		200	{object}	request.Response{data=BlogPostID}	"Success"
+// @Failure		409	{object}	request.Response					"Failed to change the status, the post is not in a status it can change from"
+// @Failure		404	{object}	request.Response					"Referenced resource does not found in the system"
+// @Failure		400	{object}	request.Response					"Failed to bind path param"
+// @Failure		500	{object}	request.Response					"Failed to process your request"
+// @Failure		401	{object}	request.Response					"Failed to authenticate, the token is missing, invalid or expired"
+// @Failure		403	{object}	request.Response					"Forbidden, the role of the user does not allow this"
+// @Security		BearerAuth
+// @Router			/api/blog-post/{id}/unschedule [post]
+func (a *app) UnscheduleBlogPost(c *fiber.Ctx) error {
+	body := new(BlogPostID)
+	return request.Handle(c, body, 15*time.Second, errs.Response,
+		func(ctx context.Context, req any) (any, error) {
+			body := req.(*BlogPostID)
+			return a.business.UnscheduleBlogPost(ctx, body.ID)
+		})
+}
+
+// @Summary		React
+// @Description	Adds the reaction of the user to a Blog Post, replacing the one the user had. Reacting again with the same reaction changes nothing. Responds with the number of readers who reacted with each reaction.
+// @Tags			Reaction
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int										true	"Blog Post ID"
+// @Param			body	body		AddReaction								true	"Payload"
+// @Success		200		{object}	request.Response{data=map[string]int}	"Success"
+// @Failure		400		{object}	request.Response						"Failed to react, the reaction is unknown"
+// @Failure		404		{object}	request.Response						"Referenced resource does not found in the system"
+// @Failure		404		{object}	request.Response						"Referenced resource is in the trash"
+// @Failure		400		{object}	request.Response						"Failed to bind JSON"
+// @Failure		400		{object}	request.Response						"Failed to bind path param"
+// @Failure		401		{object}	request.Response						"Failed to authenticate, the token is missing, invalid or expired"
+// @Failure		500		{object}	request.Response						"Failed to process your request"
+// @Security		BearerAuth
+// @Router			/api/blog-post/{id}/reactions [put]
+func (a *app) React(c *fiber.Ctx) error {
+	body := new(AddReaction)
+	return request.Handle(c, body, 15*time.Second, errs.Response,
+		func(ctx context.Context, req any) (any, error) {
+			body := req.(*AddReaction)
+			rc, err := a.business.React(ctx, body.ID, blogbus.AddReaction{
+				Reaction: blogbus.Reaction(body.Reaction),
+			})
+			return toReactions(rc), err
+		})
+}
+
+// @Summary		Unreact
+// @Description	Removes the reaction of the user to a Blog Post, removing a reaction the user does not have changes nothing. Responds with the number of readers who reacted with each reaction.
+// @Tags			Reaction
+// @Produce		json
+// @Param			id		path		int										true	"Blog Post ID"
+// @Success		200		{object}	request.Response{data=map[string]int}	"Success"
+// @Failure		404		{object}	request.Response						"Referenced resource does not found in the system"
+// @Failure		404		{object}	request.Response						"Referenced resource is in the trash"
+// @Failure		400		{object}	request.Response						"Failed to bind path param"
+// @Failure		401		{object}	request.Response						"Failed to authenticate, the token is missing, invalid or expired"
+// @Failure		500		{object}	request.Response						"Failed to process your request"
+// @Security		BearerAuth
+// @Router			/api/blog-post/{id}/reactions [delete]
+func (a *app) Unreact(c *fiber.Ctx) error {
+	body := new(BlogPostID)
+	return request.Handle(c, body, 15*time.Second, errs.Response,
+		func(ctx context.Context, req any) (any, error) {
+			body := req.(*BlogPostID)
+			rc, err := a.business.Unreact(ctx, body.ID)
+			return toReactions(rc), err
+		})
+}
+
+// @Summary		Add Comment
+// @Description	Adds a comment to a Blog Post, or a reply to one of its approved comments with the parent_id of the payload. The comment is held pending until a moderator approves it, or rejected when a spam classifier takes it for spam.
+// @Tags			Comment
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int								true	"Blog Post ID"
+// @Param			body	body		AddComment						true	"Payload"
+// @Success		201		{object}	request.Response{data=Comment}	"Success"
+// @Failure		400		{object}	request.Response				"Failed to save, the author or body is empty or too long"
+// @Failure		400		{object}	request.Response				"Failed to save, the comment replied to is not on the Blog Post"
+// @Failure		404		{object}	request.Response				"Referenced resource does not found in the system"
+// @Failure		404		{object}	request.Response				"Referenced resource is in the trash"
+// @Failure		400		{object}	request.Response				"Failed to bind JSON"
+// @Failure		400		{object}	request.Response				"Failed to bind path param"
+// @Failure		401		{object}	request.Response				"Failed to authenticate, the token is missing, invalid or expired"
+// @Failure		500		{object}	request.Response				"Failed to process your request"
+// @Security		BearerAuth
+// @Router			/api/blog-post/{id}/comments [post]
+func (a *app) AddComment(c *fiber.Ctx) error {
+	body := new(AddComment)
+	return request.Handle(c, body, 15*time.Second, errs.Response,
+		func(ctx context.Context, req any) (any, error) {
+			body := req.(*AddComment)
+			cm, err := a.comments.AddComment(ctx, body.ID, commentbus.AddComment{
+				ParentID: body.ParentID,
+				Author:   body.Author,
+				Body:     body.Body,
+			})
+			c.Status(fiber.StatusCreated)
+			return toComment(cm), err
+		})
+}
+
+// @Summary		Comments
+// @Description	Retrieves a page of the approved comments on a Blog Post, or of the approved replies to one of its comments with parent, oldest first. Every comment carries the number of its approved replies, which are retrieved with its ID as parent. Pass the next_cursor of a page as cursor to retrieve the following one.
+// @Tags			Comment
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int									true	"Blog Post ID"
+// @Param			parent	query		int									false	"ID of the comment whose replies are retrieved"
+// @Param			limit	query		int									false	"Page size, 20 by default and at most 100"
+// @Param			cursor	query		string								false	"Cursor of the page, the first page when empty"
+// @Success		200		{object}	request.Response{data=[]Comment}	"Success"
+// @Failure		400		{object}	request.Response					"Failed to list, the limit is out of range"
+// @Failure		400		{object}	request.Response					"Failed to list, the cursor is invalid"
+// @Failure		404		{object}	request.Response					"Referenced comment does not exist"
+// @Failure		404		{object}	request.Response					"Referenced resource does not found in the system"
+// @Failure		404		{object}	request.Response					"Referenced resource is in the trash"
+// @Failure		400		{object}	request.Response					"Failed to bind query"
+// @Failure		400		{object}	request.Response					"Failed to bind path param"
+// @Failure		500		{object}	request.Response					"Failed to process your request"
+// @Router			/api/blog-post/{id}/comments [get]
+func (a *app) Comments(c *fiber.Ctx) error {
+	query := new(CommentsQuery)
+	return request.Handle(c, query, 15*time.Second, errs.Response,
+		func(ctx context.Context, req any) (any, error) {
+			query := req.(*CommentsQuery)
+			page := commentbus.Page{Limit: query.Limit, Cursor: query.Cursor}
+			cp, err := a.comments.Comments(ctx, query.ID, query.Parent, page)
+			return toCommentPage(cp), err
+		})
+}
+
+// @Summary		Comment
+// @Description	Retrieves an approved comment on a Blog Post.
+// @Tags			Comment
+// @Produce		json
+// @Param			id		path		int								true	"Blog Post ID"
+// @Param			comment	path		int								true	"Comment ID"
+// @Success		200		{object}	request.Response{data=Comment}	"Success"
+// @Failure		404		{object}	request.Response				"Referenced comment does not exist"
+// @Failure		404		{object}	request.Response				"Referenced resource does not found in the system"
+// @Failure		404		{object}	request.Response				"Referenced resource is in the trash"
+// @Failure		400		{object}	request.Response				"Failed to bind path param"
+// @Failure		500		{object}	request.Response				"Failed to process your request"
+// @Router			/api/blog-post/{id}/comments/{comment} [get]
+func (a *app) Comment(c *fiber.Ctx) error {
+	body := new(CommentID)
+	return request.Handle(c, body, 15*time.Second, errs.Response,
+		func(ctx context.Context, req any) (any, error) {
+			body := req.(*CommentID)
+			cm, err := a.comments.Comment(ctx, body.ID, body.Comment)
+			return toComment(cm), err
+		})
+}
+
+// @Summary		Update Comment
+// @Description	Edits the body of a comment the user posted, which is only possible for a while after it was posted. Moderators edit any comment at any time. The edited comment is moderated again.
+// @Tags			Comment
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int								true	"Blog Post ID"
+// @Param			comment	path		int								true	"Comment ID"
+// @Param			body	body		UpdateComment					true	"Payload"
+// @Success		200		{object}	request.Response{data=Comment}	"Success"
+// @Failure		400		{object}	request.Response				"Failed to save, the author or body is empty or too long"
+// @Failure		404		{object}	request.Response				"Referenced comment does not exist"
+// @Failure		404		{object}	request.Response				"Referenced resource does not found in the system"
+// @Failure		404		{object}	request.Response				"Referenced resource is in the trash"
+// @Failure		400		{object}	request.Response				"Failed to bind JSON"
+// @Failure		400		{object}	request.Response				"Failed to bind path param"
+// @Failure		500		{object}	request.Response				"Failed to process your request"
+// @Failure		401		{object}	request.Response				"Failed to authenticate, the token is missing, invalid or expired"
+// @Failure		403		{object}	request.Response				"Forbidden, the comment was posted by another user or its edit window has passed"
+// @Security		BearerAuth
+// @Router			/api/blog-post/{id}/comments/{comment} [patch]
+func (a *app) UpdateComment(c *fiber.Ctx) error {
+	body := new(UpdateComment)
+	return request.Handle(c, body, 15*time.Second, errs.Response,
+		func(ctx context.Context, req any) (any, error) {
+			body := req.(*UpdateComment)
+			cm, err := a.comments.UpdateComment(ctx, body.ID, body.Comment, commentbus.UpdateComment{Body: body.Body})
+			return toComment(cm), err
+		})
+}
+
+// @Summary		Delete Comment
+// @Description	Deletes a comment with every reply in its thread.
+// @Tags			Comment
+// @Produce		json
+// @Param			id		path		int									true	"Blog Post ID"
+// @Param			comment	path		int									true	"Comment ID"
+// @Success		200		{object}	request.Response{data=CommentID}	"Success"
+// @Failure		404		{object}	request.Response					"Referenced comment does not exist"
+// @Failure		404		{object}	request.Response					"Referenced resource does not found in the system"
+// @Failure		404		{object}	request.Response					"Referenced resource is in the trash"
+// @Failure		400		{object}	request.Response					"Failed to bind path param"
+// @Failure		500		{object}	request.Response					"Failed to process your request"
+// @Failure		401		{object}	request.Response					"Failed to authenticate, the token is missing, invalid or expired"
+// @Failure		403		{object}	request.Response					"Forbidden, the role of the user does not allow this"
+// @Security		BearerAuth
+// @Router			/api/blog-post/{id}/comments/{comment} [delete]
+func (a *app) DeleteComment(c *fiber.Ctx) error {
+	body := new(CommentID)
+	return request.Handle(c, body, 15*time.Second, errs.Response,
+		func(ctx context.Context, req any) (any, error) {
+			body := req.(*CommentID)
+			return body, a.comments.DeleteComment(ctx, body.ID, body.Comment)
+		})
+}
+
+// @Summary		Comment Queue
+// @Description	Retrieves a page of the comments awaiting moderators across the Blog Posts that are not in the trash, oldest first. Comments are pending by default, status selects the rejected ones to review the spam classifiers, or the approved ones. Pass the next_cursor of a page as cursor to retrieve the following one.
+// @Tags			Moderation
+// @Produce		json
+// @Param			status	query		string								false	"Status of the comments: pending by default, approved or rejected"
+// @Param			limit	query		int									false	"Page size, 20 by default and at most 100"
+// @Param			cursor	query		string								false	"Cursor of the page, the first page when empty"
+// @Success		200		{object}	request.Response{data=[]Comment}	"Success"
+// @Failure		400		{object}	request.Response					"Failed to moderate, the comment status is unknown or not a decision"
+// @Failure		400		{object}	request.Response					"Failed to list, the limit is out of range"
+// @Failure		400		{object}	request.Response					"Failed to list, the cursor is invalid"
+// @Failure		400		{object}	request.Response					"Failed to bind query"
+// @Failure		500		{object}	request.Response					"Failed to process your request"
+// @Failure		401		{object}	request.Response					"Failed to authenticate, the token is missing, invalid or expired"
+// @Failure		403		{object}	request.Response					"Forbidden, the role of the user does not allow this"
+// @Security		BearerAuth
+// @Router			/api/moderation/comments [get]
+func (a *app) CommentQueue(c *fiber.Ctx) error {
+	query := new(CommentQueueQuery)
+	return request.Handle(c, query, 15*time.Second, errs.Response,
+		func(ctx context.Context, req any) (any, error) {
+			query := req.(*CommentQueueQuery)
+			page := commentbus.Page{Limit: query.Limit, Cursor: query.Cursor}
+			cp, err := a.comments.CommentQueue(ctx, query.status(), page)
+			return toCommentPage(cp), err
+		})
+}
+
+// @Summary		Approve Comment
+// @Description	Approves a comment, which makes it public. The decision is learned by the spam classifier.
+// @Tags			Moderation
+// @Produce		json
+// @Param			id	path		int								true	"Comment ID"
+// @Success		200	{object}	request.Response{data=Comment}	"Success"
+// @Failure		404	{object}	request.Response				"Referenced comment does not exist"
+// @Failure		400	{object}	request.Response				"Failed to bind path param"
+// @Failure		500	{object}	request.Response				"Failed to process your request"
+// @Failure		401	{object}	request.Response				"Failed to authenticate, the token is missing, invalid or expired"
+// @Failure		403	{object}	request.Response				"Forbidden, the role of the user does not allow this"
+// @Security		BearerAuth
+// @Router			/api/moderation/comments/{id}/approve [post]
+func (a *app) ApproveComment(c *fiber.Ctx) error {
+	return a.moderateComment(c, commentbus.StatusApproved)
+}
+
+// @Summary		Reject Comment
+// @Description	Rejects a comment, which hides it. The decision is learned by the spam classifier.
+// @Tags			Moderation
+// @Produce		json
+// @Param			id	path		int								true	"Comment ID"
+// @Success		200	{object}	request.Response{data=Comment}	"Success"
+// @Failure		404	{object}	request.Response				"Referenced comment does not exist"
+// @Failure		400	{object}	request.Response				"Failed to bind path param"
+// @Failure		500	{object}	request.Response				"Failed to process your request"
+// @Failure		401	{object}	request.Response				"Failed to authenticate, the token is missing, invalid or expired"
+// @Failure		403	{object}	request.Response				"Forbidden, the role of the user does not allow this"
+// @Security		BearerAuth
+// @Router			/api/moderation/comments/{id}/reject [post]
+func (a *app) RejectComment(c *fiber.Ctx) error {
+	return a.moderateComment(c, commentbus.StatusRejected)
+}
+
+// moderateComment decides on the comment named by the path with status.
+func (a *app) moderateComment(c *fiber.Ctx, status commentbus.Status) error {
+	body := new(ModerateComment)
+	return request.Handle(c, body, 15*time.Second, errs.Response,
+		func(ctx context.Context, req any) (any, error) {
+			body := req.(*ModerateComment)
+			cs, err := a.comments.ModerateComments(ctx, []uint64{body.ID}, status)
+			if err != nil {
+				return nil, err
+			}
+			return toComment(cs[0]), nil
+		})
+}
+
+// @Summary		Moderate Comments
+// @Description	Approves or rejects up to 100 comments at once. None is moderated when one of them does not exist.
+// @Tags			Moderation
+// @Accept			json
+// @Produce		json
+// @Param			body	body		ModerateComments					true	"Payload"
+// @Success		200		{object}	request.Response{data=[]Comment}	"Success"
+// @Failure		400		{object}	request.Response					"Failed to moderate, the comment status is unknown or not a decision"
+// @Failure		400		{object}	request.Response					"Failed to moderate, too few or too many comments"
+// @Failure		404		{object}	request.Response					"Referenced comment does not exist"
+// @Failure		400		{object}	request.Response					"Failed to bind JSON"
+// @Failure		500		{object}	request.Response					"Failed to process your request"
+// @Failure		401		{object}	request.Response					"Failed to authenticate, the token is missing, invalid or expired"
+// @Failure		403		{object}	request.Response					"Forbidden, the role of the user does not allow this"
+// @Security		BearerAuth
+// @Router			/api/moderation/comments [post]
+func (a *app) ModerateComments(c *fiber.Ctx) error {
+	body := new(ModerateComments)
+	return request.Handle(c, body, 15*time.Second, errs.Response,
+		func(ctx context.Context, req any) (any, error) {
+			body := req.(*ModerateComments)
+			cs, err := a.comments.ModerateComments(ctx, body.IDs, commentbus.Status(body.Status))
+			return toComments(cs), err
+		})
+}
+
+// @Summary		Add Tag
+// @Description	Creates a tag posts can be labeled with. The tag is named by the slug of the payload, or else by the slug of its name.
+// @Tags			Tag
+// @Accept			json
+// @Produce		json
+// @Param			body	body		AddTag						true	"Payload"
+// @Success		201		{object}	request.Response{data=Tag}	"Success"
+// @Failure		400		{object}	request.Response			"Failed to save, the name is empty"
+// @Failure		400		{object}	request.Response			"Failed to save, the slug must be lower-case letters and digits in words joined by hyphens"
+// @Failure		409		{object}	request.Response			"Failed to save, the tag already exists"
+// @Failure		400		{object}	request.Response			"Failed to bind JSON"
+// @Failure		500		{object}	request.Response			"Failed to process your request"
+// @Failure		401		{object}	request.Response			"Failed to authenticate, the token is missing, invalid or expired"
+// @Failure		403		{object}	request.Response			"Forbidden, the role of the user does not allow this"
+// @Security		BearerAuth
+// @Router			/api/tags [post]
+func (a *app) AddTag(c *fiber.Ctx) error {
+	body := new(AddTag)
+	return request.Handle(c, body, 15*time.Second, errs.Response,
+		func(ctx context.Context, req any) (any, error) {
+			body := req.(*AddTag)
+			t, err := a.business.AddTag(ctx, blogbus.AddTag{Name: body.Name, Slug: body.Slug})
+			c.Status(fiber.StatusCreated)
+			return toTag(t), err
+		})
+}
+
+// @Summary		Tags
+// @Description	Retrieves every tag, ordered by slug.
+// @Tags			Tag
+// @Produce		json
+// @Success		200	{object}	request.Response{data=[]Tag}	"Success"
+// @Failure		500	{object}	request.Response				"Failed to process your request"
+// @Router			/api/tags [get]
+func (a *app) Tags(c *fiber.Ctx) error {
+	return request.Handle(c, nil, 15*time.Second, errs.Response,
+		func(ctx context.Context, req any) (any, error) {
+			ts, err := a.business.Tags(ctx)
+			return toTags(ts), err
+		})
+}
+
+// @Summary		Tag Cloud
+// @Description	Retrieves every tag with the number of published Blog Posts that have it, the most used first.
+// @Tags			Tag
+// @Produce		json
+// @Success		200	{object}	request.Response{data=[]TagCount}	"Success"
+// @Failure		500	{object}	request.Response					"Failed to process your request"
+// @Router			/api/tags/cloud [get]
+func (a *app) TagCloud(c *fiber.Ctx) error {
+	return request.Handle(c, nil, 15*time.Second, errs.Response,
+		func(ctx context.Context, req any) (any, error) {
+			tcs, err := a.business.TagCloud(ctx)
+			return toTagCounts(tcs), err
+		})
+}
+
+// @Summary		Tag
+// @Description	Retrieves the tag with the given slug.
+// @Tags			Tag
+// @Produce		json
+// @Param			slug	path		string						true	"Tag slug"
+// @Success		200		{object}	request.Response{data=Tag}	"Success"
+// @Failure		404		{object}	request.Response			"Referenced tag does not exist"
+// @Failure		400		{object}	request.Response			"Failed to bind path param"
+// @Failure		500		{object}	request.Response			"Failed to process your request"
+// @Router			/api/tags/{slug} [get]
+func (a *app) Tag(c *fiber.Ctx) error {
+	body := new(TermSlug)
+	return request.Handle(c, body, 15*time.Second, errs.Response,
+		func(ctx context.Context, req any) (any, error) {
+			body := req.(*TermSlug)
+			t, err := a.business.Tag(ctx, body.Slug)
+			return toTag(t), err
+		})
+}
+
+// @Summary		Update Tag
+// @Description	Renames the tag with the given slug, its slug never changes.
+// @Tags			Tag
+// @Accept			json
+// @Produce		json
+// @Param			slug	path		string						true	"Tag slug"
+// @Param			body	body		UpdateTag					true	"Payload"
+// @Success		200		{object}	request.Response{data=Tag}	"Success"
+// @Failure		400		{object}	request.Response			"Failed to save, the name is empty"
+// @Failure		404		{object}	request.Response			"Referenced tag does not exist"
+// @Failure		400		{object}	request.Response			"Failed to bind JSON"
+// @Failure		400		{object}	request.Response			"Failed to bind path param"
+// @Failure		500		{object}	request.Response			"Failed to process your request"
+// @Failure		401		{object}	request.Response			"Failed to authenticate, the token is missing, invalid or expired"
+// @Failure		403		{object}	request.Response			"Forbidden, the role of the user does not allow this"
+// @Security		BearerAuth
+// @Router			/api/tags/{slug} [patch]
+func (a *app) UpdateTag(c *fiber.Ctx) error {
+	body := new(UpdateTag)
+	return request.Handle(c, body, 15*time.Second, errs.Response,
+		func(ctx context.Context, req any) (any, error) {
+			body := req.(*UpdateTag)
+			t, err := a.business.UpdateTag(ctx, body.Slug, blogbus.UpdateTag{Name: body.Name})
+			return toTag(t), err
+		})
+}
+
+// @Summary		Delete Tag
+// @Description	Deletes the tag with the given slug. A tag is only deleted once no Blog Post has it, the posts in the trash included.
+// @Tags			Tag
+// @Produce		json
+// @Param			slug	path		string							true	"Tag slug"
+// @Success		200		{object}	request.Response{data=TermSlug}	"Success"
+// @Failure		404		{object}	request.Response				"Referenced tag does not exist"
+// @Failure		409		{object}	request.Response				"Failed to delete, Blog Posts or subcategories still refer to it"
+// @Failure		400		{object}	request.Response				"Failed to bind path param"
+// @Failure		500		{object}	request.Response				"Failed to process your request"
+// @Failure		401		{object}	request.Response				"Failed to authenticate, the token is missing, invalid or expired"
+// @Failure		403		{object}	request.Response				"Forbidden, the role of the user does not allow this"
+// @Security		BearerAuth
+// @Router			/api/tags/{slug} [delete]
+func (a *app) DeleteTag(c *fiber.Ctx) error {
+	body := new(TermSlug)
+	return request.Handle(c, body, 15*time.Second, errs.Response,
+		func(ctx context.Context, req any) (any, error) {
+			body := req.(*TermSlug)
+			return body, a.business.DeleteTag(ctx, body.Slug)
+		})
+}
+
+// @Summary		Add Category
+// @Description	Creates a category Blog Posts can be filed under, at the top level or under a parent category. The category is named by the slug of the payload, or else by the slug of its name.
+// @Tags			Category
+// @Accept			json
+// @Produce		json
+// @Param			body	body		AddCategory						true	"Payload"
+// @Success		201		{object}	request.Response{data=Category}	"Success"
+// @Failure		400		{object}	request.Response				"Failed to save, the name is empty"
+// @Failure		400		{object}	request.Response				"Failed to save, the slug must be lower-case letters and digits in words joined by hyphens"
+// @Failure		400		{object}	request.Response				"Failed to save, a referenced tag or category does not exist"
+// @Failure		409		{object}	request.Response				"Failed to save, the category already exists"
+// @Failure		400		{object}	request.Response				"Failed to bind JSON"
+// @Failure		500		{object}	request.Response				"Failed to process your request"
+// @Failure		401		{object}	request.Response				"Failed to authenticate, the token is missing, invalid or expired"
+// @Failure		403		{object}	request.Response				"Forbidden, the role of the user does not allow this"
+// @Security		BearerAuth
+// @Router			/api/categories [post]
+func (a *app) AddCategory(c *fiber.Ctx) error {
+	body := new(AddCategory)
+	return request.Handle(c, body, 15*time.Second, errs.Response,
+		func(ctx context.Context, req any) (any, error) {
+			body := req.(*AddCategory)
+			cat, err := a.business.AddCategory(ctx, blogbus.AddCategory{Name: body.Name, Slug: body.Slug, Parent: body.Parent})
+			c.Status(fiber.StatusCreated)
+			return toCategory(cat), err
+		})
+}
+
+// @Summary		Categories
+// @Description	Retrieves every category depth first, each after its parent.
+// @Tags			Category
+// @Produce		json
+// @Success		200	{object}	request.Response{data=[]Category}	"Success"
+// @Failure		500	{object}	request.Response					"Failed to process your request"
+// @Router			/api/categories [get]
+func (a *app) Categories(c *fiber.Ctx) error {
+	return request.Handle(c, nil, 15*time.Second, errs.Response,
+		func(ctx context.Context, req any) (any, error) {
+			cs, err := a.business.Categories(ctx)
+			return toCategories(cs), err
+		})
+}
+
+// @Summary		Category
+// @Description	Retrieves the category with the given slug.
+// @Tags			Category
+// @Produce		json
+// @Param			slug	path		string							true	"Category slug"
+// @Success		200		{object}	request.Response{data=Category}	"Success"
+// @Failure		404		{object}	request.Response				"Referenced category does not exist"
+// @Failure		400		{object}	request.Response				"Failed to bind path param"
+// @Failure		500		{object}	request.Response				"Failed to process your request"
+// @Router			/api/categories/{slug} [get]
+func (a *app) Category(c *fiber.Ctx) error {
+	body := new(TermSlug)
+	return request.Handle(c, body, 15*time.Second, errs.Response,
+		func(ctx context.Context, req any) (any, error) {
+			body := req.(*TermSlug)
+			cat, err := a.business.Category(ctx, body.Slug)
+			return toCategory(cat), err
+		})
+}
+
+// @Summary		Update Category
+// @Description	Renames the category with the given slug or moves it under another parent, its slug never changes.
+// @Tags			Category
+// @Accept			json
+// @Produce		json
+// @Param			slug	path		string							true	"Category slug"
+// @Param			body	body		UpdateCategory					true	"Payload"
+// @Success		200		{object}	request.Response{data=Category}	"Success"
+// @Failure		400		{object}	request.Response				"Failed to save, a referenced tag or category does not exist"
+// @Failure		400		{object}	request.Response				"Failed to save, a category cannot be moved under itself"
+// @Failure		404		{object}	request.Response				"Referenced category does not exist"
+// @Failure		400		{object}	request.Response				"Failed to bind JSON"
+// @Failure		400		{object}	request.Response				"Failed to bind path param"
+// @Failure		500		{object}	request.Response				"Failed to process your request"
+// @Failure		401		{object}	request.Response				"Failed to authenticate, the token is missing, invalid or expired"
+// @Failure		403		{object}	request.Response				"Forbidden, the role of the user does not allow this"
+// @Security		BearerAuth
+// @Router			/api/categories/{slug} [patch]
+func (a *app) UpdateCategory(c *fiber.Ctx) error {
+	body := new(UpdateCategory)
+	return request.Handle(c, body, 15*time.Second, errs.Response,
+		func(ctx context.Context, req any) (any, error) {
+			body := req.(*UpdateCategory)
+			cat, err := a.business.UpdateCategory(ctx, body.Slug, blogbus.UpdateCategory{Name: body.Name, Parent: body.Parent})
+			return toCategory(cat), err
+		})
+}
+
+// @Summary		Delete Category
+// @Description	Deletes the category with the given slug. A category is only deleted once it has no subcategories and no Blog Post is filed under it, the posts in the trash included.
+// @Tags			Category
+// @Produce		json
+// @Param			slug	path		string							true	"Category slug"
+// @Success		200		{object}	request.Response{data=TermSlug}	"Success"
+// @Failure		404		{object}	request.Response				"Referenced category does not exist"
+// @Failure		409		{object}	request.Response				"Failed to delete, Blog Posts or subcategories still refer to it"
+// @Failure		400		{object}	request.Response				"Failed to bind path param"
+// @Failure		500		{object}	request.Response				"Failed to process your request"
+// @Failure		401		{object}	request.Response				"Failed to authenticate, the token is missing, invalid or expired"
+// @Failure		403		{object}	request.Response				"Forbidden, the role of the user does not allow this"
+// @Security		BearerAuth
+// @Router			/api/categories/{slug} [delete]
+func (a *app) DeleteCategory(c *fiber.Ctx) error {
+	body := new(TermSlug)
+	return request.Handle(c, body, 15*time.Second, errs.Response,
+		func(ctx context.Context, req any) (any, error) {
+			body := req.(*TermSlug)
+			return body, a.business.DeleteCategory(ctx, body.Slug)
+		})
+}
+
+// @Summary		Add Author
+// @Description	Creates the profile of an author Blog Posts can be linked to and returns it with the ID it was issued.
+// @Tags			Author
+// @Accept			json
+// @Produce		json
+// @Param			body	body		AddAuthor						true	"Payload"
+// @Success		201		{object}	request.Response{data=Author}	"Success"
+// @Failure		400		{object}	request.Response				"Failed to save, the name is empty or the email or avatar URL is malformed"
+// @Failure		400		{object}	request.Response				"Failed to bind JSON"
+// @Failure		500		{object}	request.Response				"Failed to process your request"
+// @Failure		401		{object}	request.Response				"Failed to authenticate, the token is missing, invalid or expired"
+// @Failure		403		{object}	request.Response				"Forbidden, the role of the user does not allow this"
+// @Security		BearerAuth
+// @Router			/api/authors [post]
+func (a *app) AddAuthor(c *fiber.Ctx) error {
+	body := new(AddAuthor)
+	return request.Handle(c, body, 15*time.Second, errs.Response,
+		func(ctx context.Context, req any) (any, error) {
+			body := req.(*AddAuthor)
+			au, err := a.business.AddAuthor(ctx, blogbus.AddAuthor{
+				Name:      body.Name,
+				Email:     body.Email,
+				Bio:       body.Bio,
+				AvatarURL: body.AvatarURL,
+			})
+			c.Status(fiber.StatusCreated)
+			return toAuthor(au), err
+		})
+}
+
+// @Summary		Authors
+// @Description	Retrieves every author, ordered by ID.
+// @Tags			Author
+// @Produce		json
+// @Success		200	{object}	request.Response{data=[]Author}	"Success"
+// @Failure		500	{object}	request.Response				"Failed to process your request"
+// @Router			/api/authors [get]
+func (a *app) Authors(c *fiber.Ctx) error {
+	return request.Handle(c, nil, 15*time.Second, errs.Response,
+		func(ctx context.Context, req any) (any, error) {
+			as, err := a.business.Authors(ctx)
+			return toAuthors(as), err
+		})
+}
+
+// @Summary		Author
+// @Description	Retrieves the author with the given ID.
+// @Tags			Author
+// @Produce		json
+// @Param			id	path		int								true	"Author ID"
+// @Success		200	{object}	request.Response{data=Author}	"Success"
+// @Failure		404	{object}	request.Response				"Referenced author does not exist"
+// @Failure		400	{object}	request.Response				"Failed to bind path param"
+// @Failure		500	{object}	request.Response				"Failed to process your request"
+// @Router			/api/authors/{id} [get]
+func (a *app) Author(c *fiber.Ctx) error {
+	body := new(AuthorID)
+	return request.Handle(c, body, 15*time.Second, errs.Response,
+		func(ctx context.Context, req any) (any, error) {
+			body := req.(*AuthorID)
+			au, err := a.business.Author(ctx, body.ID)
+			return toAuthor(au), err
+		})
+}
+
+// @Summary		Update Author
+// @Description	Edits the profile of the author with the given ID, the fields left out keep their value.
+// @Tags			Author
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int								true	"Author ID"
+// @Param			body	body		UpdateAuthor					true	"Payload"
+// @Success		200		{object}	request.Response{data=Author}	"Success"
+// @Failure		400		{object}	request.Response				"Failed to save, the email or avatar URL is malformed"
+// @Failure		404		{object}	request.Response				"Referenced author does not exist"
+// @Failure		400		{object}	request.Response				"Failed to bind JSON"
+// @Failure		400		{object}	request.Response				"Failed to bind path param"
+// @Failure		500		{object}	request.Response				"Failed to process your request"
+// @Failure		401		{object}	request.Response				"Failed to authenticate, the token is missing, invalid or expired"
+// @Failure		403		{object}	request.Response				"Forbidden, the role of the user does not allow this"
+// @Security		BearerAuth
+// @Router			/api/authors/{id} [patch]
+func (a *app) UpdateAuthor(c *fiber.Ctx) error {
+	body := new(UpdateAuthor)
+	return request.Handle(c, body, 15*time.Second, errs.Response,
+		func(ctx context.Context, req any) (any, error) {
+			body := req.(*UpdateAuthor)
+			au, err := a.business.UpdateAuthor(ctx, body.ID, blogbus.UpdateAuthor{
+				Name:      body.Name,
+				Email:     body.Email,
+				Bio:       body.Bio,
+				AvatarURL: body.AvatarURL,
+			})
+			return toAuthor(au), err
+		})
+}
+
+// @Summary		Delete Author
+// @Description	Deletes the author with the given ID. An author with Blog Posts, the posts in the trash included, is only deleted when its posts are reassigned to another author with reassign_to.
+// @Tags			Author
+// @Produce		json
+// @Param			id			path		int								true	"Author ID"
+// @Param			reassign_to	query		int								false	"ID of the author the Blog Posts move to"
+// @Success		200			{object}	request.Response{data=AuthorID}	"Success"
+// @Failure		400			{object}	request.Response				"Failed to delete, the posts cannot be reassigned to the author being deleted"
+// @Failure		400			{object}	request.Response				"Failed to delete, the author to reassign to does not exist"
+// @Failure		404			{object}	request.Response				"Referenced author does not exist"
+// @Failure		409			{object}	request.Response				"Failed to delete, the author still has Blog Posts"
+// @Failure		400			{object}	request.Response				"Failed to bind query"
+// @Failure		400			{object}	request.Response				"Failed to bind path param"
+// @Failure		500			{object}	request.Response				"Failed to process your request"
+// @Failure		401			{object}	request.Response				"Failed to authenticate, the token is missing, invalid or expired"
+// @Failure		403			{object}	request.Response				"Forbidden, the role of the user does not allow this"
+// @Security		BearerAuth
+// @Router			/api/authors/{id} [delete]
+func (a *app) DeleteAuthor(c *fiber.Ctx) error {
+	body := new(DeleteAuthor)
+	return request.Handle(c, body, 15*time.Second, errs.Response,
+		func(ctx context.Context, req any) (any, error) {
+			body := req.(*DeleteAuthor)
+			return a.business.DeleteAuthor(ctx, body.ID, body.ReassignTo)
+		})
+}
+
+// @Summary		Author Blog Posts
+// @Description	Retrieves a page of the Blog Posts of the author with the given ID, ordered by ID. Pass the next_cursor of a page as cursor to retrieve the following one.
+// @Tags			Author
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int									true	"Author ID"
+// @Param			status	query		string								false	"Status, published by default"	Enums(draft, published, archived)
+// @Param			limit	query		int									false	"Page size, 20 by default and at most 100"
+// @Param			cursor	query		string								false	"Cursor of the page, the first page when empty"
+// @Success		200		{object}	request.Response{data=[]BlogPost}	"Success"
+// @Failure		404		{object}	request.Response					"Referenced author does not exist"
+// @Failure		400		{object}	request.Response					"Failed to list, the limit is out of range"
+// @Failure		400		{object}	request.Response					"Failed to list, the cursor is invalid"
+// @Failure		400		{object}	request.Response					"Failed to list, the query is invalid"
+// @Failure		400		{object}	request.Response					"Failed to bind query"
+// @Failure		400		{object}	request.Response					"Failed to bind path param"
+// @Failure		500		{object}	request.Response					"Failed to process your request"
+// @Router			/api/authors/{id}/posts [get]
+func (a *app) AuthorBlogPosts(c *fiber.Ctx) error {
+	query := new(AuthorBlogPostsQuery)
+	return request.Handle(c, query, 15*time.Second, errs.Response,
+		func(ctx context.Context, req any) (any, error) {
+			query := req.(*AuthorBlogPostsQuery)
+
+			q := blogbus.Query{Status: blogbus.Status(query.Status)}
+			if q.Status == "" {
+				q.Status = blogbus.StatusPublished
+			}
+
+			page := blogbus.Page{Limit: query.Limit, Cursor: query.Cursor}
+			bpp, err := a.business.AuthorBlogPosts(ctx, query.ID, q, page)
+			return toPaginated(bpp), err
+		})
+}
+
+// @Summary		Snapshot
+// @Description	Downloads a point-in-time snapshot file of every Blog Post.
+// @Tags			Admin
+// @Produce		octet-stream
+// @Success		200	{file}		file				"Snapshot file"
+// @Failure		500	{object}	request.Response	"Failed to process your request"
+// @Failure		401	{object}	request.Response	"Failed to authenticate, the token is missing, invalid or expired"
+// @Failure		403	{object}	request.Response	"Forbidden, the role of the user does not allow this, or the request is not from the operator of the server"
+// @Param			X-Operator-Token	header	string	false	"Operator token, required from outside the host of the server"
+// @Security		BearerAuth
+// @Router			/api/admin/snapshot [get]
+func (a *app) Snapshot(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.UserContext(), 15*time.Second)
+	defer cancel()
+
+	buf := new(bytes.Buffer)
+
+	info, err := a.business.Snapshot(ctx, buf)
+	if err != nil {
+		response := errs.Response(err)
+		return c.Status(response.Status).JSON(response)
+	}
+
+	c.Attachment(fmt.Sprintf("blogapp-%s.snap", info.CreatedAt.UTC().Format("20060102T150405Z")))
+	c.Set(fiber.HeaderContentType, fiber.MIMEOctetStream)
+
+	return c.Status(fiber.StatusOK).Send(buf.Bytes())
+}
+
+// @Summary		Restore
+// @Description	Replaces every Blog Post with the contents of an uploaded snapshot file, of any size.
+// @Tags			Admin
+// @Accept			octet-stream
+// @Produce		json
+// @Param			body	body		[]byte								true	"Snapshot file"
+// @Success		200		{object}	request.Response{data=SnapshotInfo}	"Success"
+// @Failure		422		{object}	request.Response					"Failed to save, blog storage capacity reached"
+// @Failure		409		{object}	request.Response					"Failed to save, resource conflicts with an existing one"
+// @Failure		400		{object}	request.Response					"Failed to restore, the file is not a snapshot"
+// @Failure		400		{object}	request.Response					"Failed to restore, the snapshot version is not supported"
+// @Failure		400		{object}	request.Response					"Failed to restore, the snapshot is corrupted"
+// @Failure		500		{object}	request.Response					"Failed to process your request"
+// @Failure		401		{object}	request.Response					"Failed to authenticate, the token is missing, invalid or expired"
+// @Failure		403		{object}	request.Response					"Forbidden, the role of the user does not allow this, or the request is not from the operator of the server"
+// @Param			X-Operator-Token	header	string	false	"Operator token, required from outside the host of the server"
+// @Security		BearerAuth
+// @Router			/api/admin/restore [post]
+func (a *app) Restore(c *fiber.Ctx) error {
+	return request.Handle(c, nil, 15*time.Second, errs.Response,
+		func(ctx context.Context, req any) (any, error) {
+			info, err := a.business.Restore(ctx, body(c))
+			return toSnapshotInfo(info), err
+		})
+}
+
+// @Summary		Cache Stats
+// @Description	Returns the hit, miss and eviction counters of the in-memory cache.
+// @Tags			Admin
+// @Produce		json
+// @Success		200	{object}	request.Response{data=CacheStats}	"Success"
+// @Failure		500	{object}	request.Response					"Failed to process your request"
+// @Failure		401	{object}	request.Response					"Failed to authenticate, the token is missing, invalid or expired"
+// @Failure		403	{object}	request.Response					"Forbidden, the role of the user does not allow this"
+// @Security		BearerAuth
+// @Router			/api/admin/cache/stats [get]
+func (a *app) CacheStats(c *fiber.Ctx) error {
+	return request.Handle(c, nil, 15*time.Second, errs.Response,
+		func(ctx context.Context, req any) (any, error) {
+			cs, err := a.business.CacheStats(ctx)
+			return toCacheStats(cs), err
+		})
+}
+
+// @Summary		Users
+// @Description	Lists every user with their role, ordered by username.
+// @Tags			Admin
+// @Produce		json
+// @Success		200	{object}	request.Response{data=[]Account}	"Success"
+// @Failure		500	{object}	request.Response					"Failed to process your request"
+// @Failure		401	{object}	request.Response					"Failed to authenticate, the token is missing, invalid or expired"
+// @Failure		403	{object}	request.Response					"Forbidden, the role of the user does not allow this"
+// @Security		BearerAuth
+// @Router			/api/admin/users [get]
+func (a *app) Users(c *fiber.Ctx) error {
+	return request.Handle(c, nil, 15*time.Second, errs.Response,
+		func(ctx context.Context, req any) (any, error) {
+			us, err := a.auth.Users(ctx)
+			return toAccounts(us), err
+		})
+}
+
+// @Summary		Set Role
+// @Description	Gives the user with the given username a role: reader, author, editor or admin. Authors write posts and change their own, editors change and publish anyone's and run the taxonomy and comments, admins manage users and the server. The new role applies to the sessions the user has. Admins cannot change their own role, so there is always one.
+// @Tags			Admin
+// @Accept			json
+// @Produce		json
+// @Param			username	path		string							true	"Username"
+// @Param			body		body		SetRole							true	"Payload"
+// @Success		200			{object}	request.Response{data=Account}	"Success"
+// @Failure		400			{object}	request.Response				"Failed to set the role, want reader, author, editor or admin"
+// @Failure		404			{object}	request.Response				"Referenced user does not exist"
+// @Failure		400			{object}	request.Response				"Failed to bind JSON"
+// @Failure		500			{object}	request.Response				"Failed to process your request"
+// @Failure		401			{object}	request.Response				"Failed to authenticate, the token is missing, invalid or expired"
+// @Failure		403			{object}	request.Response				"Forbidden, the role of the user does not allow this"
+// @Failure		403			{object}	request.Response				"Forbidden, admins cannot change their own role"
+// @Security		BearerAuth
+// @Router			/api/admin/users/{username} [patch]
+func (a *app) SetRole(c *fiber.Ctx) error {
+	body := new(SetRole)
+	return request.Handle(c, body, 15*time.Second, errs.Response,
+		func(ctx context.Context, req any) (any, error) {
+			body := req.(*SetRole)
+			u, err := a.auth.SetRole(ctx, body.Username, authbus.Role(body.Role))
+			return toAccount(u), err
+		})
+}
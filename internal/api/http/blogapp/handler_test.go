@@ -2,6 +2,7 @@ package blogapp_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -11,9 +12,17 @@ import (
 	"time"
 
 	"github.com/anazcodes/blogapp/internal/api/http/blogapp"
+	"github.com/anazcodes/blogapp/internal/business/authbus"
 	"github.com/anazcodes/blogapp/internal/business/blogbus"
+	"github.com/anazcodes/blogapp/internal/business/commentbus"
+	mockauthbus "github.com/anazcodes/blogapp/internal/mock/business/authbus"
 	mockblogbus "github.com/anazcodes/blogapp/internal/mock/business/blogbus"
+	mockcommentbus "github.com/anazcodes/blogapp/internal/mock/business/commentbus"
+	"github.com/anazcodes/blogapp/internal/repository/blogrepo"
 	"github.com/anazcodes/blogapp/internal/repository/blogrepo/cache"
+	"github.com/anazcodes/blogapp/internal/repository/blogrepo/snapshot"
+	"github.com/anazcodes/blogapp/pkg/jwt"
+	"github.com/anazcodes/blogapp/pkg/request"
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -51,7 +60,7 @@ func TestAddBlogPost(t *testing.T) {
 			bus := mockblogbus.NewMockBusiness(ctrl)
 			tc.mockSetup(bus)
 
-			app := blogapp.NewApp(port, bus)
+			app := blogapp.NewApp(port, bus, mockcommentbus.NewMockBusiness(ctrl), authenticated(ctrl), "")
 			fbr := app.Fiber()
 
 			body, err := json.Marshal(tc.input)
@@ -88,23 +97,64 @@ func TestBlogPosts(t *testing.T) {
 
 	testCases := []struct {
 		name           string
+		query          string
 		expectedStatus int
+		expectedPage   *request.Pagination
 		setupExpect    func(bus *mockblogbus.MockBusiness)
 	}{
 		{
 			name:           "Success",
+			query:          "?limit=1&cursor=abc&sort=title&order=desc&created_from=2025-01-01T00:00:00Z",
 			expectedStatus: fiber.StatusOK,
+			expectedPage:   &request.Pagination{NextCursor: "def", HasMore: true},
 			setupExpect: func(bus *mockblogbus.MockBusiness) {
-				bus.EXPECT().BlogPosts(gomock.Any()).Return([]blogbus.BlogPost{
-					{
-						ID:          1,
-						Title:       "Title",
-						Description: "Description",
-						Body:        "Body",
-						CreatedAt:   time.Now(),
-						UpdatedAt:   time.Now(),
+				bus.EXPECT().BlogPosts(gomock.Any(), blogbus.Query{
+					CreatedFrom: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+					Status:      blogbus.StatusPublished,
+					SortBy:      blogbus.SortByTitle,
+					Desc:        true,
+				}, blogbus.Page{Limit: 1, Cursor: "abc"}).Return(blogbus.BlogPostPage{
+					BlogPosts: []blogbus.BlogPost{
+						{
+							ID:          1,
+							Title:       "Title",
+							Description: "Description",
+							Body:        "Body",
+							CreatedAt:   time.Now(),
+							UpdatedAt:   time.Now(),
+						},
 					},
-				}).AnyTimes()
+					NextCursor: "def",
+					HasMore:    true,
+				}, nil).AnyTimes()
+			},
+		},
+		{
+			name:           "Invalid Limit",
+			query:          "?limit=1000",
+			expectedStatus: fiber.StatusBadRequest,
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().BlogPosts(gomock.Any(), gomock.Any(), gomock.Any()).Return(blogbus.BlogPostPage{}, blogbus.ErrInvalidLimit).AnyTimes()
+			},
+		},
+		{
+			name:           "Invalid Order",
+			query:          "?order=random",
+			expectedStatus: fiber.StatusBadRequest,
+			setupExpect:    func(bus *mockblogbus.MockBusiness) {},
+		},
+		{
+			name:           "Invalid Date",
+			query:          "?created_to=yesterday",
+			expectedStatus: fiber.StatusBadRequest,
+			setupExpect:    func(bus *mockblogbus.MockBusiness) {},
+		},
+		{
+			name:           "Invalid Cursor",
+			query:          "?cursor=abc",
+			expectedStatus: fiber.StatusBadRequest,
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().BlogPosts(gomock.Any(), gomock.Any(), gomock.Any()).Return(blogbus.BlogPostPage{}, fmt.Errorf("repo.blogposts: %w", blogbus.ErrInvalidCursor)).AnyTimes()
 			},
 		},
 	}
@@ -117,12 +167,12 @@ func TestBlogPosts(t *testing.T) {
 			bus := mockblogbus.NewMockBusiness(ctrl)
 			tc.setupExpect(bus)
 
-			app := blogapp.NewApp(port, bus)
+			app := blogapp.NewApp(port, bus, mockcommentbus.NewMockBusiness(ctrl), authenticated(ctrl), "")
 			fbr := app.Fiber()
 
 			req, err := http.NewRequest(
 				http.MethodGet,
-				endpoint,
+				endpoint+tc.query,
 				nil,
 			)
 
@@ -140,7 +190,97 @@ func TestBlogPosts(t *testing.T) {
 
 			log.Printf("Request URL: %s \n Response Body: %s", req.URL.String(), string(resBody))
 
+			var body request.Response
+			assert.Nil(t, json.Unmarshal(resBody, &body))
+
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+			assert.Equal(t, tc.expectedPage, body.Pagination)
+		})
+	}
+}
+
+func TestSearchBlogPosts(t *testing.T) {
+	port := ":3000"
+	endpoint := "/api/blog-post/search"
+
+	testCases := []struct {
+		name           string
+		query          string
+		expectedStatus int
+		expectedPage   *request.Pagination
+		setupExpect    func(bus *mockblogbus.MockBusiness)
+	}{
+		{
+			name:           "Success",
+			query:          "?q=cache&limit=1&cursor=abc",
+			expectedStatus: fiber.StatusOK,
+			expectedPage:   &request.Pagination{NextCursor: "def", HasMore: true},
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().SearchBlogPosts(gomock.Any(), "cache", blogbus.Page{Limit: 1, Cursor: "abc"}).Return(blogbus.SearchPage{
+					Hits: []blogbus.SearchHit{
+						{
+							BlogPost: blogbus.BlogPost{ID: 1, Title: "Caching"},
+							Score:    1.5,
+							Snippets: []blogbus.Snippet{{Field: "title", Text: "<mark>Caching</mark>"}},
+						},
+					},
+					NextCursor: "def",
+					HasMore:    true,
+				}, nil).AnyTimes()
+			},
+		},
+		{
+			name:           "Empty Search",
+			query:          "",
+			expectedStatus: fiber.StatusBadRequest,
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().SearchBlogPosts(gomock.Any(), "", gomock.Any()).Return(blogbus.SearchPage{}, blogbus.ErrEmptySearch).AnyTimes()
+			},
+		},
+		{
+			name:           "Invalid Cursor",
+			query:          "?q=cache&cursor=abc",
+			expectedStatus: fiber.StatusBadRequest,
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().SearchBlogPosts(gomock.Any(), gomock.Any(), gomock.Any()).Return(blogbus.SearchPage{}, fmt.Errorf("repo.searchblogposts: %w", blogbus.ErrInvalidCursor)).AnyTimes()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			bus := mockblogbus.NewMockBusiness(ctrl)
+			tc.setupExpect(bus)
+
+			app := blogapp.NewApp(port, bus, mockcommentbus.NewMockBusiness(ctrl), authenticated(ctrl), "")
+			fbr := app.Fiber()
+
+			req, err := http.NewRequest(
+				http.MethodGet,
+				endpoint+tc.query,
+				nil,
+			)
+
+			assert.Nil(t, err)
+
+			res, err := fbr.Test(req, -1)
+			assert.Nil(t, err)
+
+			resBody, err := io.ReadAll(res.Body)
+			assert.Nil(t, err)
+
+			defer res.Body.Close()
+
+			log.Printf("Request URL: %s \n Response Body: %s", req.URL.String(), string(resBody))
+
+			var body request.Response
+			assert.Nil(t, json.Unmarshal(resBody, &body))
+
 			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+			assert.Equal(t, tc.expectedPage, body.Pagination)
 		})
 	}
 }
@@ -189,7 +329,7 @@ func TestBlogPost(t *testing.T) {
 			bus := mockblogbus.NewMockBusiness(ctrl)
 			tc.setupExpect(bus)
 
-			app := blogapp.NewApp(port, bus)
+			app := blogapp.NewApp(port, bus, mockcommentbus.NewMockBusiness(ctrl), authenticated(ctrl), "")
 			fbr := app.Fiber()
 
 			req, err := http.NewRequest(
@@ -255,7 +395,7 @@ func TestDeleteBlogPost(t *testing.T) {
 			bus := mockblogbus.NewMockBusiness(ctrl)
 			tc.setupExpect(bus)
 
-			app := blogapp.NewApp(port, bus)
+			app := blogapp.NewApp(port, bus, mockcommentbus.NewMockBusiness(ctrl), authenticated(ctrl), "")
 			fbr := app.Fiber()
 
 			req, err := http.NewRequest(
@@ -343,7 +483,7 @@ func TestUpdateBlogPost(t *testing.T) {
 			bus := mockblogbus.NewMockBusiness(ctrl)
 			tc.setupExpect(bus)
 
-			app := blogapp.NewApp(port, bus)
+			app := blogapp.NewApp(port, bus, mockcommentbus.NewMockBusiness(ctrl), authenticated(ctrl), "")
 			fbr := app.Fiber()
 
 			body, err := json.Marshal(tc.input)
@@ -373,3 +513,1961 @@ func TestUpdateBlogPost(t *testing.T) {
 		})
 	}
 }
+
+func TestSnapshot(t *testing.T) {
+	port := ":3000"
+	endpoint := "/api/admin/snapshot"
+
+	testCases := []struct {
+		name           string
+		operatorToken  string
+		setupExpect    func(bus *mockblogbus.MockBusiness)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:          "Success",
+			operatorToken: "operator",
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().Snapshot(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, w io.Writer) (blogbus.SnapshotInfo, error) {
+						_, err := w.Write([]byte("snapshot"))
+						return blogbus.SnapshotInfo{Version: 1, CreatedAt: time.Now()}, err
+					}).AnyTimes()
+			},
+			expectedStatus: fiber.StatusOK,
+			expectedBody:   "snapshot",
+		},
+		{
+			name:           "Not Operator",
+			setupExpect:    func(bus *mockblogbus.MockBusiness) {},
+			expectedStatus: fiber.StatusForbidden,
+		},
+		{
+			name:           "Wrong Operator Token",
+			operatorToken:  "guess",
+			setupExpect:    func(bus *mockblogbus.MockBusiness) {},
+			expectedStatus: fiber.StatusForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			bus := mockblogbus.NewMockBusiness(ctrl)
+			tc.setupExpect(bus)
+
+			app := blogapp.NewApp(port, bus, mockcommentbus.NewMockBusiness(ctrl), authenticated(ctrl), "operator")
+			fbr := app.Fiber()
+
+			req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+			assert.Nil(t, err)
+
+			if tc.operatorToken != "" {
+				req.Header.Set("X-Operator-Token", tc.operatorToken)
+			}
+
+			res, err := fbr.Test(req, -1)
+			assert.Nil(t, err)
+
+			resBody, err := io.ReadAll(res.Body)
+			assert.Nil(t, err)
+
+			defer res.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+			if tc.expectedStatus == fiber.StatusOK {
+				assert.Equal(t, tc.expectedBody, string(resBody))
+				assert.Contains(t, res.Header.Get(fiber.HeaderContentDisposition), ".snap")
+			}
+		})
+	}
+}
+
+func TestRestore(t *testing.T) {
+	port := ":3000"
+	endpoint := "/api/admin/restore"
+
+	// large is a snapshot over the body limit of the other routes.
+	large := bytes.Repeat([]byte("s"), 2*fiber.DefaultBodyLimit)
+
+	testCases := []struct {
+		name           string
+		operatorToken  string
+		body           []byte
+		setupExpect    func(bus *mockblogbus.MockBusiness)
+		expectedStatus int
+	}{
+		{
+			name:          "Success",
+			operatorToken: "operator",
+			body:          []byte("snapshot"),
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().Restore(gomock.Any(), gomock.Any()).Return(blogbus.SnapshotInfo{Version: 1, Posts: 1}, nil).AnyTimes()
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:          "Large Snapshot",
+			operatorToken: "operator",
+			body:          large,
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().Restore(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, r io.Reader) (blogbus.SnapshotInfo, error) {
+						b, err := io.ReadAll(r)
+						if err != nil || !bytes.Equal(b, large) {
+							return blogbus.SnapshotInfo{}, snapshot.ErrChecksum
+						}
+						return blogbus.SnapshotInfo{Version: 1, Posts: 1}, nil
+					})
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:          "Bad Snapshot",
+			operatorToken: "operator",
+			body:          []byte("snapshot"),
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().Restore(gomock.Any(), gomock.Any()).Return(blogbus.SnapshotInfo{}, snapshot.ErrChecksum).AnyTimes()
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:          "Over Capacity",
+			operatorToken: "operator",
+			body:          []byte("snapshot"),
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().Restore(gomock.Any(), gomock.Any()).Return(blogbus.SnapshotInfo{}, cache.ErrCacheInMaxCap).AnyTimes()
+			},
+			expectedStatus: fiber.StatusUnprocessableEntity,
+		},
+		{
+			name:           "Not Operator",
+			body:           []byte("snapshot"),
+			setupExpect:    func(bus *mockblogbus.MockBusiness) {},
+			expectedStatus: fiber.StatusForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			bus := mockblogbus.NewMockBusiness(ctrl)
+			tc.setupExpect(bus)
+
+			app := blogapp.NewApp(port, bus, mockcommentbus.NewMockBusiness(ctrl), authenticated(ctrl), "operator")
+			fbr := app.Fiber()
+
+			req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(tc.body))
+			assert.Nil(t, err)
+
+			req.Header.Set("Content-Type", fiber.MIMEOctetStream)
+			if tc.operatorToken != "" {
+				req.Header.Set("X-Operator-Token", tc.operatorToken)
+			}
+
+			res, err := fbr.Test(req, -1)
+			assert.Nil(t, err)
+
+			defer res.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+		})
+	}
+}
+
+func TestBodyLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := blogapp.NewApp(":3000", mockblogbus.NewMockBusiness(ctrl), mockcommentbus.NewMockBusiness(ctrl), authenticated(ctrl), "")
+	fbr := app.Fiber()
+
+	body := bytes.Repeat([]byte(" "), fiber.DefaultBodyLimit+1)
+
+	req, err := http.NewRequest(http.MethodPost, "/api/blog-post", bytes.NewReader(body))
+	assert.Nil(t, err)
+
+	req.Header.Set("Content-Type", fiber.MIMEApplicationJSON)
+
+	res, err := fbr.Test(req, -1)
+	assert.Nil(t, err)
+
+	defer res.Body.Close()
+
+	assert.Equal(t, fiber.StatusRequestEntityTooLarge, res.StatusCode)
+}
+
+func TestCacheStats(t *testing.T) {
+	port := ":3000"
+	endpoint := "/api/admin/cache/stats"
+
+	testCases := []struct {
+		name           string
+		setupExpect    func(bus *mockblogbus.MockBusiness)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "Success",
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().CacheStats(gomock.Any()).Return(blogbus.CacheStats{Hits: 3, Misses: 1, Evictions: 1, Posts: 2, Capacity: 2}, nil).AnyTimes()
+			},
+			expectedStatus: fiber.StatusOK,
+			expectedBody:   `{"hits":3,"misses":1,"evictions":1,"posts":2,"capacity":2}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			bus := mockblogbus.NewMockBusiness(ctrl)
+			tc.setupExpect(bus)
+
+			app := blogapp.NewApp(port, bus, mockcommentbus.NewMockBusiness(ctrl), authenticated(ctrl), "")
+			fbr := app.Fiber()
+
+			req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+			assert.Nil(t, err)
+
+			res, err := fbr.Test(req, -1)
+			assert.Nil(t, err)
+
+			defer res.Body.Close()
+
+			var body struct {
+				Data json.RawMessage `json:"data"`
+			}
+			assert.Nil(t, json.NewDecoder(res.Body).Decode(&body))
+
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+			assert.JSONEq(t, tc.expectedBody, string(body.Data))
+		})
+	}
+}
+
+func TestTransitions(t *testing.T) {
+	port := ":3000"
+	endpoint := "/api/blog-post/%d/%s"
+	publishAt := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		action         string
+		input          any
+		setupExpect    func(bus *mockblogbus.MockBusiness)
+		expectedStatus int
+	}{
+		{
+			name:   "Publish",
+			action: "publish",
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().PublishBlogPost(gomock.Any(), uint64(1)).Return(blogbus.ToID(1), nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:   "Unpublish",
+			action: "unpublish",
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().UnpublishBlogPost(gomock.Any(), uint64(1)).Return(blogbus.ToID(1), nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:   "Archive",
+			action: "archive",
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().ArchiveBlogPost(gomock.Any(), uint64(1)).Return(blogbus.ToID(1), nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:   "Invalid Transition",
+			action: "publish",
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().PublishBlogPost(gomock.Any(), uint64(1)).
+					Return(nil, fmt.Errorf("repo.transitionblogpost: %w", blogbus.ErrInvalidTransition))
+			},
+			expectedStatus: fiber.StatusConflict,
+		},
+		{
+			name:   "Not Found",
+			action: "archive",
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().ArchiveBlogPost(gomock.Any(), uint64(1)).Return(nil, cache.ErrItemNotFound)
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+		{
+			name:   "Schedule",
+			action: "schedule",
+			input:  blogapp.ScheduleBlogPost{PublishAt: publishAt},
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().ScheduleBlogPost(gomock.Any(), uint64(1), publishAt).Return(blogbus.ToID(1), nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:   "Schedule In The Past",
+			action: "schedule",
+			input:  blogapp.ScheduleBlogPost{PublishAt: publishAt},
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().ScheduleBlogPost(gomock.Any(), uint64(1), publishAt).Return(nil, blogbus.ErrInvalidSchedule)
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:   "Unschedule",
+			action: "unschedule",
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().UnscheduleBlogPost(gomock.Any(), uint64(1)).Return(blogbus.ToID(1), nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			bus := mockblogbus.NewMockBusiness(ctrl)
+			tc.setupExpect(bus)
+
+			app := blogapp.NewApp(port, bus, mockcommentbus.NewMockBusiness(ctrl), authenticated(ctrl), "")
+			fbr := app.Fiber()
+
+			var body io.Reader
+			if tc.input != nil {
+				b, err := json.Marshal(tc.input)
+				assert.Nil(t, err)
+				body = bytes.NewReader(b)
+			}
+
+			req, err := http.NewRequest(http.MethodPost, fmt.Sprintf(endpoint, 1, tc.action), body)
+			assert.Nil(t, err)
+			if body != nil {
+				req.Header.Set("Content-Type", "application/json")
+			}
+
+			res, err := fbr.Test(req, -1)
+			assert.Nil(t, err)
+			defer res.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+		})
+	}
+}
+
+func TestRevisions(t *testing.T) {
+	port := ":3000"
+	revs := []blogbus.Revision{
+		{Number: 1, Changed: []string{"title"}, CreatedAt: time.Now(), Title: "Title"},
+		{Number: 2, Editor: "bob", Changed: []string{"title"}, CreatedAt: time.Now(), Title: "New Title"},
+	}
+
+	testCases := []struct {
+		name           string
+		method         string
+		endpoint       string
+		setupExpect    func(bus *mockblogbus.MockBusiness)
+		expectedStatus int
+	}{
+		{
+			name:     "List",
+			method:   http.MethodGet,
+			endpoint: "/api/blog-post/1/revisions",
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().BlogPostRevisions(gomock.Any(), uint64(1)).Return(revs, nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:     "Get",
+			method:   http.MethodGet,
+			endpoint: "/api/blog-post/1/revisions/2",
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().BlogPostRevision(gomock.Any(), uint64(1), uint64(2)).Return(revs[1], nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:     "Revision Not Found",
+			method:   http.MethodGet,
+			endpoint: "/api/blog-post/1/revisions/3",
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().BlogPostRevision(gomock.Any(), uint64(1), uint64(3)).
+					Return(blogbus.Revision{}, fmt.Errorf("%w: 3 id: 1", blogbus.ErrRevisionNotFound))
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+		{
+			name:     "Diff",
+			method:   http.MethodGet,
+			endpoint: "/api/blog-post/1/revisions/diff?from=1&to=2",
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().DiffBlogPostRevisions(gomock.Any(), uint64(1), uint64(1), uint64(2)).
+					Return(blogbus.Diff(revs[0], revs[1]), nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:     "Restore",
+			method:   http.MethodPost,
+			endpoint: "/api/blog-post/1/revisions/1/restore",
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().RestoreBlogPostRevision(gomock.Any(), uint64(1), uint64(1)).Return(blogbus.ToID(1), nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			bus := mockblogbus.NewMockBusiness(ctrl)
+			tc.setupExpect(bus)
+
+			app := blogapp.NewApp(port, bus, mockcommentbus.NewMockBusiness(ctrl), authenticated(ctrl), "")
+			fbr := app.Fiber()
+
+			req, err := http.NewRequest(tc.method, tc.endpoint, nil)
+			assert.Nil(t, err)
+
+			res, err := fbr.Test(req, -1)
+			assert.Nil(t, err)
+			defer res.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+		})
+	}
+}
+
+func TestTrash(t *testing.T) {
+	port := ":3000"
+
+	testCases := []struct {
+		name           string
+		method         string
+		endpoint       string
+		setupExpect    func(bus *mockblogbus.MockBusiness)
+		expectedStatus int
+	}{
+		{
+			name:     "List",
+			method:   http.MethodGet,
+			endpoint: "/api/blog-post/trash?limit=5",
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().BlogPosts(gomock.Any(), blogbus.Query{Trashed: true}, blogbus.Page{Limit: 5}).
+					Return(blogbus.BlogPostPage{BlogPosts: []blogbus.BlogPost{{ID: 1, DeletedAt: time.Now()}}}, nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:     "Trashed Blog Post",
+			method:   http.MethodGet,
+			endpoint: "/api/blog-post/1",
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().BlogPost(gomock.Any(), uint64(1)).
+					Return(blogbus.BlogPost{}, fmt.Errorf("repo.blogpost: %w id: 1", blogbus.ErrTrashed))
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+		{
+			name:     "Restore",
+			method:   http.MethodPost,
+			endpoint: "/api/blog-post/trash/1/restore",
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().RestoreBlogPost(gomock.Any(), uint64(1)).Return(blogbus.ToID(1), nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:     "Restore Not Trashed",
+			method:   http.MethodPost,
+			endpoint: "/api/blog-post/trash/2/restore",
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().RestoreBlogPost(gomock.Any(), uint64(2)).
+					Return(nil, fmt.Errorf("repo.restoreblogpost: %w id: 2", blogbus.ErrNotTrashed))
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+		{
+			name:     "Purge",
+			method:   http.MethodDelete,
+			endpoint: "/api/blog-post/trash/1",
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().PurgeBlogPost(gomock.Any(), uint64(1)).Return(blogbus.ToID(1), nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			bus := mockblogbus.NewMockBusiness(ctrl)
+			tc.setupExpect(bus)
+
+			app := blogapp.NewApp(port, bus, mockcommentbus.NewMockBusiness(ctrl), authenticated(ctrl), "")
+			fbr := app.Fiber()
+
+			req, err := http.NewRequest(tc.method, tc.endpoint, nil)
+			assert.Nil(t, err)
+
+			res, err := fbr.Test(req, -1)
+			assert.Nil(t, err)
+			defer res.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+		})
+	}
+}
+
+func TestBlogPostBySlug(t *testing.T) {
+	port := ":3000"
+
+	testCases := []struct {
+		name             string
+		endpoint         string
+		setupExpect      func(bus *mockblogbus.MockBusiness)
+		expectedStatus   int
+		expectedLocation string
+	}{
+		{
+			name:     "Success",
+			endpoint: "/api/blog-post/by-slug/my-first-post",
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().BlogPostBySlug(gomock.Any(), "my-first-post").
+					Return(blogbus.BlogPost{ID: 1, Slug: "my-first-post"}, nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:     "Old Slug",
+			endpoint: "/api/blog-post/by-slug/my-post",
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().BlogPostBySlug(gomock.Any(), "my-post").
+					Return(blogbus.BlogPost{ID: 1, Slug: "my-first-post", OldSlugs: []string{"my-post"}}, nil)
+			},
+			expectedStatus:   fiber.StatusMovedPermanently,
+			expectedLocation: "/api/blog-post/by-slug/my-first-post",
+		},
+		{
+			name:     "Not Found",
+			endpoint: "/api/blog-post/by-slug/missing",
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().BlogPostBySlug(gomock.Any(), "missing").
+					Return(blogbus.BlogPost{}, fmt.Errorf("repo.blogpostbyslug: %w slug: missing", cache.ErrItemNotFound))
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			bus := mockblogbus.NewMockBusiness(ctrl)
+			tc.setupExpect(bus)
+
+			app := blogapp.NewApp(port, bus, mockcommentbus.NewMockBusiness(ctrl), authenticated(ctrl), "")
+			fbr := app.Fiber()
+
+			req, err := http.NewRequest(http.MethodGet, tc.endpoint, nil)
+			assert.Nil(t, err)
+
+			res, err := fbr.Test(req, -1)
+			assert.Nil(t, err)
+			defer res.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+			assert.Equal(t, tc.expectedLocation, res.Header.Get("Location"))
+		})
+	}
+}
+
+func TestSlugErrors(t *testing.T) {
+	port := ":3000"
+
+	testCases := []struct {
+		name           string
+		method         string
+		endpoint       string
+		input          any
+		setupExpect    func(bus *mockblogbus.MockBusiness)
+		expectedStatus int
+	}{
+		{
+			name:     "Invalid",
+			method:   http.MethodPost,
+			endpoint: "/api/blog-post",
+			input:    blogapp.AddBlogPost{Title: "Title", Slug: "Not A Slug"},
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().AddBlogPost(gomock.Any(), blogbus.AddBlogPost{Title: "Title", Slug: "Not A Slug"}).
+					Return(nil, fmt.Errorf("%w: %q", blogbus.ErrInvalidSlug, "Not A Slug"))
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:     "Taken",
+			method:   http.MethodPatch,
+			endpoint: "/api/blog-post/2",
+			input:    blogapp.UpdateBlogPost{Slug: "taken"},
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().UpdateBlogPost(gomock.Any(), uint64(2), blogbus.UpdateBlogPost{Slug: "taken"}).
+					Return(nil, fmt.Errorf("repo.updateblogpost: query: %w id: 2", blogbus.ErrSlugTaken))
+			},
+			expectedStatus: fiber.StatusConflict,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			bus := mockblogbus.NewMockBusiness(ctrl)
+			tc.setupExpect(bus)
+
+			app := blogapp.NewApp(port, bus, mockcommentbus.NewMockBusiness(ctrl), authenticated(ctrl), "")
+			fbr := app.Fiber()
+
+			body, err := json.Marshal(tc.input)
+			assert.Nil(t, err)
+
+			req, err := http.NewRequest(tc.method, tc.endpoint, bytes.NewReader(body))
+			assert.Nil(t, err)
+			req.Header.Set("Content-Type", "application/json")
+
+			res, err := fbr.Test(req, -1)
+			assert.Nil(t, err)
+			defer res.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+		})
+	}
+}
+
+func TestTaxonomy(t *testing.T) {
+	port := ":3000"
+	parent := ""
+
+	testCases := []struct {
+		name           string
+		method         string
+		endpoint       string
+		input          any
+		setupExpect    func(bus *mockblogbus.MockBusiness)
+		expectedStatus int
+	}{
+		{
+			name:     "Add Tag",
+			method:   http.MethodPost,
+			endpoint: "/api/tags",
+			input:    blogapp.AddTag{Name: "Go"},
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().AddTag(gomock.Any(), blogbus.AddTag{Name: "Go"}).
+					Return(blogbus.Tag{Slug: "go", Name: "Go", CreatedAt: time.Now()}, nil)
+			},
+			expectedStatus: fiber.StatusCreated,
+		},
+		{
+			name:     "Tag Exists",
+			method:   http.MethodPost,
+			endpoint: "/api/tags",
+			input:    blogapp.AddTag{Name: "Go"},
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().AddTag(gomock.Any(), blogbus.AddTag{Name: "Go"}).
+					Return(blogbus.Tag{}, fmt.Errorf("repo.addtag: query: %w slug: go", blogbus.ErrTagExists))
+			},
+			expectedStatus: fiber.StatusConflict,
+		},
+		{
+			name:     "Tag Cloud",
+			method:   http.MethodGet,
+			endpoint: "/api/tags/cloud",
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().TagCloud(gomock.Any()).
+					Return([]blogbus.TagCount{{Tag: blogbus.Tag{Slug: "go", Name: "Go"}, Posts: 2}}, nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:     "Tag Not Found",
+			method:   http.MethodGet,
+			endpoint: "/api/tags/missing",
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().Tag(gomock.Any(), "missing").
+					Return(blogbus.Tag{}, fmt.Errorf("repo.tag: query: %w slug: missing", blogbus.ErrTagNotFound))
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+		{
+			name:     "Empty Tag Name",
+			method:   http.MethodPatch,
+			endpoint: "/api/tags/go",
+			input:    blogapp.UpdateTag{},
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().UpdateTag(gomock.Any(), "go", blogbus.UpdateTag{}).
+					Return(blogbus.Tag{}, fmt.Errorf("repo.updatetag: %w slug: go", blogbus.ErrInvalidTerm))
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:     "Tag In Use",
+			method:   http.MethodDelete,
+			endpoint: "/api/tags/go",
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().DeleteTag(gomock.Any(), "go").
+					Return(fmt.Errorf("repo.deletetag: query: %w slug: go", blogbus.ErrTermInUse))
+			},
+			expectedStatus: fiber.StatusConflict,
+		},
+		{
+			name:     "Add Category",
+			method:   http.MethodPost,
+			endpoint: "/api/categories",
+			input:    blogapp.AddCategory{Name: "Go", Parent: "languages"},
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().AddCategory(gomock.Any(), blogbus.AddCategory{Name: "Go", Parent: "languages"}).
+					Return(blogbus.Category{Slug: "go", Name: "Go", Parent: "languages", CreatedAt: time.Now()}, nil)
+			},
+			expectedStatus: fiber.StatusCreated,
+		},
+		{
+			name:     "Unknown Parent",
+			method:   http.MethodPost,
+			endpoint: "/api/categories",
+			input:    blogapp.AddCategory{Name: "Go", Parent: "missing"},
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().AddCategory(gomock.Any(), blogbus.AddCategory{Name: "Go", Parent: "missing"}).
+					Return(blogbus.Category{}, fmt.Errorf("repo.addcategory: query: %w: category \"missing\" slug: go", blogbus.ErrUnknownTerm))
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:     "Move Category",
+			method:   http.MethodPatch,
+			endpoint: "/api/categories/go",
+			input:    blogapp.UpdateCategory{Parent: &parent},
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().UpdateCategory(gomock.Any(), "go", blogbus.UpdateCategory{Parent: &parent}).
+					Return(blogbus.Category{Slug: "go", Name: "Go"}, nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:     "Category Cycle",
+			method:   http.MethodPatch,
+			endpoint: "/api/categories/languages",
+			input:    blogapp.UpdateCategory{Parent: &parent},
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().UpdateCategory(gomock.Any(), "languages", gomock.Any()).
+					Return(blogbus.Category{}, fmt.Errorf("repo.updatecategory: query: %w slug: languages", blogbus.ErrCategoryCycle))
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:     "Category Not Found",
+			method:   http.MethodDelete,
+			endpoint: "/api/categories/missing",
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().DeleteCategory(gomock.Any(), "missing").
+					Return(fmt.Errorf("repo.deletecategory: query: %w slug: missing", blogbus.ErrCategoryNotFound))
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+		{
+			name:     "Filter Posts",
+			method:   http.MethodGet,
+			endpoint: "/api/blog-post?tag=go&category=languages",
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().BlogPosts(gomock.Any(), blogbus.Query{
+					Status:   blogbus.StatusPublished,
+					Tag:      "go",
+					Category: "languages",
+				}, gomock.Any()).Return(blogbus.BlogPostPage{}, nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			bus := mockblogbus.NewMockBusiness(ctrl)
+			tc.setupExpect(bus)
+
+			app := blogapp.NewApp(port, bus, mockcommentbus.NewMockBusiness(ctrl), authenticated(ctrl), "")
+			fbr := app.Fiber()
+
+			req, err := http.NewRequest(tc.method, tc.endpoint, nil)
+			assert.Nil(t, err)
+
+			if tc.input != nil {
+				body, err := json.Marshal(tc.input)
+				assert.Nil(t, err)
+
+				req, err = http.NewRequest(tc.method, tc.endpoint, bytes.NewReader(body))
+				assert.Nil(t, err)
+				req.Header.Set("Content-Type", "application/json")
+			}
+
+			res, err := fbr.Test(req, -1)
+			assert.Nil(t, err)
+			defer res.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+		})
+	}
+}
+
+func TestAuthors(t *testing.T) {
+	port := ":3000"
+
+	testCases := []struct {
+		name           string
+		method         string
+		endpoint       string
+		input          any
+		setupExpect    func(bus *mockblogbus.MockBusiness)
+		expectedStatus int
+	}{
+		{
+			name:     "Add Author",
+			method:   http.MethodPost,
+			endpoint: "/api/authors",
+			input:    blogapp.AddAuthor{Name: "Ann", Email: "ann@example.com"},
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().AddAuthor(gomock.Any(), blogbus.AddAuthor{Name: "Ann", Email: "ann@example.com"}).
+					Return(blogbus.Author{ID: 1, Name: "Ann", Email: "ann@example.com", CreatedAt: time.Now()}, nil)
+			},
+			expectedStatus: fiber.StatusCreated,
+		},
+		{
+			name:     "Invalid Author",
+			method:   http.MethodPost,
+			endpoint: "/api/authors",
+			input:    blogapp.AddAuthor{Name: "Ann", Email: "ann"},
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().AddAuthor(gomock.Any(), gomock.Any()).
+					Return(blogbus.Author{}, fmt.Errorf("%w: email \"ann\"", blogbus.ErrInvalidAuthor))
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:     "Author Not Found",
+			method:   http.MethodGet,
+			endpoint: "/api/authors/9",
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().Author(gomock.Any(), uint64(9)).
+					Return(blogbus.Author{}, fmt.Errorf("repo.author: query: %w id: 9", blogbus.ErrAuthorNotFound))
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+		{
+			name:     "Update Author",
+			method:   http.MethodPatch,
+			endpoint: "/api/authors/1",
+			input:    blogapp.UpdateAuthor{Bio: "Writes about Go"},
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().UpdateAuthor(gomock.Any(), uint64(1), blogbus.UpdateAuthor{Bio: "Writes about Go"}).
+					Return(blogbus.Author{ID: 1, Name: "Ann", Bio: "Writes about Go"}, nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:     "Author In Use",
+			method:   http.MethodDelete,
+			endpoint: "/api/authors/1",
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().DeleteAuthor(gomock.Any(), uint64(1), uint64(0)).
+					Return(nil, fmt.Errorf("repo.deleteauthor: query: %w id: 1", blogbus.ErrAuthorInUse))
+			},
+			expectedStatus: fiber.StatusConflict,
+		},
+		{
+			name:     "Delete Author Reassigning",
+			method:   http.MethodDelete,
+			endpoint: "/api/authors/1?reassign_to=2",
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().DeleteAuthor(gomock.Any(), uint64(1), uint64(2)).
+					Return(blogbus.ToID(1), nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:     "Author Posts",
+			method:   http.MethodGet,
+			endpoint: "/api/authors/1/posts?limit=5",
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().AuthorBlogPosts(gomock.Any(), uint64(1), blogbus.Query{Status: blogbus.StatusPublished}, blogbus.Page{Limit: 5}).
+					Return(blogbus.BlogPostPage{BlogPosts: []blogbus.BlogPost{{ID: 3, AuthorID: 1}}}, nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:     "Filter Posts",
+			method:   http.MethodGet,
+			endpoint: "/api/blog-post?author=1",
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().BlogPosts(gomock.Any(), blogbus.Query{
+					Status:   blogbus.StatusPublished,
+					AuthorID: 1,
+				}, gomock.Any()).Return(blogbus.BlogPostPage{}, nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			bus := mockblogbus.NewMockBusiness(ctrl)
+			tc.setupExpect(bus)
+
+			app := blogapp.NewApp(port, bus, mockcommentbus.NewMockBusiness(ctrl), authenticated(ctrl), "")
+			fbr := app.Fiber()
+
+			req, err := http.NewRequest(tc.method, tc.endpoint, nil)
+			assert.Nil(t, err)
+
+			if tc.input != nil {
+				body, err := json.Marshal(tc.input)
+				assert.Nil(t, err)
+
+				req, err = http.NewRequest(tc.method, tc.endpoint, bytes.NewReader(body))
+				assert.Nil(t, err)
+				req.Header.Set("Content-Type", "application/json")
+			}
+
+			res, err := fbr.Test(req, -1)
+			assert.Nil(t, err)
+			defer res.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+		})
+	}
+}
+
+func TestReactions(t *testing.T) {
+	port := ":3000"
+
+	testCases := []struct {
+		name              string
+		method            string
+		endpoint          string
+		input             any
+		setupExpect       func(bus *mockblogbus.MockBusiness)
+		expectedStatus    int
+		expectedReactions map[string]int
+	}{
+		{
+			name:     "React",
+			method:   http.MethodPut,
+			endpoint: "/api/blog-post/1/reactions",
+			input:    blogapp.AddReaction{Reaction: "love"},
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().React(gomock.Any(), uint64(1), blogbus.AddReaction{Reaction: blogbus.ReactionLove}).
+					Return(blogbus.ReactionCounts{blogbus.ReactionLike: 2, blogbus.ReactionLove: 1}, nil)
+			},
+			expectedStatus:    fiber.StatusOK,
+			expectedReactions: map[string]int{"like": 2, "love": 1, "laugh": 0, "wow": 0, "sad": 0, "angry": 0},
+		},
+		{
+			name:     "Unknown Reaction",
+			method:   http.MethodPut,
+			endpoint: "/api/blog-post/1/reactions",
+			input:    blogapp.AddReaction{Reaction: "clap"},
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().React(gomock.Any(), uint64(1), gomock.Any()).
+					Return(nil, fmt.Errorf("%w: unknown reaction \"clap\"", blogbus.ErrInvalidReaction))
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:     "Post In Trash",
+			method:   http.MethodPut,
+			endpoint: "/api/blog-post/1/reactions",
+			input:    blogapp.AddReaction{Reaction: "like"},
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().React(gomock.Any(), uint64(1), gomock.Any()).
+					Return(nil, fmt.Errorf("repo.react: query: %w id: 1", blogbus.ErrTrashed))
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+		{
+			name:     "Unreact",
+			method:   http.MethodDelete,
+			endpoint: "/api/blog-post/1/reactions",
+			setupExpect: func(bus *mockblogbus.MockBusiness) {
+				bus.EXPECT().Unreact(gomock.Any(), uint64(1)).Return(blogbus.ReactionCounts{}, nil)
+			},
+			expectedStatus:    fiber.StatusOK,
+			expectedReactions: map[string]int{"like": 0, "love": 0, "laugh": 0, "wow": 0, "sad": 0, "angry": 0},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			bus := mockblogbus.NewMockBusiness(ctrl)
+			tc.setupExpect(bus)
+
+			app := blogapp.NewApp(port, bus, mockcommentbus.NewMockBusiness(ctrl), authenticated(ctrl), "")
+			fbr := app.Fiber()
+
+			req, err := http.NewRequest(tc.method, tc.endpoint, nil)
+			assert.Nil(t, err)
+
+			if tc.input != nil {
+				body, err := json.Marshal(tc.input)
+				assert.Nil(t, err)
+
+				req, err = http.NewRequest(tc.method, tc.endpoint, bytes.NewReader(body))
+				assert.Nil(t, err)
+				req.Header.Set("Content-Type", "application/json")
+			}
+
+			res, err := fbr.Test(req, -1)
+			assert.Nil(t, err)
+			defer res.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+
+			if tc.expectedReactions != nil {
+				var body struct {
+					Data map[string]int `json:"data"`
+				}
+				assert.Nil(t, json.NewDecoder(res.Body).Decode(&body))
+				assert.Equal(t, tc.expectedReactions, body.Data)
+			}
+		})
+	}
+}
+
+func TestComments(t *testing.T) {
+	port := ":3000"
+
+	testCases := []struct {
+		name           string
+		method         string
+		endpoint       string
+		input          any
+		setupExpect    func(comments *mockcommentbus.MockBusiness)
+		expectedStatus int
+	}{
+		{
+			name:     "Add Comment",
+			method:   http.MethodPost,
+			endpoint: "/api/blog-post/1/comments",
+			input:    blogapp.AddComment{Author: "Ann", Body: "Nice post"},
+			setupExpect: func(comments *mockcommentbus.MockBusiness) {
+				comments.EXPECT().AddComment(gomock.Any(), uint64(1), commentbus.AddComment{Author: "Ann", Body: "Nice post"}).
+					Return(commentbus.Comment{ID: 1, PostID: 1, Author: "Ann", Body: "Nice post", CreatedAt: time.Now()}, nil)
+			},
+			expectedStatus: fiber.StatusCreated,
+		},
+		{
+			name:     "Unknown Parent",
+			method:   http.MethodPost,
+			endpoint: "/api/blog-post/1/comments",
+			input:    blogapp.AddComment{ParentID: 9, Author: "Ann", Body: "Nice post"},
+			setupExpect: func(comments *mockcommentbus.MockBusiness) {
+				comments.EXPECT().AddComment(gomock.Any(), uint64(1), gomock.Any()).
+					Return(commentbus.Comment{}, fmt.Errorf("repo.addcomment: query: %w: 9 post: 1", commentbus.ErrUnknownParent))
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:     "Post In Trash",
+			method:   http.MethodPost,
+			endpoint: "/api/blog-post/1/comments",
+			input:    blogapp.AddComment{Author: "Ann", Body: "Nice post"},
+			setupExpect: func(comments *mockcommentbus.MockBusiness) {
+				comments.EXPECT().AddComment(gomock.Any(), uint64(1), gomock.Any()).
+					Return(commentbus.Comment{}, fmt.Errorf("repo.addcomment: query: %w post: 1", blogbus.ErrTrashed))
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+		{
+			name:     "Replies",
+			method:   http.MethodGet,
+			endpoint: "/api/blog-post/1/comments?parent=2&limit=5",
+			setupExpect: func(comments *mockcommentbus.MockBusiness) {
+				comments.EXPECT().Comments(gomock.Any(), uint64(1), uint64(2), commentbus.Page{Limit: 5}).
+					Return(commentbus.CommentPage{Comments: []commentbus.Comment{{ID: 3, PostID: 1, ParentID: 2}}}, nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:     "Comment Not Found",
+			method:   http.MethodGet,
+			endpoint: "/api/blog-post/1/comments/9",
+			setupExpect: func(comments *mockcommentbus.MockBusiness) {
+				comments.EXPECT().Comment(gomock.Any(), uint64(1), uint64(9)).
+					Return(commentbus.Comment{}, fmt.Errorf("repo.comment: query: %w id: 9", commentbus.ErrCommentNotFound))
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+		{
+			name:     "Edit Window Closed",
+			method:   http.MethodPatch,
+			endpoint: "/api/blog-post/1/comments/2",
+			input:    blogapp.UpdateComment{Body: "Edited"},
+			setupExpect: func(comments *mockcommentbus.MockBusiness) {
+				comments.EXPECT().UpdateComment(gomock.Any(), uint64(1), uint64(2), commentbus.UpdateComment{Body: "Edited"}).
+					Return(commentbus.Comment{}, commentbus.ErrEditWindowClosed)
+			},
+			expectedStatus: fiber.StatusForbidden,
+		},
+		{
+			name:     "Delete Comment",
+			method:   http.MethodDelete,
+			endpoint: "/api/blog-post/1/comments/2",
+			setupExpect: func(comments *mockcommentbus.MockBusiness) {
+				comments.EXPECT().DeleteComment(gomock.Any(), uint64(1), uint64(2)).Return(nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:     "Pending Queue",
+			method:   http.MethodGet,
+			endpoint: "/api/moderation/comments?limit=5",
+			setupExpect: func(comments *mockcommentbus.MockBusiness) {
+				comments.EXPECT().CommentQueue(gomock.Any(), commentbus.StatusPending, commentbus.Page{Limit: 5}).
+					Return(commentbus.CommentPage{Comments: []commentbus.Comment{{ID: 2, PostID: 1, Status: commentbus.StatusPending}}}, nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:     "Approve Comment",
+			method:   http.MethodPost,
+			endpoint: "/api/moderation/comments/2/approve",
+			setupExpect: func(comments *mockcommentbus.MockBusiness) {
+				comments.EXPECT().ModerateComments(gomock.Any(), []uint64{2}, commentbus.StatusApproved).
+					Return([]commentbus.Comment{{ID: 2, PostID: 1, Status: commentbus.StatusApproved}}, nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:     "Reject Unknown Comment",
+			method:   http.MethodPost,
+			endpoint: "/api/moderation/comments/9/reject",
+			setupExpect: func(comments *mockcommentbus.MockBusiness) {
+				comments.EXPECT().ModerateComments(gomock.Any(), []uint64{9}, commentbus.StatusRejected).
+					Return(nil, fmt.Errorf("repo.moderatecomments: query: %w: 9 ids: [9]", commentbus.ErrCommentNotFound))
+			},
+			expectedStatus: fiber.StatusNotFound,
+		},
+		{
+			name:     "Moderate Comments",
+			method:   http.MethodPost,
+			endpoint: "/api/moderation/comments",
+			input:    blogapp.ModerateComments{IDs: []uint64{2, 3}, Status: "rejected"},
+			setupExpect: func(comments *mockcommentbus.MockBusiness) {
+				comments.EXPECT().ModerateComments(gomock.Any(), []uint64{2, 3}, commentbus.StatusRejected).
+					Return([]commentbus.Comment{{ID: 2, Status: commentbus.StatusRejected}, {ID: 3, Status: commentbus.StatusRejected}}, nil)
+			},
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:     "Moderate Comments Pending",
+			method:   http.MethodPost,
+			endpoint: "/api/moderation/comments",
+			input:    blogapp.ModerateComments{IDs: []uint64{2}, Status: "pending"},
+			setupExpect: func(comments *mockcommentbus.MockBusiness) {
+				comments.EXPECT().ModerateComments(gomock.Any(), []uint64{2}, commentbus.StatusPending).
+					Return(nil, fmt.Errorf("%w: moderators approve or reject, not \"pending\"", commentbus.ErrInvalidStatus))
+			},
+			expectedStatus: fiber.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			comments := mockcommentbus.NewMockBusiness(ctrl)
+			tc.setupExpect(comments)
+
+			app := blogapp.NewApp(port, mockblogbus.NewMockBusiness(ctrl), comments, authenticated(ctrl), "")
+			fbr := app.Fiber()
+
+			req, err := http.NewRequest(tc.method, tc.endpoint, nil)
+			assert.Nil(t, err)
+
+			if tc.input != nil {
+				body, err := json.Marshal(tc.input)
+				assert.Nil(t, err)
+
+				req, err = http.NewRequest(tc.method, tc.endpoint, bytes.NewReader(body))
+				assert.Nil(t, err)
+				req.Header.Set("Content-Type", "application/json")
+			}
+
+			res, err := fbr.Test(req, -1)
+			assert.Nil(t, err)
+			defer res.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, res.StatusCode)
+		})
+	}
+}
+
+// authenticated returns an authentication that authenticates every request, token or not.
+func authenticated(ctrl *gomock.Controller) *mockauthbus.MockBusiness {
+	auth := mockauthbus.NewMockBusiness(ctrl)
+	auth.EXPECT().Authenticate(gomock.Any(), gomock.Any()).Return(authbus.Principal{Subject: "editor", Role: authbus.RoleAdmin}, nil).AnyTimes()
+
+	return auth
+}
+
+func TestAuthentication(t *testing.T) {
+	port := ":3000"
+
+	key, err := jwt.NewHMACKey("k1", bytes.Repeat([]byte("s"), jwt.MinSecretLength))
+	assert.Nil(t, err)
+	keys, err := jwt.NewKeySet("k1", key)
+	assert.Nil(t, err)
+	repo := blogrepo.NewRepository(10)
+	auth := authbus.NewBusiness(keys, repo, authbus.Config{
+		TokenTTL: time.Hour,
+		Lockout:  authbus.Lockout{MaxFailures: 3, Duration: time.Hour},
+		Argon2:   authbus.Argon2Params{Time: 1, Memory: 64, Threads: 1},
+	})
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	bus := mockblogbus.NewMockBusiness(ctrl)
+	app := blogapp.NewApp(port, bus, mockcommentbus.NewMockBusiness(ctrl), auth, "")
+	fbr := app.Fiber()
+
+	sendAuthorized := func(method, endpoint, authorization string, input any) *http.Response {
+		body, err := json.Marshal(input)
+		assert.Nil(t, err)
+
+		req, err := http.NewRequest(method, endpoint, bytes.NewReader(body))
+		assert.Nil(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+
+		res, err := fbr.Test(req, -1)
+		assert.Nil(t, err)
+
+		return res
+	}
+
+	send := func(method, endpoint, token string, input any) *http.Response {
+		if token == "" {
+			return sendAuthorized(method, endpoint, "", input)
+		}
+
+		return sendAuthorized(method, endpoint, "Bearer "+token, input)
+	}
+
+	login := func(username, password string) (int, string) {
+		res := send(http.MethodPost, "/api/auth/login", "", blogapp.Login{Username: username, Password: password})
+		defer res.Body.Close()
+
+		var response struct {
+			Data blogapp.Token `json:"data"`
+		}
+		assert.Nil(t, json.NewDecoder(res.Body).Decode(&response))
+
+		return res.StatusCode, response.Data.Token
+	}
+
+	post := blogapp.AddBlogPost{Title: "Title", Description: "Description", Body: "Body"}
+
+	t.Run("Register", func(t *testing.T) {
+		res := send(http.MethodPost, "/api/auth/register", "", blogapp.Register{Username: "Ann", Password: "password"})
+		defer res.Body.Close()
+
+		var response struct {
+			Data map[string]any `json:"data"`
+		}
+		assert.Nil(t, json.NewDecoder(res.Body).Decode(&response))
+
+		assert.Equal(t, fiber.StatusCreated, res.StatusCode)
+		assert.Equal(t, "ann", response.Data["username"])
+		assert.NotContains(t, response.Data, "password_hash")
+	})
+
+	for name, tc := range map[string]struct {
+		input    blogapp.Register
+		expected int
+	}{
+		"Username Taken":   {input: blogapp.Register{Username: "ann", Password: "password"}, expected: fiber.StatusConflict},
+		"Invalid Username": {input: blogapp.Register{Username: "a n", Password: "password"}, expected: fiber.StatusBadRequest},
+		"Short Password":   {input: blogapp.Register{Username: "bob", Password: "pass"}, expected: fiber.StatusBadRequest},
+	} {
+		t.Run(name, func(t *testing.T) {
+			res := send(http.MethodPost, "/api/auth/register", "", tc.input)
+			defer res.Body.Close()
+
+			assert.Equal(t, tc.expected, res.StatusCode)
+		})
+	}
+
+	t.Run("Wrong Password", func(t *testing.T) {
+		status, token := login("ann", "guess")
+
+		assert.Equal(t, fiber.StatusUnauthorized, status)
+		assert.Empty(t, token)
+	})
+
+	t.Run("No Token", func(t *testing.T) {
+		res := send(http.MethodPost, "/api/blog-post", "", post)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusUnauthorized, res.StatusCode)
+		assert.Equal(t, "Bearer, ApiKey", res.Header.Get("WWW-Authenticate"))
+	})
+
+	t.Run("Unknown Scheme", func(t *testing.T) {
+		res := sendAuthorized(http.MethodPost, "/api/blog-post", "Basic YW5uOnBhc3N3b3Jk", post)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusUnauthorized, res.StatusCode)
+	})
+
+	t.Run("Forged Token", func(t *testing.T) {
+		other, err := jwt.NewHMACKey("k1", bytes.Repeat([]byte("f"), jwt.MinSecretLength))
+		assert.Nil(t, err)
+		forger, err := jwt.NewKeySet("k1", other)
+		assert.Nil(t, err)
+		token, err := forger.Sign(jwt.NewClaims("ann", time.Now(), time.Hour))
+		assert.Nil(t, err)
+
+		res := send(http.MethodGet, "/api/admin/cache/stats", token, nil)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusUnauthorized, res.StatusCode)
+	})
+
+	t.Run("Logged In", func(t *testing.T) {
+		status, token := login("ann", "password")
+		assert.Equal(t, fiber.StatusOK, status)
+
+		bus.EXPECT().AddBlogPost(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, _ blogbus.AddBlogPost) (blogbus.ID, error) {
+				p, ok := authbus.PrincipalFrom(ctx)
+				assert.True(t, ok)
+				assert.Equal(t, "ann", p.Subject)
+				return blogbus.ToID(1), nil
+			})
+
+		res := send(http.MethodPost, "/api/blog-post", token, post)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusCreated, res.StatusCode)
+	})
+
+	t.Run("Reader Routes Without Token", func(t *testing.T) {
+		for _, route := range []struct {
+			method, endpoint string
+			input            any
+		}{
+			{http.MethodPut, "/api/blog-post/1/reactions", blogapp.AddReaction{Reaction: "like"}},
+			{http.MethodDelete, "/api/blog-post/1/reactions", nil},
+			{http.MethodPost, "/api/blog-post/1/comments", blogapp.AddComment{Author: "bob", Body: "Body"}},
+		} {
+			res := send(route.method, route.endpoint, "", route.input)
+			res.Body.Close()
+
+			assert.Equal(t, fiber.StatusUnauthorized, res.StatusCode, route.method+" "+route.endpoint)
+		}
+	})
+
+	t.Run("Reader Reaction", func(t *testing.T) {
+		_, token := login("ann", "password")
+
+		bus.EXPECT().React(gomock.Any(), uint64(1), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, _ uint64, _ blogbus.AddReaction) (blogbus.ReactionCounts, error) {
+				p, ok := authbus.PrincipalFrom(ctx)
+				assert.True(t, ok)
+				assert.Equal(t, "ann", p.Subject)
+				return blogbus.ReactionCounts{blogbus.ReactionLike: 1}, nil
+			})
+
+		res := send(http.MethodPut, "/api/blog-post/1/reactions", token, blogapp.AddReaction{Reaction: "like"})
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+	})
+
+	t.Run("Roles", func(t *testing.T) {
+		res := send(http.MethodPost, "/api/auth/register", "", blogapp.Register{Username: "bob", Password: "password"})
+		res.Body.Close()
+		assert.Equal(t, fiber.StatusCreated, res.StatusCode)
+
+		// Registering first made ann no admin, the operator does, as -grant-admin does. bob is a reader.
+		_, admin := login("ann", "password")
+		res = send(http.MethodGet, "/api/admin/users", admin, nil)
+		res.Body.Close()
+		assert.Equal(t, fiber.StatusForbidden, res.StatusCode)
+
+		_, err := repo.UpdateUser(t.Context(), "ann", authbus.RoleChange{Role: authbus.RoleAdmin})
+		assert.Nil(t, err)
+
+		_, reader := login("bob", "password")
+
+		res = send(http.MethodGet, "/api/admin/users", reader, nil)
+		res.Body.Close()
+		assert.Equal(t, fiber.StatusForbidden, res.StatusCode)
+
+		for _, tc := range []struct {
+			username string
+			role     string
+			expected int
+		}{
+			{username: "bob", role: "owner", expected: fiber.StatusBadRequest},
+			{username: "eve", role: "author", expected: fiber.StatusNotFound},
+			{username: "ann", role: "editor", expected: fiber.StatusForbidden},
+			{username: "bob", role: "author", expected: fiber.StatusOK},
+		} {
+			res := send(http.MethodPatch, "/api/admin/users/"+tc.username, admin, blogapp.SetRole{Role: tc.role})
+			res.Body.Close()
+			assert.Equal(t, tc.expected, res.StatusCode, tc.username+" "+tc.role)
+		}
+
+		res = send(http.MethodGet, "/api/admin/users", admin, nil)
+		defer res.Body.Close()
+
+		var response struct {
+			Data []blogapp.Account `json:"data"`
+		}
+		assert.Nil(t, json.NewDecoder(res.Body).Decode(&response))
+
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+		assert.Equal(t, []string{"admin", "author"}, []string{response.Data[0].Role, response.Data[1].Role})
+	})
+
+	t.Run("API Keys", func(t *testing.T) {
+		_, token := login("ann", "password")
+
+		issue := func(res *http.Response) blogapp.IssuedAPIKey {
+			defer res.Body.Close()
+
+			var response struct {
+				Data blogapp.IssuedAPIKey `json:"data"`
+			}
+			assert.Nil(t, json.NewDecoder(res.Body).Decode(&response))
+
+			return response.Data
+		}
+
+		res := send(http.MethodPost, "/api/auth/keys", token, blogapp.CreateAPIKey{Name: "ci", Scopes: []string{"posts:delete"}})
+		res.Body.Close()
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+
+		res = send(http.MethodPost, "/api/auth/keys", token, blogapp.CreateAPIKey{Name: "ci", Scopes: []string{"posts:write"}})
+		assert.Equal(t, fiber.StatusCreated, res.StatusCode)
+		created := issue(res)
+		assert.NotEmpty(t, created.Key)
+		assert.Equal(t, []string{"posts:write"}, created.Scopes)
+
+		bus.EXPECT().AddBlogPost(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, _ blogbus.AddBlogPost) (blogbus.ID, error) {
+				p, ok := authbus.PrincipalFrom(ctx)
+				assert.True(t, ok)
+				assert.Equal(t, "ann", p.Subject)
+				assert.Equal(t, created.ID, p.Key)
+				return blogbus.ToID(2), nil
+			})
+
+		res = sendAuthorized(http.MethodPost, "/api/blog-post", "ApiKey "+created.Key, post)
+		res.Body.Close()
+		assert.Equal(t, fiber.StatusCreated, res.StatusCode)
+
+		// A key cannot manage keys nor end a session.
+		res = sendAuthorized(http.MethodPost, "/api/auth/keys", "ApiKey "+created.Key, blogapp.CreateAPIKey{Name: "more", Scopes: []string{"admin"}})
+		res.Body.Close()
+		assert.Equal(t, fiber.StatusForbidden, res.StatusCode)
+
+		res = sendAuthorized(http.MethodPost, "/api/auth/logout", "ApiKey "+created.Key, nil)
+		res.Body.Close()
+		assert.Equal(t, fiber.StatusForbidden, res.StatusCode)
+
+		res = send(http.MethodGet, "/api/auth/keys", token, nil)
+		var listed struct {
+			Data []map[string]any `json:"data"`
+		}
+		assert.Nil(t, json.NewDecoder(res.Body).Decode(&listed))
+		res.Body.Close()
+		assert.Len(t, listed.Data, 1)
+		assert.NotContains(t, listed.Data[0], "key")
+		assert.NotContains(t, listed.Data[0], "hash")
+		assert.Contains(t, listed.Data[0], "last_used_at")
+
+		res = send(http.MethodPost, "/api/auth/keys/"+created.ID+"/rotate", token, nil)
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+		rotated := issue(res)
+		assert.Equal(t, created.ID, rotated.ID)
+		assert.NotEqual(t, created.Key, rotated.Key)
+
+		res = sendAuthorized(http.MethodPost, "/api/blog-post", "ApiKey "+created.Key, post)
+		res.Body.Close()
+		assert.Equal(t, fiber.StatusUnauthorized, res.StatusCode)
+
+		res = send(http.MethodDelete, "/api/auth/keys/"+created.ID, token, nil)
+		res.Body.Close()
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+
+		res = sendAuthorized(http.MethodPost, "/api/blog-post", "ApiKey "+rotated.Key, post)
+		res.Body.Close()
+		assert.Equal(t, fiber.StatusUnauthorized, res.StatusCode)
+
+		res = send(http.MethodPost, "/api/auth/keys/"+created.ID+"/rotate", token, nil)
+		res.Body.Close()
+		assert.Equal(t, fiber.StatusConflict, res.StatusCode)
+
+		res = send(http.MethodDelete, "/api/auth/keys/missing", token, nil)
+		res.Body.Close()
+		assert.Equal(t, fiber.StatusNotFound, res.StatusCode)
+	})
+
+	t.Run("Change Password", func(t *testing.T) {
+		_, token := login("ann", "password")
+		_, other := login("ann", "password")
+
+		res := send(http.MethodPost, "/api/auth/password", token, blogapp.ChangePassword{CurrentPassword: "password", NewPassword: "new password"})
+		res.Body.Close()
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+
+		// The other sessions end, the one that changed the password goes on.
+		res = send(http.MethodGet, "/api/admin/cache/stats", other, nil)
+		res.Body.Close()
+		assert.Equal(t, fiber.StatusUnauthorized, res.StatusCode)
+
+		status, _ := login("ann", "password")
+		assert.Equal(t, fiber.StatusUnauthorized, status)
+
+		status, _ = login("ann", "new password")
+		assert.Equal(t, fiber.StatusOK, status)
+	})
+
+	t.Run("Logout", func(t *testing.T) {
+		_, token := login("ann", "new password")
+
+		res := send(http.MethodPost, "/api/auth/logout", token, nil)
+		res.Body.Close()
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+
+		res = send(http.MethodPost, "/api/blog-post", token, post)
+		res.Body.Close()
+		assert.Equal(t, fiber.StatusUnauthorized, res.StatusCode)
+	})
+
+	t.Run("Locked", func(t *testing.T) {
+		for range 2 {
+			status, _ := login("ann", "guess")
+			assert.Equal(t, fiber.StatusUnauthorized, status)
+		}
+
+		status, _ := login("ann", "guess")
+		assert.Equal(t, fiber.StatusLocked, status)
+
+		status, _ = login("ann", "new password")
+		assert.Equal(t, fiber.StatusLocked, status)
+	})
+}
+
+func TestTenants(t *testing.T) {
+	port := ":3000"
+
+	key, err := jwt.NewHMACKey("k1", bytes.Repeat([]byte("s"), jwt.MinSecretLength))
+	assert.Nil(t, err)
+	keys, err := jwt.NewKeySet("k1", key)
+	assert.Nil(t, err)
+
+	// Every tenant has a repository of its own, the key set is shared like in the server.
+	repos := make(map[string]authbus.Repo)
+	tenant := func(name string, quota int, hosts ...string) blogapp.Tenant {
+		repo := blogrepo.NewRepository(10)
+		repo.SetQuota(quota)
+		repos[name] = repo
+
+		return blogapp.Tenant{
+			Name:     name,
+			Hosts:    hosts,
+			Business: blogbus.NewBusiness(repo),
+			Comments: commentbus.NewBusiness(repo, commentbus.DefaultEditWindow),
+			Auth: authbus.NewBusiness(keys, repo, authbus.Config{
+				TokenTTL: time.Hour,
+				Argon2:   authbus.Argon2Params{Time: 1, Memory: 64, Threads: 1},
+			}),
+		}
+	}
+
+	app := blogapp.NewTenantApp(port, []blogapp.Tenant{
+		tenant("eng", 2, "eng.example"),
+		tenant("ops", 0, "ops.example", "blog.ops.example"),
+	}, "")
+	fbr := app.Fiber()
+
+	send := func(method, host, endpoint, token string, input any) *http.Response {
+		body, err := json.Marshal(input)
+		assert.Nil(t, err)
+
+		req, err := http.NewRequest(method, endpoint, bytes.NewReader(body))
+		assert.Nil(t, err)
+		req.Host = host
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		res, err := fbr.Test(req, -1)
+		assert.Nil(t, err)
+
+		return res
+	}
+
+	// signup registers ann to the blog named name, has the operator make them an admin of it, as
+	// -grant-admin does, and returns their token.
+	signup := func(name, host, prefix string) string {
+		res := send(http.MethodPost, host, prefix+"/api/auth/register", "", blogapp.Register{Username: "ann", Password: "password"})
+		res.Body.Close()
+		assert.Equal(t, fiber.StatusCreated, res.StatusCode)
+
+		_, err := repos[name].UpdateUser(t.Context(), "ann", authbus.RoleChange{Role: authbus.RoleAdmin})
+		assert.Nil(t, err)
+
+		res = send(http.MethodPost, host, prefix+"/api/auth/login", "", blogapp.Login{Username: "ann", Password: "password"})
+		defer res.Body.Close()
+
+		var response struct {
+			Data blogapp.Token `json:"data"`
+		}
+		assert.Nil(t, json.NewDecoder(res.Body).Decode(&response))
+
+		return response.Data.Token
+	}
+
+	// add writes post and publishes it, for anyone to read.
+	add := func(host, prefix, token string, post blogapp.AddBlogPost) (int, uint64) {
+		res := send(http.MethodPost, host, prefix+"/api/blog-post", token, post)
+		defer res.Body.Close()
+
+		var response struct {
+			Data blogapp.BlogPostID `json:"data"`
+		}
+		assert.Nil(t, json.NewDecoder(res.Body).Decode(&response))
+
+		if res.StatusCode == fiber.StatusCreated {
+			published := send(http.MethodPost, host, fmt.Sprintf("%s/api/blog-post/%d/publish", prefix, response.Data.ID), token, nil)
+			published.Body.Close()
+			assert.Equal(t, fiber.StatusOK, published.StatusCode)
+		}
+
+		return res.StatusCode, response.Data.ID
+	}
+
+	title := func(host, endpoint string) (int, string) {
+		res := send(http.MethodGet, host, endpoint, "", nil)
+		defer res.Body.Close()
+
+		// Fiber answers a route of no tenant in plain text.
+		if res.StatusCode == fiber.StatusNotFound {
+			return res.StatusCode, ""
+		}
+
+		var response struct {
+			Data blogapp.BlogPost `json:"data"`
+		}
+		assert.Nil(t, json.NewDecoder(res.Body).Decode(&response))
+
+		return res.StatusCode, response.Data.Title
+	}
+
+	// Users are per tenant, ann signs up to both.
+	eng := signup("eng", "localhost", "/blogs/eng")
+	ops := signup("ops", "blog.ops.example:3000", "")
+
+	t.Run("Own ID Space", func(t *testing.T) {
+		status, id := add("localhost", "/blogs/eng", eng, blogapp.AddBlogPost{Title: "Eng", Description: "Description", Body: "Body", Slug: "eng-post"})
+		assert.Equal(t, fiber.StatusCreated, status)
+		assert.Equal(t, uint64(1), id)
+
+		status, id = add("ops.example", "", ops, blogapp.AddBlogPost{Title: "Ops", Description: "Description", Body: "Body"})
+		assert.Equal(t, fiber.StatusCreated, status)
+		assert.Equal(t, uint64(1), id)
+
+		// Guessed on ops below.
+		status, id = add("eng.example", "", eng, blogapp.AddBlogPost{Title: "Eng 2", Description: "Description", Body: "Body"})
+		assert.Equal(t, fiber.StatusCreated, status)
+		assert.Equal(t, uint64(2), id)
+	})
+
+	for name, tc := range map[string]struct {
+		host, endpoint string
+		expectedStatus int
+		expectedTitle  string
+	}{
+		"Prefix":               {host: "localhost", endpoint: "/blogs/eng/api/blog-post/1", expectedStatus: fiber.StatusOK, expectedTitle: "Eng"},
+		"Host":                 {host: "ops.example", endpoint: "/api/blog-post/1", expectedStatus: fiber.StatusOK, expectedTitle: "Ops"},
+		"Host With Port":       {host: "ENG.example:3000", endpoint: "/api/blog-post/1", expectedStatus: fiber.StatusOK, expectedTitle: "Eng"},
+		"Host Over Prefix":     {host: "eng.example", endpoint: "/blogs/ops/api/blog-post/1", expectedStatus: fiber.StatusNotFound},
+		"Unknown Tenant":       {host: "localhost", endpoint: "/blogs/dev/api/blog-post/1", expectedStatus: fiber.StatusNotFound},
+		"Unknown Host":         {host: "dev.example", endpoint: "/api/blog-post/1", expectedStatus: fiber.StatusNotFound},
+		"ID Of Another Blog":   {host: "localhost", endpoint: "/blogs/ops/api/blog-post/2", expectedStatus: fiber.StatusNotFound},
+		"Slug Of Another Blog": {host: "ops.example", endpoint: "/api/blog-post/by-slug/eng-post", expectedStatus: fiber.StatusNotFound},
+	} {
+		t.Run(name, func(t *testing.T) {
+			status, title := title(tc.host, tc.endpoint)
+
+			assert.Equal(t, tc.expectedStatus, status)
+			assert.Equal(t, tc.expectedTitle, title)
+		})
+	}
+
+	t.Run("Token Of Another Blog", func(t *testing.T) {
+		status, _ := add("localhost", "/blogs/ops", eng, blogapp.AddBlogPost{Title: "Ops", Description: "Description", Body: "Body"})
+
+		assert.Equal(t, fiber.StatusUnauthorized, status)
+	})
+
+	t.Run("Quota", func(t *testing.T) {
+		// eng holds its quota of 2 posts.
+		status, _ := add("localhost", "/blogs/eng", eng, blogapp.AddBlogPost{Title: "More", Description: "Description", Body: "Body"})
+		assert.Equal(t, fiber.StatusUnprocessableEntity, status)
+
+		status, _ = add("localhost", "/blogs/ops", ops, blogapp.AddBlogPost{Title: "More", Description: "Description", Body: "Body"})
+		assert.Equal(t, fiber.StatusCreated, status)
+	})
+
+	t.Run("Slug Redirect", func(t *testing.T) {
+		res := send(http.MethodPatch, "localhost", "/blogs/eng/api/blog-post/1", eng, blogapp.UpdateBlogPost{Slug: "eng-renamed"})
+		res.Body.Close()
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+
+		for host, expected := range map[string]string{
+			"localhost":   "/blogs/eng/api/blog-post/by-slug/eng-renamed",
+			"eng.example": "/api/blog-post/by-slug/eng-renamed",
+		} {
+			prefix := "/blogs/eng"
+			if host != "localhost" {
+				prefix = ""
+			}
+
+			res := send(http.MethodGet, host, prefix+"/api/blog-post/by-slug/eng-post", "", nil)
+			res.Body.Close()
+
+			assert.Equal(t, fiber.StatusMovedPermanently, res.StatusCode)
+			assert.Equal(t, expected, res.Header.Get("Location"))
+		}
+	})
+}
+
+func TestDrafts(t *testing.T) {
+	port := ":3000"
+
+	key, err := jwt.NewHMACKey("k1", bytes.Repeat([]byte("s"), jwt.MinSecretLength))
+	assert.Nil(t, err)
+	keys, err := jwt.NewKeySet("k1", key)
+	assert.Nil(t, err)
+
+	repo := blogrepo.NewRepository(10)
+	auth := authbus.NewBusiness(keys, repo, authbus.Config{
+		TokenTTL: time.Hour,
+		Argon2:   authbus.Argon2Params{Time: 1, Memory: 64, Threads: 1},
+	})
+
+	app := blogapp.NewApp(port, blogbus.NewBusiness(repo), commentbus.NewBusiness(repo, commentbus.DefaultEditWindow), auth, "")
+	fbr := app.Fiber()
+
+	send := func(method, endpoint, token string, input any) *http.Response {
+		body, err := json.Marshal(input)
+		assert.Nil(t, err)
+
+		req, err := http.NewRequest(method, endpoint, bytes.NewReader(body))
+		assert.Nil(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		res, err := fbr.Test(req, -1)
+		assert.Nil(t, err)
+
+		return res
+	}
+
+	// signup registers username with role and returns their token.
+	signup := func(username string, role authbus.Role) string {
+		res := send(http.MethodPost, "/api/auth/register", "", blogapp.Register{Username: username, Password: "password"})
+		res.Body.Close()
+		assert.Equal(t, fiber.StatusCreated, res.StatusCode)
+
+		_, err := repo.UpdateUser(t.Context(), username, authbus.RoleChange{Role: role})
+		assert.Nil(t, err)
+
+		res = send(http.MethodPost, "/api/auth/login", "", blogapp.Login{Username: username, Password: "password"})
+		defer res.Body.Close()
+
+		var response struct {
+			Data blogapp.Token `json:"data"`
+		}
+		assert.Nil(t, json.NewDecoder(res.Body).Decode(&response))
+
+		return response.Data.Token
+	}
+
+	ann := signup("ann", authbus.RoleAuthor)
+	bob := signup("bob", authbus.RoleAuthor)
+	eve := signup("eve", authbus.RoleEditor)
+
+	// ann writes a draft, 1, and a post eve publishes, 2.
+	for _, title := range []string{"Draft", "Published"} {
+		res := send(http.MethodPost, "/api/blog-post", ann, blogapp.AddBlogPost{Title: title, Description: "Description", Body: "Body"})
+		res.Body.Close()
+		assert.Equal(t, fiber.StatusCreated, res.StatusCode)
+	}
+
+	res := send(http.MethodPost, "/api/blog-post/2/publish", eve, nil)
+	res.Body.Close()
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+
+	t.Run("Read", func(t *testing.T) {
+		for name, tc := range map[string]struct {
+			endpoint       string
+			token          string
+			expectedStatus int
+		}{
+			"Anonymous":                  {endpoint: "/api/blog-post/1", expectedStatus: fiber.StatusNotFound},
+			"Anonymous By Slug":          {endpoint: "/api/blog-post/by-slug/draft", expectedStatus: fiber.StatusNotFound},
+			"Anonymous Revisions":        {endpoint: "/api/blog-post/1/revisions", expectedStatus: fiber.StatusNotFound},
+			"Anonymous Revision":         {endpoint: "/api/blog-post/1/revisions/1", expectedStatus: fiber.StatusNotFound},
+			"Anonymous Diff":             {endpoint: "/api/blog-post/1/revisions/diff?from=1&to=1", expectedStatus: fiber.StatusNotFound},
+			"Another Author":             {endpoint: "/api/blog-post/1", token: bob, expectedStatus: fiber.StatusNotFound},
+			"Another Author Revisions":   {endpoint: "/api/blog-post/1/revisions", token: bob, expectedStatus: fiber.StatusNotFound},
+			"Author":                     {endpoint: "/api/blog-post/1", token: ann, expectedStatus: fiber.StatusOK},
+			"Author By Slug":             {endpoint: "/api/blog-post/by-slug/draft", token: ann, expectedStatus: fiber.StatusOK},
+			"Author Revisions":           {endpoint: "/api/blog-post/1/revisions", token: ann, expectedStatus: fiber.StatusOK},
+			"Editor":                     {endpoint: "/api/blog-post/1", token: eve, expectedStatus: fiber.StatusOK},
+			"Editor Diff":                {endpoint: "/api/blog-post/1/revisions/diff?from=1&to=1", token: eve, expectedStatus: fiber.StatusOK},
+			"Anonymous Comments":         {endpoint: "/api/blog-post/1/comments", expectedStatus: fiber.StatusNotFound},
+			"Anonymous Comment":          {endpoint: "/api/blog-post/1/comments/1", expectedStatus: fiber.StatusNotFound},
+			"Another Author Comments":    {endpoint: "/api/blog-post/1/comments", token: bob, expectedStatus: fiber.StatusNotFound},
+			"Author Comments":            {endpoint: "/api/blog-post/1/comments", token: ann, expectedStatus: fiber.StatusOK},
+			"Editor Comments":            {endpoint: "/api/blog-post/1/comments", token: eve, expectedStatus: fiber.StatusOK},
+			"Anonymous Published":        {endpoint: "/api/blog-post/2", expectedStatus: fiber.StatusOK},
+			"Anonymous Published Slug":   {endpoint: "/api/blog-post/by-slug/published", expectedStatus: fiber.StatusOK},
+			"Invalid Token On Published": {endpoint: "/api/blog-post/2", token: "invalid", expectedStatus: fiber.StatusUnauthorized},
+		} {
+			t.Run(name, func(t *testing.T) {
+				res := send(http.MethodGet, tc.endpoint, tc.token, nil)
+				res.Body.Close()
+
+				assert.Equal(t, tc.expectedStatus, res.StatusCode)
+			})
+		}
+	})
+
+	t.Run("Comment", func(t *testing.T) {
+		ray := signup("ray", authbus.RoleReader)
+
+		for name, tc := range map[string]struct {
+			endpoint       string
+			token          string
+			expectedStatus int
+		}{
+			"Reader On Draft":     {endpoint: "/api/blog-post/1/comments", token: ray, expectedStatus: fiber.StatusNotFound},
+			"Author On Draft":     {endpoint: "/api/blog-post/1/comments", token: ann, expectedStatus: fiber.StatusCreated},
+			"Reader On Published": {endpoint: "/api/blog-post/2/comments", token: ray, expectedStatus: fiber.StatusCreated},
+		} {
+			t.Run(name, func(t *testing.T) {
+				res := send(http.MethodPost, tc.endpoint, tc.token, blogapp.AddComment{Author: "Ray", Body: "Body"})
+				res.Body.Close()
+
+				assert.Equal(t, tc.expectedStatus, res.StatusCode)
+			})
+		}
+	})
+
+	t.Run("React", func(t *testing.T) {
+		rex := signup("rex", authbus.RoleReader)
+
+		for name, tc := range map[string]struct {
+			method         string
+			endpoint       string
+			token          string
+			expectedStatus int
+		}{
+			"Reader On Draft":          {method: http.MethodPut, endpoint: "/api/blog-post/1/reactions", token: rex, expectedStatus: fiber.StatusNotFound},
+			"Reader Unreacts On Draft": {method: http.MethodDelete, endpoint: "/api/blog-post/1/reactions", token: rex, expectedStatus: fiber.StatusNotFound},
+			"Author On Draft":          {method: http.MethodPut, endpoint: "/api/blog-post/1/reactions", token: ann, expectedStatus: fiber.StatusOK},
+			"Reader On Published":      {method: http.MethodPut, endpoint: "/api/blog-post/2/reactions", token: rex, expectedStatus: fiber.StatusOK},
+		} {
+			t.Run(name, func(t *testing.T) {
+				res := send(tc.method, tc.endpoint, tc.token, blogapp.AddReaction{Reaction: "like"})
+				res.Body.Close()
+
+				assert.Equal(t, tc.expectedStatus, res.StatusCode)
+			})
+		}
+	})
+
+	t.Run("List", func(t *testing.T) {
+		for name, tc := range map[string]struct {
+			endpoint       string
+			token          string
+			expectedStatus int
+			expectedTitles []string
+		}{
+			"Anonymous":          {endpoint: "/api/blog-post", expectedStatus: fiber.StatusOK, expectedTitles: []string{"Published"}},
+			"Anonymous Drafts":   {endpoint: "/api/blog-post?status=draft", expectedStatus: fiber.StatusUnauthorized},
+			"Anonymous Archived": {endpoint: "/api/blog-post?status=archived", expectedStatus: fiber.StatusUnauthorized},
+			"Reader Drafts":      {endpoint: "/api/blog-post?status=draft", token: signup("dan", authbus.RoleReader), expectedStatus: fiber.StatusForbidden},
+			"Author Drafts":      {endpoint: "/api/blog-post?status=draft", token: ann, expectedStatus: fiber.StatusOK, expectedTitles: []string{"Draft"}},
+			"Another Author":     {endpoint: "/api/blog-post?status=draft", token: bob, expectedStatus: fiber.StatusOK},
+			"Editor Drafts":      {endpoint: "/api/blog-post?status=draft", token: eve, expectedStatus: fiber.StatusOK, expectedTitles: []string{"Draft"}},
+			"Anonymous Trash":    {endpoint: "/api/blog-post/trash", expectedStatus: fiber.StatusUnauthorized},
+			"Author Trash":       {endpoint: "/api/blog-post/trash", token: ann, expectedStatus: fiber.StatusForbidden},
+			"Editor Trash":       {endpoint: "/api/blog-post/trash", token: eve, expectedStatus: fiber.StatusOK},
+		} {
+			t.Run(name, func(t *testing.T) {
+				res := send(http.MethodGet, tc.endpoint, tc.token, nil)
+				defer res.Body.Close()
+
+				var response struct {
+					Data []blogapp.BlogPost `json:"data"`
+				}
+				assert.Nil(t, json.NewDecoder(res.Body).Decode(&response))
+
+				var titles []string
+				for _, bp := range response.Data {
+					titles = append(titles, bp.Title)
+				}
+
+				assert.Equal(t, tc.expectedStatus, res.StatusCode)
+				assert.Equal(t, tc.expectedTitles, titles)
+			})
+		}
+	})
+}
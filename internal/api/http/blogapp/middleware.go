@@ -0,0 +1,101 @@
+package blogapp
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"io"
+	"strings"
+
+	"github.com/anazcodes/blogapp/internal/business/authbus"
+	errs "github.com/anazcodes/blogapp/internal/errs/blogapperr"
+	"github.com/anazcodes/blogapp/pkg/request"
+	"github.com/gofiber/fiber/v2"
+)
+
+// config is the configuration of the servers. They stream request bodies so a restore reads a snapshot
+// of any size, limitBody holds every other route to fiber.DefaultBodyLimit.
+var config = fiber.Config{StreamRequestBody: true}
+
+// operatorHeader carries the operator token of a request from outside the host of the server.
+const operatorHeader = "X-Operator-Token"
+
+// authenticate rejects a request unless its Authorization header authenticates it, with the bearer
+// token of a session or with an API key. The principal it is authenticated as is passed on in the user
+// context, which request.Handle hands to the business.
+func (a *app) authenticate(c *fiber.Ctx) error {
+	authenticate := a.auth.Authenticate
+
+	scheme, credentials := authorization(c.Get(fiber.HeaderAuthorization))
+	if strings.EqualFold(scheme, "ApiKey") {
+		authenticate = a.auth.AuthenticateAPIKey
+	} else if !strings.EqualFold(scheme, "Bearer") {
+		credentials = ""
+	}
+
+	p, err := authenticate(c.UserContext(), credentials)
+	if err != nil {
+		c.Set(fiber.HeaderWWWAuthenticate, "Bearer, ApiKey")
+		response := errs.Response(err)
+		return c.Status(response.Status).JSON(response)
+	}
+
+	c.SetUserContext(authbus.WithPrincipal(c.UserContext(), p))
+
+	return c.Next()
+}
+
+// identify authenticates a request with an Authorization header like authenticate, and passes a request
+// without one on anonymously. The routes that serve anyone, and some principals more, take it.
+func (a *app) identify(c *fiber.Ctx) error {
+	if c.Get(fiber.HeaderAuthorization) == "" {
+		return c.Next()
+	}
+
+	return a.authenticate(c)
+}
+
+// authorization returns the scheme and the credentials of an Authorization header.
+func authorization(header string) (scheme, credentials string) {
+	scheme, credentials, _ = strings.Cut(header, " ")
+
+	return scheme, strings.TrimSpace(credentials)
+}
+
+// operate rejects a request for the routes that copy or replace the whole blog unless it comes from the
+// host of the server, or carries the operator token the server was started with. An admin account alone
+// is not trusted with those, it is one leaked password away from anyone.
+func (a *app) operate(c *fiber.Ctx) error {
+	if c.IsFromLocal() {
+		return c.Next()
+	}
+
+	token := c.Get(operatorHeader)
+	if a.operatorToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(a.operatorToken)) == 1 {
+		return c.Next()
+	}
+
+	response := request.NewResponse(fiber.StatusForbidden, "Forbidden, the route only serves the operator of the server", nil, "not an operator")
+	return c.Status(response.Status).JSON(response)
+}
+
+// limitBody rejects a request whose body is larger than fiber.DefaultBodyLimit, or of a length it does
+// not declare, before a handler reads it, as a server that does not stream request bodies would. The
+// connection is closed after, the rest of the body is never read off it.
+func limitBody(c *fiber.Ctx) error {
+	if n := c.Request().Header.ContentLength(); n > fiber.DefaultBodyLimit || n == -1 {
+		c.Context().SetConnectionClose()
+		response := request.NewResponse(fiber.StatusRequestEntityTooLarge, "Failed to read, the body is too large", nil, fiber.ErrRequestEntityTooLarge.Error())
+		return c.Status(response.Status).JSON(response)
+	}
+
+	return c.Next()
+}
+
+// body returns the body of a request as it streams in, unlike c.Body it does not hold it in memory.
+func body(c *fiber.Ctx) io.Reader {
+	if r := c.Context().RequestBodyStream(); r != nil {
+		return r
+	}
+
+	return bytes.NewReader(c.Body())
+}
@@ -1,18 +1,32 @@
 package blogapp
 
 import (
+	"fmt"
 	"time"
 
+	"github.com/anazcodes/blogapp/internal/business/authbus"
 	"github.com/anazcodes/blogapp/internal/business/blogbus"
+	"github.com/anazcodes/blogapp/internal/business/commentbus"
+	"github.com/anazcodes/blogapp/pkg/request"
 )
 
 type BlogPost struct {
 	ID          uint64    `json:"id"`
+	Slug        string    `json:"slug"`
 	Title       string    `json:"title"`
 	Description string    `json:"description"`
 	Body        string    `json:"body"`
+	Status      string    `json:"status"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
+	PublishedAt time.Time `json:"published_at,omitzero"`
+	PublishAt   time.Time `json:"publish_at,omitzero"`
+	DeletedAt   time.Time `json:"deleted_at,omitzero"`
+	Tags        []string  `json:"tags"`
+	Categories  []string  `json:"categories"`
+	AuthorID    uint64    `json:"author_id,omitempty"`
+	// Reactions is the number of readers who reacted with each reaction.
+	Reactions map[string]int `json:"reactions"`
 }
 
 func toBlogPosts(bps []blogbus.BlogPost) []BlogPost {
@@ -28,18 +42,184 @@ func toBlogPosts(bps []blogbus.BlogPost) []BlogPost {
 func toBlogPost(bp blogbus.BlogPost) BlogPost {
 	return BlogPost{
 		ID:          bp.ID,
+		Slug:        bp.Slug,
 		Title:       bp.Title,
 		Description: bp.Description,
 		Body:        bp.Body,
+		Status:      string(bp.Status),
 		CreatedAt:   bp.CreatedAt,
 		UpdatedAt:   bp.UpdatedAt,
+		PublishedAt: bp.PublishedAt,
+		PublishAt:   bp.PublishAt,
+		DeletedAt:   bp.DeletedAt,
+		Tags:        orEmpty(bp.Tags),
+		Categories:  orEmpty(bp.Categories),
+		AuthorID:    bp.AuthorID,
+		Reactions:   toReactions(bp.Reactions),
+	}
+}
+
+// toReactions returns rc with every reaction, the ones no reader used counting zero.
+func toReactions(rc blogbus.ReactionCounts) map[string]int {
+	out := make(map[string]int, len(blogbus.Reactions))
+
+	for _, r := range blogbus.Reactions {
+		out[string(r)] = rc[r]
+	}
+
+	return out
+}
+
+// orEmpty returns s, or an empty slice for nil so it is encoded as an empty array.
+func orEmpty(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+
+	return s
+}
+
+type BlogPostsQuery struct {
+	Limit         int    `query:"limit"`
+	Cursor        string `query:"cursor"`
+	CreatedFrom   string `query:"created_from"`
+	CreatedTo     string `query:"created_to"`
+	UpdatedFrom   string `query:"updated_from"`
+	UpdatedTo     string `query:"updated_to"`
+	TitlePrefix   string `query:"title_prefix"`
+	TitleContains string `query:"title_contains"`
+	Status        string `query:"status"`
+	Tag           string `query:"tag"`
+	Category      string `query:"category"`
+	Author        uint64 `query:"author"`
+	Sort          string `query:"sort"`
+	Order         string `query:"order"`
+}
+
+func toBusQuery(q *BlogPostsQuery) (blogbus.Query, error) {
+	bq := blogbus.Query{
+		TitlePrefix:   q.TitlePrefix,
+		TitleContains: q.TitleContains,
+		Status:        blogbus.Status(q.Status),
+		Tag:           q.Tag,
+		Category:      q.Category,
+		AuthorID:      q.Author,
+		SortBy:        blogbus.SortField(q.Sort),
+	}
+
+	// Without a status only the published posts are listed. The business lists the others to their
+	// authors and to editors only.
+	if bq.Status == "" {
+		bq.Status = blogbus.StatusPublished
+	}
+
+	switch q.Order {
+	case "", "asc":
+	case "desc":
+		bq.Desc = true
+	default:
+		return blogbus.Query{}, fmt.Errorf("%w: unknown order %q", blogbus.ErrInvalidQuery, q.Order)
+	}
+
+	times := []struct {
+		name  string
+		value string
+		dst   *time.Time
+	}{
+		{"created_from", q.CreatedFrom, &bq.CreatedFrom},
+		{"created_to", q.CreatedTo, &bq.CreatedTo},
+		{"updated_from", q.UpdatedFrom, &bq.UpdatedFrom},
+		{"updated_to", q.UpdatedTo, &bq.UpdatedTo},
+	}
+
+	for _, t := range times {
+		if t.value == "" {
+			continue
+		}
+
+		v, err := time.Parse(time.RFC3339, t.value)
+		if err != nil {
+			return blogbus.Query{}, fmt.Errorf("%w: %s: %v", blogbus.ErrInvalidQuery, t.name, err)
+		}
+
+		*t.dst = v
+	}
+
+	return bq, nil
+}
+
+func toBusPage(q *BlogPostsQuery) blogbus.Page {
+	return blogbus.Page{
+		Limit:  q.Limit,
+		Cursor: q.Cursor,
+	}
+}
+
+func toPaginated(bpp blogbus.BlogPostPage) request.Paginated {
+	return request.Paginated{
+		Items:      toBlogPosts(bpp.BlogPosts),
+		NextCursor: bpp.NextCursor,
+		HasMore:    bpp.HasMore,
+	}
+}
+
+type PageQuery struct {
+	Limit  int    `query:"limit"`
+	Cursor string `query:"cursor"`
+}
+
+type SearchQuery struct {
+	Q      string `query:"q"`
+	Limit  int    `query:"limit"`
+	Cursor string `query:"cursor"`
+}
+
+type SearchHit struct {
+	BlogPost
+	Score    float64   `json:"score"`
+	Snippets []Snippet `json:"snippets"`
+}
+
+type Snippet struct {
+	Field string `json:"field"`
+	Text  string `json:"text"`
+}
+
+func toSearchHits(hits []blogbus.SearchHit) []SearchHit {
+	out := make([]SearchHit, len(hits))
+
+	for i, h := range hits {
+		snippets := make([]Snippet, len(h.Snippets))
+		for j, s := range h.Snippets {
+			snippets[j] = Snippet{Field: s.Field, Text: s.Text}
+		}
+
+		out[i] = SearchHit{
+			BlogPost: toBlogPost(h.BlogPost),
+			Score:    h.Score,
+			Snippets: snippets,
+		}
+	}
+
+	return out
+}
+
+func toSearchPaginated(sp blogbus.SearchPage) request.Paginated {
+	return request.Paginated{
+		Items:      toSearchHits(sp.Hits),
+		NextCursor: sp.NextCursor,
+		HasMore:    sp.HasMore,
 	}
 }
 
 type AddBlogPost struct {
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	Body        string `json:"body"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Body        string   `json:"body"`
+	Slug        string   `json:"slug"`
+	Tags        []string `json:"tags"`
+	Categories  []string `json:"categories"`
+	AuthorID    uint64   `json:"author_id"`
 }
 
 func toBusAddBlogPost(abp *AddBlogPost) blogbus.AddBlogPost {
@@ -47,6 +227,10 @@ func toBusAddBlogPost(abp *AddBlogPost) blogbus.AddBlogPost {
 		Title:       abp.Title,
 		Description: abp.Description,
 		Body:        abp.Body,
+		Slug:        abp.Slug,
+		Tags:        abp.Tags,
+		Categories:  abp.Categories,
+		AuthorID:    abp.AuthorID,
 	}
 }
 
@@ -54,11 +238,25 @@ type BlogPostID struct {
 	ID uint64 `json:"id" uri:"id"`
 }
 
+// slugPath is the path of the posts found by slug, the slug follows it.
+const slugPath = "/api/blog-post/by-slug/"
+
+type BlogPostSlug struct {
+	Slug string `json:"-" uri:"slug"`
+}
+
 type UpdateBlogPost struct {
 	ID          uint64 `json:"-" uri:"id"`
 	Title       string `json:"title"`
 	Description string `json:"description"`
 	Body        string `json:"body"`
+	Slug        string `json:"slug"`
+	// Tags and Categories replace those of the post, leaving them out keeps them and an empty array
+	// removes them.
+	Tags       []string `json:"tags"`
+	Categories []string `json:"categories"`
+	// AuthorID moves the post to another author, leaving it out keeps its author.
+	AuthorID uint64 `json:"author_id"`
 }
 
 func toBusUpdateBlogPost(ubp *UpdateBlogPost) blogbus.UpdateBlogPost {
@@ -66,5 +264,506 @@ func toBusUpdateBlogPost(ubp *UpdateBlogPost) blogbus.UpdateBlogPost {
 		Title:       ubp.Title,
 		Description: ubp.Description,
 		Body:        ubp.Body,
+		Slug:        ubp.Slug,
+		Tags:        ubp.Tags,
+		Categories:  ubp.Categories,
+		AuthorID:    ubp.AuthorID,
+	}
+}
+
+type RevisionID struct {
+	ID     uint64 `json:"-" uri:"id"`
+	Number uint64 `json:"-" uri:"number"`
+}
+
+type DiffQuery struct {
+	ID   uint64 `uri:"id"`
+	From uint64 `query:"from"`
+	To   uint64 `query:"to"`
+}
+
+type RevisionSummary struct {
+	Number       uint64    `json:"number"`
+	Editor       string    `json:"editor,omitempty"`
+	Changed      []string  `json:"changed"`
+	RestoredFrom uint64    `json:"restored_from,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type Revision struct {
+	RevisionSummary
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Body        string `json:"body"`
+}
+
+func toRevisionSummaries(revs []blogbus.Revision) []RevisionSummary {
+	out := make([]RevisionSummary, len(revs))
+
+	for i, r := range revs {
+		out[i] = toRevisionSummary(r)
+	}
+
+	return out
+}
+
+func toRevisionSummary(r blogbus.Revision) RevisionSummary {
+	changed := r.Changed
+	if changed == nil {
+		changed = []string{}
+	}
+
+	return RevisionSummary{
+		Number:       r.Number,
+		Editor:       r.Editor,
+		Changed:      changed,
+		RestoredFrom: r.RestoredFrom,
+		CreatedAt:    r.CreatedAt,
+	}
+}
+
+func toRevision(r blogbus.Revision) Revision {
+	return Revision{
+		RevisionSummary: toRevisionSummary(r),
+		Title:           r.Title,
+		Description:     r.Description,
+		Body:            r.Body,
+	}
+}
+
+type FieldDiff struct {
+	Field string     `json:"field"`
+	Lines []DiffLine `json:"lines"`
+}
+
+type DiffLine struct {
+	Op   string `json:"op" enums:"equal,delete,insert"`
+	Text string `json:"text"`
+}
+
+func toFieldDiffs(fds []blogbus.FieldDiff) []FieldDiff {
+	out := make([]FieldDiff, len(fds))
+
+	for i, fd := range fds {
+		lines := make([]DiffLine, len(fd.Lines))
+		for j, l := range fd.Lines {
+			lines[j] = DiffLine{Op: string(l.Op), Text: l.Text}
+		}
+
+		out[i] = FieldDiff{Field: fd.Field, Lines: lines}
+	}
+
+	return out
+}
+
+type Tag struct {
+	Slug      string    `json:"slug"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func toTags(ts []blogbus.Tag) []Tag {
+	out := make([]Tag, len(ts))
+
+	for i, t := range ts {
+		out[i] = toTag(t)
+	}
+
+	return out
+}
+
+func toTag(t blogbus.Tag) Tag {
+	return Tag{
+		Slug:      t.Slug,
+		Name:      t.Name,
+		CreatedAt: t.CreatedAt,
+	}
+}
+
+type TagCount struct {
+	Tag
+	Posts int `json:"posts"`
+}
+
+func toTagCounts(tcs []blogbus.TagCount) []TagCount {
+	out := make([]TagCount, len(tcs))
+
+	for i, tc := range tcs {
+		out[i] = TagCount{Tag: toTag(tc.Tag), Posts: tc.Posts}
+	}
+
+	return out
+}
+
+type Category struct {
+	Slug      string    `json:"slug"`
+	Name      string    `json:"name"`
+	Parent    string    `json:"parent,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func toCategories(cs []blogbus.Category) []Category {
+	out := make([]Category, len(cs))
+
+	for i, c := range cs {
+		out[i] = toCategory(c)
+	}
+
+	return out
+}
+
+func toCategory(c blogbus.Category) Category {
+	return Category{
+		Slug:      c.Slug,
+		Name:      c.Name,
+		Parent:    c.Parent,
+		CreatedAt: c.CreatedAt,
+	}
+}
+
+// TermSlug names a tag or a category.
+type TermSlug struct {
+	Slug string `json:"slug" uri:"slug"`
+}
+
+type AddTag struct {
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+type UpdateTag struct {
+	Slug string `json:"-" uri:"slug"`
+	Name string `json:"name"`
+}
+
+type AddCategory struct {
+	Name   string `json:"name"`
+	Slug   string `json:"slug"`
+	Parent string `json:"parent"`
+}
+
+type UpdateCategory struct {
+	Slug string `json:"-" uri:"slug"`
+	Name string `json:"name"`
+	// Parent moves the category, leaving it out keeps its parent and an empty string moves it to the
+	// top level.
+	Parent *string `json:"parent"`
+}
+
+type Author struct {
+	ID        uint64    `json:"id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email,omitempty"`
+	Bio       string    `json:"bio,omitempty"`
+	AvatarURL string    `json:"avatar_url,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func toAuthors(as []blogbus.Author) []Author {
+	out := make([]Author, len(as))
+
+	for i, a := range as {
+		out[i] = toAuthor(a)
+	}
+
+	return out
+}
+
+func toAuthor(a blogbus.Author) Author {
+	return Author{
+		ID:        a.ID,
+		Name:      a.Name,
+		Email:     a.Email,
+		Bio:       a.Bio,
+		AvatarURL: a.AvatarURL,
+		CreatedAt: a.CreatedAt,
+		UpdatedAt: a.UpdatedAt,
+	}
+}
+
+type AuthorID struct {
+	ID uint64 `json:"id" uri:"id"`
+}
+
+type AddAuthor struct {
+	Name      string `json:"name"`
+	Email     string `json:"email"`
+	Bio       string `json:"bio"`
+	AvatarURL string `json:"avatar_url"`
+}
+
+type UpdateAuthor struct {
+	ID        uint64 `json:"-" uri:"id"`
+	Name      string `json:"name"`
+	Email     string `json:"email"`
+	Bio       string `json:"bio"`
+	AvatarURL string `json:"avatar_url"`
+}
+
+type DeleteAuthor struct {
+	ID uint64 `json:"-" uri:"id"`
+	// ReassignTo is the author the posts of the deleted author move to, an author with posts is only
+	// deleted with one.
+	ReassignTo uint64 `json:"-" query:"reassign_to"`
+}
+
+type AuthorBlogPostsQuery struct {
+	ID     uint64 `json:"-" uri:"id"`
+	Status string `query:"status"`
+	Limit  int    `query:"limit"`
+	Cursor string `query:"cursor"`
+}
+
+type Comment struct {
+	ID       uint64 `json:"id"`
+	PostID   uint64 `json:"post_id"`
+	ParentID uint64 `json:"parent_id,omitempty"`
+	Author   string `json:"author"`
+	Body     string `json:"body"`
+	Status   string `json:"status"`
+	// Flag is the reason a spam classifier rejected the comment.
+	Flag        string    `json:"flag,omitempty"`
+	ModeratedAt time.Time `json:"moderated_at,omitzero"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	Replies     int       `json:"replies"`
+}
+
+func toComments(cs []commentbus.Comment) []Comment {
+	out := make([]Comment, len(cs))
+
+	for i, c := range cs {
+		out[i] = toComment(c)
+	}
+
+	return out
+}
+
+func toComment(c commentbus.Comment) Comment {
+	return Comment{
+		ID:          c.ID,
+		PostID:      c.PostID,
+		ParentID:    c.ParentID,
+		Author:      c.Author,
+		Body:        c.Body,
+		Status:      string(c.Status),
+		Flag:        c.Flag,
+		ModeratedAt: c.ModeratedAt,
+		CreatedAt:   c.CreatedAt,
+		UpdatedAt:   c.UpdatedAt,
+		Replies:     c.Replies,
+	}
+}
+
+func toCommentPage(cp commentbus.CommentPage) request.Paginated {
+	return request.Paginated{
+		Items:      toComments(cp.Comments),
+		NextCursor: cp.NextCursor,
+		HasMore:    cp.HasMore,
+	}
+}
+
+type CommentID struct {
+	ID      uint64 `json:"post_id" uri:"id"`
+	Comment uint64 `json:"id" uri:"comment"`
+}
+
+type AddComment struct {
+	ID uint64 `json:"-" uri:"id"`
+	// ParentID is the comment replied to, leaving it out comments on the post itself.
+	ParentID uint64 `json:"parent_id"`
+	Author   string `json:"author"`
+	Body     string `json:"body"`
+}
+
+type UpdateComment struct {
+	ID      uint64 `json:"-" uri:"id"`
+	Comment uint64 `json:"-" uri:"comment"`
+	Body    string `json:"body"`
+}
+
+type CommentsQuery struct {
+	ID     uint64 `json:"-" uri:"id"`
+	Parent uint64 `query:"parent"`
+	Limit  int    `query:"limit"`
+	Cursor string `query:"cursor"`
+}
+
+type CommentQueueQuery struct {
+	Status string `query:"status"`
+	Limit  int    `query:"limit"`
+	Cursor string `query:"cursor"`
+}
+
+// status returns the status of the comments queried, the pending ones awaiting a decision by default.
+func (q *CommentQueueQuery) status() commentbus.Status {
+	if q.Status == "" {
+		return commentbus.StatusPending
+	}
+
+	return commentbus.Status(q.Status)
+}
+
+type ModerateComment struct {
+	ID uint64 `json:"-" uri:"id"`
+}
+
+type ModerateComments struct {
+	IDs []uint64 `json:"ids"`
+	// Status is the decision on the comments, approved or rejected.
+	Status string `json:"status"`
+}
+
+type AddReaction struct {
+	ID uint64 `json:"-" uri:"id"`
+	// Reaction is one of like, love, laugh, wow, sad and angry.
+	Reaction string `json:"reaction"`
+}
+
+type Register struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type Account struct {
+	Username    string    `json:"username"`
+	Role        string    `json:"role"`
+	CreatedAt   time.Time `json:"created_at"`
+	LockedUntil time.Time `json:"locked_until,omitzero"`
+}
+
+func toAccounts(us []authbus.User) []Account {
+	out := make([]Account, len(us))
+
+	for i, u := range us {
+		out[i] = toAccount(u)
+	}
+
+	return out
+}
+
+func toAccount(u authbus.User) Account {
+	return Account{Username: u.Username, Role: string(u.Role), CreatedAt: u.CreatedAt, LockedUntil: u.LockedUntil}
+}
+
+type Login struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type Token struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func toToken(t authbus.Token) Token {
+	return Token{Token: t.Token, ExpiresAt: t.ExpiresAt}
+}
+
+type SetRole struct {
+	Username string `json:"-" uri:"username"`
+	Role     string `json:"role"`
+}
+
+type ChangePassword struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+type CreateAPIKey struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+type APIKeyID struct {
+	ID string `json:"-" uri:"id"`
+}
+
+type APIKey struct {
+	ID         string    `json:"id"`
+	Name       string    `json:"name"`
+	Owner      string    `json:"owner"`
+	Scopes     []string  `json:"scopes"`
+	CreatedAt  time.Time `json:"created_at"`
+	RotatedAt  time.Time `json:"rotated_at,omitzero"`
+	LastUsedAt time.Time `json:"last_used_at,omitzero"`
+	RevokedAt  time.Time `json:"revoked_at,omitzero"`
+}
+
+func toAPIKeys(ks []authbus.APIKey) []APIKey {
+	out := make([]APIKey, len(ks))
+
+	for i, k := range ks {
+		out[i] = toAPIKey(k)
+	}
+
+	return out
+}
+
+func toAPIKey(k authbus.APIKey) APIKey {
+	scopes := make([]string, len(k.Scopes))
+	for i, sc := range k.Scopes {
+		scopes[i] = string(sc)
+	}
+
+	return APIKey{
+		ID:         k.ID,
+		Name:       k.Name,
+		Owner:      k.Owner,
+		Scopes:     scopes,
+		CreatedAt:  k.CreatedAt,
+		RotatedAt:  k.RotatedAt,
+		LastUsedAt: k.LastUsedAt,
+		RevokedAt:  k.RevokedAt,
+	}
+}
+
+// IssuedAPIKey is a key with the key string its client sends, which is only ever in this response.
+type IssuedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+func toIssuedAPIKey(ik authbus.IssuedAPIKey) IssuedAPIKey {
+	return IssuedAPIKey{APIKey: toAPIKey(ik.APIKey), Key: ik.Key}
+}
+
+type ScheduleBlogPost struct {
+	ID        uint64    `json:"-" uri:"id"`
+	PublishAt time.Time `json:"publish_at"`
+}
+
+type SnapshotInfo struct {
+	Version   uint32    `json:"version"`
+	Serial    uint64    `json:"serial"`
+	Posts     int       `json:"posts"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func toSnapshotInfo(si blogbus.SnapshotInfo) SnapshotInfo {
+	return SnapshotInfo{
+		Version:   si.Version,
+		Serial:    si.Serial,
+		Posts:     si.Posts,
+		CreatedAt: si.CreatedAt,
+	}
+}
+
+type CacheStats struct {
+	Hits      uint64 `json:"hits"`
+	Misses    uint64 `json:"misses"`
+	Evictions uint64 `json:"evictions"`
+	Posts     int    `json:"posts"`
+	Capacity  int    `json:"capacity"`
+}
+
+func toCacheStats(cs blogbus.CacheStats) CacheStats {
+	return CacheStats{
+		Hits:      cs.Hits,
+		Misses:    cs.Misses,
+		Evictions: cs.Evictions,
+		Posts:     cs.Posts,
+		Capacity:  cs.Capacity,
 	}
 }
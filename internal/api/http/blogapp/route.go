@@ -21,6 +21,11 @@ import (
 
 // @license.name	Apache 2.0
 // @license.url	http://www.apache.org/licenses/LICENSE-2.0.html
+
+// @securityDefinitions.apikey	BearerAuth
+// @in							header
+// @name						Authorization
+// @description				A token from /api/auth/login, as "Bearer <token>", or an API key from /api/auth/keys, as "ApiKey <key>".
 func (b *app) register(app *fiber.App) {
 	app.Use(requestid.New())
 	app.Use(logger.New(logger.Config{
@@ -29,13 +34,94 @@ func (b *app) register(app *fiber.App) {
 
 	app.Get("/swagger/*", swagger.HandlerDefault)
 
+	// Registered before limitBody, a snapshot is as large as the blog it was taken of.
+	app.Post("/api/admin/restore", b.operate, b.authenticate, b.Restore)
+
+	app.Use(limitBody)
+
+	auth := app.Group("/api/auth")
+
+	auth.Post("/register", b.Register)
+	auth.Post("/login", b.Login)
+	auth.Post("/logout", b.authenticate, b.Logout)
+	auth.Post("/password", b.authenticate, b.ChangePassword)
+	auth.Post("/keys", b.authenticate, b.CreateAPIKey)
+	auth.Get("/keys", b.authenticate, b.APIKeys)
+	auth.Delete("/keys/:id", b.authenticate, b.RevokeAPIKey)
+	auth.Post("/keys/:id/rotate", b.authenticate, b.RotateAPIKey)
+
+	// The routes that create, change or delete take an authenticated principal, the comments and
+	// reactions of readers too. The routes that read posts serve drafts and archived posts to the
+	// principal of a request that has one.
 	router := app.Group("/api/blog-post")
 
-	router.Post("", b.AddBlogPost)
-	router.Get("", b.BlogPosts)
-	router.Delete("/:id", b.DeleteBlogPost)
-	router.Get("/:id", b.BlogPost)
-	router.Patch("/:id", b.UpdateBlogPost)
+	router.Post("", b.authenticate, b.AddBlogPost)
+	router.Get("", b.identify, b.BlogPosts)
+	router.Get("/search", b.SearchBlogPosts)
+	router.Get("/by-slug/:slug", b.identify, b.BlogPostBySlug)
+	router.Get("/trash", b.authenticate, b.TrashedBlogPosts)
+	router.Post("/trash/:id/restore", b.authenticate, b.RestoreBlogPost)
+	router.Delete("/trash/:id", b.authenticate, b.PurgeBlogPost)
+	router.Delete("/:id", b.authenticate, b.DeleteBlogPost)
+	router.Get("/:id", b.identify, b.BlogPost)
+	router.Patch("/:id", b.authenticate, b.UpdateBlogPost)
+	router.Post("/:id/publish", b.authenticate, b.PublishBlogPost)
+	router.Post("/:id/unpublish", b.authenticate, b.UnpublishBlogPost)
+	router.Post("/:id/archive", b.authenticate, b.ArchiveBlogPost)
+	router.Post("/:id/schedule", b.authenticate, b.ScheduleBlogPost)
+	router.Post("/:id/unschedule", b.authenticate, b.UnscheduleBlogPost)
+	router.Get("/:id/revisions", b.identify, b.BlogPostRevisions)
+	// Registered before /:number so "diff" is not read as a revision number.
+	router.Get("/:id/revisions/diff", b.identify, b.DiffBlogPostRevisions)
+	router.Get("/:id/revisions/:number", b.identify, b.BlogPostRevision)
+	router.Post("/:id/revisions/:number/restore", b.authenticate, b.RestoreBlogPostRevision)
+	router.Put("/:id/reactions", b.authenticate, b.React)
+	router.Delete("/:id/reactions", b.authenticate, b.Unreact)
+	router.Post("/:id/comments", b.authenticate, b.AddComment)
+	router.Get("/:id/comments", b.identify, b.Comments)
+	router.Get("/:id/comments/:comment", b.identify, b.Comment)
+	router.Patch("/:id/comments/:comment", b.authenticate, b.UpdateComment)
+	router.Delete("/:id/comments/:comment", b.authenticate, b.DeleteComment)
+
+	tags := app.Group("/api/tags")
+
+	tags.Post("", b.authenticate, b.AddTag)
+	tags.Get("", b.Tags)
+	tags.Get("/cloud", b.TagCloud)
+	tags.Get("/:slug", b.Tag)
+	tags.Patch("/:slug", b.authenticate, b.UpdateTag)
+	tags.Delete("/:slug", b.authenticate, b.DeleteTag)
+
+	categories := app.Group("/api/categories")
+
+	categories.Post("", b.authenticate, b.AddCategory)
+	categories.Get("", b.Categories)
+	categories.Get("/:slug", b.Category)
+	categories.Patch("/:slug", b.authenticate, b.UpdateCategory)
+	categories.Delete("/:slug", b.authenticate, b.DeleteCategory)
+
+	authors := app.Group("/api/authors")
+
+	authors.Post("", b.authenticate, b.AddAuthor)
+	authors.Get("", b.Authors)
+	authors.Get("/:id", b.Author)
+	authors.Patch("/:id", b.authenticate, b.UpdateAuthor)
+	authors.Delete("/:id", b.authenticate, b.DeleteAuthor)
+	authors.Get("/:id/posts", b.identify, b.AuthorBlogPosts)
+
+	moderation := app.Group("/api/moderation/comments", b.authenticate)
+
+	moderation.Get("", b.CommentQueue)
+	moderation.Post("", b.ModerateComments)
+	moderation.Post("/:id/approve", b.ApproveComment)
+	moderation.Post("/:id/reject", b.RejectComment)
+
+	admin := app.Group("/api/admin", b.authenticate)
+
+	admin.Get("/snapshot", b.operate, b.Snapshot)
+	admin.Get("/cache/stats", b.CacheStats)
+	admin.Get("/users", b.Users)
+	admin.Patch("/users/:username", b.SetRole)
 }
 
 func (b *app) Serve() {
@@ -44,5 +130,5 @@ func (b *app) Serve() {
 		log.Fatalln("Listen returned with an error: ", err)
 	}
 
-	log.Fatalln("Listening has been closed")
+	log.Println("Listening has been closed")
 }
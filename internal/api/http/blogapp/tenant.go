@@ -0,0 +1,87 @@
+package blogapp
+
+import (
+	"net"
+	"strings"
+
+	"github.com/anazcodes/blogapp/internal/business/authbus"
+	"github.com/anazcodes/blogapp/internal/business/blogbus"
+	"github.com/anazcodes/blogapp/internal/business/commentbus"
+	"github.com/gofiber/fiber/v2"
+)
+
+// Tenant is a blog served next to others by one server. Its businesses work on a repository of its
+// own, so its posts, their IDs, its users and its keys are never reached from another blog.
+type Tenant struct {
+	Name     string   // Names the blog in the prefix its routes are served under, see TenantPrefix.
+	Hosts    []string // Hosts whose requests are for the blog, whose routes they reach without the prefix.
+	Business blogbus.Business
+	Comments commentbus.Business
+	Auth     authbus.Business
+}
+
+// TenantPrefix returns the prefix the routes of the blog named name are served under.
+func TenantPrefix(name string) string {
+	return "/blogs/" + name
+}
+
+// NewTenantApp returns an app serving the routes of every tenant under its prefix, and on its hosts
+// without it. A request for no tenant is not found.
+func NewTenantApp(port string, tenants []Tenant, operatorToken string) App {
+	root := &app{port: port, fbr: fiber.New(config)}
+
+	hosts := make(map[string]string)
+	for _, t := range tenants {
+		for _, h := range t.Hosts {
+			hosts[strings.ToLower(h)] = t.Name
+		}
+	}
+
+	root.fbr.Use(resolveHost(hosts))
+
+	for _, t := range tenants {
+		blog := newApp(port, t.Business, t.Comments, t.Auth, operatorToken)
+		root.fbr.Mount(TenantPrefix(t.Name), blog.fbr)
+	}
+
+	return root
+}
+
+// hostedKey marks the locals of a request whose tenant its host chose.
+type hostedKey struct{}
+
+// resolveHost routes a request for a host of a tenant to the routes of the tenant, as if it were sent
+// under its prefix. Whatever its path, such a request never reaches another tenant.
+func resolveHost(hosts map[string]string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		name, ok := hosts[hostname(c)]
+		if !ok || c.Locals(hostedKey{}) != nil {
+			return c.Next()
+		}
+
+		c.Locals(hostedKey{}, true)
+		c.Path(TenantPrefix(name) + c.Path())
+
+		return c.RestartRouting()
+	}
+}
+
+// hostname returns the host of a request, lowercased and without its port.
+func hostname(c *fiber.Ctx) string {
+	host := c.Hostname()
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+
+	return strings.ToLower(host)
+}
+
+// base returns the prefix the client sends the requests for the routes of a under, empty when a is not
+// a tenant or its host chose it.
+func (a *app) base(c *fiber.Ctx) string {
+	if c.Locals(hostedKey{}) != nil {
+		return ""
+	}
+
+	return a.fbr.MountPath()
+}
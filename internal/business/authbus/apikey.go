@@ -0,0 +1,382 @@
+package authbus
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+var (
+	ErrAPIKeyNotFound = errors.New("api key not found")
+	ErrAPIKeyRevoked  = errors.New("api key revoked")
+	ErrInvalidAPIKey  = errors.New("invalid api key")
+	ErrOutOfScope     = errors.New("out of scope")
+)
+
+// Scope is a part of what the user of an API key may do that the key is trusted with.
+type Scope string
+
+const (
+	ScopePostsRead        Scope = "posts:read"
+	ScopePostsWrite       Scope = "posts:write"
+	ScopePostsPublish     Scope = "posts:publish"
+	ScopeTaxonomyWrite    Scope = "taxonomy:write"
+	ScopeCommentsWrite    Scope = "comments:write"
+	ScopeReactionsWrite   Scope = "reactions:write"
+	ScopeCommentsModerate Scope = "comments:moderate"
+	ScopeAdmin            Scope = "admin"
+)
+
+// scopes are the actions of every scope. Reading the published posts needs no key, posts:read reads
+// the others its user may. No scope manages users or keys, those take a user who logged in.
+var scopes = map[Scope][]Action{
+	ScopePostsRead:        {ReadPost},
+	ScopePostsWrite:       {ReadPost, CreatePost, EditPost, ManageTrash},
+	ScopePostsPublish:     {PublishPost},
+	ScopeTaxonomyWrite:    {ManageTaxonomy},
+	ScopeCommentsWrite:    {Comment, EditComment},
+	ScopeReactionsWrite:   {React},
+	ScopeCommentsModerate: {EditComment, ModerateComments},
+	ScopeAdmin:            {Operate},
+}
+
+// ParseScopes returns the scopes named by ss, sorted and without duplicates, or ErrInvalidAPIKey when
+// there are none or one is unknown.
+func ParseScopes(ss []string) ([]Scope, error) {
+	out := make([]Scope, 0, len(ss))
+
+	for _, s := range ss {
+		sc := Scope(strings.TrimSpace(s))
+		if _, ok := scopes[sc]; !ok {
+			return nil, fmt.Errorf("%w: unknown scope %q", ErrInvalidAPIKey, s)
+		}
+
+		out = append(out, sc)
+	}
+
+	if len(out) == 0 {
+		return nil, fmt.Errorf("%w: no scopes", ErrInvalidAPIKey)
+	}
+
+	slices.Sort(out)
+
+	return slices.Compact(out), nil
+}
+
+// APIKey is a key a machine client authenticates with, acting as the user who created it within its
+// scopes. Only the hash of its secret is stored, the key itself is shown once.
+type APIKey struct {
+	ID         string
+	Name       string
+	Owner      string // Username of the user the key acts as.
+	Scopes     []Scope
+	Hash       string // SHA-256 of the secret, see hashSecret.
+	CreatedAt  time.Time
+	RotatedAt  time.Time // Zero until the secret is rotated.
+	LastUsedAt time.Time // Zero until the key is used, recorded to the minute.
+	RevokedAt  time.Time // Zero unless the key is revoked.
+}
+
+// Revoked reports whether k no longer authenticates.
+func (k APIKey) Revoked() bool {
+	return !k.RevokedAt.IsZero()
+}
+
+// APIKeyUpdate changes an API key. Like a UserUpdate the storage applies it to the key as stored.
+type APIKeyUpdate interface {
+	Apply(k APIKey) APIKey
+}
+
+// KeyRevocation revokes a key at At.
+type KeyRevocation struct {
+	At time.Time
+}
+
+// Apply returns k revoked, a key revoked before stays revoked since then.
+func (kr KeyRevocation) Apply(k APIKey) APIKey {
+	if !k.Revoked() {
+		k.RevokedAt = kr.At
+	}
+
+	return k
+}
+
+// KeyRotation replaces the secret of a key with the secret with Hash at At.
+type KeyRotation struct {
+	Hash string
+	At   time.Time
+}
+
+// Apply returns k with the new secret.
+func (kr KeyRotation) Apply(k APIKey) APIKey {
+	k.Hash = kr.Hash
+	k.RotatedAt = kr.At
+
+	return k
+}
+
+// KeyUse is a use of a key at At.
+type KeyUse struct {
+	At time.Time
+}
+
+// Apply returns k last used at At, unless it was used later.
+func (ku KeyUse) Apply(k APIKey) APIKey {
+	if ku.At.After(k.LastUsedAt) {
+		k.LastUsedAt = ku.At
+	}
+
+	return k
+}
+
+type CreateAPIKey struct {
+	Name   string
+	Scopes []Scope
+}
+
+// IssuedAPIKey is a key that was created or rotated with the key string its client sends, which is
+// never shown again.
+type IssuedAPIKey struct {
+	APIKey
+	Key string
+}
+
+// MaxAPIKeyNameLength is the length of the longest name of a key, in characters.
+const MaxAPIKeyNameLength = 64
+
+// validAPIKeyName returns ErrInvalidAPIKey unless name is within its length.
+func validAPIKeyName(name string) error {
+	if n := utf8.RuneCountInString(name); n == 0 || n > MaxAPIKeyNameLength {
+		return fmt.Errorf("%w: want a name of 1 to %d characters", ErrInvalidAPIKey, MaxAPIKeyNameLength)
+	}
+
+	return nil
+}
+
+// apiKeyPrefix starts every key string, so a leaked key is easy to recognize.
+const apiKeyPrefix = "bk"
+
+// newAPIKeyID returns a random key ID of 64 bits, hex encoded so it never holds the separator of the
+// key string.
+func newAPIKeyID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
+
+// newAPIKeySecret returns a random secret of 256 bits.
+func newAPIKeySecret() string {
+	b := make([]byte, 32)
+	rand.Read(b)
+
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// apiKeyString returns the key string of the key with id and secret, bk_<id>_<secret>.
+func apiKeyString(id, secret string) string {
+	return apiKeyPrefix + "_" + id + "_" + secret
+}
+
+// parseAPIKey returns the ID and secret of the key string key.
+func parseAPIKey(key string) (id, secret string, ok bool) {
+	parts := strings.SplitN(key, "_", 3)
+	if len(parts) != 3 || parts[0] != apiKeyPrefix || parts[1] == "" || parts[2] == "" {
+		return "", "", false
+	}
+
+	return parts[1], parts[2], true
+}
+
+// hashSecret returns the hash of secret as stored. The secrets are random and long, a fast hash keeps
+// authenticating every request cheap without making them any easier to guess.
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// checkSecret reports whether secret is the secret of k.
+func (k APIKey) checkSecret(secret string) bool {
+	return subtle.ConstantTimeCompare([]byte(hashSecret(secret)), []byte(k.Hash)) == 1
+}
+
+// owns returns p as the owner, of what p creates or of their own account.
+func (p Principal) owns() (string, error) {
+	return p.Subject, nil
+}
+
+// keyUseInterval is how often a use of a key is recorded, so a busy client does not write on every
+// request.
+const keyUseInterval = time.Minute
+
+func (b *business) CreateAPIKey(ctx context.Context, ck CreateAPIKey) (IssuedAPIKey, error) {
+	p, ok := PrincipalFrom(ctx)
+	if !ok {
+		return IssuedAPIKey{}, ErrUnauthenticated
+	}
+
+	if err := p.Can(ManageKeys, p.owns); err != nil {
+		return IssuedAPIKey{}, err
+	}
+
+	if err := validAPIKeyName(ck.Name); err != nil {
+		return IssuedAPIKey{}, err
+	}
+
+	ss := make([]string, len(ck.Scopes))
+	for i, sc := range ck.Scopes {
+		ss[i] = string(sc)
+	}
+
+	scs, err := ParseScopes(ss)
+	if err != nil {
+		return IssuedAPIKey{}, err
+	}
+
+	secret := newAPIKeySecret()
+	k := APIKey{
+		ID:        newAPIKeyID(),
+		Name:      ck.Name,
+		Owner:     p.Subject,
+		Scopes:    scs,
+		Hash:      hashSecret(secret),
+		CreatedAt: b.now(),
+	}
+
+	if err := b.repo.AddAPIKey(ctx, k); err != nil {
+		return IssuedAPIKey{}, fmt.Errorf("repo.addapikey: %w username: %s", err, p.Subject)
+	}
+
+	return IssuedAPIKey{APIKey: k, Key: apiKeyString(k.ID, secret)}, nil
+}
+
+func (b *business) APIKeys(ctx context.Context) ([]APIKey, error) {
+	p, ok := PrincipalFrom(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+
+	if err := p.Can(ManageKeys, p.owns); err != nil {
+		return nil, err
+	}
+
+	// Only those who may manage the keys of others see them.
+	owner := p.Subject
+	if p.Can(ManageKeys, func() (string, error) { return "", nil }) == nil {
+		owner = ""
+	}
+
+	keys, err := b.repo.APIKeys(ctx, owner)
+	if err != nil {
+		return nil, fmt.Errorf("repo.apikeys: %w username: %s", err, p.Subject)
+	}
+
+	return keys, nil
+}
+
+func (b *business) RevokeAPIKey(ctx context.Context, id string) (APIKey, error) {
+	if err := b.authorizeKey(ctx, id); err != nil {
+		return APIKey{}, err
+	}
+
+	k, err := b.repo.UpdateAPIKey(ctx, id, KeyRevocation{At: b.now()})
+	if err != nil {
+		return APIKey{}, fmt.Errorf("repo.updateapikey: %w id: %s", err, id)
+	}
+
+	return k, nil
+}
+
+func (b *business) RotateAPIKey(ctx context.Context, id string) (IssuedAPIKey, error) {
+	if err := b.authorizeKey(ctx, id); err != nil {
+		return IssuedAPIKey{}, err
+	}
+
+	secret := newAPIKeySecret()
+
+	k, err := b.repo.UpdateAPIKey(ctx, id, KeyRotation{Hash: hashSecret(secret), At: b.now()})
+	if err != nil {
+		return IssuedAPIKey{}, fmt.Errorf("repo.updateapikey: %w id: %s", err, id)
+	}
+
+	return IssuedAPIKey{APIKey: k, Key: apiKeyString(k.ID, secret)}, nil
+}
+
+// authorizeKey returns nil when the principal of ctx may manage the key with id, which is not revoked.
+// Keys of others are not found to users who may not manage them, so their IDs are not disclosed.
+func (b *business) authorizeKey(ctx context.Context, id string) error {
+	p, ok := PrincipalFrom(ctx)
+	if !ok {
+		return ErrUnauthenticated
+	}
+
+	k, err := b.repo.APIKey(ctx, id)
+	if err != nil {
+		return fmt.Errorf("repo.apikey: %w id: %s", err, id)
+	}
+
+	err = p.Can(ManageKeys, func() (string, error) { return k.Owner, nil })
+	if errors.Is(err, ErrNotOwner) {
+		return fmt.Errorf("%w: id: %s", ErrAPIKeyNotFound, id)
+	}
+	if err != nil {
+		return err
+	}
+
+	if k.Revoked() {
+		return fmt.Errorf("%w: since %s", ErrAPIKeyRevoked, k.RevokedAt.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+func (b *business) AuthenticateAPIKey(ctx context.Context, key string) (Principal, error) {
+	if key == "" {
+		return Principal{}, fmt.Errorf("%w: no api key", ErrUnauthenticated)
+	}
+
+	id, secret, ok := parseAPIKey(key)
+	if !ok {
+		return Principal{}, fmt.Errorf("%w: malformed api key", ErrUnauthenticated)
+	}
+
+	k, err := b.repo.APIKey(ctx, id)
+	if errors.Is(err, ErrAPIKeyNotFound) {
+		return Principal{}, fmt.Errorf("%w: unknown api key", ErrUnauthenticated)
+	}
+	if err != nil {
+		return Principal{}, fmt.Errorf("repo.apikey: %w id: %s", err, id)
+	}
+
+	if !k.checkSecret(secret) {
+		return Principal{}, fmt.Errorf("%w: unknown api key", ErrUnauthenticated)
+	}
+
+	if k.Revoked() {
+		return Principal{}, fmt.Errorf("%w: api key revoked", ErrUnauthenticated)
+	}
+
+	// Like a session the key acts with the role its owner has now, within its scopes.
+	u, err := b.repo.User(ctx, k.Owner)
+	if err != nil {
+		return Principal{}, fmt.Errorf("repo.user: %w username: %s", err, k.Owner)
+	}
+
+	if now := b.now(); now.Sub(k.LastUsedAt) >= keyUseInterval {
+		if _, err := b.repo.UpdateAPIKey(ctx, k.ID, KeyUse{At: now}); err != nil {
+			return Principal{}, fmt.Errorf("repo.updateapikey: %w id: %s", err, k.ID)
+		}
+	}
+
+	return Principal{Subject: u.Username, Role: u.Role, Key: k.ID, Scopes: k.Scopes}, nil
+}
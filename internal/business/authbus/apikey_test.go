@@ -0,0 +1,187 @@
+package authbus_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/anazcodes/blogapp/internal/business/authbus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateAPIKey(t *testing.T) {
+	bus := newBusiness(t, keySet(t), authbus.DefaultLockout)
+
+	ann, err := login(t, bus, authbus.Login{Username: "ann", Password: "password"})
+	assert.Nil(t, err)
+	ctx := authbus.WithPrincipal(t.Context(), ann)
+
+	testCases := []struct {
+		name           string
+		input          authbus.CreateAPIKey
+		expectedScopes []authbus.Scope
+		expectedErr    error
+	}{
+		{
+			name:           "Sorted And Deduplicated",
+			input:          authbus.CreateAPIKey{Name: "ci", Scopes: []authbus.Scope{"posts:write", "posts:read", "posts:write"}},
+			expectedScopes: []authbus.Scope{authbus.ScopePostsRead, authbus.ScopePostsWrite},
+		},
+		{
+			name:        "No Name",
+			input:       authbus.CreateAPIKey{Scopes: []authbus.Scope{authbus.ScopePostsRead}},
+			expectedErr: authbus.ErrInvalidAPIKey,
+		},
+		{
+			name:        "Name Too Long",
+			input:       authbus.CreateAPIKey{Name: strings.Repeat("k", authbus.MaxAPIKeyNameLength+1), Scopes: []authbus.Scope{authbus.ScopePostsRead}},
+			expectedErr: authbus.ErrInvalidAPIKey,
+		},
+		{
+			name:        "No Scopes",
+			input:       authbus.CreateAPIKey{Name: "ci"},
+			expectedErr: authbus.ErrInvalidAPIKey,
+		},
+		{
+			name:        "Unknown Scope",
+			input:       authbus.CreateAPIKey{Name: "ci", Scopes: []authbus.Scope{"posts:delete"}},
+			expectedErr: authbus.ErrInvalidAPIKey,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			k, err := bus.CreateAPIKey(ctx, tc.input)
+			assert.ErrorIs(t, err, tc.expectedErr)
+
+			if tc.expectedErr != nil {
+				return
+			}
+
+			assert.Equal(t, "ann", k.Owner)
+			assert.Equal(t, tc.expectedScopes, k.Scopes)
+			assert.True(t, strings.HasPrefix(k.Key, "bk_"+k.ID+"_"))
+			assert.NotContains(t, k.Hash, strings.TrimPrefix(k.Key, "bk_"+k.ID+"_"))
+		})
+	}
+
+	t.Run("Unauthenticated", func(t *testing.T) {
+		_, err := bus.CreateAPIKey(t.Context(), authbus.CreateAPIKey{Name: "ci", Scopes: []authbus.Scope{authbus.ScopePostsRead}})
+		assert.ErrorIs(t, err, authbus.ErrUnauthenticated)
+	})
+}
+
+func TestAuthenticateAPIKey(t *testing.T) {
+	bus := newBusiness(t, keySet(t), authbus.DefaultLockout)
+
+	ann, err := login(t, bus, authbus.Login{Username: "ann", Password: "password"})
+	assert.Nil(t, err)
+	ctx := authbus.WithPrincipal(t.Context(), ann)
+
+	k, err := bus.CreateAPIKey(ctx, authbus.CreateAPIKey{Name: "ci", Scopes: []authbus.Scope{authbus.ScopePostsWrite}})
+	assert.Nil(t, err)
+
+	p, err := bus.AuthenticateAPIKey(t.Context(), k.Key)
+	assert.Nil(t, err)
+	assert.Equal(t, authbus.Principal{Subject: "ann", Role: authbus.RoleAdmin, Key: k.ID, Scopes: k.Scopes}, p)
+
+	// The key does what the role of ann allows within its scopes only.
+	assert.Nil(t, p.Can(authbus.CreatePost, nil))
+	assert.ErrorIs(t, p.Can(authbus.Operate, nil), authbus.ErrOutOfScope)
+
+	keys, err := bus.APIKeys(ctx)
+	assert.Nil(t, err)
+	assert.False(t, keys[0].LastUsedAt.IsZero(), "last used")
+
+	for _, key := range []string{"", "bk_" + k.ID, "ak_" + strings.TrimPrefix(k.Key, "bk_"), "bk_0000000000000000_secret", k.Key + "x"} {
+		_, err := bus.AuthenticateAPIKey(t.Context(), key)
+		assert.ErrorIs(t, err, authbus.ErrUnauthenticated, key)
+	}
+
+	rotated, err := bus.RotateAPIKey(ctx, k.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, k.ID, rotated.ID)
+	assert.False(t, rotated.RotatedAt.IsZero())
+
+	_, err = bus.AuthenticateAPIKey(t.Context(), k.Key)
+	assert.ErrorIs(t, err, authbus.ErrUnauthenticated)
+
+	_, err = bus.AuthenticateAPIKey(t.Context(), rotated.Key)
+	assert.Nil(t, err)
+
+	revoked, err := bus.RevokeAPIKey(ctx, k.ID)
+	assert.Nil(t, err)
+	assert.True(t, revoked.Revoked())
+
+	_, err = bus.AuthenticateAPIKey(t.Context(), rotated.Key)
+	assert.ErrorIs(t, err, authbus.ErrUnauthenticated)
+
+	_, err = bus.RotateAPIKey(ctx, k.ID)
+	assert.ErrorIs(t, err, authbus.ErrAPIKeyRevoked)
+}
+
+func TestAPIKeyOwners(t *testing.T) {
+	bus := newBusiness(t, keySet(t), authbus.DefaultLockout)
+
+	_, err := bus.Register(t.Context(), authbus.Register{Username: "bob", Password: "password"})
+	assert.Nil(t, err)
+
+	ann, err := login(t, bus, authbus.Login{Username: "ann", Password: "password"})
+	assert.Nil(t, err)
+	bob, err := login(t, bus, authbus.Login{Username: "bob", Password: "password"})
+	assert.Nil(t, err)
+
+	admin := authbus.WithPrincipal(t.Context(), ann)
+	reader := authbus.WithPrincipal(t.Context(), bob)
+
+	annKey, err := bus.CreateAPIKey(admin, authbus.CreateAPIKey{Name: "ci", Scopes: []authbus.Scope{authbus.ScopeAdmin}})
+	assert.Nil(t, err)
+
+	// A reader may have a key, it does no more than the reader may.
+	bobKey, err := bus.CreateAPIKey(reader, authbus.CreateAPIKey{Name: "feed", Scopes: []authbus.Scope{authbus.ScopePostsWrite}})
+	assert.Nil(t, err)
+
+	p, err := bus.AuthenticateAPIKey(t.Context(), bobKey.Key)
+	assert.Nil(t, err)
+	assert.ErrorIs(t, p.Can(authbus.CreatePost, nil), authbus.ErrForbidden)
+
+	keys, err := bus.APIKeys(reader)
+	assert.Nil(t, err)
+	assert.Len(t, keys, 1)
+	assert.Equal(t, bobKey.ID, keys[0].ID)
+
+	keys, err = bus.APIKeys(admin)
+	assert.Nil(t, err)
+	assert.Len(t, keys, 2)
+
+	// The keys of others are not found to who may not manage them.
+	_, err = bus.RevokeAPIKey(reader, annKey.ID)
+	assert.ErrorIs(t, err, authbus.ErrAPIKeyNotFound)
+
+	_, err = bus.RevokeAPIKey(admin, bobKey.ID)
+	assert.Nil(t, err)
+
+	// A key manages no keys, nor the session of its user.
+	p, err = bus.AuthenticateAPIKey(t.Context(), annKey.Key)
+	assert.Nil(t, err)
+	byKey := authbus.WithPrincipal(t.Context(), p)
+
+	_, err = bus.APIKeys(byKey)
+	assert.ErrorIs(t, err, authbus.ErrOutOfScope)
+
+	_, err = bus.CreateAPIKey(byKey, authbus.CreateAPIKey{Name: "more", Scopes: []authbus.Scope{authbus.ScopeAdmin}})
+	assert.ErrorIs(t, err, authbus.ErrOutOfScope)
+
+	assert.ErrorIs(t, bus.Logout(byKey), authbus.ErrOutOfScope)
+	assert.ErrorIs(t, bus.ChangePassword(byKey, authbus.ChangePassword{CurrentPassword: "password", NewPassword: "new password"}), authbus.ErrOutOfScope)
+}
+
+func TestParseScopes(t *testing.T) {
+	scopes, err := authbus.ParseScopes([]string{"admin", " posts:read", "admin"})
+	assert.Nil(t, err)
+	assert.Equal(t, []authbus.Scope{authbus.ScopeAdmin, authbus.ScopePostsRead}, scopes)
+
+	for _, ss := range [][]string{nil, {"Admin"}, {"posts:read", "posts"}} {
+		_, err := authbus.ParseScopes(ss)
+		assert.ErrorIs(t, err, authbus.ErrInvalidAPIKey)
+	}
+}
@@ -0,0 +1,393 @@
+// Package authbus implements the accounts of the users who write to the blog: they register, log in
+// for a session whose signed token authenticates them as a Principal on the writes that follow, and
+// log out to end it. Machine clients authenticate with the API keys their users create instead, within
+// the scopes of the key.
+package authbus
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"runtime"
+	"time"
+
+	"github.com/anazcodes/blogapp/pkg/jwt"
+)
+
+var (
+	ErrInvalidCredentials = errors.New("invalid username or password")
+	ErrUnauthenticated    = errors.New("unauthenticated")
+	ErrAccountLocked      = errors.New("account locked")
+	ErrInvalidUsername    = errors.New("invalid username")
+	ErrInvalidPassword    = errors.New("invalid password")
+	ErrUsernameTaken      = errors.New("username taken")
+	ErrUserNotFound       = errors.New("user not found")
+	ErrSessionNotFound    = errors.New("session not found")
+	ErrBusy               = errors.New("too many passwords being hashed")
+)
+
+// DefaultTokenTTL is the time a session authenticates its user for.
+const DefaultTokenTTL = time.Hour
+
+// DefaultMaxHashes is the number of passwords hashed or checked at once unless the operator changes it,
+// one per CPU, as hashing one takes a CPU and the memory of its Argon2Params.
+var DefaultMaxHashes = runtime.NumCPU()
+
+// Config configures the accounts.
+type Config struct {
+	TokenTTL time.Duration
+	Lockout  Lockout
+	Argon2   Argon2Params // Costs of the hashes of the passwords set from now on.
+	// MaxHashes is the most passwords hashed or checked at once, zero for DefaultMaxHashes. A register,
+	// login or password change past it is refused with ErrBusy rather than waiting for memory.
+	MaxHashes int
+}
+
+// DefaultConfig is the configuration of the accounts unless the operator changes it.
+var DefaultConfig = Config{
+	TokenTTL:  DefaultTokenTTL,
+	Lockout:   DefaultLockout,
+	Argon2:    DefaultArgon2Params,
+	MaxHashes: DefaultMaxHashes,
+}
+
+type business struct {
+	keys *jwt.KeySet
+	repo Repo
+	cfg  Config
+	now  func() time.Time
+	// decoy is a hash with the costs of cfg, checked against the passwords of unknown users so a login
+	// takes as long whether its user exists or not.
+	decoy string
+	// hashes holds a slot for every password being hashed or checked, see Config.MaxHashes.
+	hashes chan struct{}
+}
+
+type Repo interface {
+	// AddUser adds u, or returns ErrUsernameTaken.
+	AddUser(ctx context.Context, u User) error
+	User(ctx context.Context, username string) (User, error)
+	// Users returns every user, ordered by username.
+	Users(ctx context.Context) ([]User, error)
+	// UpdateUser applies uu to the user with username and returns it after.
+	UpdateUser(ctx context.Context, username string, uu UserUpdate) (User, error)
+
+	// AddSession adds s. The sessions of its user that expired by the time s was created are deleted.
+	AddSession(ctx context.Context, s Session) error
+	Session(ctx context.Context, id string) (Session, error)
+	// DeleteSession deletes the session with id, deleting one that does not exist is not an error.
+	DeleteSession(ctx context.Context, id string) error
+	// DeleteSessions deletes every session of the user with username but the session with keep.
+	DeleteSessions(ctx context.Context, username, keep string) error
+
+	AddAPIKey(ctx context.Context, k APIKey) error
+	// APIKey returns the key with id, or ErrAPIKeyNotFound.
+	APIKey(ctx context.Context, id string) (APIKey, error)
+	// APIKeys returns the keys of the user with owner, or every key when owner is empty, oldest first.
+	APIKeys(ctx context.Context, owner string) ([]APIKey, error)
+	// UpdateAPIKey applies ku to the key with id and returns it after.
+	UpdateAPIKey(ctx context.Context, id string, ku APIKeyUpdate) (APIKey, error)
+}
+
+type Business interface {
+	// Register adds the user r signs up, who can log in from then on. Users register as readers, even
+	// the first one: only the operator makes an admin, see the -grant-admin flag.
+	Register(ctx context.Context, r Register) (User, error)
+	// Login starts a session of the user with the credentials of l and returns its token. It returns
+	// ErrAccountLocked while too many logins to the account failed.
+	Login(ctx context.Context, l Login) (Token, error)
+	// Logout ends the session of the principal of ctx.
+	Logout(ctx context.Context) error
+	// ChangePassword changes the password of the principal of ctx and ends every other session of theirs.
+	ChangePassword(ctx context.Context, cp ChangePassword) error
+	// Authenticate returns the principal token authenticates, or ErrUnauthenticated when token is
+	// missing, malformed, signed with an unknown key or expired, or its session ended.
+	Authenticate(ctx context.Context, token string) (Principal, error)
+
+	// Users returns every user, to an admin.
+	Users(ctx context.Context) ([]User, error)
+	// SetRole gives the user with username role, or returns ErrOwnRole when an admin changes their
+	// own role, which keeps at least one admin.
+	SetRole(ctx context.Context, username string, role Role) (User, error)
+
+	// CreateAPIKey creates a key acting as the principal of ctx within the scopes of ck and returns it
+	// with its key string, which is never shown again.
+	CreateAPIKey(ctx context.Context, ck CreateAPIKey) (IssuedAPIKey, error)
+	// APIKeys returns the keys of the principal of ctx, or every key to an admin.
+	APIKeys(ctx context.Context) ([]APIKey, error)
+	// RevokeAPIKey revokes the key with id, which no longer authenticates.
+	RevokeAPIKey(ctx context.Context, id string) (APIKey, error)
+	// RotateAPIKey replaces the secret of the key with id and returns it with its new key string. The
+	// old key string no longer authenticates. It returns ErrAPIKeyRevoked for a revoked key.
+	RotateAPIKey(ctx context.Context, id string) (IssuedAPIKey, error)
+	// AuthenticateAPIKey returns the principal key authenticates, or ErrUnauthenticated when key is
+	// missing, malformed, unknown or revoked.
+	AuthenticateAPIKey(ctx context.Context, key string) (Principal, error)
+}
+
+// NewBusiness returns the accounts stored in repo, whose sessions have tokens signed by the active key
+// of keys.
+func NewBusiness(keys *jwt.KeySet, repo Repo, cfg Config) Business {
+	if cfg.MaxHashes <= 0 {
+		cfg.MaxHashes = DefaultMaxHashes
+	}
+
+	return &business{
+		keys:   keys,
+		repo:   repo,
+		cfg:    cfg,
+		now:    time.Now,
+		decoy:  HashPassword("", cfg.Argon2),
+		hashes: make(chan struct{}, cfg.MaxHashes),
+	}
+}
+
+func (b *business) Register(ctx context.Context, r Register) (User, error) {
+	username := NormalizeUsername(r.Username)
+
+	if err := validUsername(username); err != nil {
+		return User{}, err
+	}
+
+	if err := validPassword(r.Password); err != nil {
+		return User{}, err
+	}
+
+	release, err := b.hashing()
+	if err != nil {
+		return User{}, err
+	}
+	hash := HashPassword(r.Password, b.cfg.Argon2)
+	release()
+
+	now := b.now()
+	u := User{
+		Username:          username,
+		PasswordHash:      hash,
+		Role:              RoleReader,
+		CreatedAt:         now,
+		PasswordChangedAt: now,
+	}
+
+	if err := b.repo.AddUser(ctx, u); err != nil {
+		return User{}, fmt.Errorf("repo.adduser: %w username: %s", err, username)
+	}
+
+	return u, nil
+}
+
+func (b *business) Login(ctx context.Context, l Login) (Token, error) {
+	now := b.now()
+
+	u, err := b.check(ctx, NormalizeUsername(l.Username), l.Password, now)
+	if err != nil {
+		return Token{}, err
+	}
+
+	claims := jwt.NewClaims(u.Username, now, b.cfg.TokenTTL)
+	claims.ID = newSessionID()
+
+	token, err := b.keys.Sign(claims)
+	if err != nil {
+		return Token{}, fmt.Errorf("keys.sign: %w", err)
+	}
+
+	s := Session{ID: claims.ID, Username: u.Username, CreatedAt: now, ExpiresAt: time.Unix(claims.ExpiresAt, 0)}
+
+	if err := b.repo.AddSession(ctx, s); err != nil {
+		return Token{}, fmt.Errorf("repo.addsession: %w username: %s", err, u.Username)
+	}
+
+	return Token{Token: token, ExpiresAt: s.ExpiresAt}, nil
+}
+
+// check returns the user with username once password is theirs, counting the attempt towards the
+// lockout. It returns ErrInvalidCredentials for an unknown user or a wrong password alike.
+func (b *business) check(ctx context.Context, username, password string, now time.Time) (User, error) {
+	release, err := b.hashing()
+	if err != nil {
+		return User{}, err
+	}
+	defer release()
+
+	u, err := b.repo.User(ctx, username)
+	if errors.Is(err, ErrUserNotFound) {
+		CheckPassword(b.decoy, password)
+		return User{}, ErrInvalidCredentials
+	}
+	if err != nil {
+		return User{}, fmt.Errorf("repo.user: %w username: %s", err, username)
+	}
+
+	if u.Locked(now) {
+		return User{}, fmt.Errorf("%w: until %s", ErrAccountLocked, u.LockedUntil.Format(time.RFC3339))
+	}
+
+	ok, err := CheckPassword(u.PasswordHash, password)
+	if err != nil {
+		return User{}, fmt.Errorf("checkpassword: %w username: %s", err, username)
+	}
+
+	// A success only needs storing when it clears failed logins.
+	if !ok || u.FailedLogins > 0 {
+		attempt := LoginAttempt{Failed: !ok, At: now, Lockout: b.cfg.Lockout}
+		if u, err = b.repo.UpdateUser(ctx, username, attempt); err != nil {
+			return User{}, fmt.Errorf("repo.updateuser: %w username: %s", err, username)
+		}
+	}
+
+	if !ok {
+		if u.Locked(now) {
+			return User{}, fmt.Errorf("%w: until %s", ErrAccountLocked, u.LockedUntil.Format(time.RFC3339))
+		}
+
+		return User{}, ErrInvalidCredentials
+	}
+
+	return u, nil
+}
+
+// hashing takes a slot to hash or check a password in, released by calling the func it returns, or
+// returns ErrBusy when every slot is taken.
+func (b *business) hashing() (func(), error) {
+	select {
+	case b.hashes <- struct{}{}:
+		return func() { <-b.hashes }, nil
+	default:
+		return nil, fmt.Errorf("%w: %d at once", ErrBusy, cap(b.hashes))
+	}
+}
+
+func (b *business) Logout(ctx context.Context) error {
+	p, ok := PrincipalFrom(ctx)
+	if !ok {
+		return ErrUnauthenticated
+	}
+
+	if p.Key != "" {
+		return fmt.Errorf("%w: logging out needs a session", ErrOutOfScope)
+	}
+
+	if err := b.repo.DeleteSession(ctx, p.Session); err != nil {
+		return fmt.Errorf("repo.deletesession: %w username: %s", err, p.Subject)
+	}
+
+	return nil
+}
+
+func (b *business) ChangePassword(ctx context.Context, cp ChangePassword) error {
+	p, ok := PrincipalFrom(ctx)
+	if !ok {
+		return ErrUnauthenticated
+	}
+
+	if p.Key != "" {
+		return fmt.Errorf("%w: changing the password needs a session", ErrOutOfScope)
+	}
+
+	if err := validPassword(cp.NewPassword); err != nil {
+		return err
+	}
+
+	now := b.now()
+
+	// The current password is asked for, and counted towards the lockout, so a stolen token cannot
+	// take over the account.
+	if _, err := b.check(ctx, p.Subject, cp.CurrentPassword, now); err != nil {
+		return err
+	}
+
+	release, err := b.hashing()
+	if err != nil {
+		return err
+	}
+	change := PasswordChange{Hash: HashPassword(cp.NewPassword, b.cfg.Argon2), At: now}
+	release()
+
+	if _, err := b.repo.UpdateUser(ctx, p.Subject, change); err != nil {
+		return fmt.Errorf("repo.updateuser: %w username: %s", err, p.Subject)
+	}
+
+	if err := b.repo.DeleteSessions(ctx, p.Subject, p.Session); err != nil {
+		return fmt.Errorf("repo.deletesessions: %w username: %s", err, p.Subject)
+	}
+
+	return nil
+}
+
+func (b *business) Authenticate(ctx context.Context, token string) (Principal, error) {
+	if token == "" {
+		return Principal{}, fmt.Errorf("%w: no token", ErrUnauthenticated)
+	}
+
+	claims, err := b.keys.Verify(token, b.now())
+	if err != nil {
+		return Principal{}, fmt.Errorf("%w: %v", ErrUnauthenticated, err)
+	}
+
+	s, err := b.repo.Session(ctx, claims.ID)
+	if errors.Is(err, ErrSessionNotFound) {
+		return Principal{}, fmt.Errorf("%w: session ended", ErrUnauthenticated)
+	}
+	if err != nil {
+		return Principal{}, fmt.Errorf("repo.session: %w", err)
+	}
+
+	if s.Username != claims.Subject || s.Expired(b.now()) {
+		return Principal{}, fmt.Errorf("%w: session ended", ErrUnauthenticated)
+	}
+
+	// The role is read on every request, so a new role applies to the sessions the user has.
+	u, err := b.repo.User(ctx, s.Username)
+	if err != nil {
+		return Principal{}, fmt.Errorf("repo.user: %w username: %s", err, s.Username)
+	}
+
+	return Principal{Subject: s.Username, Session: s.ID, Role: u.Role}, nil
+}
+
+func (b *business) Users(ctx context.Context) ([]User, error) {
+	if err := Authorize(ctx, ManageUsers, nil); err != nil {
+		return nil, err
+	}
+
+	users, err := b.repo.Users(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("repo.users: %w", err)
+	}
+
+	return users, nil
+}
+
+func (b *business) SetRole(ctx context.Context, username string, role Role) (User, error) {
+	if err := Authorize(ctx, ManageUsers, nil); err != nil {
+		return User{}, err
+	}
+
+	if _, err := ParseRole(string(role)); err != nil {
+		return User{}, err
+	}
+
+	username = NormalizeUsername(username)
+
+	if p, ok := PrincipalFrom(ctx); ok && p.Subject == username {
+		return User{}, ErrOwnRole
+	}
+
+	u, err := b.repo.UpdateUser(ctx, username, RoleChange{Role: role})
+	if err != nil {
+		return User{}, fmt.Errorf("repo.updateuser: %w username: %s", err, username)
+	}
+
+	return u, nil
+}
+
+// newSessionID returns a random session ID of 128 bits.
+func newSessionID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,453 @@
+package authbus_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anazcodes/blogapp/internal/business/authbus"
+	mockauthbus "github.com/anazcodes/blogapp/internal/mock/business/authbus"
+	"github.com/anazcodes/blogapp/internal/repository/blogrepo"
+	"github.com/anazcodes/blogapp/pkg/jwt"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// cheap keeps the hashing of the tests fast, the costs do not change what is tested.
+var cheap = authbus.Argon2Params{Time: 1, Memory: 64, Threads: 1}
+
+func keySet(t *testing.T) *jwt.KeySet {
+	key, err := jwt.NewHMACKey("k1", bytes.Repeat([]byte("s"), jwt.MinSecretLength))
+	assert.Nil(t, err)
+
+	ks, err := jwt.NewKeySet("k1", key)
+	assert.Nil(t, err)
+
+	return ks
+}
+
+// newBusiness returns accounts in memory with ann registered, whose password is "password", and made an
+// admin by the operator.
+func newBusiness(t *testing.T, ks *jwt.KeySet, lockout authbus.Lockout) authbus.Business {
+	repo := blogrepo.NewRepository(10)
+	bus := authbus.NewBusiness(ks, repo, authbus.Config{
+		TokenTTL: time.Hour,
+		Lockout:  lockout,
+		Argon2:   cheap,
+	})
+
+	_, err := bus.Register(t.Context(), authbus.Register{Username: "ann", Password: "password"})
+	assert.Nil(t, err)
+
+	_, err = repo.UpdateUser(t.Context(), "ann", authbus.RoleChange{Role: authbus.RoleAdmin})
+	assert.Nil(t, err)
+
+	return bus
+}
+
+// login logs in with l and returns the principal its token authenticates.
+func login(t *testing.T, bus authbus.Business, l authbus.Login) (authbus.Principal, error) {
+	token, err := bus.Login(t.Context(), l)
+	if err != nil {
+		return authbus.Principal{}, err
+	}
+
+	return bus.Authenticate(t.Context(), token.Token)
+}
+
+func TestRegister(t *testing.T) {
+	testCases := []struct {
+		name        string
+		input       authbus.Register
+		expected    string
+		expectedErr error
+	}{
+		{
+			name:     "Normalized",
+			input:    authbus.Register{Username: " Bob.Smith ", Password: "password"},
+			expected: "bob.smith",
+		},
+		{
+			name:        "Taken",
+			input:       authbus.Register{Username: "ANN", Password: "password"},
+			expectedErr: authbus.ErrUsernameTaken,
+		},
+		{
+			name:        "Username Too Short",
+			input:       authbus.Register{Username: "bo", Password: "password"},
+			expectedErr: authbus.ErrInvalidUsername,
+		},
+		{
+			name:        "Username With Spaces",
+			input:       authbus.Register{Username: "bob smith", Password: "password"},
+			expectedErr: authbus.ErrInvalidUsername,
+		},
+		{
+			name:        "Password Too Short",
+			input:       authbus.Register{Username: "bob", Password: "pass"},
+			expectedErr: authbus.ErrInvalidPassword,
+		},
+		{
+			name:        "Password Too Long",
+			input:       authbus.Register{Username: "bob", Password: strings.Repeat("p", authbus.MaxPasswordLength+1)},
+			expectedErr: authbus.ErrInvalidPassword,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			bus := newBusiness(t, keySet(t), authbus.DefaultLockout)
+
+			u, err := bus.Register(t.Context(), tc.input)
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+			if tc.expectedErr != nil {
+				return
+			}
+
+			assert.Equal(t, tc.expected, u.Username)
+			assert.NotContains(t, u.PasswordHash, tc.input.Password)
+
+			p, err := login(t, bus, authbus.Login{Username: tc.expected, Password: tc.input.Password})
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, p.Subject)
+		})
+	}
+}
+
+func TestLogin(t *testing.T) {
+	testCases := []struct {
+		name        string
+		input       authbus.Login
+		expectedErr error
+	}{
+		{
+			name:  "Success",
+			input: authbus.Login{Username: " Ann ", Password: "password"},
+		},
+		{
+			name:        "Wrong Password",
+			input:       authbus.Login{Username: "ann", Password: "guess"},
+			expectedErr: authbus.ErrInvalidCredentials,
+		},
+		{
+			name:        "Unknown User",
+			input:       authbus.Login{Username: "eve", Password: "password"},
+			expectedErr: authbus.ErrInvalidCredentials,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			bus := newBusiness(t, keySet(t), authbus.DefaultLockout)
+
+			token, err := bus.Login(t.Context(), tc.input)
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+			if tc.expectedErr != nil {
+				return
+			}
+
+			p, err := bus.Authenticate(t.Context(), token.Token)
+			assert.Nil(t, err)
+			assert.Equal(t, "ann", p.Subject)
+			assert.NotEmpty(t, p.Session)
+			assert.WithinDuration(t, time.Now().Add(time.Hour), token.ExpiresAt, time.Minute)
+		})
+	}
+}
+
+func TestLockout(t *testing.T) {
+	bus := newBusiness(t, keySet(t), authbus.Lockout{MaxFailures: 3, Duration: time.Hour})
+
+	// A success clears the failures before it.
+	for range 2 {
+		_, err := login(t, bus, authbus.Login{Username: "ann", Password: "guess"})
+		assert.ErrorIs(t, err, authbus.ErrInvalidCredentials)
+	}
+	_, err := login(t, bus, authbus.Login{Username: "ann", Password: "password"})
+	assert.Nil(t, err)
+
+	for range 2 {
+		_, err := login(t, bus, authbus.Login{Username: "ann", Password: "guess"})
+		assert.ErrorIs(t, err, authbus.ErrInvalidCredentials)
+	}
+
+	_, err = login(t, bus, authbus.Login{Username: "ann", Password: "guess"})
+	assert.ErrorIs(t, err, authbus.ErrAccountLocked)
+
+	_, err = login(t, bus, authbus.Login{Username: "ann", Password: "password"})
+	assert.ErrorIs(t, err, authbus.ErrAccountLocked)
+}
+
+func TestLoginAttempt(t *testing.T) {
+	at := time.Now()
+	lockout := authbus.Lockout{MaxFailures: 3, Duration: time.Minute}
+
+	testCases := []struct {
+		name     string
+		user     authbus.User
+		attempt  authbus.LoginAttempt
+		expected authbus.User
+	}{
+		{
+			name:     "Success Clears Failures",
+			user:     authbus.User{FailedLogins: 2},
+			attempt:  authbus.LoginAttempt{At: at, Lockout: lockout},
+			expected: authbus.User{},
+		},
+		{
+			name:     "Failure Counts",
+			user:     authbus.User{FailedLogins: 1},
+			attempt:  authbus.LoginAttempt{Failed: true, At: at, Lockout: lockout},
+			expected: authbus.User{FailedLogins: 2},
+		},
+		{
+			name:     "Last Failure Locks",
+			user:     authbus.User{FailedLogins: 2},
+			attempt:  authbus.LoginAttempt{Failed: true, At: at, Lockout: lockout},
+			expected: authbus.User{LockedUntil: at.Add(time.Minute)},
+		},
+		{
+			name:     "Locked Does Not Count",
+			user:     authbus.User{LockedUntil: at.Add(time.Second)},
+			attempt:  authbus.LoginAttempt{Failed: true, At: at, Lockout: lockout},
+			expected: authbus.User{LockedUntil: at.Add(time.Second)},
+		},
+		{
+			name:     "Counts Again Once Unlocked",
+			user:     authbus.User{LockedUntil: at.Add(-time.Second)},
+			attempt:  authbus.LoginAttempt{Failed: true, At: at, Lockout: lockout},
+			expected: authbus.User{FailedLogins: 1, LockedUntil: at.Add(-time.Second)},
+		},
+		{
+			name:     "No Lockout",
+			user:     authbus.User{FailedLogins: 9},
+			attempt:  authbus.LoginAttempt{Failed: true, At: at},
+			expected: authbus.User{FailedLogins: 10},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.attempt.Apply(tc.user))
+		})
+	}
+}
+
+func TestBusy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// A login holds the only slot until its user is found.
+	found, release := make(chan struct{}), make(chan struct{})
+	repo := mockauthbus.NewMockRepo(ctrl)
+	repo.EXPECT().User(gomock.Any(), "ann").DoAndReturn(func(_ any, _ string) (authbus.User, error) {
+		close(found)
+		<-release
+		return authbus.User{}, authbus.ErrUserNotFound
+	})
+	repo.EXPECT().AddUser(gomock.Any(), gomock.Any()).Return(nil)
+
+	bus := authbus.NewBusiness(keySet(t), repo, authbus.Config{TokenTTL: time.Hour, Argon2: cheap, MaxHashes: 1})
+
+	done := make(chan error)
+	go func() {
+		_, err := bus.Login(t.Context(), authbus.Login{Username: "ann", Password: "password"})
+		done <- err
+	}()
+	<-found
+
+	_, err := bus.Register(t.Context(), authbus.Register{Username: "bob", Password: "password"})
+	assert.ErrorIs(t, err, authbus.ErrBusy)
+
+	_, err = bus.Login(t.Context(), authbus.Login{Username: "bob", Password: "password"})
+	assert.ErrorIs(t, err, authbus.ErrBusy)
+
+	close(release)
+	assert.ErrorIs(t, <-done, authbus.ErrInvalidCredentials)
+
+	// The slot is free again.
+	_, err = bus.Register(t.Context(), authbus.Register{Username: "bob", Password: "password"})
+	assert.Nil(t, err)
+}
+
+func TestLogout(t *testing.T) {
+	bus := newBusiness(t, keySet(t), authbus.DefaultLockout)
+
+	token, err := bus.Login(t.Context(), authbus.Login{Username: "ann", Password: "password"})
+	assert.Nil(t, err)
+	other, err := bus.Login(t.Context(), authbus.Login{Username: "ann", Password: "password"})
+	assert.Nil(t, err)
+
+	p, err := bus.Authenticate(t.Context(), token.Token)
+	assert.Nil(t, err)
+
+	assert.Nil(t, bus.Logout(authbus.WithPrincipal(t.Context(), p)))
+
+	_, err = bus.Authenticate(t.Context(), token.Token)
+	assert.ErrorIs(t, err, authbus.ErrUnauthenticated)
+
+	// The other sessions of the user go on.
+	_, err = bus.Authenticate(t.Context(), other.Token)
+	assert.Nil(t, err)
+
+	assert.ErrorIs(t, bus.Logout(t.Context()), authbus.ErrUnauthenticated)
+}
+
+func TestChangePassword(t *testing.T) {
+	testCases := []struct {
+		name        string
+		input       authbus.ChangePassword
+		expectedErr error
+	}{
+		{
+			name:  "Changed",
+			input: authbus.ChangePassword{CurrentPassword: "password", NewPassword: "new password"},
+		},
+		{
+			name:        "Wrong Current Password",
+			input:       authbus.ChangePassword{CurrentPassword: "guess", NewPassword: "new password"},
+			expectedErr: authbus.ErrInvalidCredentials,
+		},
+		{
+			name:        "New Password Too Short",
+			input:       authbus.ChangePassword{CurrentPassword: "password", NewPassword: "new"},
+			expectedErr: authbus.ErrInvalidPassword,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			bus := newBusiness(t, keySet(t), authbus.DefaultLockout)
+
+			p, err := login(t, bus, authbus.Login{Username: "ann", Password: "password"})
+			assert.Nil(t, err)
+			other, err := bus.Login(t.Context(), authbus.Login{Username: "ann", Password: "password"})
+			assert.Nil(t, err)
+
+			err = bus.ChangePassword(authbus.WithPrincipal(t.Context(), p), tc.input)
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+			if tc.expectedErr != nil {
+				_, err = bus.Authenticate(t.Context(), other.Token)
+				assert.Nil(t, err)
+				return
+			}
+
+			_, err = login(t, bus, authbus.Login{Username: "ann", Password: "password"})
+			assert.ErrorIs(t, err, authbus.ErrInvalidCredentials)
+
+			_, err = login(t, bus, authbus.Login{Username: "ann", Password: tc.input.NewPassword})
+			assert.Nil(t, err)
+
+			// Every other session ends, the one that changed the password goes on.
+			_, err = bus.Authenticate(t.Context(), other.Token)
+			assert.ErrorIs(t, err, authbus.ErrUnauthenticated)
+		})
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	ks := keySet(t)
+	bus := newBusiness(t, ks, authbus.DefaultLockout)
+
+	expired, err := ks.Sign(jwt.NewClaims("ann", time.Now().Add(-2*time.Hour), time.Hour))
+	assert.Nil(t, err)
+
+	// A token signed with a known key still needs its session.
+	claims := jwt.NewClaims("ann", time.Now(), time.Hour)
+	claims.ID = "forged"
+	sessionless, err := ks.Sign(claims)
+	assert.Nil(t, err)
+
+	for name, token := range map[string]string{
+		"No Token":   "",
+		"Malformed":  "token",
+		"Expired":    expired,
+		"No Session": sessionless,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := bus.Authenticate(t.Context(), token)
+
+			assert.ErrorIs(t, err, authbus.ErrUnauthenticated)
+		})
+	}
+}
+
+func TestPassword(t *testing.T) {
+	hash := authbus.HashPassword("password", cheap)
+	assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=64,t=1,p=1$"))
+	assert.NotEqual(t, hash, authbus.HashPassword("password", cheap), "salted")
+
+	ok, err := authbus.CheckPassword(hash, "password")
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	ok, err = authbus.CheckPassword(hash, "Password")
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	_, err = authbus.CheckPassword("$2a$10$bcrypt", "password")
+	assert.NotNil(t, err)
+
+	for _, costs := range []string{"m=64,t=0,p=1", "m=64,t=1,p=0", "m=0,t=1,p=1"} {
+		_, err = authbus.CheckPassword(strings.Replace(hash, "m=64,t=1,p=1", costs, 1), "password")
+		assert.NotNil(t, err, costs)
+	}
+}
+
+func TestRoles(t *testing.T) {
+	// Registering first makes no admin, whoever reaches a new blog first would own it.
+	first, err := authbus.NewBusiness(keySet(t), blogrepo.NewRepository(10), authbus.Config{Argon2: cheap}).
+		Register(t.Context(), authbus.Register{Username: "eve", Password: "password"})
+	assert.Nil(t, err)
+	assert.Equal(t, authbus.RoleReader, first.Role)
+
+	bus := newBusiness(t, keySet(t), authbus.DefaultLockout)
+
+	bob, err := bus.Register(t.Context(), authbus.Register{Username: "bob", Password: "password"})
+	assert.Nil(t, err)
+	assert.Equal(t, authbus.RoleReader, bob.Role)
+
+	ann, err := login(t, bus, authbus.Login{Username: "ann", Password: "password"})
+	assert.Nil(t, err)
+	assert.Equal(t, authbus.RoleAdmin, ann.Role)
+
+	token, err := bus.Login(t.Context(), authbus.Login{Username: "bob", Password: "password"})
+	assert.Nil(t, err)
+	reader, err := bus.Authenticate(t.Context(), token.Token)
+	assert.Nil(t, err)
+
+	_, err = bus.SetRole(authbus.WithPrincipal(t.Context(), reader), "bob", authbus.RoleAdmin)
+	assert.ErrorIs(t, err, authbus.ErrForbidden)
+
+	_, err = bus.Users(authbus.WithPrincipal(t.Context(), reader))
+	assert.ErrorIs(t, err, authbus.ErrForbidden)
+
+	admin := authbus.WithPrincipal(t.Context(), ann)
+
+	_, err = bus.SetRole(admin, "ann", authbus.RoleEditor)
+	assert.ErrorIs(t, err, authbus.ErrOwnRole)
+
+	_, err = bus.SetRole(admin, "bob", "owner")
+	assert.ErrorIs(t, err, authbus.ErrInvalidRole)
+
+	_, err = bus.SetRole(admin, "eve", authbus.RoleAuthor)
+	assert.ErrorIs(t, err, authbus.ErrUserNotFound)
+
+	bob, err = bus.SetRole(admin, " Bob ", authbus.RoleAuthor)
+	assert.Nil(t, err)
+	assert.Equal(t, authbus.RoleAuthor, bob.Role)
+
+	users, err := bus.Users(admin)
+	assert.Nil(t, err)
+	assert.Len(t, users, 2)
+	assert.Equal(t, []authbus.Role{authbus.RoleAdmin, authbus.RoleAuthor}, []authbus.Role{users[0].Role, users[1].Role})
+
+	// The sessions the user has take the new role.
+	p, err := bus.Authenticate(t.Context(), token.Token)
+	assert.Nil(t, err)
+	assert.Equal(t, authbus.RoleAuthor, p.Role)
+}
@@ -0,0 +1,189 @@
+package authbus
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Principal is the user a request is authenticated as.
+type Principal struct {
+	Subject string // Username of the user.
+	Session string // ID of the session the request belongs to.
+	Role    Role   // Role of the user, as it is when the request is made.
+	Key     string // ID of the API key the request is authenticated with, empty for a session.
+	Scopes  []Scope
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns ctx carrying p, the principal the request of ctx is authenticated as.
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFrom returns the principal the request of ctx is authenticated as, if any.
+func PrincipalFrom(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
+
+// User is an account users log in to.
+type User struct {
+	Username          string
+	PasswordHash      string // argon2id hash of the password, see HashPassword.
+	Role              Role
+	CreatedAt         time.Time
+	PasswordChangedAt time.Time
+	FailedLogins      int       // Failed logins since the last successful one or lockout.
+	LockedUntil       time.Time // Time the account takes logins again after a lockout.
+}
+
+// Locked reports whether u refuses logins at at.
+func (u User) Locked(at time.Time) bool {
+	return at.Before(u.LockedUntil)
+}
+
+// UserUpdate changes a user. The storage applies it to the user as stored, so concurrent updates of a
+// user are never lost.
+type UserUpdate interface {
+	Apply(u User) User
+}
+
+// Lockout locks an account for Duration after MaxFailures failed logins in a row. A zero MaxFailures
+// never locks an account.
+type Lockout struct {
+	MaxFailures int
+	Duration    time.Duration
+}
+
+// DefaultLockout locks an account for 15 minutes after 5 failed logins in a row.
+var DefaultLockout = Lockout{MaxFailures: 5, Duration: 15 * time.Minute}
+
+// LoginAttempt is a login to an account that succeeded or failed at At.
+type LoginAttempt struct {
+	Failed  bool
+	At      time.Time
+	Lockout Lockout
+}
+
+// Apply returns u after the attempt: a success clears its failed logins, a failure counts and locks
+// the account once it is the last one the lockout allows. A locked account does not count failures.
+func (a LoginAttempt) Apply(u User) User {
+	if !a.Failed {
+		u.FailedLogins = 0
+		return u
+	}
+
+	if u.Locked(a.At) {
+		return u
+	}
+
+	u.FailedLogins++
+
+	if a.Lockout.MaxFailures > 0 && u.FailedLogins >= a.Lockout.MaxFailures {
+		u.FailedLogins = 0
+		u.LockedUntil = a.At.Add(a.Lockout.Duration)
+	}
+
+	return u
+}
+
+// PasswordChange sets the password of a user to the password with Hash at At.
+type PasswordChange struct {
+	Hash string
+	At   time.Time
+}
+
+// Apply returns u with the new password. It clears the failed logins made against the old one.
+func (pc PasswordChange) Apply(u User) User {
+	u.PasswordHash = pc.Hash
+	u.PasswordChangedAt = pc.At
+	u.FailedLogins = 0
+
+	return u
+}
+
+// RoleChange gives a user Role.
+type RoleChange struct {
+	Role Role
+}
+
+func (rc RoleChange) Apply(u User) User {
+	u.Role = rc.Role
+	return u
+}
+
+// Session is a login of a user. Its token authenticates the user until it expires or the user logs out.
+type Session struct {
+	ID        string
+	Username  string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// Expired reports whether s no longer authenticates its user at at.
+func (s Session) Expired(at time.Time) bool {
+	return !at.Before(s.ExpiresAt)
+}
+
+type Register struct {
+	Username string
+	Password string
+}
+
+type Login struct {
+	Username string
+	Password string
+}
+
+type ChangePassword struct {
+	CurrentPassword string
+	NewPassword     string
+}
+
+// Token is a signed token authenticating a user until it expires.
+type Token struct {
+	Token     string
+	ExpiresAt time.Time
+}
+
+// The lengths of usernames and passwords, in characters.
+const (
+	MinUsernameLength = 3
+	MaxUsernameLength = 32
+	MinPasswordLength = 8
+	MaxPasswordLength = 128
+)
+
+var usernamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+
+// NormalizeUsername returns username trimmed and lowercased, as users are stored and looked up by it.
+func NormalizeUsername(username string) string {
+	return strings.ToLower(strings.TrimSpace(username))
+}
+
+// validUsername returns ErrInvalidUsername unless username, normalized, is made of lowercase letters,
+// digits, dots, dashes and underscores within its length.
+func validUsername(username string) error {
+	switch n := len(username); {
+	case n < MinUsernameLength || n > MaxUsernameLength:
+		return fmt.Errorf("%w: want %d to %d characters", ErrInvalidUsername, MinUsernameLength, MaxUsernameLength)
+	case !usernamePattern.MatchString(username):
+		return fmt.Errorf("%w: want letters, digits, dots, dashes and underscores", ErrInvalidUsername)
+	}
+
+	return nil
+}
+
+// validPassword returns ErrInvalidPassword unless password is within its length.
+func validPassword(password string) error {
+	if n := utf8.RuneCountInString(password); n < MinPasswordLength || n > MaxPasswordLength {
+		return fmt.Errorf("%w: want %d to %d characters", ErrInvalidPassword, MinPasswordLength, MaxPasswordLength)
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package authbus
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+var errUnknownHash = errors.New("unknown password hash")
+
+// Argon2Params are the costs of the argon2id hashes of passwords. A hash records the costs it was made
+// with, so changing them only affects the passwords set after.
+type Argon2Params struct {
+	Time    uint32 // Passes over the memory.
+	Memory  uint32 // Memory in KiB.
+	Threads uint8
+}
+
+// DefaultArgon2Params are the costs RFC 9106 recommends for when memory is constrained.
+var DefaultArgon2Params = Argon2Params{Time: 3, Memory: 64 * 1024, Threads: 4}
+
+const (
+	saltLength = 16
+	keyLength  = 32
+)
+
+// HashPassword returns the argon2id hash of password with a random salt, in the PHC string format
+// $argon2id$v=19$m=<memory>,t=<time>,p=<threads>$<salt>$<key>.
+func HashPassword(password string, p Argon2Params) string {
+	salt := make([]byte, saltLength)
+	rand.Read(salt)
+
+	key := argon2.IDKey([]byte(password), salt, p.Time, p.Memory, p.Threads, keyLength)
+
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, p.Memory, p.Time, p.Threads,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key),
+	)
+}
+
+// CheckPassword reports whether password hashes to hash, with the salt and costs recorded in hash.
+func CheckPassword(hash, password string) (bool, error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return false, errUnknownHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return false, errUnknownHash
+	}
+
+	var p Argon2Params
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.Memory, &p.Time, &p.Threads); err != nil {
+		return false, errUnknownHash
+	}
+
+	// argon2 panics without a pass, a thread or memory to work with.
+	if p.Time < 1 || p.Threads < 1 || p.Memory == 0 {
+		return false, errUnknownHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return false, errUnknownHash
+	}
+
+	want, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(want) == 0 {
+		return false, errUnknownHash
+	}
+
+	got := argon2.IDKey([]byte(password), salt, p.Time, p.Memory, p.Threads, uint32(len(want)))
+
+	return subtle.ConstantTimeCompare(got, want) == 1, nil
+}
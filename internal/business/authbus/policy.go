@@ -0,0 +1,158 @@
+package authbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var (
+	ErrForbidden   = errors.New("forbidden")
+	ErrNotOwner    = errors.New("not the owner")
+	ErrOwnRole     = errors.New("cannot change own role")
+	ErrInvalidRole = errors.New("invalid role")
+)
+
+// Role is what a user is trusted with. Every role may do what the roles before it may.
+type Role string
+
+const (
+	RoleReader Role = "reader"
+	RoleAuthor Role = "author"
+	RoleEditor Role = "editor"
+	RoleAdmin  Role = "admin"
+)
+
+// roles are the roles, least trusted first.
+var roles = [...]Role{RoleReader, RoleAuthor, RoleEditor, RoleAdmin}
+
+// ParseRole returns the role named s, or ErrInvalidRole.
+func ParseRole(s string) (Role, error) {
+	r := Role(s)
+	if !slices.Contains(roles[:], r) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidRole, s)
+	}
+
+	return r, nil
+}
+
+// rank returns the position of r among the roles. Users stored before roles existed have none and
+// rank as readers.
+func (r Role) rank() int {
+	if r == "" {
+		return 0
+	}
+
+	return slices.Index(roles[:], r)
+}
+
+// AtLeast reports whether r may do what min may.
+func (r Role) AtLeast(min Role) bool {
+	return r.rank() >= min.rank()
+}
+
+// Action is something a principal is authorized to do or not.
+type Action string
+
+const (
+	ReadPost         Action = "read_post"         // Read a post that is not published, and its revisions.
+	CreatePost       Action = "create_post"       // Write a new post.
+	EditPost         Action = "edit_post"         // Change a post, move it to the trash or restore its revisions.
+	PublishPost      Action = "publish_post"      // Publish, unpublish, archive or schedule a post.
+	ManageTrash      Action = "manage_trash"      // Restore or purge the posts in the trash.
+	ManageTaxonomy   Action = "manage_taxonomy"   // Add, change or delete tags, categories and authors.
+	Comment          Action = "comment"           // Comment on a post or reply to one of its comments.
+	React            Action = "react"             // React to a post or take the reaction back.
+	EditComment      Action = "edit_comment"      // Change the body of a comment.
+	ModerateComments Action = "moderate_comments" // Moderate, change or delete the comments of readers.
+	ManageUsers      Action = "manage_users"      // List users and change their roles.
+	ManageKeys       Action = "manage_keys"       // Create, list, revoke and rotate API keys.
+	Operate          Action = "operate"           // Take and restore snapshots and read the cache stats.
+)
+
+// rule is who may do an action: the least role that may do it to anything, and the least role that
+// may do it to what they own, empty when owning does not matter.
+type rule struct {
+	any Role
+	own Role
+}
+
+// policy is the permission of every action. Authors write posts and read and change their own before
+// they are published, editors read, change and publish anyone's and run the taxonomy and the comments,
+// admins manage users and the server. Every user comments, reacts and edits their own comments, and
+// manages their own API keys, admins anyone's.
+var policy = map[Action]rule{
+	ReadPost:         {any: RoleEditor, own: RoleAuthor},
+	CreatePost:       {any: RoleAuthor},
+	EditPost:         {any: RoleEditor, own: RoleAuthor},
+	PublishPost:      {any: RoleEditor},
+	ManageTrash:      {any: RoleEditor},
+	ManageTaxonomy:   {any: RoleEditor},
+	Comment:          {any: RoleReader},
+	React:            {any: RoleReader},
+	EditComment:      {any: RoleEditor, own: RoleReader},
+	ModerateComments: {any: RoleEditor},
+	ManageUsers:      {any: RoleAdmin},
+	ManageKeys:       {any: RoleAdmin, own: RoleReader},
+	Operate:          {any: RoleAdmin},
+}
+
+// Can returns nil when p may do a to what owner returns the owner of, ErrForbidden when their role
+// does not allow a and ErrNotOwner when it only allows a to what they own. A principal authenticated
+// with an API key may only do what its role allows within the scopes of the key, or gets
+// ErrOutOfScope. owner is only called when owning decides, it may be nil for an action owning never
+// does.
+func (p Principal) Can(a Action, owner func() (string, error)) error {
+	r, ok := policy[a]
+	switch {
+	case !ok:
+		return fmt.Errorf("%w: unknown action %s", ErrForbidden, a)
+	case p.Key != "" && !p.scoped(a):
+		return fmt.Errorf("%w: api key %s is not scoped to %s", ErrOutOfScope, p.Key, a)
+	case p.Role.AtLeast(r.any):
+		return nil
+	case r.own == "" || !p.Role.AtLeast(r.own):
+		return fmt.Errorf("%w: %s cannot %s", ErrForbidden, p.role(), a)
+	}
+
+	username, err := owner()
+	if err != nil {
+		return err
+	}
+
+	if username != p.Subject {
+		return fmt.Errorf("%w: %s can only %s of their own", ErrNotOwner, p.role(), a)
+	}
+
+	return nil
+}
+
+// scoped reports whether a scope of p allows a.
+func (p Principal) scoped(a Action) bool {
+	return slices.ContainsFunc(p.Scopes, func(sc Scope) bool { return slices.Contains(scopes[sc], a) })
+}
+
+// role returns the role of p, naming the missing role of users stored before roles existed.
+func (p Principal) role() Role {
+	if p.Role == "" {
+		return RoleReader
+	}
+
+	return p.Role
+}
+
+// System is the principal of the server acting on its own, like its background jobs, which may do
+// anything. Its subject is no valid username, so it never owns what a user does.
+var System = Principal{Subject: "@system", Role: RoleAdmin}
+
+// Authorize returns nil when the principal of ctx may do a, see Principal.Can, and ErrUnauthenticated
+// when ctx has none: a route that forgot to authenticate its requests is refused, not trusted.
+func Authorize(ctx context.Context, a Action, owner func() (string, error)) error {
+	p, ok := PrincipalFrom(ctx)
+	if !ok {
+		return fmt.Errorf("%w: no principal may %s", ErrUnauthenticated, a)
+	}
+
+	return p.Can(a, owner)
+}
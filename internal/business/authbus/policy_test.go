@@ -0,0 +1,277 @@
+package authbus_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/anazcodes/blogapp/internal/business/authbus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicy(t *testing.T) {
+	// What each role may do, to what they own and to what others own.
+	type permission struct{ own, others error }
+
+	var (
+		allowed   = permission{}
+		forbidden = permission{own: authbus.ErrForbidden, others: authbus.ErrForbidden}
+		ownOnly   = permission{others: authbus.ErrNotOwner}
+	)
+
+	testCases := []struct {
+		action   authbus.Action
+		expected map[authbus.Role]permission
+	}{
+		{
+			action: authbus.ReadPost,
+			expected: map[authbus.Role]permission{
+				"":                 forbidden,
+				authbus.RoleReader: forbidden,
+				authbus.RoleAuthor: ownOnly,
+				authbus.RoleEditor: allowed,
+				authbus.RoleAdmin:  allowed,
+			},
+		},
+		{
+			action: authbus.CreatePost,
+			expected: map[authbus.Role]permission{
+				"":                 forbidden,
+				authbus.RoleReader: forbidden,
+				authbus.RoleAuthor: allowed,
+				authbus.RoleEditor: allowed,
+				authbus.RoleAdmin:  allowed,
+			},
+		},
+		{
+			action: authbus.EditPost,
+			expected: map[authbus.Role]permission{
+				"":                 forbidden,
+				authbus.RoleReader: forbidden,
+				authbus.RoleAuthor: ownOnly,
+				authbus.RoleEditor: allowed,
+				authbus.RoleAdmin:  allowed,
+			},
+		},
+		{
+			action: authbus.PublishPost,
+			expected: map[authbus.Role]permission{
+				authbus.RoleReader: forbidden,
+				authbus.RoleAuthor: forbidden,
+				authbus.RoleEditor: allowed,
+				authbus.RoleAdmin:  allowed,
+			},
+		},
+		{
+			action: authbus.ManageTrash,
+			expected: map[authbus.Role]permission{
+				authbus.RoleReader: forbidden,
+				authbus.RoleAuthor: forbidden,
+				authbus.RoleEditor: allowed,
+				authbus.RoleAdmin:  allowed,
+			},
+		},
+		{
+			action: authbus.ManageTaxonomy,
+			expected: map[authbus.Role]permission{
+				authbus.RoleReader: forbidden,
+				authbus.RoleAuthor: forbidden,
+				authbus.RoleEditor: allowed,
+				authbus.RoleAdmin:  allowed,
+			},
+		},
+		{
+			action: authbus.Comment,
+			expected: map[authbus.Role]permission{
+				"":                 allowed,
+				authbus.RoleReader: allowed,
+				authbus.RoleAuthor: allowed,
+				authbus.RoleEditor: allowed,
+				authbus.RoleAdmin:  allowed,
+			},
+		},
+		{
+			action: authbus.React,
+			expected: map[authbus.Role]permission{
+				"":                 allowed,
+				authbus.RoleReader: allowed,
+				authbus.RoleAuthor: allowed,
+				authbus.RoleEditor: allowed,
+				authbus.RoleAdmin:  allowed,
+			},
+		},
+		{
+			action: authbus.EditComment,
+			expected: map[authbus.Role]permission{
+				"":                 ownOnly,
+				authbus.RoleReader: ownOnly,
+				authbus.RoleAuthor: ownOnly,
+				authbus.RoleEditor: allowed,
+				authbus.RoleAdmin:  allowed,
+			},
+		},
+		{
+			action: authbus.ModerateComments,
+			expected: map[authbus.Role]permission{
+				authbus.RoleReader: forbidden,
+				authbus.RoleAuthor: forbidden,
+				authbus.RoleEditor: allowed,
+				authbus.RoleAdmin:  allowed,
+			},
+		},
+		{
+			action: authbus.ManageUsers,
+			expected: map[authbus.Role]permission{
+				authbus.RoleReader: forbidden,
+				authbus.RoleAuthor: forbidden,
+				authbus.RoleEditor: forbidden,
+				authbus.RoleAdmin:  allowed,
+			},
+		},
+		{
+			action: authbus.ManageKeys,
+			expected: map[authbus.Role]permission{
+				"":                 ownOnly,
+				authbus.RoleReader: ownOnly,
+				authbus.RoleAuthor: ownOnly,
+				authbus.RoleEditor: ownOnly,
+				authbus.RoleAdmin:  allowed,
+			},
+		},
+		{
+			action: authbus.Operate,
+			expected: map[authbus.Role]permission{
+				authbus.RoleReader: forbidden,
+				authbus.RoleAuthor: forbidden,
+				authbus.RoleEditor: forbidden,
+				authbus.RoleAdmin:  allowed,
+			},
+		},
+		{
+			action: "fly",
+			expected: map[authbus.Role]permission{
+				authbus.RoleAdmin: forbidden,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		for role, expected := range tc.expected {
+			t.Run(string(tc.action)+"/"+string(role), func(t *testing.T) {
+				p := authbus.Principal{Subject: "ann", Role: role}
+
+				err := p.Can(tc.action, func() (string, error) { return "ann", nil })
+				assert.ErrorIs(t, err, expected.own, "own")
+
+				err = p.Can(tc.action, func() (string, error) { return "bob", nil })
+				assert.ErrorIs(t, err, expected.others, "others")
+			})
+		}
+	}
+}
+
+func TestScopes(t *testing.T) {
+	testCases := []struct {
+		name        string
+		principal   authbus.Principal
+		action      authbus.Action
+		expectedErr error
+	}{
+		{
+			name:      "In Scope",
+			principal: authbus.Principal{Subject: "ann", Role: authbus.RoleEditor, Key: "k", Scopes: []authbus.Scope{authbus.ScopePostsPublish}},
+			action:    authbus.PublishPost,
+		},
+		{
+			name:        "Out Of Scope",
+			principal:   authbus.Principal{Subject: "ann", Role: authbus.RoleEditor, Key: "k", Scopes: []authbus.Scope{authbus.ScopePostsPublish}},
+			action:      authbus.EditPost,
+			expectedErr: authbus.ErrOutOfScope,
+		},
+		{
+			name:      "Reads Drafts",
+			principal: authbus.Principal{Subject: "ann", Role: authbus.RoleEditor, Key: "k", Scopes: []authbus.Scope{authbus.ScopePostsRead}},
+			action:    authbus.ReadPost,
+		},
+		{
+			name:        "Read Only",
+			principal:   authbus.Principal{Subject: "ann", Role: authbus.RoleAdmin, Key: "k", Scopes: []authbus.Scope{authbus.ScopePostsRead}},
+			action:      authbus.CreatePost,
+			expectedErr: authbus.ErrOutOfScope,
+		},
+		{
+			name:        "Beyond The Role",
+			principal:   authbus.Principal{Subject: "ann", Role: authbus.RoleAuthor, Key: "k", Scopes: []authbus.Scope{authbus.ScopeAdmin}},
+			action:      authbus.Operate,
+			expectedErr: authbus.ErrForbidden,
+		},
+		{
+			name:        "Keys Manage No Keys",
+			principal:   authbus.Principal{Subject: "ann", Role: authbus.RoleAdmin, Key: "k", Scopes: []authbus.Scope{authbus.ScopeAdmin}},
+			action:      authbus.ManageKeys,
+			expectedErr: authbus.ErrOutOfScope,
+		},
+		{
+			name:        "Read Only Comments",
+			principal:   authbus.Principal{Subject: "ann", Role: authbus.RoleReader, Key: "k", Scopes: []authbus.Scope{authbus.ScopePostsRead}},
+			action:      authbus.Comment,
+			expectedErr: authbus.ErrOutOfScope,
+		},
+		{
+			name:        "Read Only Reacts",
+			principal:   authbus.Principal{Subject: "ann", Role: authbus.RoleReader, Key: "k", Scopes: []authbus.Scope{authbus.ScopePostsRead}},
+			action:      authbus.React,
+			expectedErr: authbus.ErrOutOfScope,
+		},
+		{
+			name:      "Comments",
+			principal: authbus.Principal{Subject: "ann", Role: authbus.RoleReader, Key: "k", Scopes: []authbus.Scope{authbus.ScopeCommentsWrite}},
+			action:    authbus.Comment,
+		},
+		{
+			name:      "Reacts",
+			principal: authbus.Principal{Subject: "ann", Role: authbus.RoleReader, Key: "k", Scopes: []authbus.Scope{authbus.ScopeReactionsWrite}},
+			action:    authbus.React,
+		},
+		{
+			name:      "Session",
+			principal: authbus.Principal{Subject: "ann", Role: authbus.RoleAdmin},
+			action:    authbus.ManageKeys,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.principal.Can(tc.action, func() (string, error) { return "bob", nil })
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	errLookup := errors.New("lookup")
+	lookup := func() (string, error) { return "", errLookup }
+
+	// A context without a principal is refused, the server acting on its own says so.
+	assert.ErrorIs(t, authbus.Authorize(t.Context(), authbus.Operate, nil), authbus.ErrUnauthenticated)
+	assert.Nil(t, authbus.Authorize(authbus.WithPrincipal(t.Context(), authbus.System), authbus.Operate, nil))
+
+	// The owner is only looked up when owning decides.
+	editor := authbus.WithPrincipal(t.Context(), authbus.Principal{Subject: "ann", Role: authbus.RoleEditor})
+	assert.Nil(t, authbus.Authorize(editor, authbus.EditPost, lookup))
+
+	author := authbus.WithPrincipal(t.Context(), authbus.Principal{Subject: "ann", Role: authbus.RoleAuthor})
+	assert.ErrorIs(t, authbus.Authorize(author, authbus.EditPost, lookup), errLookup)
+}
+
+func TestParseRole(t *testing.T) {
+	for _, s := range []string{"reader", "author", "editor", "admin"} {
+		r, err := authbus.ParseRole(s)
+		assert.Nil(t, err)
+		assert.Equal(t, authbus.Role(s), r)
+	}
+
+	for _, s := range []string{"", "Admin", "owner"} {
+		_, err := authbus.ParseRole(s)
+		assert.ErrorIs(t, err, authbus.ErrInvalidRole)
+	}
+}
@@ -0,0 +1,108 @@
+package blogbus
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var (
+	ErrAuthorNotFound  = errors.New("author not found")
+	ErrInvalidAuthor   = errors.New("invalid author")
+	ErrUnknownAuthor   = errors.New("unknown author")
+	ErrAuthorInUse     = errors.New("author still has posts")
+	ErrInvalidReassign = errors.New("posts cannot be reassigned to the author being deleted")
+)
+
+// Author is the profile of who writes posts, a post links to its author by ID.
+type Author struct {
+	ID        uint64
+	Name      string
+	Email     string // Empty when the author keeps it private.
+	Bio       string
+	AvatarURL string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type AddAuthor struct {
+	Name      string
+	Email     string
+	Bio       string
+	AvatarURL string
+}
+
+// UpdateAuthor edits the profile of an author, its empty fields keep those of the author.
+type UpdateAuthor struct {
+	Name      string
+	Email     string
+	Bio       string
+	AvatarURL string
+}
+
+// NewAuthor returns the author aa adds at at, without the ID the repository issues it.
+func NewAuthor(aa AddAuthor, at time.Time) (Author, error) {
+	a := Author{
+		Name:      strings.TrimSpace(aa.Name),
+		Email:     aa.Email,
+		Bio:       aa.Bio,
+		AvatarURL: aa.AvatarURL,
+		CreatedAt: at,
+		UpdatedAt: at,
+	}
+
+	if err := a.Validate(); err != nil {
+		return Author{}, err
+	}
+
+	return a, nil
+}
+
+// Apply returns a edited by ua at at.
+func (ua UpdateAuthor) Apply(a Author, at time.Time) (Author, error) {
+	if name := strings.TrimSpace(ua.Name); name != "" {
+		a.Name = name
+	}
+	if ua.Email != "" {
+		a.Email = ua.Email
+	}
+	if ua.Bio != "" {
+		a.Bio = ua.Bio
+	}
+	if ua.AvatarURL != "" {
+		a.AvatarURL = ua.AvatarURL
+	}
+
+	if err := a.Validate(); err != nil {
+		return Author{}, err
+	}
+
+	a.UpdatedAt = at
+
+	return a, nil
+}
+
+// Validate returns ErrInvalidAuthor unless a has a name, and its email and avatar URL are well formed
+// when it has them.
+func (a Author) Validate() error {
+	if a.Name == "" {
+		return fmt.Errorf("%w: name is empty", ErrInvalidAuthor)
+	}
+
+	if a.Email != "" {
+		if addr, err := mail.ParseAddress(a.Email); err != nil || addr.Address != a.Email {
+			return fmt.Errorf("%w: email %q", ErrInvalidAuthor, a.Email)
+		}
+	}
+
+	if a.AvatarURL != "" {
+		if u, err := url.Parse(a.AvatarURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%w: avatar URL %q", ErrInvalidAuthor, a.AvatarURL)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,153 @@
+package blogbus_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/anazcodes/blogapp/internal/business/blogbus"
+	mockblogbus "github.com/anazcodes/blogapp/internal/mock/business/blogbus"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAuthor(t *testing.T) {
+	testCases := []struct {
+		name        string
+		input       blogbus.AddAuthor
+		expectedErr error
+	}{
+		{
+			name:  "Full Profile",
+			input: blogbus.AddAuthor{Name: " Ann ", Email: "ann@example.com", Bio: "Writes about Go", AvatarURL: "https://example.com/ann.png"},
+		},
+		{
+			name:  "Name Only",
+			input: blogbus.AddAuthor{Name: "Ann"},
+		},
+		{
+			name:        "Empty Name",
+			input:       blogbus.AddAuthor{Name: "  "},
+			expectedErr: blogbus.ErrInvalidAuthor,
+		},
+		{
+			name:        "Malformed Email",
+			input:       blogbus.AddAuthor{Name: "Ann", Email: "ann.example.com"},
+			expectedErr: blogbus.ErrInvalidAuthor,
+		},
+		{
+			name:        "Named Email",
+			input:       blogbus.AddAuthor{Name: "Ann", Email: "Ann <ann@example.com>"},
+			expectedErr: blogbus.ErrInvalidAuthor,
+		},
+		{
+			name:        "Avatar Without Scheme",
+			input:       blogbus.AddAuthor{Name: "Ann", AvatarURL: "example.com/ann.png"},
+			expectedErr: blogbus.ErrInvalidAuthor,
+		},
+		{
+			name:        "Avatar Not HTTP",
+			input:       blogbus.AddAuthor{Name: "Ann", AvatarURL: "ftp://example.com/ann.png"},
+			expectedErr: blogbus.ErrInvalidAuthor,
+		},
+	}
+
+	at := time.Now()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a, err := blogbus.NewAuthor(tc.input, at)
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+			if tc.expectedErr == nil {
+				assert.Equal(t, "Ann", a.Name)
+				assert.Equal(t, at, a.CreatedAt)
+			}
+		})
+	}
+}
+
+func TestUpdateAuthorApply(t *testing.T) {
+	created := time.Now().Add(-time.Hour)
+	at := time.Now()
+	a := blogbus.Author{ID: 1, Name: "Ann", Email: "ann@example.com", CreatedAt: created, UpdatedAt: created}
+
+	updated, err := blogbus.UpdateAuthor{Bio: "Writes about Go"}.Apply(a, at)
+	assert.Nil(t, err)
+	assert.Equal(t, blogbus.Author{ID: 1, Name: "Ann", Email: "ann@example.com", Bio: "Writes about Go", CreatedAt: created, UpdatedAt: at}, updated)
+
+	_, err = blogbus.UpdateAuthor{Email: "not an email"}.Apply(a, at)
+	assert.ErrorIs(t, err, blogbus.ErrInvalidAuthor)
+}
+
+func TestDeleteAuthor(t *testing.T) {
+	testCases := []struct {
+		name        string
+		id          uint64
+		reassignTo  uint64
+		setupExpect func(repo *mockblogbus.MockRepo)
+		expectedErr error
+	}{
+		{
+			name:       "Reassigned",
+			id:         1,
+			reassignTo: 2,
+			setupExpect: func(repo *mockblogbus.MockRepo) {
+				repo.EXPECT().DeleteAuthor(gomock.Any(), uint64(1), uint64(2)).Return(nil)
+			},
+		},
+		{
+			name: "In Use",
+			id:   1,
+			setupExpect: func(repo *mockblogbus.MockRepo) {
+				repo.EXPECT().DeleteAuthor(gomock.Any(), uint64(1), uint64(0)).Return(blogbus.ErrAuthorInUse)
+			},
+			expectedErr: blogbus.ErrAuthorInUse,
+		},
+		{
+			name:        "Reassigned To Itself",
+			id:          1,
+			reassignTo:  1,
+			setupExpect: func(repo *mockblogbus.MockRepo) {},
+			expectedErr: blogbus.ErrInvalidReassign,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mockblogbus.NewMockRepo(ctrl)
+			tc.setupExpect(repo)
+			bus := blogbus.NewBusiness(repo)
+
+			_, err := bus.DeleteAuthor(editing(t), tc.id, tc.reassignTo)
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestAuthorBlogPosts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mockblogbus.NewMockRepo(ctrl)
+	repo.EXPECT().Author(gomock.Any(), uint64(1)).Return(blogbus.Author{ID: 1, Name: "Ann"}, nil)
+	repo.EXPECT().BlogPosts(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ any, q blogbus.Query, page blogbus.Page) (blogbus.BlogPostPage, error) {
+			assert.Equal(t, uint64(1), q.AuthorID)
+			assert.Equal(t, blogbus.DefaultLimit, page.Limit)
+			return blogbus.BlogPostPage{BlogPosts: []blogbus.BlogPost{{ID: 4, AuthorID: 1}}}, nil
+		})
+	repo.EXPECT().Author(gomock.Any(), uint64(9)).Return(blogbus.Author{}, blogbus.ErrAuthorNotFound)
+
+	bus := blogbus.NewBusiness(repo)
+
+	bpp, err := bus.AuthorBlogPosts(editing(t), 1, blogbus.Query{Status: blogbus.StatusPublished}, blogbus.Page{})
+	assert.Nil(t, err)
+	assert.Len(t, bpp.BlogPosts, 1)
+
+	_, err = bus.AuthorBlogPosts(editing(t), 9, blogbus.Query{}, blogbus.Page{})
+	assert.ErrorIs(t, err, blogbus.ErrAuthorNotFound)
+}
@@ -2,7 +2,22 @@ package blogbus
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/anazcodes/blogapp/internal/business/authbus"
+)
+
+var (
+	ErrInvalidLimit  = fmt.Errorf("limit must be between 1 and %d", MaxLimit)
+	ErrInvalidCursor = errors.New("invalid cursor")
+	ErrEmptySearch   = errors.New("empty search")
+	ErrQuotaExceeded = errors.New("post quota exceeded")
+	// ErrBlogPostNotFound is returned for a post the principal may not read, as if it did not exist.
+	ErrBlogPostNotFound = errors.New("blog post not found")
 )
 
 type business struct {
@@ -10,19 +25,173 @@ type business struct {
 }
 
 type Repo interface {
+	// AddBlogPost adds a post under the slug abp asks for, or returns ErrSlugTaken. A post that does
+	// not ask for one gets a unique slug generated from its title. It returns ErrUnknownTerm when abp
+	// has a tag or category that does not exist, ErrUnknownAuthor when its author does not exist and
+	// ErrQuotaExceeded when the blog holds as many posts as its quota allows.
 	AddBlogPost(ctx context.Context, abp AddBlogPost) (uint64, error)
 	BlogPost(ctx context.Context, id uint64) (BlogPost, error)
-	BlogPosts(ctx context.Context) []BlogPost
-	DeleteBlogPost(ctx context.Context, id uint64) (uint64, error)
+	// BlogPostBySlug returns the post slug leads to, which is either its slug or one of its old slugs.
+	BlogPostBySlug(ctx context.Context, slug string) (BlogPost, error)
+	// BlogPosts returns the page of the posts matching q selected by page, ordered by q. The tag and
+	// category of q are looked up in an index of the posts of every tag and category.
+	BlogPosts(ctx context.Context, q Query, page Page) (BlogPostPage, error)
+	// SearchBlogPosts returns the page of the posts matching the full-text search q selected by page,
+	// the most relevant first.
+	SearchBlogPosts(ctx context.Context, q string, page Page) (SearchPage, error)
+	// TrashBlogPost moves the post with id to the trash at at, which hides it from every read but the
+	// listing of the trash.
+	TrashBlogPost(ctx context.Context, id uint64, at time.Time) (uint64, error)
+	// RestoreBlogPost moves the post with id out of the trash, or returns ErrNotTrashed.
+	RestoreBlogPost(ctx context.Context, id uint64) (uint64, error)
+	// PurgeBlogPost permanently deletes the post with id when it was moved to the trash at or before
+	// by, or returns ErrNotTrashed.
+	PurgeBlogPost(ctx context.Context, id uint64, by time.Time) (uint64, error)
+	// UpdateBlogPost applies ubp to the post with id. The post moves to the slug ubp asks for, or
+	// returns ErrSlugTaken, and a slug generated from its title follows a new title. It returns
+	// ErrUnknownTerm when ubp has a tag or category that does not exist, and ErrUnknownAuthor when its
+	// author does not exist.
 	UpdateBlogPost(ctx context.Context, id uint64, ubp UpdateBlogPost) (uint64, error)
+	// TransitionBlogPost applies t to the post with id, or returns ErrInvalidTransition when the post
+	// is not in the t.From status.
+	TransitionBlogPost(ctx context.Context, id uint64, t Transition) (uint64, error)
+
+	// AddTag adds t, or returns ErrTagExists.
+	AddTag(ctx context.Context, t Tag) error
+	Tag(ctx context.Context, slug string) (Tag, error)
+	// Tags returns every tag, ordered by slug.
+	Tags(ctx context.Context) ([]Tag, error)
+	// TagCloud returns every tag with the number of published posts that have it, the most used first.
+	TagCloud(ctx context.Context) ([]TagCount, error)
+	UpdateTag(ctx context.Context, slug string, ut UpdateTag) (Tag, error)
+	// DeleteTag deletes the tag with slug, or returns ErrTermInUse while a post has it.
+	DeleteTag(ctx context.Context, slug string) error
+	// AddCategory adds c, or returns ErrCategoryExists.
+	AddCategory(ctx context.Context, c Category) error
+	Category(ctx context.Context, slug string) (Category, error)
+	// Categories returns every category, each after its parent.
+	Categories(ctx context.Context) ([]Category, error)
+	// UpdateCategory applies uc to the category with slug, or returns ErrCategoryCycle when it would
+	// move the category under itself.
+	UpdateCategory(ctx context.Context, slug string, uc UpdateCategory) (Category, error)
+	// DeleteCategory deletes the category with slug, or returns ErrTermInUse while it has a post or
+	// a subcategory.
+	DeleteCategory(ctx context.Context, slug string) error
+
+	// AddAuthor adds a under the next author ID and returns it with that ID.
+	AddAuthor(ctx context.Context, a Author) (Author, error)
+	Author(ctx context.Context, id uint64) (Author, error)
+	// Authors returns every author, ordered by ID.
+	Authors(ctx context.Context) ([]Author, error)
+	UpdateAuthor(ctx context.Context, id uint64, ua UpdateAuthor) (Author, error)
+	// DeleteAuthor deletes the author with id. Its posts, even those in the trash, move to the author
+	// with reassignTo, or it returns ErrAuthorInUse while it has posts and reassignTo is zero.
+	DeleteAuthor(ctx context.Context, id, reassignTo uint64) error
+
+	// React records rr, replacing the reaction its reader had to the post, and returns the reaction
+	// counts of the post.
+	React(ctx context.Context, rr ReaderReaction) (ReactionCounts, error)
+	// Unreact removes the reaction of reader to the post with postID, if any, and returns the reaction
+	// counts of the post.
+	Unreact(ctx context.Context, postID uint64, reader string) (ReactionCounts, error)
+
+	Snapshot(ctx context.Context, w io.Writer) (SnapshotInfo, error)
+	Restore(ctx context.Context, r io.Reader) (SnapshotInfo, error)
+	CacheStats(ctx context.Context) (CacheStats, error)
 }
 
 type Business interface {
 	AddBlogPost(ctx context.Context, abp AddBlogPost) (ID, error)
+	// BlogPost returns the post with id. A post that is not published is only returned to its author
+	// or an editor, anyone else gets ErrBlogPostNotFound; the same goes for the revisions of a post.
 	BlogPost(ctx context.Context, id uint64) (BlogPost, error)
-	BlogPosts(ctx context.Context) []BlogPost
+	// BlogPostBySlug returns the post slug leads to. The slug of the post differs from slug when slug
+	// is one of its old slugs.
+	BlogPostBySlug(ctx context.Context, slug string) (BlogPost, error)
+	// BlogPosts returns the page of the posts matching q selected by page, ordered by q. Only an editor
+	// may list posts that are not published, an author only theirs, and the trash.
+	BlogPosts(ctx context.Context, q Query, page Page) (BlogPostPage, error)
+	// SearchBlogPosts returns the page of the posts matching the full-text search q selected by page,
+	// the most relevant first.
+	SearchBlogPosts(ctx context.Context, q string, page Page) (SearchPage, error)
+	// DeleteBlogPost moves a post to the trash, where it stays until it is restored or purged.
 	DeleteBlogPost(ctx context.Context, id uint64) (ID, error)
+	// RestoreBlogPost moves a post out of the trash.
+	RestoreBlogPost(ctx context.Context, id uint64) (ID, error)
+	// PurgeBlogPost permanently deletes a post in the trash.
+	PurgeBlogPost(ctx context.Context, id uint64) (ID, error)
 	UpdateBlogPost(ctx context.Context, id uint64, ubp UpdateBlogPost) (ID, error)
+
+	// BlogPostRevisions returns the revisions of a post, oldest first.
+	BlogPostRevisions(ctx context.Context, id uint64) ([]Revision, error)
+	// BlogPostRevision returns the revision of a post with number.
+	BlogPostRevision(ctx context.Context, id, number uint64) (Revision, error)
+	// DiffBlogPostRevisions returns how the fields of a post changed from one revision to another.
+	DiffBlogPostRevisions(ctx context.Context, id, from, to uint64) ([]FieldDiff, error)
+	// RestoreBlogPostRevision updates a post back to the content of an older revision, recording the
+	// restore as a new revision by the user who asks for it.
+	RestoreBlogPostRevision(ctx context.Context, id, number uint64) (ID, error)
+
+	// PublishBlogPost makes a draft public.
+	PublishBlogPost(ctx context.Context, id uint64) (ID, error)
+	// UnpublishBlogPost turns a published post back into a draft.
+	UnpublishBlogPost(ctx context.Context, id uint64) (ID, error)
+	// ArchiveBlogPost retires a published post.
+	ArchiveBlogPost(ctx context.Context, id uint64) (ID, error)
+	// ScheduleBlogPost queues a draft to be published by the Scheduler at the future time at.
+	ScheduleBlogPost(ctx context.Context, id uint64, at time.Time) (ID, error)
+	// UnscheduleBlogPost keeps a scheduled draft from being published.
+	UnscheduleBlogPost(ctx context.Context, id uint64) (ID, error)
+
+	// AddTag adds a tag, named by the slug of atg or else by the slug of its name.
+	AddTag(ctx context.Context, atg AddTag) (Tag, error)
+	Tag(ctx context.Context, slug string) (Tag, error)
+	// Tags returns every tag, ordered by slug.
+	Tags(ctx context.Context) ([]Tag, error)
+	// TagCloud returns every tag with the number of published posts that have it, the most used first.
+	TagCloud(ctx context.Context) ([]TagCount, error)
+	// UpdateTag renames a tag, its slug never changes.
+	UpdateTag(ctx context.Context, slug string, ut UpdateTag) (Tag, error)
+	// DeleteTag deletes a tag no post has.
+	DeleteTag(ctx context.Context, slug string) error
+	// AddCategory adds a category under its parent, named by the slug of ac or else by the slug of its
+	// name.
+	AddCategory(ctx context.Context, ac AddCategory) (Category, error)
+	Category(ctx context.Context, slug string) (Category, error)
+	// Categories returns every category, each after its parent.
+	Categories(ctx context.Context) ([]Category, error)
+	// UpdateCategory renames a category or moves it under another parent, its slug never changes.
+	UpdateCategory(ctx context.Context, slug string, uc UpdateCategory) (Category, error)
+	// DeleteCategory deletes a category without posts or subcategories.
+	DeleteCategory(ctx context.Context, slug string) error
+
+	// AddAuthor adds an author and returns it with its ID.
+	AddAuthor(ctx context.Context, aa AddAuthor) (Author, error)
+	Author(ctx context.Context, id uint64) (Author, error)
+	// Authors returns every author, ordered by ID.
+	Authors(ctx context.Context) ([]Author, error)
+	// UpdateAuthor edits the profile of an author.
+	UpdateAuthor(ctx context.Context, id uint64, ua UpdateAuthor) (Author, error)
+	// DeleteAuthor deletes an author. An author with posts is only deleted when its posts are
+	// reassigned to the author with reassignTo.
+	DeleteAuthor(ctx context.Context, id, reassignTo uint64) (ID, error)
+	// AuthorBlogPosts returns the page of the posts of an author matching q selected by page.
+	AuthorBlogPosts(ctx context.Context, id uint64, q Query, page Page) (BlogPostPage, error)
+
+	// React sets the reaction of the principal of ctx to a post, a reader reacting twice keeps one
+	// reaction.
+	React(ctx context.Context, postID uint64, ar AddReaction) (ReactionCounts, error)
+	// Unreact removes the reaction of the principal of ctx to a post.
+	Unreact(ctx context.Context, postID uint64) (ReactionCounts, error)
+
+	// Snapshot writes a consistent copy of every post, tag, category, author, comment and reaction to
+	// w.
+	Snapshot(ctx context.Context, w io.Writer) (SnapshotInfo, error)
+	// Restore replaces every post, tag, category, author, comment and reaction with the snapshot read
+	// from r.
+	Restore(ctx context.Context, r io.Reader) (SnapshotInfo, error)
+	// CacheStats returns the hit, miss and eviction counters of the cache.
+	CacheStats(ctx context.Context) (CacheStats, error)
 }
 
 func NewBusiness(repo Repo) Business {
@@ -32,6 +201,17 @@ func NewBusiness(repo Repo) Business {
 }
 
 func (b *business) AddBlogPost(ctx context.Context, abp AddBlogPost) (ID, error) {
+	if err := authbus.Authorize(ctx, authbus.CreatePost, nil); err != nil {
+		return nil, err
+	}
+
+	if abp.Slug != "" && !ValidSlug(abp.Slug) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidSlug, abp.Slug)
+	}
+
+	abp.Tags, abp.Categories = uniqueTerms(abp.Tags), uniqueTerms(abp.Categories)
+	abp.Editor = editor(ctx)
+
 	id, err := b.repo.AddBlogPost(ctx, abp)
 	if err != nil {
 		return nil, fmt.Errorf("repo.addblogpost: %w", err)
@@ -40,9 +220,104 @@ func (b *business) AddBlogPost(ctx context.Context, abp AddBlogPost) (ID, error)
 	return ToID(id), nil
 }
 
-func (b *business) BlogPosts(ctx context.Context) []BlogPost {
-	v := b.repo.BlogPosts(ctx)
-	return v
+func (b *business) BlogPosts(ctx context.Context, q Query, page Page) (BlogPostPage, error) {
+	if err := q.Validate(); err != nil {
+		return BlogPostPage{}, err
+	}
+
+	if q.Trashed {
+		if err := authbus.Authorize(ctx, authbus.ManageTrash, nil); err != nil {
+			return BlogPostPage{}, err
+		}
+	}
+
+	q, err := readableQuery(ctx, q)
+	if err != nil {
+		return BlogPostPage{}, err
+	}
+
+	page, err = validPage(page)
+	if err != nil {
+		return BlogPostPage{}, err
+	}
+
+	bpp, err := b.repo.BlogPosts(ctx, q, page)
+	if err != nil {
+		return BlogPostPage{}, fmt.Errorf("repo.blogposts: %w", err)
+	}
+
+	return bpp, nil
+}
+
+func (b *business) SearchBlogPosts(ctx context.Context, q string, page Page) (SearchPage, error) {
+	if strings.TrimSpace(q) == "" {
+		return SearchPage{}, ErrEmptySearch
+	}
+
+	page, err := validPage(page)
+	if err != nil {
+		return SearchPage{}, err
+	}
+
+	sp, err := b.repo.SearchBlogPosts(ctx, q, page)
+	if err != nil {
+		return SearchPage{}, fmt.Errorf("repo.searchblogposts: %w", err)
+	}
+
+	return sp, nil
+}
+
+// readable returns nil when the principal of ctx may read bp: anyone may read a published post, only
+// its author or an editor one that is not, see authbus.ReadPost. Anyone else gets ErrBlogPostNotFound,
+// as if the post did not exist.
+func readable(ctx context.Context, bp BlogPost) error {
+	if bp.Status == StatusPublished {
+		return nil
+	}
+
+	p, ok := authbus.PrincipalFrom(ctx)
+	if !ok || p.Can(authbus.ReadPost, func() (string, error) { return bp.Owner, nil }) != nil {
+		return fmt.Errorf("%w id: %d", ErrBlogPostNotFound, bp.ID)
+	}
+
+	return nil
+}
+
+// readableQuery returns q narrowed to the posts the principal of ctx may read. Anyone may list the
+// published posts, the others need an editor, or an author and are narrowed to their own.
+func readableQuery(ctx context.Context, q Query) (Query, error) {
+	if q.Status == StatusPublished {
+		return q, nil
+	}
+
+	p, ok := authbus.PrincipalFrom(ctx)
+	if !ok {
+		return Query{}, fmt.Errorf("%w: only published posts are listed to anyone", authbus.ErrUnauthenticated)
+	}
+
+	// Nobody owns a post of no owner, a principal that may only read their own is told ErrNotOwner.
+	err := p.Can(authbus.ReadPost, func() (string, error) { return "", nil })
+	switch {
+	case errors.Is(err, authbus.ErrNotOwner):
+		q.Owner = p.Subject
+	case err != nil:
+		return Query{}, err
+	}
+
+	return q, nil
+}
+
+// validPage returns page with its default limit, or ErrInvalidLimit when its limit is out of range.
+func validPage(page Page) (Page, error) {
+	if page.Limit == 0 {
+		page.Limit = DefaultLimit
+	}
+
+	if page.Limit < 0 || page.Limit > MaxLimit {
+		return Page{}, ErrInvalidLimit
+	}
+
+	return page, nil
 }
 
 func (b *business) BlogPost(ctx context.Context, id uint64) (BlogPost, error) {
@@ -51,19 +326,77 @@ func (b *business) BlogPost(ctx context.Context, id uint64) (BlogPost, error) {
 		return BlogPost{}, fmt.Errorf("repo.blogpost: %w id: %d", err, id)
 	}
 
+	if err := readable(ctx, bp); err != nil {
+		return BlogPost{}, err
+	}
+
+	return bp, nil
+}
+
+func (b *business) BlogPostBySlug(ctx context.Context, slug string) (BlogPost, error) {
+	bp, err := b.repo.BlogPostBySlug(ctx, slug)
+	if err != nil {
+		return BlogPost{}, fmt.Errorf("repo.blogpostbyslug: %w slug: %s", err, slug)
+	}
+
+	if err := readable(ctx, bp); err != nil {
+		return BlogPost{}, err
+	}
+
 	return bp, nil
 }
 
 func (b *business) DeleteBlogPost(ctx context.Context, id uint64) (ID, error) {
-	id, err := b.repo.DeleteBlogPost(ctx, id)
+	if err := authbus.Authorize(ctx, authbus.EditPost, b.owner(ctx, id)); err != nil {
+		return nil, err
+	}
+
+	out, err := b.repo.TrashBlogPost(ctx, id, time.Now())
 	if err != nil {
-		return nil, fmt.Errorf("repo.deleteblogpost: %w id: %d", err, id)
+		return nil, fmt.Errorf("repo.trashblogpost: %w id: %d", err, id)
 	}
 
-	return ToID(id), nil
+	return ToID(out), nil
+}
+
+func (b *business) RestoreBlogPost(ctx context.Context, id uint64) (ID, error) {
+	if err := authbus.Authorize(ctx, authbus.ManageTrash, nil); err != nil {
+		return nil, err
+	}
+
+	out, err := b.repo.RestoreBlogPost(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("repo.restoreblogpost: %w id: %d", err, id)
+	}
+
+	return ToID(out), nil
+}
+
+func (b *business) PurgeBlogPost(ctx context.Context, id uint64) (ID, error) {
+	if err := authbus.Authorize(ctx, authbus.ManageTrash, nil); err != nil {
+		return nil, err
+	}
+
+	out, err := b.repo.PurgeBlogPost(ctx, id, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("repo.purgeblogpost: %w id: %d", err, id)
+	}
+
+	return ToID(out), nil
 }
 
 func (b *business) UpdateBlogPost(ctx context.Context, id uint64, ubp UpdateBlogPost) (ID, error) {
+	if err := authbus.Authorize(ctx, authbus.EditPost, b.owner(ctx, id)); err != nil {
+		return nil, err
+	}
+
+	if ubp.Slug != "" && !ValidSlug(ubp.Slug) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidSlug, ubp.Slug)
+	}
+
+	ubp.Tags, ubp.Categories = uniqueTerms(ubp.Tags), uniqueTerms(ubp.Categories)
+	ubp.Editor = editor(ctx)
+
 	id, err := b.repo.UpdateBlogPost(ctx, id, ubp)
 	if err != nil {
 		return nil, fmt.Errorf("repo.updateblogpost: %w id: %d", err, id)
@@ -71,3 +404,424 @@ func (b *business) UpdateBlogPost(ctx context.Context, id uint64, ubp UpdateBlog
 
 	return ToID(id), nil
 }
+
+func (b *business) BlogPostRevisions(ctx context.Context, id uint64) ([]Revision, error) {
+	bp, err := b.repo.BlogPost(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("repo.blogpost: %w id: %d", err, id)
+	}
+
+	if err := readable(ctx, bp); err != nil {
+		return nil, err
+	}
+
+	return bp.History(), nil
+}
+
+func (b *business) BlogPostRevision(ctx context.Context, id, number uint64) (Revision, error) {
+	bp, err := b.repo.BlogPost(ctx, id)
+	if err != nil {
+		return Revision{}, fmt.Errorf("repo.blogpost: %w id: %d", err, id)
+	}
+
+	if err := readable(ctx, bp); err != nil {
+		return Revision{}, err
+	}
+
+	rev, err := bp.Revision(number)
+	if err != nil {
+		return Revision{}, fmt.Errorf("%w: %d id: %d", err, number, id)
+	}
+
+	return rev, nil
+}
+
+func (b *business) DiffBlogPostRevisions(ctx context.Context, id, from, to uint64) ([]FieldDiff, error) {
+	bp, err := b.repo.BlogPost(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("repo.blogpost: %w id: %d", err, id)
+	}
+
+	if err := readable(ctx, bp); err != nil {
+		return nil, err
+	}
+
+	a, err := bp.Revision(from)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %d id: %d", err, from, id)
+	}
+
+	z, err := bp.Revision(to)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %d id: %d", err, to, id)
+	}
+
+	return Diff(a, z), nil
+}
+
+func (b *business) RestoreBlogPostRevision(ctx context.Context, id, number uint64) (ID, error) {
+	if err := authbus.Authorize(ctx, authbus.EditPost, b.owner(ctx, id)); err != nil {
+		return nil, err
+	}
+
+	rev, err := b.BlogPostRevision(ctx, id, number)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := b.repo.UpdateBlogPost(ctx, id, UpdateBlogPost{
+		Title:        rev.Title,
+		Description:  rev.Description,
+		Body:         rev.Body,
+		Editor:       editor(ctx),
+		RestoredFrom: rev.Number,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repo.updateblogpost: %w id: %d", err, id)
+	}
+
+	return ToID(out), nil
+}
+
+func (b *business) PublishBlogPost(ctx context.Context, id uint64) (ID, error) {
+	return b.transition(ctx, id, publish, time.Time{})
+}
+
+func (b *business) UnpublishBlogPost(ctx context.Context, id uint64) (ID, error) {
+	return b.transition(ctx, id, unpublish, time.Time{})
+}
+
+func (b *business) ArchiveBlogPost(ctx context.Context, id uint64) (ID, error) {
+	return b.transition(ctx, id, archive, time.Time{})
+}
+
+func (b *business) ScheduleBlogPost(ctx context.Context, id uint64, at time.Time) (ID, error) {
+	if !at.After(time.Now()) {
+		return nil, ErrInvalidSchedule
+	}
+
+	return b.transition(ctx, id, schedule, at)
+}
+
+func (b *business) UnscheduleBlogPost(ctx context.Context, id uint64) (ID, error) {
+	return b.transition(ctx, id, unschedule, time.Time{})
+}
+
+// transition moves the post with id through the state machine by a, leaving it scheduled at
+// publishAt.
+func (b *business) transition(ctx context.Context, id uint64, a action, publishAt time.Time) (ID, error) {
+	if err := authbus.Authorize(ctx, authbus.PublishPost, nil); err != nil {
+		return nil, err
+	}
+
+	t := transitions[a]
+	t.At = time.Now()
+	t.PublishAt = publishAt
+
+	out, err := b.repo.TransitionBlogPost(ctx, id, t)
+	if err != nil {
+		return nil, fmt.Errorf("repo.transitionblogpost: %w id: %d", err, id)
+	}
+
+	return ToID(out), nil
+}
+
+func (b *business) AddTag(ctx context.Context, atg AddTag) (Tag, error) {
+	if err := authbus.Authorize(ctx, authbus.ManageTaxonomy, nil); err != nil {
+		return Tag{}, err
+	}
+
+	t, err := NewTag(atg, time.Now())
+	if err != nil {
+		return Tag{}, err
+	}
+
+	if err := b.repo.AddTag(ctx, t); err != nil {
+		return Tag{}, fmt.Errorf("repo.addtag: %w slug: %s", err, t.Slug)
+	}
+
+	return t, nil
+}
+
+func (b *business) Tag(ctx context.Context, slug string) (Tag, error) {
+	t, err := b.repo.Tag(ctx, slug)
+	if err != nil {
+		return Tag{}, fmt.Errorf("repo.tag: %w slug: %s", err, slug)
+	}
+
+	return t, nil
+}
+
+func (b *business) Tags(ctx context.Context) ([]Tag, error) {
+	ts, err := b.repo.Tags(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("repo.tags: %w", err)
+	}
+
+	return ts, nil
+}
+
+func (b *business) TagCloud(ctx context.Context) ([]TagCount, error) {
+	tcs, err := b.repo.TagCloud(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("repo.tagcloud: %w", err)
+	}
+
+	return tcs, nil
+}
+
+func (b *business) UpdateTag(ctx context.Context, slug string, ut UpdateTag) (Tag, error) {
+	if err := authbus.Authorize(ctx, authbus.ManageTaxonomy, nil); err != nil {
+		return Tag{}, err
+	}
+
+	t, err := b.repo.UpdateTag(ctx, slug, ut)
+	if err != nil {
+		return Tag{}, fmt.Errorf("repo.updatetag: %w slug: %s", err, slug)
+	}
+
+	return t, nil
+}
+
+func (b *business) DeleteTag(ctx context.Context, slug string) error {
+	if err := authbus.Authorize(ctx, authbus.ManageTaxonomy, nil); err != nil {
+		return err
+	}
+
+	if err := b.repo.DeleteTag(ctx, slug); err != nil {
+		return fmt.Errorf("repo.deletetag: %w slug: %s", err, slug)
+	}
+
+	return nil
+}
+
+func (b *business) AddCategory(ctx context.Context, ac AddCategory) (Category, error) {
+	if err := authbus.Authorize(ctx, authbus.ManageTaxonomy, nil); err != nil {
+		return Category{}, err
+	}
+
+	c, err := NewCategory(ac, time.Now())
+	if err != nil {
+		return Category{}, err
+	}
+
+	if err := b.repo.AddCategory(ctx, c); err != nil {
+		return Category{}, fmt.Errorf("repo.addcategory: %w slug: %s", err, c.Slug)
+	}
+
+	return c, nil
+}
+
+func (b *business) Category(ctx context.Context, slug string) (Category, error) {
+	c, err := b.repo.Category(ctx, slug)
+	if err != nil {
+		return Category{}, fmt.Errorf("repo.category: %w slug: %s", err, slug)
+	}
+
+	return c, nil
+}
+
+func (b *business) Categories(ctx context.Context) ([]Category, error) {
+	cs, err := b.repo.Categories(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("repo.categories: %w", err)
+	}
+
+	return cs, nil
+}
+
+func (b *business) UpdateCategory(ctx context.Context, slug string, uc UpdateCategory) (Category, error) {
+	if err := authbus.Authorize(ctx, authbus.ManageTaxonomy, nil); err != nil {
+		return Category{}, err
+	}
+
+	c, err := b.repo.UpdateCategory(ctx, slug, uc)
+	if err != nil {
+		return Category{}, fmt.Errorf("repo.updatecategory: %w slug: %s", err, slug)
+	}
+
+	return c, nil
+}
+
+func (b *business) DeleteCategory(ctx context.Context, slug string) error {
+	if err := authbus.Authorize(ctx, authbus.ManageTaxonomy, nil); err != nil {
+		return err
+	}
+
+	if err := b.repo.DeleteCategory(ctx, slug); err != nil {
+		return fmt.Errorf("repo.deletecategory: %w slug: %s", err, slug)
+	}
+
+	return nil
+}
+
+func (b *business) AddAuthor(ctx context.Context, aa AddAuthor) (Author, error) {
+	if err := authbus.Authorize(ctx, authbus.ManageTaxonomy, nil); err != nil {
+		return Author{}, err
+	}
+
+	a, err := NewAuthor(aa, time.Now())
+	if err != nil {
+		return Author{}, err
+	}
+
+	a, err = b.repo.AddAuthor(ctx, a)
+	if err != nil {
+		return Author{}, fmt.Errorf("repo.addauthor: %w", err)
+	}
+
+	return a, nil
+}
+
+func (b *business) Author(ctx context.Context, id uint64) (Author, error) {
+	a, err := b.repo.Author(ctx, id)
+	if err != nil {
+		return Author{}, fmt.Errorf("repo.author: %w id: %d", err, id)
+	}
+
+	return a, nil
+}
+
+func (b *business) Authors(ctx context.Context) ([]Author, error) {
+	as, err := b.repo.Authors(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("repo.authors: %w", err)
+	}
+
+	return as, nil
+}
+
+func (b *business) UpdateAuthor(ctx context.Context, id uint64, ua UpdateAuthor) (Author, error) {
+	if err := authbus.Authorize(ctx, authbus.ManageTaxonomy, nil); err != nil {
+		return Author{}, err
+	}
+
+	a, err := b.repo.UpdateAuthor(ctx, id, ua)
+	if err != nil {
+		return Author{}, fmt.Errorf("repo.updateauthor: %w id: %d", err, id)
+	}
+
+	return a, nil
+}
+
+func (b *business) DeleteAuthor(ctx context.Context, id, reassignTo uint64) (ID, error) {
+	if err := authbus.Authorize(ctx, authbus.ManageTaxonomy, nil); err != nil {
+		return nil, err
+	}
+
+	if reassignTo == id {
+		return nil, ErrInvalidReassign
+	}
+
+	if err := b.repo.DeleteAuthor(ctx, id, reassignTo); err != nil {
+		return nil, fmt.Errorf("repo.deleteauthor: %w id: %d", err, id)
+	}
+
+	return ToID(id), nil
+}
+
+func (b *business) AuthorBlogPosts(ctx context.Context, id uint64, q Query, page Page) (BlogPostPage, error) {
+	if _, err := b.Author(ctx, id); err != nil {
+		return BlogPostPage{}, err
+	}
+
+	q.AuthorID = id
+
+	return b.BlogPosts(ctx, q, page)
+}
+
+func (b *business) React(ctx context.Context, postID uint64, ar AddReaction) (ReactionCounts, error) {
+	if err := authbus.Authorize(ctx, authbus.React, nil); err != nil {
+		return nil, err
+	}
+
+	p, _ := authbus.PrincipalFrom(ctx)
+
+	rr, err := NewReaderReaction(postID, p.Subject, ar, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := b.reactable(ctx, postID); err != nil {
+		return nil, err
+	}
+
+	counts, err := b.repo.React(ctx, rr)
+	if err != nil {
+		return nil, fmt.Errorf("repo.react: %w id: %d", err, postID)
+	}
+
+	return counts, nil
+}
+
+func (b *business) Unreact(ctx context.Context, postID uint64) (ReactionCounts, error) {
+	if err := authbus.Authorize(ctx, authbus.React, nil); err != nil {
+		return nil, err
+	}
+
+	p, _ := authbus.PrincipalFrom(ctx)
+
+	if err := validReader(p.Subject); err != nil {
+		return nil, err
+	}
+
+	if err := b.reactable(ctx, postID); err != nil {
+		return nil, err
+	}
+
+	counts, err := b.repo.Unreact(ctx, postID, p.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("repo.unreact: %w id: %d", err, postID)
+	}
+
+	return counts, nil
+}
+
+// reactable returns nil when the principal of ctx may read the post with id and so react to it.
+func (b *business) reactable(ctx context.Context, id uint64) error {
+	bp, err := b.repo.BlogPost(ctx, id)
+	if err != nil {
+		return fmt.Errorf("repo.blogpost: %w id: %d", err, id)
+	}
+
+	return readable(ctx, bp)
+}
+
+func (b *business) Snapshot(ctx context.Context, w io.Writer) (SnapshotInfo, error) {
+	if err := authbus.Authorize(ctx, authbus.Operate, nil); err != nil {
+		return SnapshotInfo{}, err
+	}
+
+	info, err := b.repo.Snapshot(ctx, w)
+	if err != nil {
+		return SnapshotInfo{}, fmt.Errorf("repo.snapshot: %w", err)
+	}
+
+	return info, nil
+}
+
+func (b *business) Restore(ctx context.Context, r io.Reader) (SnapshotInfo, error) {
+	if err := authbus.Authorize(ctx, authbus.Operate, nil); err != nil {
+		return SnapshotInfo{}, err
+	}
+
+	info, err := b.repo.Restore(ctx, r)
+	if err != nil {
+		return SnapshotInfo{}, fmt.Errorf("repo.restore: %w", err)
+	}
+
+	return info, nil
+}
+
+func (b *business) CacheStats(ctx context.Context) (CacheStats, error) {
+	if err := authbus.Authorize(ctx, authbus.Operate, nil); err != nil {
+		return CacheStats{}, err
+	}
+
+	cs, err := b.repo.CacheStats(ctx)
+	if err != nil {
+		return CacheStats{}, fmt.Errorf("repo.cachestats: %w", err)
+	}
+
+	return cs, nil
+}
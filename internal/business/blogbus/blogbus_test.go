@@ -1,11 +1,14 @@
 package blogbus_test
 
 import (
+	"context"
 	"testing"
 	"time"
 
+	"github.com/anazcodes/blogapp/internal/business/authbus"
 	"github.com/anazcodes/blogapp/internal/business/blogbus"
 	mockblogbus "github.com/anazcodes/blogapp/internal/mock/business/blogbus"
+	"github.com/anazcodes/blogapp/internal/repository/blogrepo"
 	"github.com/anazcodes/blogapp/internal/repository/blogrepo/cache"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -18,6 +21,10 @@ func TestAddBlogPost(t *testing.T) {
 		Body:        "Body",
 	}
 
+	// The principal is recorded as the editor of the first revision.
+	stored := input
+	stored.Editor = "editor"
+
 	testCases := []struct {
 		name        string
 		setupExpect func(repo *mockblogbus.MockRepo)
@@ -28,7 +35,7 @@ func TestAddBlogPost(t *testing.T) {
 		{
 			name: "Success",
 			setupExpect: func(repo *mockblogbus.MockRepo) {
-				repo.EXPECT().AddBlogPost(gomock.Any(), input).Return(uint64(1), nil).AnyTimes()
+				repo.EXPECT().AddBlogPost(gomock.Any(), stored).Return(uint64(1), nil).AnyTimes()
 			},
 			output:      blogbus.ToID(1),
 			expectedErr: nil,
@@ -36,7 +43,7 @@ func TestAddBlogPost(t *testing.T) {
 		{
 			name: "Failure",
 			setupExpect: func(repo *mockblogbus.MockRepo) {
-				repo.EXPECT().AddBlogPost(gomock.Any(), input).Return(uint64(0), cache.ErrCacheInMaxCap).AnyTimes()
+				repo.EXPECT().AddBlogPost(gomock.Any(), stored).Return(uint64(0), cache.ErrCacheInMaxCap).AnyTimes()
 			},
 			output:      blogbus.ToID(0),
 			expectedErr: cache.ErrCacheInMaxCap,
@@ -52,7 +59,7 @@ func TestAddBlogPost(t *testing.T) {
 			tc.setupExpect(repo)
 			bus := blogbus.NewBusiness(repo)
 
-			output, err := bus.AddBlogPost(t.Context(), input)
+			output, err := bus.AddBlogPost(editing(t), input)
 
 			assert.ErrorIs(t, err, tc.expectedErr)
 			assert.Equal(t, tc.output.ID(), output.ID())
@@ -69,6 +76,8 @@ func TestBlogPosts(t *testing.T) {
 
 	testCases := []struct {
 		name        string
+		query       blogbus.Query
+		page        blogbus.Page
 		setupExpect func(repo *mockblogbus.MockRepo)
 		output      []blogbus.BlogPost
 		expectedLen int
@@ -77,17 +86,20 @@ func TestBlogPosts(t *testing.T) {
 
 		{
 			name: "Success",
+			page: blogbus.Page{},
 			setupExpect: func(repo *mockblogbus.MockRepo) {
-				repo.EXPECT().BlogPosts(gomock.Any()).Return([]blogbus.BlogPost{
-					{
-						ID:          1,
-						Title:       bp.Title,
-						Description: bp.Description,
-						Body:        bp.Body,
-						CreatedAt:   time.Now(),
-						UpdatedAt:   time.Now(),
+				repo.EXPECT().BlogPosts(gomock.Any(), gomock.Any(), blogbus.Page{Limit: blogbus.DefaultLimit}).Return(blogbus.BlogPostPage{
+					BlogPosts: []blogbus.BlogPost{
+						{
+							ID:          1,
+							Title:       bp.Title,
+							Description: bp.Description,
+							Body:        bp.Body,
+							CreatedAt:   time.Now(),
+							UpdatedAt:   time.Now(),
+						},
 					},
-				}).AnyTimes()
+				}, nil).AnyTimes()
 			},
 			output: []blogbus.BlogPost{
 				{
@@ -102,6 +114,29 @@ func TestBlogPosts(t *testing.T) {
 			expectedLen: 1,
 			expectedErr: nil,
 		},
+		{
+			name: "Failure",
+			page: blogbus.Page{Limit: 10},
+			setupExpect: func(repo *mockblogbus.MockRepo) {
+				repo.EXPECT().BlogPosts(gomock.Any(), gomock.Any(), blogbus.Page{Limit: 10}).Return(blogbus.BlogPostPage{}, context.DeadlineExceeded).AnyTimes()
+			},
+			expectedLen: 0,
+			expectedErr: context.DeadlineExceeded,
+		},
+		{
+			name:        "Invalid Query",
+			query:       blogbus.Query{SortBy: "body"},
+			setupExpect: func(repo *mockblogbus.MockRepo) {},
+			expectedLen: 0,
+			expectedErr: blogbus.ErrInvalidQuery,
+		},
+		{
+			name:        "Invalid Limit",
+			page:        blogbus.Page{Limit: blogbus.MaxLimit + 1},
+			setupExpect: func(repo *mockblogbus.MockRepo) {},
+			expectedLen: 0,
+			expectedErr: blogbus.ErrInvalidLimit,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -113,20 +148,252 @@ func TestBlogPosts(t *testing.T) {
 			tc.setupExpect(repo)
 			bus := blogbus.NewBusiness(repo)
 
-			output := bus.BlogPosts(t.Context())
+			output, err := bus.BlogPosts(editing(t), tc.query, tc.page)
+			assert.ErrorIs(t, err, tc.expectedErr)
 
-			len := len(output)
+			len := len(output.BlogPosts)
 			assert.Equal(t, tc.expectedLen, len)
 
 			if len > 0 {
-				assert.Equal(t, tc.output[0].Title, output[0].Title)
-				assert.Equal(t, tc.output[0].Description, output[0].Description)
-				assert.Equal(t, tc.output[0].Body, output[0].Body)
+				assert.Equal(t, tc.output[0].Title, output.BlogPosts[0].Title)
+				assert.Equal(t, tc.output[0].Description, output.BlogPosts[0].Description)
+				assert.Equal(t, tc.output[0].Body, output.BlogPosts[0].Body)
 			}
 		})
 	}
 }
 
+func TestSearchBlogPosts(t *testing.T) {
+	hit := blogbus.SearchHit{
+		BlogPost: blogbus.BlogPost{ID: 1, Title: "Caching"},
+		Score:    1.5,
+		Snippets: []blogbus.Snippet{{Field: "title", Text: "<mark>Caching</mark>"}},
+	}
+
+	testCases := []struct {
+		name        string
+		query       string
+		page        blogbus.Page
+		setupExpect func(repo *mockblogbus.MockRepo)
+		output      blogbus.SearchPage
+		expectedErr error
+	}{
+		{
+			name:  "Success",
+			query: "cache",
+			setupExpect: func(repo *mockblogbus.MockRepo) {
+				repo.EXPECT().SearchBlogPosts(gomock.Any(), "cache", blogbus.Page{Limit: blogbus.DefaultLimit}).Return(blogbus.SearchPage{
+					Hits: []blogbus.SearchHit{hit},
+				}, nil).AnyTimes()
+			},
+			output: blogbus.SearchPage{Hits: []blogbus.SearchHit{hit}},
+		},
+		{
+			name:  "Failure",
+			query: "cache",
+			page:  blogbus.Page{Limit: 10},
+			setupExpect: func(repo *mockblogbus.MockRepo) {
+				repo.EXPECT().SearchBlogPosts(gomock.Any(), "cache", blogbus.Page{Limit: 10}).Return(blogbus.SearchPage{}, context.DeadlineExceeded).AnyTimes()
+			},
+			expectedErr: context.DeadlineExceeded,
+		},
+		{
+			name:        "Empty Search",
+			query:       "  ",
+			setupExpect: func(repo *mockblogbus.MockRepo) {},
+			expectedErr: blogbus.ErrEmptySearch,
+		},
+		{
+			name:        "Invalid Limit",
+			query:       "cache",
+			page:        blogbus.Page{Limit: -1},
+			setupExpect: func(repo *mockblogbus.MockRepo) {},
+			expectedErr: blogbus.ErrInvalidLimit,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo := mockblogbus.NewMockRepo(ctrl)
+
+			tc.setupExpect(repo)
+			bus := blogbus.NewBusiness(repo)
+
+			output, err := bus.SearchBlogPosts(editing(t), tc.query, tc.page)
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.output, output)
+		})
+	}
+}
+
+func TestTransitions(t *testing.T) {
+	publishAt := time.Now().Add(time.Hour)
+
+	testCases := []struct {
+		name        string
+		transition  func(bus blogbus.Business, id uint64) (blogbus.ID, error)
+		from, to    blogbus.Status
+		publishAt   time.Time
+		repoErr     error
+		expectedErr error
+	}{
+		{
+			name:       "Publish",
+			transition: func(bus blogbus.Business, id uint64) (blogbus.ID, error) { return bus.PublishBlogPost(editing(t), id) },
+			from:       blogbus.StatusDraft,
+			to:         blogbus.StatusPublished,
+		},
+		{
+			name: "Unpublish",
+			transition: func(bus blogbus.Business, id uint64) (blogbus.ID, error) {
+				return bus.UnpublishBlogPost(editing(t), id)
+			},
+			from: blogbus.StatusPublished,
+			to:   blogbus.StatusDraft,
+		},
+		{
+			name:       "Archive",
+			transition: func(bus blogbus.Business, id uint64) (blogbus.ID, error) { return bus.ArchiveBlogPost(editing(t), id) },
+			from:       blogbus.StatusPublished,
+			to:         blogbus.StatusArchived,
+		},
+		{
+			name:        "Invalid Transition",
+			transition:  func(bus blogbus.Business, id uint64) (blogbus.ID, error) { return bus.PublishBlogPost(editing(t), id) },
+			from:        blogbus.StatusDraft,
+			to:          blogbus.StatusPublished,
+			repoErr:     blogbus.ErrInvalidTransition,
+			expectedErr: blogbus.ErrInvalidTransition,
+		},
+		{
+			name: "Schedule",
+			transition: func(bus blogbus.Business, id uint64) (blogbus.ID, error) {
+				return bus.ScheduleBlogPost(editing(t), id, publishAt)
+			},
+			from:      blogbus.StatusDraft,
+			to:        blogbus.StatusDraft,
+			publishAt: publishAt,
+		},
+		{
+			name: "Schedule In The Past",
+			transition: func(bus blogbus.Business, id uint64) (blogbus.ID, error) {
+				return bus.ScheduleBlogPost(editing(t), id, time.Now().Add(-time.Minute))
+			},
+			expectedErr: blogbus.ErrInvalidSchedule,
+		},
+		{
+			name: "Unschedule",
+			transition: func(bus blogbus.Business, id uint64) (blogbus.ID, error) {
+				return bus.UnscheduleBlogPost(editing(t), id)
+			},
+			from: blogbus.StatusDraft,
+			to:   blogbus.StatusDraft,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo := mockblogbus.NewMockRepo(ctrl)
+
+			repo.EXPECT().TransitionBlogPost(gomock.Any(), uint64(1), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, id uint64, tr blogbus.Transition) (uint64, error) {
+					assert.Equal(t, tc.from, tr.From)
+					assert.Equal(t, tc.to, tr.To)
+					assert.False(t, tr.At.IsZero())
+					assert.Equal(t, tc.publishAt, tr.PublishAt)
+
+					if tc.repoErr != nil {
+						return 0, tc.repoErr
+					}
+
+					return id, nil
+				}).MaxTimes(1)
+
+			output, err := tc.transition(blogbus.NewBusiness(repo), 1)
+			assert.ErrorIs(t, err, tc.expectedErr)
+
+			if tc.expectedErr == nil {
+				assert.Equal(t, uint64(1), output.ID())
+			}
+		})
+	}
+}
+
+func TestTransitionApply(t *testing.T) {
+	at := time.Now()
+	bp := blogbus.BlogPost{ID: 1, Status: blogbus.StatusDraft}
+
+	bp = blogbus.Transition{From: blogbus.StatusDraft, To: blogbus.StatusPublished, At: at}.Apply(bp)
+	assert.Equal(t, blogbus.StatusPublished, bp.Status)
+	assert.Equal(t, at, bp.PublishedAt)
+
+	archived := blogbus.Transition{From: blogbus.StatusPublished, To: blogbus.StatusArchived, At: at.Add(time.Hour)}.Apply(bp)
+	assert.Equal(t, blogbus.StatusArchived, archived.Status)
+	assert.Equal(t, at, archived.PublishedAt)
+
+	draft := blogbus.Transition{From: blogbus.StatusPublished, To: blogbus.StatusDraft, At: at}.Apply(bp)
+	assert.Equal(t, blogbus.StatusDraft, draft.Status)
+	assert.True(t, draft.PublishedAt.IsZero())
+
+	scheduled := blogbus.Transition{From: blogbus.StatusDraft, To: blogbus.StatusDraft, PublishAt: at}.Apply(draft)
+	assert.Equal(t, at, scheduled.PublishAt)
+
+	// Publishing clears the schedule.
+	published := blogbus.Transition{From: blogbus.StatusDraft, To: blogbus.StatusPublished, At: at}.Apply(scheduled)
+	assert.True(t, published.PublishAt.IsZero())
+}
+
+func TestTransitionCheck(t *testing.T) {
+	at := time.Now()
+	publish := blogbus.Transition{From: blogbus.StatusDraft, To: blogbus.StatusPublished, At: at, DueBy: at}
+
+	testCases := []struct {
+		name        string
+		post        blogbus.BlogPost
+		transition  blogbus.Transition
+		expectedErr error
+	}{
+		{
+			name:       "From Status",
+			post:       blogbus.BlogPost{Status: blogbus.StatusDraft},
+			transition: blogbus.Transition{From: blogbus.StatusDraft, To: blogbus.StatusPublished},
+		},
+		{
+			name:        "Other Status",
+			post:        blogbus.BlogPost{Status: blogbus.StatusArchived},
+			transition:  blogbus.Transition{From: blogbus.StatusDraft, To: blogbus.StatusPublished},
+			expectedErr: blogbus.ErrInvalidTransition,
+		},
+		{
+			name:       "Due",
+			post:       blogbus.BlogPost{Status: blogbus.StatusDraft, PublishAt: at},
+			transition: publish,
+		},
+		{
+			name:        "Not Due",
+			post:        blogbus.BlogPost{Status: blogbus.StatusDraft, PublishAt: at.Add(time.Second)},
+			transition:  publish,
+			expectedErr: blogbus.ErrInvalidTransition,
+		},
+		{
+			name:        "Not Scheduled",
+			post:        blogbus.BlogPost{Status: blogbus.StatusDraft},
+			transition:  publish,
+			expectedErr: blogbus.ErrInvalidTransition,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.ErrorIs(t, tc.transition.Check(tc.post), tc.expectedErr)
+		})
+	}
+}
+
 func TestBlogPost(t *testing.T) {
 	input := blogbus.AddBlogPost{
 		Title:       "Title",
@@ -185,7 +452,7 @@ func TestBlogPost(t *testing.T) {
 			tc.setupExpect(repo)
 			bus := blogbus.NewBusiness(repo)
 
-			output, err := bus.BlogPost(t.Context(), tc.id)
+			output, err := bus.BlogPost(editing(t), tc.id)
 
 			assert.ErrorIs(t, err, tc.expectedErr)
 			if err == nil {
@@ -210,7 +477,7 @@ func TestDeleteBlogPost(t *testing.T) {
 		{
 			name: "Success",
 			setupExpect: func(repo *mockblogbus.MockRepo) {
-				repo.EXPECT().DeleteBlogPost(gomock.Any(), uint64(1)).Return(uint64(1), nil).AnyTimes()
+				repo.EXPECT().TrashBlogPost(gomock.Any(), uint64(1), gomock.Any()).Return(uint64(1), nil).AnyTimes()
 			},
 			id:          1,
 			output:      blogbus.ToID(1),
@@ -219,7 +486,7 @@ func TestDeleteBlogPost(t *testing.T) {
 		{
 			name: "Failure",
 			setupExpect: func(repo *mockblogbus.MockRepo) {
-				repo.EXPECT().DeleteBlogPost(gomock.Any(), uint64(2)).Return(uint64(0), cache.ErrItemNotFound).AnyTimes()
+				repo.EXPECT().TrashBlogPost(gomock.Any(), uint64(2), gomock.Any()).Return(uint64(0), cache.ErrItemNotFound).AnyTimes()
 			},
 			id:          2,
 			output:      nil,
@@ -236,7 +503,7 @@ func TestDeleteBlogPost(t *testing.T) {
 			tc.setupExpect(repo)
 			bus := blogbus.NewBusiness(repo)
 
-			output, err := bus.DeleteBlogPost(t.Context(), tc.id)
+			output, err := bus.DeleteBlogPost(editing(t), tc.id)
 			assert.ErrorIs(t, err, tc.expectedErr)
 			if err == nil {
 				assert.Equal(t, tc.output.ID(), output.ID())
@@ -261,6 +528,7 @@ func TestUpdateBlogPost(t *testing.T) {
 					Title:       "Title",
 					Description: "Description",
 					Body:        "Body",
+					Editor:      "editor",
 				}).Return(uint64(1), nil).AnyTimes()
 			},
 			input: blogbus.UpdateBlogPost{
@@ -279,6 +547,7 @@ func TestUpdateBlogPost(t *testing.T) {
 					Title:       "Title",
 					Description: "Description",
 					Body:        "Body",
+					Editor:      "editor",
 				}).Return(uint64(0), cache.ErrItemNotFound).AnyTimes()
 			},
 			input: blogbus.UpdateBlogPost{
@@ -301,9 +570,432 @@ func TestUpdateBlogPost(t *testing.T) {
 			tc.setupExpect(repo)
 			bus := blogbus.NewBusiness(repo)
 
-			output, err := bus.UpdateBlogPost(t.Context(), tc.id, tc.input)
+			output, err := bus.UpdateBlogPost(editing(t), tc.id, tc.input)
 			assert.ErrorIs(t, err, tc.expectedErr)
 			assert.Equal(t, tc.output.ID(), output.ID())
 		})
 	}
 }
+
+func TestUpdateApply(t *testing.T) {
+	created := time.Now()
+	bp := blogbus.NewBlogPost(1, blogbus.AddBlogPost{Title: "Title", Body: "Body", Editor: "alice"}, created)
+
+	assert.Equal(t, []blogbus.Revision{{
+		Number:    1,
+		Editor:    "alice",
+		Changed:   []string{"title", "body"},
+		CreatedAt: created,
+		Title:     "Title",
+		Body:      "Body",
+	}}, bp.Revisions)
+
+	edited := created.Add(time.Hour)
+	next := blogbus.UpdateBlogPost{Body: "New Body", Editor: "bob"}.Apply(bp, edited)
+
+	assert.Equal(t, "Title", next.Title)
+	assert.Equal(t, "New Body", next.Body)
+	assert.Equal(t, edited, next.UpdatedAt)
+	assert.Len(t, next.Revisions, 2)
+	assert.Equal(t, blogbus.Revision{
+		Number:    2,
+		Editor:    "bob",
+		Changed:   []string{"body"},
+		CreatedAt: edited,
+		Title:     "Title",
+		Body:      "New Body",
+	}, next.Revisions[1])
+
+	// The post the update was applied to keeps its history.
+	assert.Len(t, bp.Revisions, 1)
+
+	// A restore replaces every field, the empty ones included.
+	restored := blogbus.UpdateBlogPost{Title: "Title", RestoredFrom: 1}.Apply(next, edited)
+	assert.Empty(t, restored.Body)
+	assert.Equal(t, uint64(1), restored.Revisions[2].RestoredFrom)
+	assert.Equal(t, []string{"body"}, restored.Revisions[2].Changed)
+}
+
+func TestHistory(t *testing.T) {
+	updated := time.Now()
+
+	// A post stored before revisions were recorded stands for its own first revision.
+	bp := blogbus.BlogPost{ID: 1, Title: "Title", UpdatedAt: updated}
+	assert.Equal(t, []blogbus.Revision{{Number: 1, CreatedAt: updated, Title: "Title"}}, bp.History())
+
+	next := blogbus.UpdateBlogPost{Title: "New Title"}.Apply(bp, updated.Add(time.Hour))
+	assert.Len(t, next.Revisions, 2)
+	assert.Equal(t, "Title", next.Revisions[0].Title)
+
+	_, err := next.Revision(3)
+	assert.ErrorIs(t, err, blogbus.ErrRevisionNotFound)
+}
+
+func TestDiff(t *testing.T) {
+	from := blogbus.Revision{Title: "Title", Body: "one\ntwo\nthree"}
+	to := blogbus.Revision{Title: "Title", Description: "Description", Body: "one\n2\nthree\nfour"}
+
+	assert.Equal(t, []blogbus.FieldDiff{
+		{
+			Field: "description",
+			Lines: []blogbus.DiffLine{
+				{Op: blogbus.DiffDelete, Text: ""},
+				{Op: blogbus.DiffInsert, Text: "Description"},
+			},
+		},
+		{
+			Field: "body",
+			Lines: []blogbus.DiffLine{
+				{Op: blogbus.DiffEqual, Text: "one"},
+				{Op: blogbus.DiffDelete, Text: "two"},
+				{Op: blogbus.DiffInsert, Text: "2"},
+				{Op: blogbus.DiffEqual, Text: "three"},
+				{Op: blogbus.DiffInsert, Text: "four"},
+			},
+		},
+	}, blogbus.Diff(from, to))
+
+	assert.Empty(t, blogbus.Diff(from, from))
+}
+
+func TestRevisions(t *testing.T) {
+	bp := blogbus.NewBlogPost(1, blogbus.AddBlogPost{Title: "Title"}, time.Now())
+	bp = blogbus.UpdateBlogPost{Title: "New Title", Editor: "bob"}.Apply(bp, time.Now())
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mockblogbus.NewMockRepo(ctrl)
+	repo.EXPECT().BlogPost(gomock.Any(), uint64(1)).Return(bp, nil).AnyTimes()
+	repo.EXPECT().BlogPost(gomock.Any(), uint64(2)).Return(blogbus.BlogPost{}, cache.ErrItemNotFound).AnyTimes()
+
+	bus := blogbus.NewBusiness(repo)
+
+	revs, err := bus.BlogPostRevisions(editing(t), 1)
+	assert.Nil(t, err)
+	assert.Equal(t, bp.Revisions, revs)
+
+	_, err = bus.BlogPostRevisions(editing(t), 2)
+	assert.ErrorIs(t, err, cache.ErrItemNotFound)
+
+	rev, err := bus.BlogPostRevision(editing(t), 1, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, "Title", rev.Title)
+
+	_, err = bus.BlogPostRevision(editing(t), 1, 3)
+	assert.ErrorIs(t, err, blogbus.ErrRevisionNotFound)
+
+	diff, err := bus.DiffBlogPostRevisions(editing(t), 1, 1, 2)
+	assert.Nil(t, err)
+	assert.Len(t, diff, 1)
+	assert.Equal(t, "title", diff[0].Field)
+
+	_, err = bus.DiffBlogPostRevisions(editing(t), 1, 1, 3)
+	assert.ErrorIs(t, err, blogbus.ErrRevisionNotFound)
+
+	// A request without a principal restores nothing.
+	_, err = bus.RestoreBlogPostRevision(t.Context(), 1, 1)
+	assert.ErrorIs(t, err, authbus.ErrUnauthenticated)
+
+	// The authenticated principal is recorded as the editor.
+	repo.EXPECT().UpdateBlogPost(gomock.Any(), uint64(1), blogbus.UpdateBlogPost{
+		Title:        "Title",
+		Editor:       "dave",
+		RestoredFrom: 1,
+	}).Return(uint64(1), nil)
+
+	ctx := authbus.WithPrincipal(t.Context(), authbus.Principal{Subject: "dave", Role: authbus.RoleEditor})
+	id, err := bus.RestoreBlogPostRevision(ctx, 1, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), id.ID())
+}
+
+func TestBlogPostBySlug(t *testing.T) {
+	testCases := []struct {
+		name        string
+		slug        string
+		setupExpect func(repo *mockblogbus.MockRepo)
+		expectedErr error
+	}{
+		{
+			name: "Success",
+			slug: "title",
+			setupExpect: func(repo *mockblogbus.MockRepo) {
+				repo.EXPECT().BlogPostBySlug(gomock.Any(), "title").Return(blogbus.BlogPost{ID: 1, Slug: "title"}, nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "Not Found",
+			slug: "missing",
+			setupExpect: func(repo *mockblogbus.MockRepo) {
+				repo.EXPECT().BlogPostBySlug(gomock.Any(), "missing").Return(blogbus.BlogPost{}, cache.ErrItemNotFound)
+			},
+			expectedErr: cache.ErrItemNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mockblogbus.NewMockRepo(ctrl)
+			tc.setupExpect(repo)
+			bus := blogbus.NewBusiness(repo)
+
+			_, err := bus.BlogPostBySlug(editing(t), tc.slug)
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestInvalidSlug(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// An invalid slug never reaches the repository.
+	bus := blogbus.NewBusiness(mockblogbus.NewMockRepo(ctrl))
+
+	_, err := bus.AddBlogPost(editing(t), blogbus.AddBlogPost{Title: "Title", Slug: "Not A Slug"})
+	assert.ErrorIs(t, err, blogbus.ErrInvalidSlug)
+
+	_, err = bus.UpdateBlogPost(editing(t), 1, blogbus.UpdateBlogPost{Slug: "not--a-slug"})
+	assert.ErrorIs(t, err, blogbus.ErrInvalidSlug)
+}
+
+func TestAuthorization(t *testing.T) {
+	as := func(subject string, role authbus.Role) context.Context {
+		return authbus.WithPrincipal(t.Context(), authbus.Principal{Subject: subject, Role: role})
+	}
+
+	testCases := []struct {
+		name        string
+		ctx         context.Context
+		do          func(ctx context.Context, bus blogbus.Business) error
+		expectedErr error
+	}{
+		{
+			name: "Reader Writes",
+			ctx:  as("ann", authbus.RoleReader),
+			do: func(ctx context.Context, bus blogbus.Business) error {
+				_, err := bus.AddBlogPost(ctx, blogbus.AddBlogPost{Title: "Title"})
+				return err
+			},
+			expectedErr: authbus.ErrForbidden,
+		},
+		{
+			name: "Author Writes",
+			ctx:  as("ann", authbus.RoleAuthor),
+			do: func(ctx context.Context, bus blogbus.Business) error {
+				_, err := bus.AddBlogPost(ctx, blogbus.AddBlogPost{Title: "Title"})
+				return err
+			},
+		},
+		{
+			name: "Author Edits Own",
+			ctx:  as("ann", authbus.RoleAuthor),
+			do: func(ctx context.Context, bus blogbus.Business) error {
+				_, err := bus.UpdateBlogPost(ctx, 1, blogbus.UpdateBlogPost{Title: "Edited"})
+				return err
+			},
+		},
+		{
+			name: "Author Edits Other",
+			ctx:  as("ann", authbus.RoleAuthor),
+			do: func(ctx context.Context, bus blogbus.Business) error {
+				_, err := bus.UpdateBlogPost(ctx, 2, blogbus.UpdateBlogPost{Title: "Edited"})
+				return err
+			},
+			expectedErr: authbus.ErrNotOwner,
+		},
+		{
+			name: "Author Trashes Other",
+			ctx:  as("ann", authbus.RoleAuthor),
+			do: func(ctx context.Context, bus blogbus.Business) error {
+				_, err := bus.DeleteBlogPost(ctx, 2)
+				return err
+			},
+			expectedErr: authbus.ErrNotOwner,
+		},
+		{
+			name: "Author Restores Revision Of Other",
+			ctx:  as("ann", authbus.RoleAuthor),
+			do: func(ctx context.Context, bus blogbus.Business) error {
+				_, err := bus.RestoreBlogPostRevision(ctx, 2, 1)
+				return err
+			},
+			expectedErr: authbus.ErrNotOwner,
+		},
+		{
+			name: "Author Publishes Own",
+			ctx:  as("ann", authbus.RoleAuthor),
+			do: func(ctx context.Context, bus blogbus.Business) error {
+				_, err := bus.PublishBlogPost(ctx, 1)
+				return err
+			},
+			expectedErr: authbus.ErrForbidden,
+		},
+		{
+			name: "Editor Edits Other",
+			ctx:  as("carol", authbus.RoleEditor),
+			do: func(ctx context.Context, bus blogbus.Business) error {
+				_, err := bus.UpdateBlogPost(ctx, 2, blogbus.UpdateBlogPost{Title: "Edited"})
+				return err
+			},
+		},
+		{
+			name: "Editor Publishes Other",
+			ctx:  as("carol", authbus.RoleEditor),
+			do: func(ctx context.Context, bus blogbus.Business) error {
+				_, err := bus.PublishBlogPost(ctx, 2)
+				return err
+			},
+		},
+		{
+			name: "Author Tags",
+			ctx:  as("ann", authbus.RoleAuthor),
+			do: func(ctx context.Context, bus blogbus.Business) error {
+				_, err := bus.AddTag(ctx, blogbus.AddTag{Name: "Go"})
+				return err
+			},
+			expectedErr: authbus.ErrForbidden,
+		},
+		{
+			name: "Editor Tags",
+			ctx:  as("carol", authbus.RoleEditor),
+			do: func(ctx context.Context, bus blogbus.Business) error {
+				_, err := bus.AddTag(ctx, blogbus.AddTag{Name: "Go"})
+				return err
+			},
+		},
+		{
+			name: "Anonymous Lists Trash",
+			ctx:  t.Context(),
+			do: func(ctx context.Context, bus blogbus.Business) error {
+				_, err := bus.BlogPosts(ctx, blogbus.Query{Trashed: true}, blogbus.Page{Limit: 5})
+				return err
+			},
+			expectedErr: authbus.ErrUnauthenticated,
+		},
+		{
+			name: "Author Lists Trash",
+			ctx:  as("ann", authbus.RoleAuthor),
+			do: func(ctx context.Context, bus blogbus.Business) error {
+				_, err := bus.BlogPosts(ctx, blogbus.Query{Trashed: true}, blogbus.Page{Limit: 5})
+				return err
+			},
+			expectedErr: authbus.ErrForbidden,
+		},
+		{
+			name: "Editor Lists Trash",
+			ctx:  as("carol", authbus.RoleEditor),
+			do: func(ctx context.Context, bus blogbus.Business) error {
+				_, err := bus.BlogPosts(ctx, blogbus.Query{Trashed: true}, blogbus.Page{Limit: 5})
+				return err
+			},
+		},
+		{
+			name: "Editor Operates",
+			ctx:  as("carol", authbus.RoleEditor),
+			do: func(ctx context.Context, bus blogbus.Business) error {
+				_, err := bus.CacheStats(ctx)
+				return err
+			},
+			expectedErr: authbus.ErrForbidden,
+		},
+		{
+			name: "Admin Operates",
+			ctx:  as("dave", authbus.RoleAdmin),
+			do: func(ctx context.Context, bus blogbus.Business) error {
+				_, err := bus.CacheStats(ctx)
+				return err
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			bus := blogbus.NewBusiness(blogrepo.NewRepository(10))
+
+			// Post 1 is ann's, post 2 is bob's.
+			for _, owner := range []string{"ann", "bob"} {
+				_, err := bus.AddBlogPost(as(owner, authbus.RoleAuthor), blogbus.AddBlogPost{Title: "Title"})
+				assert.Nil(t, err)
+			}
+
+			assert.ErrorIs(t, tc.do(tc.ctx, bus), tc.expectedErr)
+		})
+	}
+}
+
+// editing returns the context of a request of an editor, who may do anything to the posts.
+func editing(t *testing.T) context.Context {
+	return authbus.WithPrincipal(t.Context(), authbus.Principal{Subject: "editor", Role: authbus.RoleEditor})
+}
+
+func TestReadDrafts(t *testing.T) {
+	as := func(subject string, role authbus.Role) context.Context {
+		return authbus.WithPrincipal(t.Context(), authbus.Principal{Subject: subject, Role: role})
+	}
+
+	draft := blogbus.NewBlogPost(1, blogbus.AddBlogPost{Title: "Title", Editor: "ann"}, time.Now())
+
+	// A draft written before users has no owner, the editor its client named does not own it.
+	legacy := draft
+	legacy.ID, legacy.Owner = 2, ""
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mockblogbus.NewMockRepo(ctrl)
+	repo.EXPECT().BlogPost(gomock.Any(), uint64(1)).Return(draft, nil).AnyTimes()
+	repo.EXPECT().BlogPost(gomock.Any(), uint64(2)).Return(legacy, nil).AnyTimes()
+
+	bus := blogbus.NewBusiness(repo)
+
+	for name, tc := range map[string]struct {
+		ctx         context.Context
+		expectedErr error
+	}{
+		"Anonymous":      {ctx: t.Context(), expectedErr: blogbus.ErrBlogPostNotFound},
+		"Reader":         {ctx: as("bob", authbus.RoleReader), expectedErr: blogbus.ErrBlogPostNotFound},
+		"Another Author": {ctx: as("bob", authbus.RoleAuthor), expectedErr: blogbus.ErrBlogPostNotFound},
+		"Author":         {ctx: as("ann", authbus.RoleAuthor)},
+		"Editor":         {ctx: as("eve", authbus.RoleEditor)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := bus.BlogPost(tc.ctx, 1)
+			assert.ErrorIs(t, err, tc.expectedErr)
+
+			_, err = bus.BlogPostRevisions(tc.ctx, 1)
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+
+	_, err := bus.BlogPost(as("ann", authbus.RoleAuthor), 2)
+	assert.ErrorIs(t, err, blogbus.ErrBlogPostNotFound)
+
+	_, err = bus.BlogPost(as("eve", authbus.RoleEditor), 2)
+	assert.Nil(t, err)
+
+	// An author lists only their own drafts, an editor every one.
+	drafts := blogbus.Query{Status: blogbus.StatusDraft}
+	own := drafts
+	own.Owner = "ann"
+
+	repo.EXPECT().BlogPosts(gomock.Any(), own, gomock.Any()).Return(blogbus.BlogPostPage{}, nil)
+	repo.EXPECT().BlogPosts(gomock.Any(), drafts, gomock.Any()).Return(blogbus.BlogPostPage{}, nil)
+
+	_, err = bus.BlogPosts(as("ann", authbus.RoleAuthor), drafts, blogbus.Page{})
+	assert.Nil(t, err)
+
+	_, err = bus.BlogPosts(as("eve", authbus.RoleEditor), drafts, blogbus.Page{})
+	assert.Nil(t, err)
+
+	_, err = bus.BlogPosts(t.Context(), drafts, blogbus.Page{})
+	assert.ErrorIs(t, err, authbus.ErrUnauthenticated)
+
+	_, err = bus.BlogPosts(as("bob", authbus.RoleReader), drafts, blogbus.Page{})
+	assert.ErrorIs(t, err, authbus.ErrForbidden)
+}
@@ -1,26 +1,130 @@
 package blogbus
 
-import "time"
+import (
+	"time"
+
+	"github.com/anazcodes/blogapp/internal/business/commentbus"
+)
 
 type BlogPost struct {
 	ID          uint64
 	Title       string
 	Description string
 	Body        string
+	Status      Status
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
+	PublishedAt time.Time  // Zero unless the post is published or archived.
+	PublishAt   time.Time  // Time a draft is scheduled to be published at, zero when it is not scheduled.
+	DeletedAt   time.Time  // Time the post was moved to the trash, zero unless it is in the trash.
+	Revisions   []Revision // Content of the post after each of its edits, oldest first.
+	Slug        string     // Unique human-readable name of the post in URLs.
+	OldSlugs    []string   // Slugs the post had before, which still lead to it, oldest first.
+	Tags        []string   // Slugs of the tags of the post, sorted.
+	Categories  []string   // Slugs of the categories the post is filed under, sorted.
+	AuthorID    uint64     // ID of the author of the post, zero when it has none.
+	// Owner is the username of the user who wrote the post, who may read and change it before it is
+	// published. The server sets it, it is empty for the posts written before users, whose editors were
+	// named by the clients and are not trusted.
+	Owner string
+	// Reactions of the readers to the post, counted when the post is read and never stored with it.
+	Reactions ReactionCounts
+}
+
+// Due reports whether bp is scheduled to be published at or before t.
+func (bp BlogPost) Due(t time.Time) bool {
+	return !bp.PublishAt.IsZero() && !bp.PublishAt.After(t)
 }
 
 type AddBlogPost struct {
 	Title       string
 	Description string
 	Body        string
+	Editor      string // Who wrote the post, recorded on its first revision and as its owner.
+	Slug        string // Slug chosen by the author, empty generates one from the title.
+	Tags        []string
+	Categories  []string
+	AuthorID    uint64 // Author of the post, zero for none.
 }
 
 type UpdateBlogPost struct {
-	Title       string
-	Description string
-	Body        string
+	Title        string
+	Description  string
+	Body         string
+	Editor       string   // Who made the edit, recorded on its revision.
+	RestoredFrom uint64   // Revision the content of the update is restored from, zero for an edit.
+	Slug         string   // Slug the post moves to, empty keeps it.
+	Tags         []string // Replace the tags of the post, nil keeps them and empty removes them.
+	Categories   []string // Replace the categories of the post, nil keeps them and empty removes them.
+	AuthorID     uint64   // Author the post moves to, zero keeps its author.
+}
+
+const (
+	DefaultLimit = 20  // Page size used when a page does not set one.
+	MaxLimit     = 100 // Largest page size a page may set.
+)
+
+// Page selects a page of a listing.
+type Page struct {
+	Limit  int    // Maximum number of posts, zero selects DefaultLimit.
+	Cursor string // Opaque position returned with the previous page, empty selects the first page.
+}
+
+// BlogPostPage is a page of posts in listing order.
+type BlogPostPage struct {
+	BlogPosts  []BlogPost
+	NextCursor string // Selects the following page, empty on the last page.
+	HasMore    bool
+}
+
+// Snippet is an excerpt of a post field around the terms of a search, which are wrapped in <mark> tags.
+// The text of the excerpt is HTML escaped.
+type Snippet struct {
+	Field string // title, description or body.
+	Text  string
+}
+
+// SearchHit is a post matching a search.
+type SearchHit struct {
+	BlogPost
+	Score    float64 // BM25 relevance of the post to the search, higher is more relevant.
+	Snippets []Snippet
+}
+
+// SearchPage is a page of the posts matching a search, the most relevant first.
+type SearchPage struct {
+	Hits       []SearchHit
+	NextCursor string // Selects the following page, empty on the last page.
+	HasMore    bool
+}
+
+// Snapshot is a point-in-time copy of every stored post, with the tags, categories and authors they
+// refer to and the comments and reactions on them.
+type Snapshot struct {
+	Serial    uint64 // Highest ID issued when the snapshot was taken.
+	Posts     []BlogPost
+	Taxonomy  Taxonomy
+	Authors   []Author             // Ordered by ID.
+	Comments  []commentbus.Comment // Ordered by ID.
+	Reactions []ReaderReaction     // Ordered by post ID and reader.
+	CreatedAt time.Time
+}
+
+// SnapshotInfo describes a snapshot without its posts.
+type SnapshotInfo struct {
+	Version   uint32
+	Serial    uint64
+	Posts     int
+	CreatedAt time.Time
+}
+
+// CacheStats are the counters of the in-memory cache in front of the storage.
+type CacheStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	Posts     int
+	Capacity  int
 }
 
 type ID map[string]uint64
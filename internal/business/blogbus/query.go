@@ -0,0 +1,109 @@
+package blogbus
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var ErrInvalidQuery = errors.New("invalid query")
+
+// SortField is the post field a listing is ordered by.
+type SortField string
+
+const (
+	SortByID        SortField = "id"
+	SortByCreatedAt SortField = "created_at"
+	SortByUpdatedAt SortField = "updated_at"
+	SortByTitle     SortField = "title"
+)
+
+// Query filters and orders a listing of posts.
+// Zero fields do not filter, time ranges include From and exclude To. Title matching is case-sensitive.
+// Tag and Category are answered by the repository from its index of the posts of every term, Match
+// does not check them.
+type Query struct {
+	CreatedFrom   time.Time
+	CreatedTo     time.Time
+	UpdatedFrom   time.Time
+	UpdatedTo     time.Time
+	TitlePrefix   string
+	TitleContains string
+	Status        Status    // Empty lists every status.
+	ScheduledBy   time.Time // Non-zero lists only the posts scheduled to be published at or before it.
+	Trashed       bool      // Lists the posts in the trash instead of the others.
+	TrashedBy     time.Time // Non-zero lists only the posts moved to the trash at or before it.
+	Tag           string    // Lists only the posts with the tag of this slug.
+	Category      string    // Lists only the posts filed under the category of this slug or its subcategories.
+	AuthorID      uint64    // Non-zero lists only the posts of the author with this ID.
+	Owner         string    // Non-empty lists only the posts this user owns, see BlogPost.Owner.
+	SortBy        SortField // Empty sorts by ID.
+	Desc          bool
+}
+
+// Validate reports whether q can be answered.
+func (q Query) Validate() error {
+	switch q.SortBy {
+	case "", SortByID, SortByCreatedAt, SortByUpdatedAt, SortByTitle:
+	default:
+		return fmt.Errorf("%w: unknown sort field %q", ErrInvalidQuery, q.SortBy)
+	}
+
+	if q.Status != "" && !q.Status.Valid() {
+		return fmt.Errorf("%w: unknown status %q", ErrInvalidQuery, q.Status)
+	}
+
+	if !q.CreatedFrom.IsZero() && !q.CreatedTo.IsZero() && q.CreatedTo.Before(q.CreatedFrom) {
+		return fmt.Errorf("%w: created range ends before it starts", ErrInvalidQuery)
+	}
+
+	if !q.UpdatedFrom.IsZero() && !q.UpdatedTo.IsZero() && q.UpdatedTo.Before(q.UpdatedFrom) {
+		return fmt.Errorf("%w: updated range ends before it starts", ErrInvalidQuery)
+	}
+
+	return nil
+}
+
+// Match reports whether bp passes the filters of q.
+func (q Query) Match(bp BlogPost) bool {
+	return inRange(bp.CreatedAt, q.CreatedFrom, q.CreatedTo) &&
+		inRange(bp.UpdatedAt, q.UpdatedFrom, q.UpdatedTo) &&
+		strings.HasPrefix(bp.Title, q.TitlePrefix) &&
+		strings.Contains(bp.Title, q.TitleContains) &&
+		(q.Status == "" || bp.Status == q.Status) &&
+		(q.AuthorID == 0 || bp.AuthorID == q.AuthorID) &&
+		(q.Owner == "" || bp.Owner == q.Owner) &&
+		(q.ScheduledBy.IsZero() || bp.Due(q.ScheduledBy)) &&
+		bp.Trashed() == q.Trashed &&
+		(q.TrashedBy.IsZero() || bp.Trashed() && !bp.DeletedAt.After(q.TrashedBy))
+}
+
+// Compare orders a and b as listed by q, posts with equal sort keys are ordered by ID.
+func (q Query) Compare(a, b BlogPost) int {
+	var c int
+
+	switch q.SortBy {
+	case SortByCreatedAt:
+		c = a.CreatedAt.Compare(b.CreatedAt)
+	case SortByUpdatedAt:
+		c = a.UpdatedAt.Compare(b.UpdatedAt)
+	case SortByTitle:
+		c = strings.Compare(a.Title, b.Title)
+	}
+
+	if c == 0 {
+		c = cmp.Compare(a.ID, b.ID)
+	}
+
+	if q.Desc {
+		return -c
+	}
+
+	return c
+}
+
+func inRange(t, from, to time.Time) bool {
+	return (from.IsZero() || !t.Before(from)) && (to.IsZero() || t.Before(to))
+}
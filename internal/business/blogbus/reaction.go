@@ -0,0 +1,88 @@
+package blogbus
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+var ErrInvalidReaction = errors.New("invalid reaction")
+
+// MaxReaderLength is the longest reader identity, in characters.
+const MaxReaderLength = 100
+
+// Reaction is one of the fixed set of emoji readers react to posts with.
+type Reaction string
+
+const (
+	ReactionLike  Reaction = "like"  // 👍
+	ReactionLove  Reaction = "love"  // ❤️
+	ReactionLaugh Reaction = "laugh" // 😂
+	ReactionWow   Reaction = "wow"   // 😮
+	ReactionSad   Reaction = "sad"   // 😢
+	ReactionAngry Reaction = "angry" // 😠
+)
+
+// Reactions lists every reaction, in the order they are shown.
+var Reactions = []Reaction{ReactionLike, ReactionLove, ReactionLaugh, ReactionWow, ReactionSad, ReactionAngry}
+
+// Valid reports whether r is a known reaction.
+func (r Reaction) Valid() bool {
+	switch r {
+	case ReactionLike, ReactionLove, ReactionLaugh, ReactionWow, ReactionSad, ReactionAngry:
+		return true
+	}
+
+	return false
+}
+
+// ReactionCounts is the number of readers who reacted to a post with each reaction, a reaction no
+// reader used is left out.
+type ReactionCounts map[Reaction]int
+
+// ReaderReaction is the reaction of a reader to a post. A reader has at most one reaction to a post,
+// reacting again replaces it.
+type ReaderReaction struct {
+	PostID   uint64
+	Reader   string // Username of the reader, the principal who reacted.
+	Reaction Reaction
+	At       time.Time
+}
+
+type AddReaction struct {
+	Reaction Reaction
+}
+
+// NewReaderReaction returns the reaction ar of reader adds to the post with postID at at.
+func NewReaderReaction(postID uint64, reader string, ar AddReaction, at time.Time) (ReaderReaction, error) {
+	rr := ReaderReaction{
+		PostID:   postID,
+		Reader:   strings.TrimSpace(reader),
+		Reaction: ar.Reaction,
+		At:       at,
+	}
+
+	if err := validReader(rr.Reader); err != nil {
+		return ReaderReaction{}, err
+	}
+
+	if !rr.Reaction.Valid() {
+		return ReaderReaction{}, fmt.Errorf("%w: unknown reaction %q", ErrInvalidReaction, rr.Reaction)
+	}
+
+	return rr, nil
+}
+
+// validReader returns ErrInvalidReaction unless reader is a reader identity within its length.
+func validReader(reader string) error {
+	switch {
+	case reader == "":
+		return fmt.Errorf("%w: reader is empty", ErrInvalidReaction)
+	case utf8.RuneCountInString(reader) > MaxReaderLength:
+		return fmt.Errorf("%w: reader is longer than %d characters", ErrInvalidReaction, MaxReaderLength)
+	}
+
+	return nil
+}
@@ -0,0 +1,194 @@
+package blogbus_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anazcodes/blogapp/internal/business/authbus"
+	"github.com/anazcodes/blogapp/internal/business/blogbus"
+	mockblogbus "github.com/anazcodes/blogapp/internal/mock/business/blogbus"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewReaderReaction(t *testing.T) {
+	testCases := []struct {
+		name        string
+		reader      string
+		input       blogbus.AddReaction
+		expectedErr error
+	}{
+		{
+			name:   "Like",
+			reader: " ann ",
+			input:  blogbus.AddReaction{Reaction: blogbus.ReactionLike},
+		},
+		{
+			name:        "Empty Reader",
+			reader:      "  ",
+			input:       blogbus.AddReaction{Reaction: blogbus.ReactionLike},
+			expectedErr: blogbus.ErrInvalidReaction,
+		},
+		{
+			name:        "Reader Too Long",
+			reader:      strings.Repeat("a", blogbus.MaxReaderLength+1),
+			input:       blogbus.AddReaction{Reaction: blogbus.ReactionLike},
+			expectedErr: blogbus.ErrInvalidReaction,
+		},
+		{
+			name:        "Unknown Reaction",
+			reader:      "ann",
+			input:       blogbus.AddReaction{Reaction: "clap"},
+			expectedErr: blogbus.ErrInvalidReaction,
+		},
+	}
+
+	at := time.Now()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rr, err := blogbus.NewReaderReaction(1, tc.reader, tc.input, at)
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+			if tc.expectedErr == nil {
+				assert.Equal(t, blogbus.ReaderReaction{PostID: 1, Reader: "ann", Reaction: blogbus.ReactionLike, At: at}, rr)
+			}
+		})
+	}
+}
+
+func TestReact(t *testing.T) {
+	testCases := []struct {
+		name        string
+		ctx         context.Context
+		input       blogbus.AddReaction
+		setupExpect func(repo *mockblogbus.MockRepo)
+		expected    blogbus.ReactionCounts
+		expectedErr error
+	}{
+		{
+			name:  "Counted",
+			ctx:   reading(t, "ann"),
+			input: blogbus.AddReaction{Reaction: blogbus.ReactionLove},
+			setupExpect: func(repo *mockblogbus.MockRepo) {
+				published(repo)
+				repo.EXPECT().React(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, rr blogbus.ReaderReaction) (blogbus.ReactionCounts, error) {
+						assert.Equal(t, uint64(1), rr.PostID)
+						assert.Equal(t, "ann", rr.Reader)
+						return blogbus.ReactionCounts{rr.Reaction: 1}, nil
+					})
+			},
+			expected: blogbus.ReactionCounts{blogbus.ReactionLove: 1},
+		},
+		{
+			name:        "Unknown Reaction",
+			ctx:         reading(t, "ann"),
+			input:       blogbus.AddReaction{Reaction: "clap"},
+			setupExpect: func(repo *mockblogbus.MockRepo) {},
+			expectedErr: blogbus.ErrInvalidReaction,
+		},
+		{
+			name:  "Trashed",
+			ctx:   reading(t, "ann"),
+			input: blogbus.AddReaction{Reaction: blogbus.ReactionLike},
+			setupExpect: func(repo *mockblogbus.MockRepo) {
+				repo.EXPECT().BlogPost(gomock.Any(), uint64(1)).Return(blogbus.BlogPost{}, blogbus.ErrTrashed)
+			},
+			expectedErr: blogbus.ErrTrashed,
+		},
+		{
+			name:  "Draft",
+			ctx:   reading(t, "ann"),
+			input: blogbus.AddReaction{Reaction: blogbus.ReactionLike},
+			setupExpect: func(repo *mockblogbus.MockRepo) {
+				repo.EXPECT().BlogPost(gomock.Any(), uint64(1)).
+					Return(blogbus.BlogPost{ID: 1, Status: blogbus.StatusDraft}, nil)
+			},
+			expectedErr: blogbus.ErrBlogPostNotFound,
+		},
+		{
+			name: "Out Of Scope",
+			ctx: authbus.WithPrincipal(t.Context(), authbus.Principal{
+				Subject: "ann", Role: authbus.RoleReader, Key: "k", Scopes: []authbus.Scope{authbus.ScopePostsRead},
+			}),
+			input:       blogbus.AddReaction{Reaction: blogbus.ReactionLike},
+			setupExpect: func(repo *mockblogbus.MockRepo) {},
+			expectedErr: authbus.ErrOutOfScope,
+		},
+		{
+			name:        "Anonymous",
+			ctx:         t.Context(),
+			input:       blogbus.AddReaction{Reaction: blogbus.ReactionLike},
+			setupExpect: func(repo *mockblogbus.MockRepo) {},
+			expectedErr: authbus.ErrUnauthenticated,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mockblogbus.NewMockRepo(ctrl)
+			tc.setupExpect(repo)
+			bus := blogbus.NewBusiness(repo)
+
+			counts, err := bus.React(tc.ctx, 1, tc.input)
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.expected, counts)
+		})
+	}
+}
+
+func TestUnreact(t *testing.T) {
+	testCases := []struct {
+		name        string
+		ctx         context.Context
+		setupExpect func(repo *mockblogbus.MockRepo)
+		expectedErr error
+	}{
+		{
+			name: "Removed",
+			ctx:  reading(t, "ann"),
+			setupExpect: func(repo *mockblogbus.MockRepo) {
+				published(repo)
+				repo.EXPECT().Unreact(gomock.Any(), uint64(1), "ann").Return(blogbus.ReactionCounts{}, nil)
+			},
+		},
+		{
+			name:        "Anonymous",
+			ctx:         t.Context(),
+			setupExpect: func(repo *mockblogbus.MockRepo) {},
+			expectedErr: authbus.ErrUnauthenticated,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mockblogbus.NewMockRepo(ctrl)
+			tc.setupExpect(repo)
+			bus := blogbus.NewBusiness(repo)
+
+			_, err := bus.Unreact(tc.ctx, 1)
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
+// published expects the reactions to be on post 1, which is published.
+func published(repo *mockblogbus.MockRepo) {
+	repo.EXPECT().BlogPost(gomock.Any(), uint64(1)).Return(blogbus.BlogPost{ID: 1, Status: blogbus.StatusPublished}, nil)
+}
+
+// reading returns the context of a request of the reader with username.
+func reading(t *testing.T, username string) context.Context {
+	return authbus.WithPrincipal(t.Context(), authbus.Principal{Subject: username, Role: authbus.RoleReader})
+}
@@ -0,0 +1,254 @@
+package blogbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/anazcodes/blogapp/internal/business/authbus"
+)
+
+var ErrRevisionNotFound = errors.New("revision not found")
+
+// editor returns who makes an edit, the user the request of ctx is authenticated as.
+func editor(ctx context.Context) string {
+	p, _ := authbus.PrincipalFrom(ctx)
+	return p.Subject
+}
+
+// owner returns the owner of the post with id once asked, for authbus.Authorize.
+func (b *business) owner(ctx context.Context, id uint64) func() (string, error) {
+	return func() (string, error) {
+		bp, err := b.repo.BlogPost(ctx, id)
+		if err != nil {
+			return "", fmt.Errorf("repo.blogpost: %w id: %d", err, id)
+		}
+
+		return bp.Owner, nil
+	}
+}
+
+// Revision is the content of a post after one of its edits. A revision is never changed once it is
+// recorded, restoring an older revision records a new one.
+type Revision struct {
+	Number       uint64   // 1 for the content the post was created with, every edit increments it.
+	Editor       string   // Who made the edit, empty when unknown.
+	Changed      []string // Fields the edit changed: title, description or body.
+	RestoredFrom uint64   // Revision whose content the edit restored, zero for a regular edit.
+	CreatedAt    time.Time
+	Title        string
+	Description  string
+	Body         string
+}
+
+// revision returns the content of bp as the revision with number made at at.
+func revision(bp BlogPost, number uint64, at time.Time) Revision {
+	return Revision{
+		Number:      number,
+		CreatedAt:   at,
+		Title:       bp.Title,
+		Description: bp.Description,
+		Body:        bp.Body,
+	}
+}
+
+// revisionFields are the fields of a post recorded by its revisions.
+var revisionFields = [...]struct {
+	name string
+	text func(r Revision) string
+}{
+	{"title", func(r Revision) string { return r.Title }},
+	{"description", func(r Revision) string { return r.Description }},
+	{"body", func(r Revision) string { return r.Body }},
+}
+
+// NewBlogPost returns the draft abp creates with id at at, its content recorded as revision 1.
+func NewBlogPost(id uint64, abp AddBlogPost, at time.Time) BlogPost {
+	bp := BlogPost{
+		ID:          id,
+		Title:       abp.Title,
+		Description: abp.Description,
+		Body:        abp.Body,
+		Status:      StatusDraft,
+		CreatedAt:   at,
+		UpdatedAt:   at,
+		Slug:        abp.Slug,
+		Tags:        abp.Tags,
+		Categories:  abp.Categories,
+		AuthorID:    abp.AuthorID,
+		Owner:       abp.Editor,
+	}
+
+	first := revision(bp, 1, at)
+	first.Editor = abp.Editor
+
+	for _, f := range revisionFields {
+		if f.text(first) != "" {
+			first.Changed = append(first.Changed, f.name)
+		}
+	}
+
+	bp.Revisions = []Revision{first}
+
+	return bp
+}
+
+// History returns the revisions of bp, oldest first. A post stored before revisions were recorded has
+// its current content as its only revision.
+func (bp BlogPost) History() []Revision {
+	if len(bp.Revisions) == 0 {
+		return []Revision{revision(bp, 1, bp.UpdatedAt)}
+	}
+
+	return bp.Revisions
+}
+
+// Revision returns the revision of bp with number, or ErrRevisionNotFound.
+func (bp BlogPost) Revision(number uint64) (Revision, error) {
+	history := bp.History()
+
+	i := slices.IndexFunc(history, func(r Revision) bool { return r.Number == number })
+	if i < 0 {
+		return Revision{}, ErrRevisionNotFound
+	}
+
+	return history[i], nil
+}
+
+// Apply returns bp edited by ubp at at, with the edit recorded as a new revision. The empty fields of
+// ubp are kept, unless it restores a revision whose content replaces every field. A new slug keeps the
+// previous one as an old slug, and the tags and categories of ubp replace those of bp unless nil. The
+// author of ubp replaces that of bp unless zero.
+func (ubp UpdateBlogPost) Apply(bp BlogPost, at time.Time) BlogPost {
+	history := bp.History()
+	prev := history[len(history)-1]
+
+	if ubp.RestoredFrom != 0 {
+		bp.Title, bp.Description, bp.Body = ubp.Title, ubp.Description, ubp.Body
+	} else {
+		if ubp.Title != "" {
+			bp.Title = ubp.Title
+		}
+		if ubp.Description != "" {
+			bp.Description = ubp.Description
+		}
+		if ubp.Body != "" {
+			bp.Body = ubp.Body
+		}
+	}
+
+	next := revision(bp, prev.Number+1, at)
+	next.Editor = ubp.Editor
+	next.RestoredFrom = ubp.RestoredFrom
+
+	for _, f := range revisionFields {
+		if f.text(next) != f.text(prev) {
+			next.Changed = append(next.Changed, f.name)
+		}
+	}
+
+	bp = bp.moveSlug(ubp.Slug)
+	if ubp.Tags != nil {
+		bp.Tags = ubp.Tags
+	}
+	if ubp.Categories != nil {
+		bp.Categories = ubp.Categories
+	}
+	if ubp.AuthorID != 0 {
+		bp.AuthorID = ubp.AuthorID
+	}
+	bp.UpdatedAt = at
+	// Clipping makes append copy the history, posts sharing it never see each other's revisions.
+	bp.Revisions = append(slices.Clip(history), next)
+
+	return bp
+}
+
+// DiffOp tells whether a line of a diff is kept, removed or added.
+type DiffOp string
+
+const (
+	DiffEqual  DiffOp = "equal"
+	DiffDelete DiffOp = "delete"
+	DiffInsert DiffOp = "insert"
+)
+
+type DiffLine struct {
+	Op   DiffOp
+	Text string
+}
+
+// FieldDiff is the line by line difference of a post field between two revisions.
+type FieldDiff struct {
+	Field string
+	Lines []DiffLine
+}
+
+// Diff returns the difference of every field that differs from one revision to the other.
+func Diff(from, to Revision) []FieldDiff {
+	var out []FieldDiff
+
+	for _, f := range revisionFields {
+		a, b := f.text(from), f.text(to)
+		if a == b {
+			continue
+		}
+
+		out = append(out, FieldDiff{
+			Field: f.name,
+			Lines: diffLines(strings.Split(a, "\n"), strings.Split(b, "\n")),
+		})
+	}
+
+	return out
+}
+
+// diffLines returns the shortest edit turning a into b, taken from their longest common subsequence.
+func diffLines(a, b []string) []DiffLine {
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var out []DiffLine
+	i, j := 0, 0
+
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			out = append(out, DiffLine{Op: DiffEqual, Text: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			out = append(out, DiffLine{Op: DiffDelete, Text: a[i]})
+			i++
+		default:
+			out = append(out, DiffLine{Op: DiffInsert, Text: b[j]})
+			j++
+		}
+	}
+
+	for ; i < len(a); i++ {
+		out = append(out, DiffLine{Op: DiffDelete, Text: a[i]})
+	}
+
+	for ; j < len(b); j++ {
+		out = append(out, DiffLine{Op: DiffInsert, Text: b[j]})
+	}
+
+	return out
+}
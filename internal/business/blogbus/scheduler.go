@@ -0,0 +1,104 @@
+package blogbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/anazcodes/blogapp/internal/business/authbus"
+)
+
+// Clock tells the time to the Scheduler, tests replace the wall clock with one they move by hand.
+type Clock interface {
+	Now() time.Time
+	// After returns a channel that receives once d has elapsed, like time.After.
+	After(d time.Duration) <-chan time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// SystemClock is the wall clock.
+var SystemClock Clock = systemClock{}
+
+// Scheduler publishes the drafts whose scheduled publish time has come.
+//
+// Schedules are stored with the posts, so the Scheduler keeps no state of its own: every pass scans
+// the repository for the due drafts, and the pass Run makes on start publishes the drafts that fell
+// due while the application was down.
+type Scheduler struct {
+	repo  Repo
+	clock Clock
+	every time.Duration
+}
+
+// NewScheduler returns a Scheduler looking for due drafts in repo every interval of clock.
+func NewScheduler(repo Repo, clock Clock, every time.Duration) *Scheduler {
+	return &Scheduler{
+		repo:  repo,
+		clock: clock,
+		every: every,
+	}
+}
+
+// Run publishes the due drafts immediately and then every interval until ctx is done.
+func (s *Scheduler) Run(ctx context.Context) {
+	for {
+		if _, err := s.PublishDue(ctx); err != nil && ctx.Err() == nil {
+			log.Println("scheduler: publishing due posts failed: ", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.clock.After(s.every):
+		}
+	}
+}
+
+// PublishDue publishes every draft scheduled at or before the current time and returns how many it
+// published. It acts as authbus.System.
+func (s *Scheduler) PublishDue(ctx context.Context) (int, error) {
+	ctx = authbus.WithPrincipal(ctx, authbus.System)
+
+	now := s.clock.Now()
+
+	t := transitions[publish]
+	t.At = now
+	t.DueBy = now
+
+	q := Query{Status: StatusDraft, ScheduledBy: now}
+	page := Page{Limit: MaxLimit}
+	published := 0
+
+	for {
+		bpp, err := s.repo.BlogPosts(ctx, q, page)
+		if err != nil {
+			return published, fmt.Errorf("repo.blogposts: %w", err)
+		}
+
+		for _, bp := range bpp.BlogPosts {
+			_, err := s.repo.TransitionBlogPost(ctx, bp.ID, t)
+			// The author published or unscheduled the post since it was listed.
+			if errors.Is(err, ErrInvalidTransition) {
+				continue
+			}
+			if err != nil {
+				return published, fmt.Errorf("repo.transitionblogpost: %w id: %d", err, bp.ID)
+			}
+
+			published++
+		}
+
+		if !bpp.HasMore {
+			return published, nil
+		}
+
+		page.Cursor = bpp.NextCursor
+	}
+}
@@ -0,0 +1,172 @@
+package blogbus_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/anazcodes/blogapp/internal/business/blogbus"
+	"github.com/anazcodes/blogapp/internal/repository/blogrepo"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeClock only moves when the test advances it.
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []timer
+}
+
+type timer struct {
+	at time.Time
+	ch chan time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch := make(chan time.Time, 1)
+	c.timers = append(c.timers, timer{at: c.now.Add(d), ch: ch})
+
+	return ch
+}
+
+// Advance moves the clock forward by d and fires the timers that are due.
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+
+	pending := c.timers[:0]
+	for _, t := range c.timers {
+		if t.at.After(c.now) {
+			pending = append(pending, t)
+			continue
+		}
+
+		t.ch <- c.now
+	}
+
+	c.timers = pending
+}
+
+// scheduled adds a draft per publish time to a new repository, scheduling those with a non-zero time.
+func scheduled(t *testing.T, publishAt ...time.Time) (blogbus.Repo, []uint64) {
+	repo := blogrepo.NewRepository(10)
+	bus := blogbus.NewBusiness(repo)
+
+	ids := make([]uint64, len(publishAt))
+	for i, at := range publishAt {
+		id, err := bus.AddBlogPost(editing(t), blogbus.AddBlogPost{Title: "Title"})
+		assert.Nil(t, err)
+		ids[i] = id.ID()
+
+		if !at.IsZero() {
+			_, err = bus.ScheduleBlogPost(editing(t), ids[i], at)
+			assert.Nil(t, err)
+		}
+	}
+
+	return repo, ids
+}
+
+func TestSchedulerPublishDue(t *testing.T) {
+	now := time.Now()
+	clock := &fakeClock{now: now}
+	repo, ids := scheduled(t, now.Add(time.Hour), now.Add(2*time.Hour), time.Time{})
+
+	sched := blogbus.NewScheduler(repo, clock, time.Minute)
+
+	published, err := sched.PublishDue(t.Context())
+	assert.Nil(t, err)
+	assert.Equal(t, 0, published)
+
+	clock.Advance(90 * time.Minute)
+
+	published, err = sched.PublishDue(t.Context())
+	assert.Nil(t, err)
+	assert.Equal(t, 1, published)
+
+	bp, err := repo.BlogPost(t.Context(), ids[0])
+	assert.Nil(t, err)
+	assert.Equal(t, blogbus.StatusPublished, bp.Status)
+	assert.Equal(t, clock.Now(), bp.PublishedAt)
+	assert.True(t, bp.PublishAt.IsZero())
+
+	clock.Advance(24 * time.Hour)
+
+	published, err = sched.PublishDue(t.Context())
+	assert.Nil(t, err)
+	assert.Equal(t, 1, published)
+
+	// The draft that was never scheduled stays a draft.
+	bp, err = repo.BlogPost(t.Context(), ids[2])
+	assert.Nil(t, err)
+	assert.Equal(t, blogbus.StatusDraft, bp.Status)
+}
+
+func TestSchedulerUnscheduled(t *testing.T) {
+	now := time.Now()
+	clock := &fakeClock{now: now}
+	repo, ids := scheduled(t, now.Add(time.Hour))
+
+	_, err := blogbus.NewBusiness(repo).UnscheduleBlogPost(editing(t), ids[0])
+	assert.Nil(t, err)
+
+	clock.Advance(2 * time.Hour)
+
+	published, err := blogbus.NewScheduler(repo, clock, time.Minute).PublishDue(t.Context())
+	assert.Nil(t, err)
+	assert.Equal(t, 0, published)
+}
+
+func TestSchedulerRun(t *testing.T) {
+	now := time.Now()
+	clock := &fakeClock{now: now}
+	repo, ids := scheduled(t, now.Add(time.Hour), now.Add(3*time.Hour))
+
+	status := func(id uint64) blogbus.Status {
+		bp, err := repo.BlogPost(t.Context(), id)
+		assert.Nil(t, err)
+		return bp.Status
+	}
+
+	// The first post fell due while the scheduler was not running, as if the application was down.
+	clock.Advance(2 * time.Hour)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		blogbus.NewScheduler(repo, clock, time.Minute).Run(ctx)
+	}()
+
+	assert.Eventually(t, func() bool {
+		return status(ids[0]) == blogbus.StatusPublished
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, blogbus.StatusDraft, status(ids[1]))
+
+	assert.Eventually(t, func() bool {
+		clock.Advance(time.Minute)
+		return status(ids[1]) == blogbus.StatusPublished
+	}, time.Second, time.Millisecond)
+
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("scheduler did not stop")
+	}
+}
@@ -0,0 +1,181 @@
+package blogbus
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var (
+	ErrInvalidSlug = errors.New("invalid slug")
+	ErrSlugTaken   = errors.New("slug is taken by another post")
+)
+
+// MaxSlugLen is the length of the longest slug, longer titles are cut at a word boundary.
+const MaxSlugLen = 80
+
+// fallbackSlug names the posts whose title has nothing to transliterate, such as an empty title.
+const fallbackSlug = "post"
+
+// translit spells the non-ASCII letters of the Latin, Greek and Cyrillic alphabets in ASCII.
+// A letter spelled empty is dropped, any other letter missing from it separates words.
+var translit = map[rune]string{}
+
+func init() {
+	spellings := []struct {
+		letters  string
+		spelling string
+	}{
+		{"àáâãäåāăą", "a"}, {"æ", "ae"}, {"çćĉċč", "c"}, {"ďđð", "d"}, {"èéêëēĕėęě", "e"}, {"ĝğġģ", "g"},
+		{"ĥħ", "h"}, {"ìíîïĩīĭįı", "i"}, {"ĳ", "ij"}, {"ĵ", "j"}, {"ķ", "k"}, {"ĺļľŀł", "l"},
+		{"ñńņňŉ", "n"}, {"òóôõöøōŏő", "o"}, {"œ", "oe"}, {"ŕŗř", "r"}, {"śŝşšș", "s"}, {"ß", "ss"},
+		{"ţťŧț", "t"}, {"þ", "th"}, {"ùúûüũūŭůűų", "u"}, {"ŵ", "w"}, {"ýÿŷ", "y"}, {"źżž", "z"},
+
+		{"αά", "a"}, {"β", "v"}, {"γ", "g"}, {"δ", "d"}, {"εέ", "e"}, {"ζ", "z"}, {"ηή", "i"},
+		{"θ", "th"}, {"ιίϊΐ", "i"}, {"κ", "k"}, {"λ", "l"}, {"μ", "m"}, {"ν", "n"}, {"ξ", "x"},
+		{"οό", "o"}, {"π", "p"}, {"ρ", "r"}, {"σς", "s"}, {"τ", "t"}, {"υύϋΰ", "y"}, {"φ", "f"},
+		{"χ", "ch"}, {"ψ", "ps"}, {"ωώ", "o"},
+
+		{"а", "a"}, {"б", "b"}, {"в", "v"}, {"гґ", "g"}, {"д", "d"}, {"еэ", "e"}, {"ё", "yo"},
+		{"є", "ye"}, {"ж", "zh"}, {"з", "z"}, {"иі", "i"}, {"ї", "yi"}, {"йы", "y"}, {"к", "k"},
+		{"л", "l"}, {"м", "m"}, {"н", "n"}, {"о", "o"}, {"п", "p"}, {"р", "r"}, {"с", "s"}, {"т", "t"},
+		{"у", "u"}, {"ф", "f"}, {"х", "kh"}, {"ц", "ts"}, {"ч", "ch"}, {"ш", "sh"}, {"щ", "shch"},
+		{"ъь", ""}, {"ю", "yu"}, {"я", "ya"},
+	}
+
+	for _, s := range spellings {
+		for _, r := range s.letters {
+			translit[r] = s.spelling
+		}
+	}
+}
+
+// Slugify returns the slug of title: its words transliterated to lower-case ASCII and joined by hyphens.
+func Slugify(title string) string {
+	var b strings.Builder
+
+	sep := false
+	word := func(s string) {
+		if sep && b.Len() > 0 {
+			b.WriteByte('-')
+		}
+		sep = false
+		b.WriteString(s)
+	}
+
+	for _, r := range strings.ToLower(title) {
+		spelling, ok := translit[r]
+
+		switch {
+		case 'a' <= r && r <= 'z' || '0' <= r && r <= '9':
+			word(string(r))
+		case ok:
+			word(spelling)
+		// Accents given as combining marks and the apostrophes within words do not separate words.
+		case unicode.Is(unicode.Mn, r), r == '\'', r == '’':
+		default:
+			sep = true
+		}
+	}
+
+	slug := truncateSlug(b.String(), MaxSlugLen)
+	if slug == "" {
+		return fallbackSlug
+	}
+
+	return slug
+}
+
+// truncateSlug cuts slug to at most n bytes, at the end of a word when it has one to cut at.
+func truncateSlug(slug string, n int) string {
+	if len(slug) <= n {
+		return slug
+	}
+
+	slug = slug[:n]
+	if i := strings.LastIndexByte(slug, '-'); i > 0 {
+		slug = slug[:i]
+	}
+
+	return strings.TrimSuffix(slug, "-")
+}
+
+// SlugCandidate returns the n-th slug tried for a post whose title slugifies to base: base itself,
+// then base suffixed with -2, -3 and so on.
+func SlugCandidate(base string, n int) string {
+	if n < 2 {
+		return base
+	}
+
+	suffix := "-" + strconv.Itoa(n)
+
+	return truncateSlug(base, MaxSlugLen-len(suffix)) + suffix
+}
+
+// ValidSlug reports whether slug is made of lower-case ASCII letters and digits in words joined by
+// single hyphens, and is no longer than MaxSlugLen.
+func ValidSlug(slug string) bool {
+	if slug == "" || len(slug) > MaxSlugLen {
+		return false
+	}
+
+	for _, word := range strings.Split(slug, "-") {
+		if word == "" {
+			return false
+		}
+
+		for _, r := range word {
+			if !('a' <= r && r <= 'z' || '0' <= r && r <= '9') {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// SlugFollowsTitle reports whether the slug of bp was generated from its title, rather than chosen by
+// its author. Such a slug follows the title when it changes.
+func (bp BlogPost) SlugFollowsTitle() bool {
+	base := Slugify(bp.Title)
+	if bp.Slug == base {
+		return true
+	}
+
+	i := strings.LastIndexByte(bp.Slug, '-')
+	if i < 0 {
+		return false
+	}
+
+	n, err := strconv.Atoi(bp.Slug[i+1:])
+
+	return err == nil && SlugCandidate(base, n) == bp.Slug
+}
+
+// Slugs returns the current slug of bp followed by the old slugs that still lead to it.
+func (bp BlogPost) Slugs() []string {
+	if bp.Slug == "" {
+		return bp.OldSlugs
+	}
+
+	return append([]string{bp.Slug}, bp.OldSlugs...)
+}
+
+// moveSlug returns bp under slug, keeping its current slug as an old slug leading to it.
+func (bp BlogPost) moveSlug(slug string) BlogPost {
+	if slug == "" || slug == bp.Slug {
+		return bp
+	}
+
+	// A post taking one of its old slugs back no longer redirects from it.
+	old := slices.DeleteFunc(slices.Clone(bp.OldSlugs), func(s string) bool { return s == slug })
+	if bp.Slug != "" {
+		old = append(old, bp.Slug)
+	}
+
+	bp.Slug, bp.OldSlugs = slug, old
+
+	return bp
+}
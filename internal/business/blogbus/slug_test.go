@@ -0,0 +1,117 @@
+package blogbus_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anazcodes/blogapp/internal/business/blogbus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlugify(t *testing.T) {
+	testCases := []struct {
+		name   string
+		title  string
+		output string
+	}{
+		{name: "ASCII", title: "Caching in Go: Part 2!", output: "caching-in-go-part-2"},
+		{name: "Accents", title: "Crème Brûlée à la Française", output: "creme-brulee-a-la-francaise"},
+		{name: "Combining Marks", title: "Café Crème", output: "cafe-creme"},
+		{name: "Special Letters", title: "Straße, Œuvre og Ærø", output: "strasse-oeuvre-og-aero"},
+		{name: "Apostrophes", title: "Don't Panic, It’s Fine", output: "dont-panic-its-fine"},
+		{name: "Cyrillic", title: "Привет, мир", output: "privet-mir"},
+		{name: "Greek", title: "Καλημέρα κόσμε", output: "kalimera-kosme"},
+		{name: "Mixed Separators", title: "  --Go__and  Rust--  ", output: "go-and-rust"},
+		{name: "Untransliterated", title: "你好 Go", output: "go"},
+		{name: "Nothing To Transliterate", title: "你好", output: "post"},
+		{name: "Empty", title: "", output: "post"},
+		{name: "Long", title: strings.Repeat("word ", 20), output: strings.TrimSuffix(strings.Repeat("word-", 16), "-")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			output := blogbus.Slugify(tc.title)
+			assert.Equal(t, tc.output, output)
+			assert.True(t, blogbus.ValidSlug(output))
+		})
+	}
+}
+
+func TestSlugCandidate(t *testing.T) {
+	long := blogbus.Slugify(strings.Repeat("word ", 20))
+
+	assert.Equal(t, "hello", blogbus.SlugCandidate("hello", 1))
+	assert.Equal(t, "hello-2", blogbus.SlugCandidate("hello", 2))
+	assert.Equal(t, "hello-12", blogbus.SlugCandidate("hello", 12))
+
+	// The suffix of a long slug replaces its last word rather than making it too long.
+	candidate := blogbus.SlugCandidate(long, 2)
+	assert.Equal(t, strings.TrimSuffix(long, "-word")+"-2", candidate)
+	assert.True(t, blogbus.ValidSlug(candidate))
+}
+
+func TestValidSlug(t *testing.T) {
+	testCases := []struct {
+		slug  string
+		valid bool
+	}{
+		{slug: "my-first-post", valid: true},
+		{slug: "2024", valid: true},
+		{slug: "", valid: false},
+		{slug: "My-Post", valid: false},
+		{slug: "my--post", valid: false},
+		{slug: "-my-post", valid: false},
+		{slug: "my-post-", valid: false},
+		{slug: "my_post", valid: false},
+		{slug: "crème", valid: false},
+		{slug: strings.Repeat("a", blogbus.MaxSlugLen+1), valid: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.slug, func(t *testing.T) {
+			assert.Equal(t, tc.valid, blogbus.ValidSlug(tc.slug))
+		})
+	}
+}
+
+func TestSlugFollowsTitle(t *testing.T) {
+	testCases := []struct {
+		name    string
+		slug    string
+		follows bool
+	}{
+		{name: "Generated", slug: "hello-world", follows: true},
+		{name: "Generated With Suffix", slug: "hello-world-3", follows: true},
+		{name: "Custom", slug: "greetings", follows: false},
+		{name: "Custom With Number", slug: "greetings-3", follows: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			bp := blogbus.BlogPost{Title: "Hello, World", Slug: tc.slug}
+			assert.Equal(t, tc.follows, bp.SlugFollowsTitle())
+		})
+	}
+}
+
+func TestUpdateApplySlug(t *testing.T) {
+	now := time.Now()
+	bp := blogbus.NewBlogPost(1, blogbus.AddBlogPost{Title: "Hello", Slug: "hello"}, now)
+
+	bp = blogbus.UpdateBlogPost{Title: "Hi"}.Apply(bp, now)
+	assert.Equal(t, "hello", bp.Slug)
+	assert.Empty(t, bp.OldSlugs)
+
+	bp = blogbus.UpdateBlogPost{Slug: "hi"}.Apply(bp, now)
+	assert.Equal(t, "hi", bp.Slug)
+	assert.Equal(t, []string{"hello"}, bp.OldSlugs)
+
+	bp = blogbus.UpdateBlogPost{Slug: "hey"}.Apply(bp, now)
+	assert.Equal(t, []string{"hey", "hello", "hi"}, bp.Slugs())
+
+	// Taking an old slug back stops it from redirecting.
+	bp = blogbus.UpdateBlogPost{Slug: "hello"}.Apply(bp, now)
+	assert.Equal(t, "hello", bp.Slug)
+	assert.Equal(t, []string{"hi", "hey"}, bp.OldSlugs)
+}
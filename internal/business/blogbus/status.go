@@ -0,0 +1,98 @@
+package blogbus
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrInvalidTransition = errors.New("invalid status transition")
+	ErrInvalidSchedule   = errors.New("publish time must be in the future")
+)
+
+// Status is the stage of the lifecycle of a post. Posts are created as drafts and only published
+// posts are public.
+type Status string
+
+const (
+	StatusDraft     Status = "draft"
+	StatusPublished Status = "published"
+	StatusArchived  Status = "archived"
+)
+
+// Valid reports whether s is a known status.
+func (s Status) Valid() bool {
+	switch s {
+	case StatusDraft, StatusPublished, StatusArchived:
+		return true
+	}
+
+	return false
+}
+
+// Transition moves a post from one status to another. The storage applies it only while Check passes,
+// so concurrent transitions of a post cannot both succeed.
+type Transition struct {
+	From      Status
+	To        Status
+	At        time.Time
+	PublishAt time.Time // Time the post is scheduled to be published at, zero leaves it unscheduled.
+	DueBy     time.Time // Non-zero applies the transition only to a post scheduled at or before it.
+}
+
+// Check returns ErrInvalidTransition when t cannot be applied to bp, or ErrTrashed when bp is in the
+// trash.
+func (t Transition) Check(bp BlogPost) error {
+	if bp.Trashed() {
+		return ErrTrashed
+	}
+
+	if bp.Status != t.From {
+		return fmt.Errorf("%w: post is %s", ErrInvalidTransition, bp.Status)
+	}
+
+	if !t.DueBy.IsZero() && !bp.Due(t.DueBy) {
+		return fmt.Errorf("%w: post is not scheduled by %s", ErrInvalidTransition, t.DueBy.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+// Apply returns bp moved to the To status with the schedule of t. Publishing sets the time the post
+// was published at and unpublishing clears it, an archived post keeps it.
+func (t Transition) Apply(bp BlogPost) BlogPost {
+	bp.Status = t.To
+	bp.PublishAt = t.PublishAt
+
+	switch t.To {
+	case StatusPublished:
+		bp.PublishedAt = t.At
+	case StatusDraft:
+		bp.PublishedAt = time.Time{}
+	}
+
+	return bp
+}
+
+// action is a change of the status of a post requested by its author.
+type action string
+
+const (
+	publish   action = "publish"
+	unpublish action = "unpublish"
+	archive   action = "archive"
+	// schedule and unschedule set and clear the time a draft is published at by the Scheduler.
+	schedule   action = "schedule"
+	unschedule action = "unschedule"
+)
+
+// transitions is the post state machine, the status each action moves a post from and to.
+var transitions = map[action]Transition{
+	publish:   {From: StatusDraft, To: StatusPublished},
+	unpublish: {From: StatusPublished, To: StatusDraft},
+	archive:   {From: StatusPublished, To: StatusArchived},
+
+	schedule:   {From: StatusDraft, To: StatusDraft},
+	unschedule: {From: StatusDraft, To: StatusDraft},
+}
@@ -0,0 +1,143 @@
+package blogbus
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	ErrTagNotFound      = errors.New("tag not found")
+	ErrCategoryNotFound = errors.New("category not found")
+	ErrTagExists        = errors.New("tag already exists")
+	ErrCategoryExists   = errors.New("category already exists")
+	ErrInvalidTerm      = errors.New("tag and category names must not be empty")
+	ErrUnknownTerm      = errors.New("unknown tag or category")
+	ErrTermInUse        = errors.New("tag or category is in use")
+	ErrCategoryCycle    = errors.New("category cannot be filed under itself or its subcategories")
+)
+
+// Tag labels posts, a post may have any number of tags.
+type Tag struct {
+	Slug      string // Unique name of the tag in URLs and on posts, it never changes.
+	Name      string
+	CreatedAt time.Time
+}
+
+// Category files posts in a tree, a post filed under a category is also found under its ancestors.
+type Category struct {
+	Slug      string // Unique name of the category in URLs and on posts, it never changes.
+	Name      string
+	Parent    string // Slug of the parent category, empty for a top-level category.
+	CreatedAt time.Time
+}
+
+// TagCount is a tag with the number of published posts that have it.
+type TagCount struct {
+	Tag
+	Posts int
+}
+
+// Taxonomy is every tag and category.
+type Taxonomy struct {
+	Tags       []Tag
+	Categories []Category // Every category follows its parent.
+}
+
+type AddTag struct {
+	Name string
+	Slug string // Slug chosen by the author, empty generates one from the name.
+}
+
+type UpdateTag struct {
+	Name string
+}
+
+type AddCategory struct {
+	Name   string
+	Slug   string // Slug chosen by the author, empty generates one from the name.
+	Parent string // Empty adds a top-level category.
+}
+
+type UpdateCategory struct {
+	Name   string  // Empty keeps the name.
+	Parent *string // Nil keeps the parent, empty moves the category to the top level.
+}
+
+// NewTag returns the tag atg adds at at.
+func NewTag(atg AddTag, at time.Time) (Tag, error) {
+	slug, err := termSlug(atg.Name, atg.Slug)
+	if err != nil {
+		return Tag{}, err
+	}
+
+	return Tag{Slug: slug, Name: strings.TrimSpace(atg.Name), CreatedAt: at}, nil
+}
+
+// NewCategory returns the category ac adds at at.
+func NewCategory(ac AddCategory, at time.Time) (Category, error) {
+	slug, err := termSlug(ac.Name, ac.Slug)
+	if err != nil {
+		return Category{}, err
+	}
+
+	return Category{Slug: slug, Name: strings.TrimSpace(ac.Name), Parent: ac.Parent, CreatedAt: at}, nil
+}
+
+// termSlug returns the slug of a tag or category named name: slug when it is given, or else the slug
+// of the name.
+func termSlug(name, slug string) (string, error) {
+	if strings.TrimSpace(name) == "" {
+		return "", ErrInvalidTerm
+	}
+
+	if slug == "" {
+		return Slugify(name), nil
+	}
+
+	if !ValidSlug(slug) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidSlug, slug)
+	}
+
+	return slug, nil
+}
+
+// Apply returns t renamed by ut.
+func (ut UpdateTag) Apply(t Tag) (Tag, error) {
+	name := strings.TrimSpace(ut.Name)
+	if name == "" {
+		return Tag{}, ErrInvalidTerm
+	}
+
+	t.Name = name
+
+	return t, nil
+}
+
+// Apply returns c renamed and moved by uc. The empty name of uc keeps the name of c.
+func (uc UpdateCategory) Apply(c Category) Category {
+	if name := strings.TrimSpace(uc.Name); name != "" {
+		c.Name = name
+	}
+
+	if uc.Parent != nil {
+		c.Parent = *uc.Parent
+	}
+
+	return c
+}
+
+// uniqueTerms returns the slugs of the tags or categories attached to a post sorted and without
+// duplicates. A nil slugs stays nil, so an update without them keeps those of the post.
+func uniqueTerms(slugs []string) []string {
+	if slugs == nil {
+		return nil
+	}
+
+	out := slices.Clone(slugs)
+	slices.Sort(out)
+
+	return slices.Compact(out)
+}
@@ -0,0 +1,138 @@
+package blogbus_test
+
+import (
+	"testing"
+
+	"github.com/anazcodes/blogapp/internal/business/blogbus"
+	mockblogbus "github.com/anazcodes/blogapp/internal/mock/business/blogbus"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddTag(t *testing.T) {
+	testCases := []struct {
+		name        string
+		input       blogbus.AddTag
+		setupExpect func(repo *mockblogbus.MockRepo)
+		slug        string
+		expectedErr error
+	}{
+		{
+			name:  "Slug From Name",
+			input: blogbus.AddTag{Name: " Go Generics "},
+			setupExpect: func(repo *mockblogbus.MockRepo) {
+				repo.EXPECT().AddTag(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			slug: "go-generics",
+		},
+		{
+			name:  "Chosen Slug",
+			input: blogbus.AddTag{Name: "Go", Slug: "golang"},
+			setupExpect: func(repo *mockblogbus.MockRepo) {
+				repo.EXPECT().AddTag(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			slug: "golang",
+		},
+		{
+			name:  "Exists",
+			input: blogbus.AddTag{Name: "Go"},
+			setupExpect: func(repo *mockblogbus.MockRepo) {
+				repo.EXPECT().AddTag(gomock.Any(), gomock.Any()).Return(blogbus.ErrTagExists)
+			},
+			expectedErr: blogbus.ErrTagExists,
+		},
+		{
+			name:        "Empty Name",
+			input:       blogbus.AddTag{Name: "  "},
+			setupExpect: func(repo *mockblogbus.MockRepo) {},
+			expectedErr: blogbus.ErrInvalidTerm,
+		},
+		{
+			name:        "Invalid Slug",
+			input:       blogbus.AddTag{Name: "Go", Slug: "Go Lang"},
+			setupExpect: func(repo *mockblogbus.MockRepo) {},
+			expectedErr: blogbus.ErrInvalidSlug,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mockblogbus.NewMockRepo(ctrl)
+			tc.setupExpect(repo)
+			bus := blogbus.NewBusiness(repo)
+
+			output, err := bus.AddTag(editing(t), tc.input)
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.slug, output.Slug)
+		})
+	}
+}
+
+func TestAddCategory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mockblogbus.NewMockRepo(ctrl)
+	repo.EXPECT().AddCategory(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ any, c blogbus.Category) error {
+			assert.Equal(t, "web-development", c.Slug)
+			assert.Equal(t, "programming", c.Parent)
+			assert.False(t, c.CreatedAt.IsZero())
+			return nil
+		})
+
+	bus := blogbus.NewBusiness(repo)
+
+	c, err := bus.AddCategory(editing(t), blogbus.AddCategory{Name: "Web Development", Parent: "programming"})
+	assert.Nil(t, err)
+	assert.Equal(t, "Web Development", c.Name)
+}
+
+func TestUpdateTermApply(t *testing.T) {
+	tag, err := blogbus.UpdateTag{Name: " Golang "}.Apply(blogbus.Tag{Slug: "go", Name: "Go"})
+	assert.Nil(t, err)
+	assert.Equal(t, blogbus.Tag{Slug: "go", Name: "Golang"}, tag)
+
+	_, err = blogbus.UpdateTag{}.Apply(blogbus.Tag{Slug: "go", Name: "Go"})
+	assert.ErrorIs(t, err, blogbus.ErrInvalidTerm)
+
+	c := blogbus.Category{Slug: "go", Name: "Go", Parent: "languages"}
+	assert.Equal(t, c, blogbus.UpdateCategory{}.Apply(c))
+
+	top := ""
+	assert.Equal(t, blogbus.Category{Slug: "go", Name: "Golang"}, blogbus.UpdateCategory{Name: "Golang", Parent: &top}.Apply(c))
+}
+
+func TestPostTerms(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Terms reach the repository sorted and without duplicates, a nil list stays nil.
+	repo := mockblogbus.NewMockRepo(ctrl)
+	repo.EXPECT().AddBlogPost(gomock.Any(), blogbus.AddBlogPost{
+		Title:      "Title",
+		Editor:     "editor",
+		Tags:       []string{"go", "rust"},
+		Categories: []string{"programming"},
+	}).Return(uint64(1), nil)
+	repo.EXPECT().UpdateBlogPost(gomock.Any(), uint64(1), blogbus.UpdateBlogPost{
+		Editor: "editor",
+		Tags:   []string{"go"},
+	}).Return(uint64(1), nil)
+
+	bus := blogbus.NewBusiness(repo)
+
+	_, err := bus.AddBlogPost(editing(t), blogbus.AddBlogPost{
+		Title:      "Title",
+		Tags:       []string{"rust", "go", "rust"},
+		Categories: []string{"programming"},
+	})
+	assert.Nil(t, err)
+
+	_, err = bus.UpdateBlogPost(editing(t), 1, blogbus.UpdateBlogPost{Tags: []string{"go", "go"}})
+	assert.Nil(t, err)
+}
@@ -0,0 +1,112 @@
+package blogbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/anazcodes/blogapp/internal/business/authbus"
+)
+
+var (
+	ErrTrashed    = errors.New("post is in the trash")
+	ErrNotTrashed = errors.New("post is not in the trash")
+)
+
+// Trashed reports whether bp is in the trash.
+func (bp BlogPost) Trashed() bool {
+	return !bp.DeletedAt.IsZero()
+}
+
+// Trash returns bp moved to the trash at at, or restored from the trash when at is zero.
+// It returns ErrTrashed when trashing a post already in the trash and ErrNotTrashed when restoring a
+// post that is not.
+func (bp BlogPost) Trash(at time.Time) (BlogPost, error) {
+	if !at.IsZero() && bp.Trashed() {
+		return BlogPost{}, ErrTrashed
+	}
+
+	if at.IsZero() && !bp.Trashed() {
+		return BlogPost{}, ErrNotTrashed
+	}
+
+	bp.DeletedAt = at
+
+	return bp, nil
+}
+
+// Purger permanently deletes the posts that stayed in the trash longer than the retention period.
+//
+// Like the Scheduler it keeps no state, every pass scans the repository for the expired posts.
+type Purger struct {
+	repo      Repo
+	clock     Clock
+	every     time.Duration
+	retention time.Duration
+}
+
+// NewPurger returns a Purger deleting the posts trashed for longer than retention from repo every
+// interval of clock.
+func NewPurger(repo Repo, clock Clock, every, retention time.Duration) *Purger {
+	return &Purger{
+		repo:      repo,
+		clock:     clock,
+		every:     every,
+		retention: retention,
+	}
+}
+
+// Run purges the expired posts immediately and then every interval until ctx is done.
+func (p *Purger) Run(ctx context.Context) {
+	for {
+		if _, err := p.PurgeExpired(ctx); err != nil && ctx.Err() == nil {
+			log.Println("purger: purging expired posts failed: ", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-p.clock.After(p.every):
+		}
+	}
+}
+
+// PurgeExpired permanently deletes every post trashed before the retention period and returns how
+// many it deleted. It acts as authbus.System.
+func (p *Purger) PurgeExpired(ctx context.Context) (int, error) {
+	ctx = authbus.WithPrincipal(ctx, authbus.System)
+
+	cutoff := p.clock.Now().Add(-p.retention)
+
+	q := Query{Trashed: true, TrashedBy: cutoff}
+	page := Page{Limit: MaxLimit}
+	purged := 0
+
+	for {
+		bpp, err := p.repo.BlogPosts(ctx, q, page)
+		if err != nil {
+			return purged, fmt.Errorf("repo.blogposts: %w", err)
+		}
+
+		for _, bp := range bpp.BlogPosts {
+			_, err := p.repo.PurgeBlogPost(ctx, bp.ID, cutoff)
+			// The post was restored since it was listed.
+			if errors.Is(err, ErrNotTrashed) {
+				continue
+			}
+			if err != nil {
+				return purged, fmt.Errorf("repo.purgeblogpost: %w id: %d", err, bp.ID)
+			}
+
+			purged++
+		}
+
+		if !bpp.HasMore {
+			return purged, nil
+		}
+
+		page.Cursor = bpp.NextCursor
+	}
+}
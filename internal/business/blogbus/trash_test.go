@@ -0,0 +1,106 @@
+package blogbus_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/anazcodes/blogapp/internal/business/blogbus"
+	"github.com/anazcodes/blogapp/internal/repository/blogrepo"
+	"github.com/anazcodes/blogapp/internal/repository/blogrepo/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrash(t *testing.T) {
+	now := time.Now()
+
+	testCases := []struct {
+		name        string
+		deletedAt   time.Time
+		at          time.Time
+		expectedErr error
+	}{
+		{
+			name: "Trash",
+			at:   now,
+		},
+		{
+			name:        "Trash Trashed",
+			deletedAt:   now,
+			at:          now,
+			expectedErr: blogbus.ErrTrashed,
+		},
+		{
+			name:      "Restore",
+			deletedAt: now,
+		},
+		{
+			name:        "Restore Not Trashed",
+			expectedErr: blogbus.ErrNotTrashed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			bp, err := blogbus.BlogPost{ID: 1, DeletedAt: tc.deletedAt}.Trash(tc.at)
+			assert.ErrorIs(t, err, tc.expectedErr)
+			if err == nil {
+				assert.Equal(t, tc.at, bp.DeletedAt)
+				assert.Equal(t, !tc.at.IsZero(), bp.Trashed())
+			}
+		})
+	}
+}
+
+func TestPurgerPurgeExpired(t *testing.T) {
+	now := time.Now()
+	clock := &fakeClock{now: now}
+
+	repo := blogrepo.NewRepository(10)
+	ids := make([]uint64, 3)
+
+	for i := range ids {
+		id, err := repo.AddBlogPost(t.Context(), blogbus.AddBlogPost{Title: "Title"})
+		assert.Nil(t, err)
+		ids[i] = id
+	}
+
+	// Post 1 is trashed now, post 2 a day later, post 3 stays out of the trash.
+	_, err := repo.TrashBlogPost(t.Context(), ids[0], now)
+	assert.Nil(t, err)
+
+	_, err = repo.TrashBlogPost(t.Context(), ids[1], now.Add(24*time.Hour))
+	assert.Nil(t, err)
+
+	purger := blogbus.NewPurger(repo, clock, time.Hour, 48*time.Hour)
+
+	purged, err := purger.PurgeExpired(t.Context())
+	assert.Nil(t, err)
+	assert.Equal(t, 0, purged)
+
+	clock.Advance(60 * time.Hour)
+
+	purged, err = purger.PurgeExpired(t.Context())
+	assert.Nil(t, err)
+	assert.Equal(t, 1, purged)
+
+	_, err = repo.BlogPost(t.Context(), ids[0])
+	assert.ErrorIs(t, err, cache.ErrItemNotFound)
+
+	_, err = repo.BlogPost(t.Context(), ids[1])
+	assert.ErrorIs(t, err, blogbus.ErrTrashed)
+
+	// A post restored before its retention ran out is kept.
+	_, err = repo.RestoreBlogPost(t.Context(), ids[1])
+	assert.Nil(t, err)
+
+	clock.Advance(24 * time.Hour)
+
+	purged, err = purger.PurgeExpired(t.Context())
+	assert.Nil(t, err)
+	assert.Equal(t, 0, purged)
+
+	for _, id := range ids[1:] {
+		_, err = repo.BlogPost(t.Context(), id)
+		assert.Nil(t, err)
+	}
+}
@@ -0,0 +1,152 @@
+package commentbus
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// DefaultSpamThreshold is the probability of spam over which a Bayes classifier rejects a comment.
+const DefaultSpamThreshold = 0.9
+
+// LinkLimit takes a comment holding more links than its value for spam.
+type LinkLimit int
+
+var link = regexp.MustCompile(`(?i)\b(?:https?://|www\.)`)
+
+func (l LinkLimit) Classify(c Comment) Verdict {
+	if n := len(link.FindAllStringIndex(c.Author+" "+c.Body, -1)); n > int(l) {
+		return Verdict{Spam: true, Reason: fmt.Sprintf("holds %d links, more than %d", n, l)}
+	}
+
+	return Verdict{}
+}
+
+// Blocklist takes a comment using one of its words for spam.
+type Blocklist map[string]bool
+
+// NewBlocklist returns the blocklist of words, which are matched regardless of case.
+func NewBlocklist(words ...string) Blocklist {
+	b := make(Blocklist, len(words))
+
+	for _, w := range words {
+		if w = strings.ToLower(strings.TrimSpace(w)); w != "" {
+			b[w] = true
+		}
+	}
+
+	return b
+}
+
+func (b Blocklist) Classify(c Comment) Verdict {
+	for _, w := range words(c) {
+		if b[w] {
+			return Verdict{Spam: true, Reason: fmt.Sprintf("uses the blocked word %q", w)}
+		}
+	}
+
+	return Verdict{}
+}
+
+// bayesMinExamples is the number of examples of both spam and legitimate comments a Bayes classifier
+// learns before it classifies, as a few decisions say little of the comments to come.
+const bayesMinExamples = 5
+
+// Bayes is a naive Bayes classifier learning spam from the decisions of moderators. It takes a comment
+// for spam once the probability of spam it estimates from the words of the comment is over its
+// threshold.
+type Bayes struct {
+	threshold float64
+
+	mu       sync.RWMutex
+	examples [2]int            // Number of legitimate and spam examples.
+	counts   [2]map[string]int // Occurrences of every word in the legitimate and spam examples.
+	totals   [2]int            // Number of words in the legitimate and spam examples.
+}
+
+// NewBayes returns a Bayes classifier rejecting comments over threshold, a threshold of 1 or more
+// never rejects one.
+func NewBayes(threshold float64) *Bayes {
+	return &Bayes{
+		threshold: threshold,
+		counts:    [2]map[string]int{{}, {}},
+	}
+}
+
+func (b *Bayes) Classify(c Comment) Verdict {
+	if p := b.Spamicity(c); p > b.threshold {
+		return Verdict{Spam: true, Reason: fmt.Sprintf("estimated %.0f%% likely to be spam", p*100)}
+	}
+
+	return Verdict{}
+}
+
+// Spamicity returns the probability that c is spam, zero until enough examples are learned.
+func (b *Bayes) Spamicity(c Comment) float64 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.examples[0] < bayesMinExamples || b.examples[1] < bayesMinExamples {
+		return 0
+	}
+
+	// Every word seen in an example is in the vocabulary. Laplace smoothing gives the words an
+	// example of one kind lacks a small probability instead of none.
+	vocabulary := len(b.counts[0])
+	for w := range b.counts[1] {
+		if _, ok := b.counts[0][w]; !ok {
+			vocabulary++
+		}
+	}
+
+	var logs [2]float64
+
+	for k := range logs {
+		logs[k] = math.Log(float64(b.examples[k]) / float64(b.examples[0]+b.examples[1]))
+
+		for _, w := range words(c) {
+			logs[k] += math.Log(float64(b.counts[k][w]+1) / float64(b.totals[k]+vocabulary))
+		}
+	}
+
+	return 1 / (1 + math.Exp(logs[0]-logs[1]))
+}
+
+func (b *Bayes) Learn(c Comment, spam bool) {
+	b.add(c, spam, 1)
+}
+
+func (b *Bayes) Forget(c Comment, spam bool) {
+	b.add(c, spam, -1)
+}
+
+// add adds c to the examples of its kind n times, a negative n removes it.
+func (b *Bayes) add(c Comment, spam bool, n int) {
+	k := 0
+	if spam {
+		k = 1
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.examples[k] += n
+
+	for _, w := range words(c) {
+		b.totals[k] += n
+
+		if b.counts[k][w] += n; b.counts[k][w] <= 0 {
+			delete(b.counts[k], w)
+		}
+	}
+}
+
+// words returns the lowercased words of the author and body of c.
+func words(c Comment) []string {
+	return strings.FieldsFunc(strings.ToLower(c.Author+" "+c.Body), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
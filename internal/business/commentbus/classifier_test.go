@@ -0,0 +1,75 @@
+package commentbus_test
+
+import (
+	"testing"
+
+	"github.com/anazcodes/blogapp/internal/business/commentbus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifiers(t *testing.T) {
+	testCases := []struct {
+		name         string
+		classifier   commentbus.Classifier
+		input        commentbus.Comment
+		expectedSpam bool
+	}{
+		{
+			name:       "Links Within Limit",
+			classifier: commentbus.LinkLimit(2),
+			input:      commentbus.Comment{Author: "Ann", Body: "See https://go.dev and www.example.com"},
+		},
+		{
+			name:         "Links Over Limit",
+			classifier:   commentbus.LinkLimit(2),
+			input:        commentbus.Comment{Author: "Ann", Body: "http://a.example HTTPS://b.example www.c.example"},
+			expectedSpam: true,
+		},
+		{
+			name:         "Blocked Word",
+			classifier:   commentbus.NewBlocklist(" Casino ", ""),
+			input:        commentbus.Comment{Author: "Ann", Body: "Best CASINO bonus!"},
+			expectedSpam: true,
+		},
+		{
+			name:       "Blocked Word Within Another",
+			classifier: commentbus.NewBlocklist("casino"),
+			input:      commentbus.Comment{Author: "Ann", Body: "Casinos are a poor example."},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			v := tc.classifier.Classify(tc.input)
+
+			assert.Equal(t, tc.expectedSpam, v.Spam)
+			if tc.expectedSpam {
+				assert.NotEmpty(t, v.Reason)
+			}
+		})
+	}
+}
+
+func TestBayes(t *testing.T) {
+	b := commentbus.NewBayes(commentbus.DefaultSpamThreshold)
+
+	spam := commentbus.Comment{Author: "Winner", Body: "Cheap pills, free money, click now"}
+	ham := commentbus.Comment{Author: "Ann", Body: "Thanks for the clear explanation of channels"}
+
+	// Too few decisions are learned to classify.
+	b.Learn(spam, true)
+	b.Learn(ham, false)
+	assert.False(t, b.Classify(spam).Spam)
+
+	for range 4 {
+		b.Learn(commentbus.Comment{Author: "Promo", Body: "Free money and cheap pills, click here"}, true)
+		b.Learn(commentbus.Comment{Author: "Bob", Body: "Great explanation, the channels example helped"}, false)
+	}
+
+	assert.True(t, b.Classify(commentbus.Comment{Author: "Deals", Body: "Click for free pills"}).Spam)
+	assert.False(t, b.Classify(commentbus.Comment{Author: "Eve", Body: "The explanation of channels was clear"}).Spam)
+
+	// A reversed decision is forgotten, which leaves too few spam examples to classify.
+	b.Forget(spam, true)
+	assert.Zero(t, b.Spamicity(spam))
+}
@@ -0,0 +1,290 @@
+// Package commentbus implements the comments readers leave on blog posts, threaded by the comments
+// they reply to and held for moderation until they are approved.
+package commentbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/anazcodes/blogapp/internal/business/authbus"
+)
+
+var (
+	ErrCommentNotFound  = errors.New("comment not found")
+	ErrPostNotFound     = errors.New("post not found")
+	ErrInvalidComment   = errors.New("invalid comment")
+	ErrUnknownParent    = errors.New("comment replied to is not on the post")
+	ErrEditWindowClosed = errors.New("comment can no longer be edited")
+	ErrInvalidLimit     = fmt.Errorf("limit must be between 1 and %d", MaxLimit)
+	ErrInvalidCursor    = errors.New("invalid cursor")
+)
+
+// DefaultEditWindow is the time a comment can be edited for after it is posted.
+const DefaultEditWindow = 15 * time.Minute
+
+type business struct {
+	repo        Repo
+	editWindow  time.Duration
+	classifiers []Classifier
+}
+
+// Repo stores the comments of the posts. The post of a comment must exist and not be in the trash for
+// the comment to be read or written, a comment is deleted with its post.
+type Repo interface {
+	// Post returns the post with id the comments are on, it must exist and not be in the trash.
+	Post(ctx context.Context, id uint64) (Post, error)
+	// AddComment adds c under the next comment ID and returns it with that ID. It returns
+	// ErrUnknownParent when c replies to a comment that is not an approved comment of its post.
+	AddComment(ctx context.Context, c Comment) (Comment, error)
+	// Comment returns the comment with id on the post with postID, whatever its status.
+	Comment(ctx context.Context, postID, id uint64) (Comment, error)
+	// Comments returns the page of the approved replies to the approved comment with parentID selected
+	// by page, oldest first. A zero parentID selects the comments on the post itself.
+	Comments(ctx context.Context, postID, parentID uint64, page Page) (CommentPage, error)
+	// UpdateComment edits a comment with uc and sends it back to moderation with v.
+	UpdateComment(ctx context.Context, postID, id uint64, uc UpdateComment, v Verdict, at time.Time) (Comment, error)
+	// DeleteComment deletes the comment with id and every reply in its thread.
+	DeleteComment(ctx context.Context, postID, id uint64) error
+	// CommentQueue returns the page of the comments with status selected by page across the posts
+	// that are not in the trash, oldest first.
+	CommentQueue(ctx context.Context, status Status, page Page) (CommentPage, error)
+	// ModerateComments applies m to the comments with ids and returns them as they were before. It
+	// returns ErrCommentNotFound and moderates none when one of them does not exist.
+	ModerateComments(ctx context.Context, ids []uint64, m Moderation) ([]Comment, error)
+	// ModeratedComments returns every comment a moderator decided on, ordered by ID.
+	ModeratedComments(ctx context.Context) ([]Comment, error)
+}
+
+type Business interface {
+	// AddComment adds a comment to the post with postID, in reply to the comment ac.ParentID when it
+	// is not zero. The comment is public once a moderator approves it.
+	AddComment(ctx context.Context, postID uint64, ac AddComment) (Comment, error)
+	Comment(ctx context.Context, postID, id uint64) (Comment, error)
+	// Comments returns the page of the approved replies to the comment with parentID selected by page,
+	// oldest first. A zero parentID selects the comments on the post itself.
+	Comments(ctx context.Context, postID, parentID uint64, page Page) (CommentPage, error)
+	// UpdateComment edits a comment for the user who posted it, or returns ErrEditWindowClosed once the
+	// edit window after it was posted has passed. A moderator edits any comment at any time. The edited
+	// comment is moderated again.
+	UpdateComment(ctx context.Context, postID, id uint64, uc UpdateComment) (Comment, error)
+	// DeleteComment deletes a comment with its replies.
+	DeleteComment(ctx context.Context, postID, id uint64) error
+	// CommentQueue returns the page of the comments awaiting moderators with status, oldest first.
+	CommentQueue(ctx context.Context, status Status, page Page) (CommentPage, error)
+	// ModerateComments approves or rejects the comments with ids at once, and returns them moderated.
+	ModerateComments(ctx context.Context, ids []uint64, status Status) ([]Comment, error)
+}
+
+// NewBusiness returns the comments stored in repo, which their commenters can edit for editWindow after
+// they are posted. A non-positive editWindow closes the edits of every commenter. New and edited
+// comments are rejected as spam by the first of classifiers that takes them for spam, and otherwise held
+// pending for the moderators, whose decisions the Learner classifiers learn.
+func NewBusiness(repo Repo, editWindow time.Duration, classifiers ...Classifier) Business {
+	return &business{
+		repo:        repo,
+		editWindow:  editWindow,
+		classifiers: classifiers,
+	}
+}
+
+func (b *business) AddComment(ctx context.Context, postID uint64, ac AddComment) (Comment, error) {
+	if err := authbus.Authorize(ctx, authbus.Comment, nil); err != nil {
+		return Comment{}, err
+	}
+
+	p, _ := authbus.PrincipalFrom(ctx)
+
+	if err := b.readable(ctx, postID); err != nil {
+		return Comment{}, err
+	}
+
+	c, err := NewComment(postID, p.Subject, ac, time.Now())
+	if err != nil {
+		return Comment{}, err
+	}
+
+	c = classify(b.classifiers, c).Apply(c)
+
+	c, err = b.repo.AddComment(ctx, c)
+	if err != nil {
+		return Comment{}, fmt.Errorf("repo.addcomment: %w post: %d", err, postID)
+	}
+
+	return c, nil
+}
+
+// Comment returns an approved comment, the others are not public.
+func (b *business) Comment(ctx context.Context, postID, id uint64) (Comment, error) {
+	if err := b.readable(ctx, postID); err != nil {
+		return Comment{}, err
+	}
+
+	c, err := b.repo.Comment(ctx, postID, id)
+	if err != nil {
+		return Comment{}, fmt.Errorf("repo.comment: %w id: %d", err, id)
+	}
+
+	if c.Status != StatusApproved {
+		return Comment{}, fmt.Errorf("%w: comment is %s id: %d", ErrCommentNotFound, c.Status, id)
+	}
+
+	return c, nil
+}
+
+func (b *business) Comments(ctx context.Context, postID, parentID uint64, page Page) (CommentPage, error) {
+	page, err := validPage(page)
+	if err != nil {
+		return CommentPage{}, err
+	}
+
+	if err := b.readable(ctx, postID); err != nil {
+		return CommentPage{}, err
+	}
+
+	cp, err := b.repo.Comments(ctx, postID, parentID, page)
+	if err != nil {
+		return CommentPage{}, fmt.Errorf("repo.comments: %w post: %d", err, postID)
+	}
+
+	return cp, nil
+}
+
+// UpdateComment edits a comment whatever its status, as its commenter may edit it while it is held for
+// moderation. The edit window only holds the commenter, a moderator may correct a comment any time.
+func (b *business) UpdateComment(ctx context.Context, postID, id uint64, uc UpdateComment) (Comment, error) {
+	if err := b.readable(ctx, postID); err != nil {
+		return Comment{}, err
+	}
+
+	c, err := b.repo.Comment(ctx, postID, id)
+	if err != nil {
+		return Comment{}, fmt.Errorf("repo.comment: %w id: %d", err, id)
+	}
+
+	at := time.Now()
+	if authbus.Authorize(ctx, authbus.ModerateComments, nil) != nil {
+		err = authbus.Authorize(ctx, authbus.EditComment, func() (string, error) { return c.Commenter, nil })
+		if err != nil {
+			return Comment{}, err
+		}
+
+		if !c.Editable(at, b.editWindow) {
+			return Comment{}, ErrEditWindowClosed
+		}
+	}
+
+	edited, err := uc.Apply(c, at)
+	if err != nil {
+		return Comment{}, err
+	}
+
+	edited, err = b.repo.UpdateComment(ctx, postID, id, uc, classify(b.classifiers, edited), at)
+	if err != nil {
+		return Comment{}, fmt.Errorf("repo.updatecomment: %w id: %d", err, id)
+	}
+
+	// The edit clears the decision of a moderator, which no longer applies to the comment.
+	learn(b.classifiers, c, edited)
+
+	return edited, nil
+}
+
+func (b *business) DeleteComment(ctx context.Context, postID, id uint64) error {
+	if err := authbus.Authorize(ctx, authbus.ModerateComments, nil); err != nil {
+		return err
+	}
+
+	if err := b.repo.DeleteComment(ctx, postID, id); err != nil {
+		return fmt.Errorf("repo.deletecomment: %w id: %d", err, id)
+	}
+
+	return nil
+}
+
+func (b *business) CommentQueue(ctx context.Context, status Status, page Page) (CommentPage, error) {
+	if err := authbus.Authorize(ctx, authbus.ModerateComments, nil); err != nil {
+		return CommentPage{}, err
+	}
+
+	if !status.Valid() {
+		return CommentPage{}, fmt.Errorf("%w: %q", ErrInvalidStatus, status)
+	}
+
+	page, err := validPage(page)
+	if err != nil {
+		return CommentPage{}, err
+	}
+
+	cp, err := b.repo.CommentQueue(ctx, status, page)
+	if err != nil {
+		return CommentPage{}, fmt.Errorf("repo.commentqueue: %w status: %s", err, status)
+	}
+
+	return cp, nil
+}
+
+func (b *business) ModerateComments(ctx context.Context, ids []uint64, status Status) ([]Comment, error) {
+	if err := authbus.Authorize(ctx, authbus.ModerateComments, nil); err != nil {
+		return nil, err
+	}
+
+	ids = slices.Compact(slices.Sorted(slices.Values(ids)))
+	if len(ids) == 0 || len(ids) > MaxLimit {
+		return nil, ErrInvalidModeration
+	}
+
+	m := Moderation{Status: status, At: time.Now()}
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+
+	before, err := b.repo.ModerateComments(ctx, ids, m)
+	if err != nil {
+		return nil, fmt.Errorf("repo.moderatecomments: %w ids: %v", err, ids)
+	}
+
+	cs := make([]Comment, len(before))
+	for i, c := range before {
+		cs[i] = m.Apply(c)
+		learn(b.classifiers, c, cs[i])
+	}
+
+	return cs, nil
+}
+
+// readable returns nil when the principal of ctx may read the post with id and so its comments: anyone
+// may read a published post, only its author or an editor one that is not, see authbus.ReadPost.
+// Anyone else gets ErrPostNotFound, as if the post did not exist.
+func (b *business) readable(ctx context.Context, id uint64) error {
+	post, err := b.repo.Post(ctx, id)
+	if err != nil {
+		return fmt.Errorf("repo.post: %w id: %d", err, id)
+	}
+
+	if post.Published {
+		return nil
+	}
+
+	p, ok := authbus.PrincipalFrom(ctx)
+	if !ok || p.Can(authbus.ReadPost, func() (string, error) { return post.Owner, nil }) != nil {
+		return fmt.Errorf("%w id: %d", ErrPostNotFound, id)
+	}
+
+	return nil
+}
+
+// validPage returns page with its default limit, or ErrInvalidLimit when its limit is out of range.
+func validPage(page Page) (Page, error) {
+	if page.Limit == 0 {
+		page.Limit = DefaultLimit
+	}
+
+	if page.Limit < 0 || page.Limit > MaxLimit {
+		return Page{}, ErrInvalidLimit
+	}
+
+	return page, nil
+}
@@ -0,0 +1,387 @@
+package commentbus_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/anazcodes/blogapp/internal/business/authbus"
+	"github.com/anazcodes/blogapp/internal/business/commentbus"
+	mockcommentbus "github.com/anazcodes/blogapp/internal/mock/business/commentbus"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewComment(t *testing.T) {
+	testCases := []struct {
+		name        string
+		input       commentbus.AddComment
+		expectedErr error
+	}{
+		{
+			name:  "Comment",
+			input: commentbus.AddComment{Author: " Ann ", Body: " Nice post "},
+		},
+		{
+			name:  "Reply",
+			input: commentbus.AddComment{ParentID: 1, Author: "Ann", Body: "Nice post"},
+		},
+		{
+			name:        "Empty Author",
+			input:       commentbus.AddComment{Author: "  ", Body: "Nice post"},
+			expectedErr: commentbus.ErrInvalidComment,
+		},
+		{
+			name:        "Empty Body",
+			input:       commentbus.AddComment{Author: "Ann"},
+			expectedErr: commentbus.ErrInvalidComment,
+		},
+		{
+			name:        "Long Body",
+			input:       commentbus.AddComment{Author: "Ann", Body: strings.Repeat("a", commentbus.MaxBodyLength+1)},
+			expectedErr: commentbus.ErrInvalidComment,
+		},
+	}
+
+	at := time.Now()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := commentbus.NewComment(1, "ann", tc.input, at)
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+			if tc.expectedErr == nil {
+				assert.Equal(t, "Ann", c.Author)
+				assert.Equal(t, "ann", c.Commenter)
+				assert.Equal(t, "Nice post", c.Body)
+				assert.Equal(t, tc.input.ParentID, c.ParentID)
+				assert.Equal(t, at, c.CreatedAt)
+			}
+		})
+	}
+}
+
+func TestUpdateComment(t *testing.T) {
+	edited := func(repo *mockcommentbus.MockRepo) {
+		repo.EXPECT().UpdateComment(gomock.Any(), uint64(1), uint64(2), commentbus.UpdateComment{Body: "Edited"}, commentbus.Verdict{}, gomock.Any()).
+			Return(commentbus.Comment{ID: 2, PostID: 1, Body: "Edited"}, nil)
+	}
+
+	testCases := []struct {
+		name        string
+		ctx         context.Context
+		created     time.Time
+		setupExpect func(repo *mockcommentbus.MockRepo)
+		expectedErr error
+	}{
+		{
+			name:        "Within Window",
+			ctx:         commenting(t, "ann"),
+			created:     time.Now().Add(-time.Minute),
+			setupExpect: edited,
+		},
+		{
+			name:        "Window Closed",
+			ctx:         commenting(t, "ann"),
+			created:     time.Now().Add(-time.Hour),
+			setupExpect: func(repo *mockcommentbus.MockRepo) {},
+			expectedErr: commentbus.ErrEditWindowClosed,
+		},
+		{
+			name:        "Another Commenter",
+			ctx:         commenting(t, "bob"),
+			created:     time.Now().Add(-time.Minute),
+			setupExpect: func(repo *mockcommentbus.MockRepo) {},
+			expectedErr: authbus.ErrNotOwner,
+		},
+		{
+			name:        "Anonymous",
+			ctx:         t.Context(),
+			created:     time.Now().Add(-time.Minute),
+			setupExpect: func(repo *mockcommentbus.MockRepo) {},
+			expectedErr: authbus.ErrUnauthenticated,
+		},
+		{
+			name:        "Moderator",
+			ctx:         editing(t),
+			created:     time.Now().Add(-time.Minute),
+			setupExpect: edited,
+		},
+		{
+			name:        "Moderator After Window",
+			ctx:         editing(t),
+			created:     time.Now().Add(-time.Hour),
+			setupExpect: edited,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mockcommentbus.NewMockRepo(ctrl)
+			published(repo)
+			repo.EXPECT().Comment(gomock.Any(), uint64(1), uint64(2)).
+				Return(commentbus.Comment{ID: 2, PostID: 1, Author: "Ann", Commenter: "ann", Body: "Body", CreatedAt: tc.created}, nil)
+			tc.setupExpect(repo)
+			bus := commentbus.NewBusiness(repo, commentbus.DefaultEditWindow)
+
+			_, err := bus.UpdateComment(tc.ctx, 1, 2, commentbus.UpdateComment{Body: "Edited"})
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestComments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mockcommentbus.NewMockRepo(ctrl)
+	published(repo)
+	repo.EXPECT().Comments(gomock.Any(), uint64(1), uint64(0), commentbus.Page{Limit: commentbus.DefaultLimit}).
+		Return(commentbus.CommentPage{Comments: []commentbus.Comment{{ID: 2, PostID: 1}}}, nil)
+
+	bus := commentbus.NewBusiness(repo, commentbus.DefaultEditWindow)
+
+	cp, err := bus.Comments(editing(t), 1, 0, commentbus.Page{})
+	assert.Nil(t, err)
+	assert.Len(t, cp.Comments, 1)
+
+	_, err = bus.Comments(editing(t), 1, 0, commentbus.Page{Limit: commentbus.MaxLimit + 1})
+	assert.ErrorIs(t, err, commentbus.ErrInvalidLimit)
+}
+
+// learner records the decisions it learns.
+type learner struct {
+	mu      sync.Mutex
+	learned map[uint64]bool
+}
+
+func (l *learner) Classify(c commentbus.Comment) commentbus.Verdict {
+	return commentbus.Verdict{}
+}
+
+func (l *learner) Learn(c commentbus.Comment, spam bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.learned[c.ID] = spam
+}
+
+func (l *learner) Forget(c commentbus.Comment, spam bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.learned, c.ID)
+}
+
+func TestAddCommentModeration(t *testing.T) {
+	testCases := []struct {
+		name           string
+		body           string
+		expectedStatus commentbus.Status
+		expectedFlag   string
+	}{
+		{
+			name:           "Pending",
+			body:           "Nice post, see https://example.com",
+			expectedStatus: commentbus.StatusPending,
+		},
+		{
+			name:           "Spam",
+			body:           "https://a.example http://b.example www.c.example",
+			expectedStatus: commentbus.StatusRejected,
+			expectedFlag:   "holds 3 links, more than 1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mockcommentbus.NewMockRepo(ctrl)
+			published(repo)
+			repo.EXPECT().AddComment(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ any, c commentbus.Comment) (commentbus.Comment, error) {
+					c.ID = 1
+					return c, nil
+				})
+
+			bus := commentbus.NewBusiness(repo, commentbus.DefaultEditWindow, commentbus.LinkLimit(1))
+
+			c, err := bus.AddComment(editing(t), 1, commentbus.AddComment{Author: "Ann", Body: tc.body})
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedStatus, c.Status)
+			assert.Equal(t, tc.expectedFlag, c.Flag)
+		})
+	}
+}
+
+func TestAddCommentOutOfScope(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	bus := commentbus.NewBusiness(mockcommentbus.NewMockRepo(ctrl), commentbus.DefaultEditWindow)
+
+	ctx := authbus.WithPrincipal(t.Context(), authbus.Principal{
+		Subject: "ann", Role: authbus.RoleReader, Key: "k", Scopes: []authbus.Scope{authbus.ScopePostsRead},
+	})
+
+	_, err := bus.AddComment(ctx, 1, commentbus.AddComment{Author: "Ann", Body: "Body"})
+	assert.ErrorIs(t, err, authbus.ErrOutOfScope)
+}
+
+func TestCommentNotApproved(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mockcommentbus.NewMockRepo(ctrl)
+	published(repo)
+	repo.EXPECT().Comment(gomock.Any(), uint64(1), uint64(2)).
+		Return(commentbus.Comment{ID: 2, PostID: 1, Status: commentbus.StatusPending}, nil)
+
+	bus := commentbus.NewBusiness(repo, commentbus.DefaultEditWindow)
+
+	_, err := bus.Comment(editing(t), 1, 2)
+	assert.ErrorIs(t, err, commentbus.ErrCommentNotFound)
+}
+
+func TestModerateComments(t *testing.T) {
+	testCases := []struct {
+		name            string
+		ids             []uint64
+		status          commentbus.Status
+		setupExpect     func(repo *mockcommentbus.MockRepo)
+		expectedErr     error
+		expectedLearned map[uint64]bool
+	}{
+		{
+			name:   "Rejected",
+			ids:    []uint64{3, 2, 3},
+			status: commentbus.StatusRejected,
+			setupExpect: func(repo *mockcommentbus.MockRepo) {
+				repo.EXPECT().ModerateComments(gomock.Any(), []uint64{2, 3}, gomock.Any()).Return([]commentbus.Comment{
+					{ID: 2, Status: commentbus.StatusPending},
+					{ID: 3, Status: commentbus.StatusApproved, ModeratedAt: time.Now()},
+				}, nil)
+			},
+			expectedLearned: map[uint64]bool{2: true, 3: true},
+		},
+		{
+			name:            "Pending Is No Decision",
+			ids:             []uint64{2},
+			status:          commentbus.StatusPending,
+			setupExpect:     func(repo *mockcommentbus.MockRepo) {},
+			expectedErr:     commentbus.ErrInvalidStatus,
+			expectedLearned: map[uint64]bool{},
+		},
+		{
+			name:            "No Comments",
+			status:          commentbus.StatusApproved,
+			setupExpect:     func(repo *mockcommentbus.MockRepo) {},
+			expectedErr:     commentbus.ErrInvalidModeration,
+			expectedLearned: map[uint64]bool{},
+		},
+		{
+			name:   "Unknown Comment",
+			ids:    []uint64{9},
+			status: commentbus.StatusApproved,
+			setupExpect: func(repo *mockcommentbus.MockRepo) {
+				repo.EXPECT().ModerateComments(gomock.Any(), []uint64{9}, gomock.Any()).Return(nil, commentbus.ErrCommentNotFound)
+			},
+			expectedErr:     commentbus.ErrCommentNotFound,
+			expectedLearned: map[uint64]bool{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mockcommentbus.NewMockRepo(ctrl)
+			tc.setupExpect(repo)
+
+			l := &learner{learned: map[uint64]bool{3: false}}
+			bus := commentbus.NewBusiness(repo, commentbus.DefaultEditWindow, l)
+
+			cs, err := bus.ModerateComments(editing(t), tc.ids, tc.status)
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+			if tc.expectedErr == nil {
+				for _, c := range cs {
+					assert.Equal(t, tc.status, c.Status)
+				}
+				assert.Equal(t, tc.expectedLearned, l.learned)
+			}
+		})
+	}
+}
+
+// editing returns the context of a request of an editor, who may moderate the comments.
+func TestDraftComments(t *testing.T) {
+	testCases := []struct {
+		name        string
+		ctx         func(t *testing.T) context.Context
+		expectedErr error
+	}{
+		{
+			name:        "Anonymous",
+			ctx:         func(t *testing.T) context.Context { return t.Context() },
+			expectedErr: commentbus.ErrPostNotFound,
+		},
+		{
+			name:        "Another Reader",
+			ctx:         func(t *testing.T) context.Context { return commenting(t, "bob") },
+			expectedErr: commentbus.ErrPostNotFound,
+		},
+		{
+			name: "Author",
+			ctx: func(t *testing.T) context.Context {
+				return authbus.WithPrincipal(t.Context(), authbus.Principal{Subject: "ann", Role: authbus.RoleAuthor})
+			},
+		},
+		{
+			name: "Editor",
+			ctx:  editing,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mockcommentbus.NewMockRepo(ctrl)
+			repo.EXPECT().Post(gomock.Any(), uint64(1)).Return(commentbus.Post{ID: 1, Owner: "ann"}, nil)
+			if tc.expectedErr == nil {
+				repo.EXPECT().Comments(gomock.Any(), uint64(1), uint64(0), gomock.Any()).Return(commentbus.CommentPage{}, nil)
+			}
+
+			bus := commentbus.NewBusiness(repo, commentbus.DefaultEditWindow)
+
+			_, err := bus.Comments(tc.ctx(t), 1, 0, commentbus.Page{})
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func editing(t *testing.T) context.Context {
+	return authbus.WithPrincipal(t.Context(), authbus.Principal{Subject: "editor", Role: authbus.RoleEditor})
+}
+
+// published expects the comments to be on post 1, which is published.
+func published(repo *mockcommentbus.MockRepo) {
+	repo.EXPECT().Post(gomock.Any(), uint64(1)).Return(commentbus.Post{ID: 1, Published: true}, nil).AnyTimes()
+}
+
+// commenting returns the context of a request of the reader with username, who may edit their own
+// comments.
+func commenting(t *testing.T, username string) context.Context {
+	return authbus.WithPrincipal(t.Context(), authbus.Principal{Subject: username, Role: authbus.RoleReader})
+}
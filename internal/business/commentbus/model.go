@@ -0,0 +1,124 @@
+package commentbus
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	MaxAuthorLength = 100   // Longest name a commenter may sign with, in characters.
+	MaxBodyLength   = 10000 // Longest comment, in characters.
+)
+
+// Comment is a message of a reader on a post, either on the post itself or in reply to another comment
+// on the post.
+type Comment struct {
+	ID          uint64
+	PostID      uint64
+	ParentID    uint64 // Comment replied to, zero for a comment on the post.
+	Author      string // Name the commenter signs with.
+	Commenter   string // Username of the user who posted the comment, empty for comments posted before users.
+	Body        string
+	Status      Status
+	Flag        string    // Reason a classifier took the comment for spam, empty when none did.
+	ModeratedAt time.Time // Time a moderator decided on the comment, zero until one does.
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	Replies     int // Number of approved direct replies, counted when the comment is read.
+}
+
+// Moderated reports whether a moderator decided on c since it was last posted or edited.
+func (c Comment) Moderated() bool {
+	return !c.ModeratedAt.IsZero()
+}
+
+// Editable reports whether c may still be edited at at, which is until window after it was posted.
+func (c Comment) Editable(at time.Time, window time.Duration) bool {
+	return at.Before(c.CreatedAt.Add(window))
+}
+
+// Post is what the comments need to know of the post they are on.
+type Post struct {
+	ID        uint64
+	Published bool
+	Owner     string // Username of the user who wrote the post, empty when unknown.
+}
+
+type AddComment struct {
+	ParentID uint64 // Comment replied to, zero comments on the post.
+	Author   string
+	Body     string
+}
+
+type UpdateComment struct {
+	Body string
+}
+
+// NewComment returns the comment ac of commenter adds to the post with postID at at, without the ID the
+// repository issues it and before it is sent to moderation.
+func NewComment(postID uint64, commenter string, ac AddComment, at time.Time) (Comment, error) {
+	c := Comment{
+		PostID:    postID,
+		ParentID:  ac.ParentID,
+		Author:    strings.TrimSpace(ac.Author),
+		Commenter: commenter,
+		Body:      strings.TrimSpace(ac.Body),
+		CreatedAt: at,
+		UpdatedAt: at,
+	}
+
+	if err := c.Validate(); err != nil {
+		return Comment{}, err
+	}
+
+	return c, nil
+}
+
+// Apply returns c edited by uc at at.
+func (uc UpdateComment) Apply(c Comment, at time.Time) (Comment, error) {
+	c.Body = strings.TrimSpace(uc.Body)
+
+	if err := c.Validate(); err != nil {
+		return Comment{}, err
+	}
+
+	c.UpdatedAt = at
+
+	return c, nil
+}
+
+// Validate returns ErrInvalidComment unless c is signed and has a body, both within their length.
+func (c Comment) Validate() error {
+	switch {
+	case c.Author == "":
+		return fmt.Errorf("%w: author is empty", ErrInvalidComment)
+	case utf8.RuneCountInString(c.Author) > MaxAuthorLength:
+		return fmt.Errorf("%w: author is longer than %d characters", ErrInvalidComment, MaxAuthorLength)
+	case c.Body == "":
+		return fmt.Errorf("%w: body is empty", ErrInvalidComment)
+	case utf8.RuneCountInString(c.Body) > MaxBodyLength:
+		return fmt.Errorf("%w: body is longer than %d characters", ErrInvalidComment, MaxBodyLength)
+	}
+
+	return nil
+}
+
+const (
+	DefaultLimit = 20  // Page size used when a page does not set one.
+	MaxLimit     = 100 // Largest page size a page may set.
+)
+
+// Page selects a page of a listing.
+type Page struct {
+	Limit  int    // Maximum number of comments, zero selects DefaultLimit.
+	Cursor string // Opaque position returned with the previous page, empty selects the first page.
+}
+
+// CommentPage is a page of comments, oldest first.
+type CommentPage struct {
+	Comments   []Comment
+	NextCursor string // Selects the following page, empty on the last page.
+	HasMore    bool
+}
@@ -0,0 +1,137 @@
+package commentbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrInvalidStatus     = errors.New("invalid comment status")
+	ErrInvalidModeration = fmt.Errorf("moderation must name between 1 and %d comments", MaxLimit)
+)
+
+// Status is the stage of the moderation of a comment. Comments are held pending until a moderator
+// approves them, only approved comments are public.
+type Status string
+
+const (
+	StatusPending  Status = "pending"
+	StatusApproved Status = "approved"
+	StatusRejected Status = "rejected"
+)
+
+// Valid reports whether s is a known status.
+func (s Status) Valid() bool {
+	switch s {
+	case StatusPending, StatusApproved, StatusRejected:
+		return true
+	}
+
+	return false
+}
+
+// Moderation is the decision of a moderator on comments.
+type Moderation struct {
+	Status Status // StatusApproved or StatusRejected.
+	At     time.Time
+}
+
+// Validate returns ErrInvalidStatus unless m approves or rejects.
+func (m Moderation) Validate() error {
+	if m.Status != StatusApproved && m.Status != StatusRejected {
+		return fmt.Errorf("%w: moderators approve or reject, not %q", ErrInvalidStatus, m.Status)
+	}
+
+	return nil
+}
+
+// Apply returns c decided by m. The flag a classifier raised is kept for the moderators to review.
+func (m Moderation) Apply(c Comment) Comment {
+	c.Status = m.Status
+	c.ModeratedAt = m.At
+
+	return c
+}
+
+// Verdict is what the classifiers make of a comment.
+type Verdict struct {
+	Spam   bool
+	Reason string // Why the comment is spam, shown to moderators.
+}
+
+// Apply returns c sent to moderation with v: rejected and flagged with its reason when v is spam,
+// pending otherwise. A decision of a moderator on c is cleared.
+func (v Verdict) Apply(c Comment) Comment {
+	c.Status = StatusPending
+	c.Flag = ""
+	c.ModeratedAt = time.Time{}
+
+	if v.Spam {
+		c.Status = StatusRejected
+		c.Flag = v.Reason
+	}
+
+	return c
+}
+
+// Classifier tells spam from the comments readers post. It must be safe for concurrent use.
+type Classifier interface {
+	Classify(c Comment) Verdict
+}
+
+// Learner is a Classifier that learns from the decisions of moderators.
+type Learner interface {
+	Classifier
+	// Learn adds c to the examples of spam, or of legitimate comments when spam is false.
+	Learn(c Comment, spam bool)
+	// Forget removes c from the examples it was learned as, when a moderator decision on it is
+	// reversed or cleared.
+	Forget(c Comment, spam bool)
+}
+
+// classify returns the verdict of the first classifier that takes c for spam.
+func classify(classifiers []Classifier, c Comment) Verdict {
+	for _, cl := range classifiers {
+		if v := cl.Classify(c); v.Spam {
+			return v
+		}
+	}
+
+	return Verdict{}
+}
+
+// learn has every Learner of classifiers forget the decision of a moderator on before, the comment as
+// it was, and learn the one on after.
+func learn(classifiers []Classifier, before, after Comment) {
+	for _, cl := range classifiers {
+		l, ok := cl.(Learner)
+		if !ok {
+			continue
+		}
+
+		if before.Moderated() {
+			l.Forget(before, before.Status == StatusRejected)
+		}
+
+		if after.Moderated() {
+			l.Learn(after, after.Status == StatusRejected)
+		}
+	}
+}
+
+// Train has l learn every decision moderators made on the comments of repo, as a Learner keeps what it
+// learns in memory only.
+func Train(ctx context.Context, repo Repo, l Learner) error {
+	cs, err := repo.ModeratedComments(ctx)
+	if err != nil {
+		return fmt.Errorf("repo.moderatedcomments: %w", err)
+	}
+
+	for _, c := range cs {
+		l.Learn(c, c.Status == StatusRejected)
+	}
+
+	return nil
+}
@@ -3,8 +3,12 @@ package blogapperr
 import (
 	"net/http"
 
+	"github.com/anazcodes/blogapp/internal/business/authbus"
+	"github.com/anazcodes/blogapp/internal/business/blogbus"
+	"github.com/anazcodes/blogapp/internal/business/commentbus"
 	"github.com/anazcodes/blogapp/internal/errs"
 	"github.com/anazcodes/blogapp/internal/repository/blogrepo/cache"
+	"github.com/anazcodes/blogapp/internal/repository/blogrepo/snapshot"
 	"github.com/anazcodes/blogapp/pkg/request"
 )
 
@@ -19,6 +23,286 @@ var responses = map[error]request.Response{
 		Error:   cache.ErrItemNotFound.Error(),
 		Message: "Referenced resource does not found in the system",
 	},
+	cache.ErrItemConflict: {
+		Status:  http.StatusConflict,
+		Error:   cache.ErrItemConflict.Error(),
+		Message: "Failed to save, resource conflicts with an existing one",
+	},
+	blogbus.ErrInvalidLimit: {
+		Status:  http.StatusBadRequest,
+		Error:   blogbus.ErrInvalidLimit.Error(),
+		Message: "Failed to list, the limit is out of range",
+	},
+	blogbus.ErrInvalidCursor: {
+		Status:  http.StatusBadRequest,
+		Error:   blogbus.ErrInvalidCursor.Error(),
+		Message: "Failed to list, the cursor is invalid",
+	},
+	blogbus.ErrInvalidQuery: {
+		Status:  http.StatusBadRequest,
+		Error:   blogbus.ErrInvalidQuery.Error(),
+		Message: "Failed to list, the query is invalid",
+	},
+	blogbus.ErrEmptySearch: {
+		Status:  http.StatusBadRequest,
+		Error:   blogbus.ErrEmptySearch.Error(),
+		Message: "Failed to search, the query is empty",
+	},
+	blogbus.ErrInvalidTransition: {
+		Status:  http.StatusConflict,
+		Error:   blogbus.ErrInvalidTransition.Error(),
+		Message: "Failed to change the status, the post is not in a status it can change from",
+	},
+	blogbus.ErrInvalidSchedule: {
+		Status:  http.StatusBadRequest,
+		Error:   blogbus.ErrInvalidSchedule.Error(),
+		Message: "Failed to schedule, the publish time is not in the future",
+	},
+	blogbus.ErrBlogPostNotFound: {
+		Status:  http.StatusNotFound,
+		Error:   blogbus.ErrBlogPostNotFound.Error(),
+		Message: "Referenced resource does not found in the system",
+	},
+	commentbus.ErrPostNotFound: {
+		Status:  http.StatusNotFound,
+		Error:   commentbus.ErrPostNotFound.Error(),
+		Message: "Referenced resource does not found in the system",
+	},
+	blogbus.ErrRevisionNotFound: {
+		Status:  http.StatusNotFound,
+		Error:   blogbus.ErrRevisionNotFound.Error(),
+		Message: "Referenced revision does not exist for the Blog Post",
+	},
+	blogbus.ErrTrashed: {
+		Status:  http.StatusNotFound,
+		Error:   blogbus.ErrTrashed.Error(),
+		Message: "Referenced resource is in the trash",
+	},
+	blogbus.ErrNotTrashed: {
+		Status:  http.StatusNotFound,
+		Error:   blogbus.ErrNotTrashed.Error(),
+		Message: "Referenced resource is not in the trash",
+	},
+	blogbus.ErrInvalidSlug: {
+		Status:  http.StatusBadRequest,
+		Error:   blogbus.ErrInvalidSlug.Error(),
+		Message: "Failed to save, the slug must be lower-case letters and digits in words joined by hyphens",
+	},
+	blogbus.ErrSlugTaken: {
+		Status:  http.StatusConflict,
+		Error:   blogbus.ErrSlugTaken.Error(),
+		Message: "Failed to save, the slug is taken by another post",
+	},
+	blogbus.ErrQuotaExceeded: {
+		Status:  http.StatusUnprocessableEntity,
+		Error:   blogbus.ErrQuotaExceeded.Error(),
+		Message: "Failed to save, the blog holds as many posts as its quota allows",
+	},
+	blogbus.ErrTagNotFound: {
+		Status:  http.StatusNotFound,
+		Error:   blogbus.ErrTagNotFound.Error(),
+		Message: "Referenced tag does not exist",
+	},
+	blogbus.ErrCategoryNotFound: {
+		Status:  http.StatusNotFound,
+		Error:   blogbus.ErrCategoryNotFound.Error(),
+		Message: "Referenced category does not exist",
+	},
+	blogbus.ErrTagExists: {
+		Status:  http.StatusConflict,
+		Error:   blogbus.ErrTagExists.Error(),
+		Message: "Failed to save, the tag already exists",
+	},
+	blogbus.ErrCategoryExists: {
+		Status:  http.StatusConflict,
+		Error:   blogbus.ErrCategoryExists.Error(),
+		Message: "Failed to save, the category already exists",
+	},
+	blogbus.ErrInvalidTerm: {
+		Status:  http.StatusBadRequest,
+		Error:   blogbus.ErrInvalidTerm.Error(),
+		Message: "Failed to save, the name is empty",
+	},
+	blogbus.ErrUnknownTerm: {
+		Status:  http.StatusBadRequest,
+		Error:   blogbus.ErrUnknownTerm.Error(),
+		Message: "Failed to save, a referenced tag or category does not exist",
+	},
+	blogbus.ErrTermInUse: {
+		Status:  http.StatusConflict,
+		Error:   blogbus.ErrTermInUse.Error(),
+		Message: "Failed to delete, Blog Posts or subcategories still refer to it",
+	},
+	blogbus.ErrCategoryCycle: {
+		Status:  http.StatusBadRequest,
+		Error:   blogbus.ErrCategoryCycle.Error(),
+		Message: "Failed to save, a category cannot be moved under itself",
+	},
+	blogbus.ErrAuthorNotFound: {
+		Status:  http.StatusNotFound,
+		Error:   blogbus.ErrAuthorNotFound.Error(),
+		Message: "Referenced author does not exist",
+	},
+	blogbus.ErrInvalidAuthor: {
+		Status:  http.StatusBadRequest,
+		Error:   blogbus.ErrInvalidAuthor.Error(),
+		Message: "Failed to save, the name is empty or the email or avatar URL is malformed",
+	},
+	blogbus.ErrUnknownAuthor: {
+		Status:  http.StatusBadRequest,
+		Error:   blogbus.ErrUnknownAuthor.Error(),
+		Message: "Failed to save, the referenced author does not exist",
+	},
+	blogbus.ErrAuthorInUse: {
+		Status:  http.StatusConflict,
+		Error:   blogbus.ErrAuthorInUse.Error(),
+		Message: "Failed to delete, the author still has Blog Posts, reassign them to another author",
+	},
+	blogbus.ErrInvalidReassign: {
+		Status:  http.StatusBadRequest,
+		Error:   blogbus.ErrInvalidReassign.Error(),
+		Message: "Failed to delete, the posts cannot be reassigned to the author being deleted",
+	},
+	blogbus.ErrInvalidReaction: {
+		Status:  http.StatusBadRequest,
+		Error:   blogbus.ErrInvalidReaction.Error(),
+		Message: "Failed to react, the reader is empty or too long or the reaction is unknown",
+	},
+	authbus.ErrUnauthenticated: {
+		Status:  http.StatusUnauthorized,
+		Error:   authbus.ErrUnauthenticated.Error(),
+		Message: "Failed to authenticate, the token is missing, invalid or expired",
+	},
+	authbus.ErrInvalidCredentials: {
+		Status:  http.StatusUnauthorized,
+		Error:   authbus.ErrInvalidCredentials.Error(),
+		Message: "Failed to log in, the username or password is wrong",
+	},
+	authbus.ErrForbidden: {
+		Status:  http.StatusForbidden,
+		Error:   authbus.ErrForbidden.Error(),
+		Message: "Forbidden, the role of the user does not allow this",
+	},
+	authbus.ErrNotOwner: {
+		Status:  http.StatusForbidden,
+		Error:   authbus.ErrNotOwner.Error(),
+		Message: "Forbidden, authors can only change their own posts",
+	},
+	authbus.ErrOwnRole: {
+		Status:  http.StatusForbidden,
+		Error:   authbus.ErrOwnRole.Error(),
+		Message: "Forbidden, admins cannot change their own role",
+	},
+	authbus.ErrInvalidRole: {
+		Status:  http.StatusBadRequest,
+		Error:   authbus.ErrInvalidRole.Error(),
+		Message: "Failed to set the role, want reader, author, editor or admin",
+	},
+	authbus.ErrUserNotFound: {
+		Status:  http.StatusNotFound,
+		Error:   authbus.ErrUserNotFound.Error(),
+		Message: "Referenced user does not exist",
+	},
+	authbus.ErrAccountLocked: {
+		Status:  http.StatusLocked,
+		Error:   authbus.ErrAccountLocked.Error(),
+		Message: "Failed to log in, the account is locked after repeated failed logins",
+	},
+	authbus.ErrBusy: {
+		Status:  http.StatusServiceUnavailable,
+		Error:   authbus.ErrBusy.Error(),
+		Message: "Failed to process your request, too many passwords are being checked, try again later",
+	},
+	authbus.ErrInvalidUsername: {
+		Status:  http.StatusBadRequest,
+		Error:   authbus.ErrInvalidUsername.Error(),
+		Message: "Failed to register, the username is invalid",
+	},
+	authbus.ErrInvalidPassword: {
+		Status:  http.StatusBadRequest,
+		Error:   authbus.ErrInvalidPassword.Error(),
+		Message: "Failed to register, the password is too short or too long",
+	},
+	authbus.ErrUsernameTaken: {
+		Status:  http.StatusConflict,
+		Error:   authbus.ErrUsernameTaken.Error(),
+		Message: "Failed to register, the username is taken",
+	},
+	authbus.ErrOutOfScope: {
+		Status:  http.StatusForbidden,
+		Error:   authbus.ErrOutOfScope.Error(),
+		Message: "Forbidden, the API key is not scoped for this",
+	},
+	authbus.ErrInvalidAPIKey: {
+		Status:  http.StatusBadRequest,
+		Error:   authbus.ErrInvalidAPIKey.Error(),
+		Message: "Failed to create the API key, want a name of 1 to 64 characters and known scopes",
+	},
+	authbus.ErrAPIKeyNotFound: {
+		Status:  http.StatusNotFound,
+		Error:   authbus.ErrAPIKeyNotFound.Error(),
+		Message: "Referenced API key does not exist",
+	},
+	authbus.ErrAPIKeyRevoked: {
+		Status:  http.StatusConflict,
+		Error:   authbus.ErrAPIKeyRevoked.Error(),
+		Message: "The API key is revoked",
+	},
+	commentbus.ErrCommentNotFound: {
+		Status:  http.StatusNotFound,
+		Error:   commentbus.ErrCommentNotFound.Error(),
+		Message: "Referenced comment does not exist",
+	},
+	commentbus.ErrInvalidComment: {
+		Status:  http.StatusBadRequest,
+		Error:   commentbus.ErrInvalidComment.Error(),
+		Message: "Failed to save, the author or body is empty or too long",
+	},
+	commentbus.ErrUnknownParent: {
+		Status:  http.StatusBadRequest,
+		Error:   commentbus.ErrUnknownParent.Error(),
+		Message: "Failed to save, the comment replied to is not on the Blog Post",
+	},
+	commentbus.ErrEditWindowClosed: {
+		Status:  http.StatusForbidden,
+		Error:   commentbus.ErrEditWindowClosed.Error(),
+		Message: "Failed to save, the edit window of the comment has passed",
+	},
+	commentbus.ErrInvalidLimit: {
+		Status:  http.StatusBadRequest,
+		Error:   commentbus.ErrInvalidLimit.Error(),
+		Message: "Failed to list, the limit is out of range",
+	},
+	commentbus.ErrInvalidCursor: {
+		Status:  http.StatusBadRequest,
+		Error:   commentbus.ErrInvalidCursor.Error(),
+		Message: "Failed to list, the cursor is invalid",
+	},
+	commentbus.ErrInvalidStatus: {
+		Status:  http.StatusBadRequest,
+		Error:   commentbus.ErrInvalidStatus.Error(),
+		Message: "Failed to moderate, the comment status is unknown or not a decision",
+	},
+	commentbus.ErrInvalidModeration: {
+		Status:  http.StatusBadRequest,
+		Error:   commentbus.ErrInvalidModeration.Error(),
+		Message: "Failed to moderate, too few or too many comments",
+	},
+	snapshot.ErrBadMagic: {
+		Status:  http.StatusBadRequest,
+		Error:   snapshot.ErrBadMagic.Error(),
+		Message: "Failed to restore, the file is not a snapshot",
+	},
+	snapshot.ErrUnsupportedVersion: {
+		Status:  http.StatusBadRequest,
+		Error:   snapshot.ErrUnsupportedVersion.Error(),
+		Message: "Failed to restore, the snapshot version is not supported",
+	},
+	snapshot.ErrChecksum: {
+		Status:  http.StatusBadRequest,
+		Error:   snapshot.ErrChecksum.Error(),
+		Message: "Failed to restore, the snapshot is corrupted",
+	},
 }
 
 // Response maps an error to a request.Response.
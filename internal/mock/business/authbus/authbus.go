@@ -0,0 +1,412 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/business/authbus/authbus.go
+
+// Package mockauthbus is a generated GoMock package.
+package mockauthbus
+
+import (
+	context "context"
+	reflect "reflect"
+
+	authbus "github.com/anazcodes/blogapp/internal/business/authbus"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepo is a mock of Repo interface.
+type MockRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepoMockRecorder
+}
+
+// MockRepoMockRecorder is the mock recorder for MockRepo.
+type MockRepoMockRecorder struct {
+	mock *MockRepo
+}
+
+// NewMockRepo creates a new mock instance.
+func NewMockRepo(ctrl *gomock.Controller) *MockRepo {
+	mock := &MockRepo{ctrl: ctrl}
+	mock.recorder = &MockRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepo) EXPECT() *MockRepoMockRecorder {
+	return m.recorder
+}
+
+// APIKey mocks base method.
+func (m *MockRepo) APIKey(ctx context.Context, id string) (authbus.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "APIKey", ctx, id)
+	ret0, _ := ret[0].(authbus.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// APIKey indicates an expected call of APIKey.
+func (mr *MockRepoMockRecorder) APIKey(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "APIKey", reflect.TypeOf((*MockRepo)(nil).APIKey), ctx, id)
+}
+
+// APIKeys mocks base method.
+func (m *MockRepo) APIKeys(ctx context.Context, owner string) ([]authbus.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "APIKeys", ctx, owner)
+	ret0, _ := ret[0].([]authbus.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// APIKeys indicates an expected call of APIKeys.
+func (mr *MockRepoMockRecorder) APIKeys(ctx, owner interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "APIKeys", reflect.TypeOf((*MockRepo)(nil).APIKeys), ctx, owner)
+}
+
+// AddAPIKey mocks base method.
+func (m *MockRepo) AddAPIKey(ctx context.Context, k authbus.APIKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAPIKey", ctx, k)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddAPIKey indicates an expected call of AddAPIKey.
+func (mr *MockRepoMockRecorder) AddAPIKey(ctx, k interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAPIKey", reflect.TypeOf((*MockRepo)(nil).AddAPIKey), ctx, k)
+}
+
+// AddSession mocks base method.
+func (m *MockRepo) AddSession(ctx context.Context, s authbus.Session) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSession", ctx, s)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddSession indicates an expected call of AddSession.
+func (mr *MockRepoMockRecorder) AddSession(ctx, s interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSession", reflect.TypeOf((*MockRepo)(nil).AddSession), ctx, s)
+}
+
+// AddUser mocks base method.
+func (m *MockRepo) AddUser(ctx context.Context, u authbus.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddUser", ctx, u)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddUser indicates an expected call of AddUser.
+func (mr *MockRepoMockRecorder) AddUser(ctx, u interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUser", reflect.TypeOf((*MockRepo)(nil).AddUser), ctx, u)
+}
+
+// DeleteSession mocks base method.
+func (m *MockRepo) DeleteSession(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSession", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSession indicates an expected call of DeleteSession.
+func (mr *MockRepoMockRecorder) DeleteSession(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSession", reflect.TypeOf((*MockRepo)(nil).DeleteSession), ctx, id)
+}
+
+// DeleteSessions mocks base method.
+func (m *MockRepo) DeleteSessions(ctx context.Context, username, keep string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSessions", ctx, username, keep)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSessions indicates an expected call of DeleteSessions.
+func (mr *MockRepoMockRecorder) DeleteSessions(ctx, username, keep interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSessions", reflect.TypeOf((*MockRepo)(nil).DeleteSessions), ctx, username, keep)
+}
+
+// Session mocks base method.
+func (m *MockRepo) Session(ctx context.Context, id string) (authbus.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Session", ctx, id)
+	ret0, _ := ret[0].(authbus.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Session indicates an expected call of Session.
+func (mr *MockRepoMockRecorder) Session(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Session", reflect.TypeOf((*MockRepo)(nil).Session), ctx, id)
+}
+
+// UpdateAPIKey mocks base method.
+func (m *MockRepo) UpdateAPIKey(ctx context.Context, id string, ku authbus.APIKeyUpdate) (authbus.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAPIKey", ctx, id, ku)
+	ret0, _ := ret[0].(authbus.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAPIKey indicates an expected call of UpdateAPIKey.
+func (mr *MockRepoMockRecorder) UpdateAPIKey(ctx, id, ku interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAPIKey", reflect.TypeOf((*MockRepo)(nil).UpdateAPIKey), ctx, id, ku)
+}
+
+// UpdateUser mocks base method.
+func (m *MockRepo) UpdateUser(ctx context.Context, username string, uu authbus.UserUpdate) (authbus.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUser", ctx, username, uu)
+	ret0, _ := ret[0].(authbus.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUser indicates an expected call of UpdateUser.
+func (mr *MockRepoMockRecorder) UpdateUser(ctx, username, uu interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockRepo)(nil).UpdateUser), ctx, username, uu)
+}
+
+// User mocks base method.
+func (m *MockRepo) User(ctx context.Context, username string) (authbus.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "User", ctx, username)
+	ret0, _ := ret[0].(authbus.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// User indicates an expected call of User.
+func (mr *MockRepoMockRecorder) User(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "User", reflect.TypeOf((*MockRepo)(nil).User), ctx, username)
+}
+
+// Users mocks base method.
+func (m *MockRepo) Users(ctx context.Context) ([]authbus.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Users", ctx)
+	ret0, _ := ret[0].([]authbus.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Users indicates an expected call of Users.
+func (mr *MockRepoMockRecorder) Users(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Users", reflect.TypeOf((*MockRepo)(nil).Users), ctx)
+}
+
+// MockBusiness is a mock of Business interface.
+type MockBusiness struct {
+	ctrl     *gomock.Controller
+	recorder *MockBusinessMockRecorder
+}
+
+// MockBusinessMockRecorder is the mock recorder for MockBusiness.
+type MockBusinessMockRecorder struct {
+	mock *MockBusiness
+}
+
+// NewMockBusiness creates a new mock instance.
+func NewMockBusiness(ctrl *gomock.Controller) *MockBusiness {
+	mock := &MockBusiness{ctrl: ctrl}
+	mock.recorder = &MockBusinessMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBusiness) EXPECT() *MockBusinessMockRecorder {
+	return m.recorder
+}
+
+// APIKeys mocks base method.
+func (m *MockBusiness) APIKeys(ctx context.Context) ([]authbus.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "APIKeys", ctx)
+	ret0, _ := ret[0].([]authbus.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// APIKeys indicates an expected call of APIKeys.
+func (mr *MockBusinessMockRecorder) APIKeys(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "APIKeys", reflect.TypeOf((*MockBusiness)(nil).APIKeys), ctx)
+}
+
+// Authenticate mocks base method.
+func (m *MockBusiness) Authenticate(ctx context.Context, token string) (authbus.Principal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authenticate", ctx, token)
+	ret0, _ := ret[0].(authbus.Principal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authenticate indicates an expected call of Authenticate.
+func (mr *MockBusinessMockRecorder) Authenticate(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockBusiness)(nil).Authenticate), ctx, token)
+}
+
+// AuthenticateAPIKey mocks base method.
+func (m *MockBusiness) AuthenticateAPIKey(ctx context.Context, key string) (authbus.Principal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthenticateAPIKey", ctx, key)
+	ret0, _ := ret[0].(authbus.Principal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthenticateAPIKey indicates an expected call of AuthenticateAPIKey.
+func (mr *MockBusinessMockRecorder) AuthenticateAPIKey(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthenticateAPIKey", reflect.TypeOf((*MockBusiness)(nil).AuthenticateAPIKey), ctx, key)
+}
+
+// ChangePassword mocks base method.
+func (m *MockBusiness) ChangePassword(ctx context.Context, cp authbus.ChangePassword) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", ctx, cp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockBusinessMockRecorder) ChangePassword(ctx, cp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockBusiness)(nil).ChangePassword), ctx, cp)
+}
+
+// CreateAPIKey mocks base method.
+func (m *MockBusiness) CreateAPIKey(ctx context.Context, ck authbus.CreateAPIKey) (authbus.IssuedAPIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", ctx, ck)
+	ret0, _ := ret[0].(authbus.IssuedAPIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockBusinessMockRecorder) CreateAPIKey(ctx, ck interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockBusiness)(nil).CreateAPIKey), ctx, ck)
+}
+
+// Login mocks base method.
+func (m *MockBusiness) Login(ctx context.Context, l authbus.Login) (authbus.Token, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Login", ctx, l)
+	ret0, _ := ret[0].(authbus.Token)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Login indicates an expected call of Login.
+func (mr *MockBusinessMockRecorder) Login(ctx, l interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockBusiness)(nil).Login), ctx, l)
+}
+
+// Logout mocks base method.
+func (m *MockBusiness) Logout(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockBusinessMockRecorder) Logout(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockBusiness)(nil).Logout), ctx)
+}
+
+// Register mocks base method.
+func (m *MockBusiness) Register(ctx context.Context, r authbus.Register) (authbus.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Register", ctx, r)
+	ret0, _ := ret[0].(authbus.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Register indicates an expected call of Register.
+func (mr *MockBusinessMockRecorder) Register(ctx, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockBusiness)(nil).Register), ctx, r)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockBusiness) RevokeAPIKey(ctx context.Context, id string) (authbus.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", ctx, id)
+	ret0, _ := ret[0].(authbus.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockBusinessMockRecorder) RevokeAPIKey(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockBusiness)(nil).RevokeAPIKey), ctx, id)
+}
+
+// RotateAPIKey mocks base method.
+func (m *MockBusiness) RotateAPIKey(ctx context.Context, id string) (authbus.IssuedAPIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateAPIKey", ctx, id)
+	ret0, _ := ret[0].(authbus.IssuedAPIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateAPIKey indicates an expected call of RotateAPIKey.
+func (mr *MockBusinessMockRecorder) RotateAPIKey(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateAPIKey", reflect.TypeOf((*MockBusiness)(nil).RotateAPIKey), ctx, id)
+}
+
+// SetRole mocks base method.
+func (m *MockBusiness) SetRole(ctx context.Context, username string, role authbus.Role) (authbus.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRole", ctx, username, role)
+	ret0, _ := ret[0].(authbus.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetRole indicates an expected call of SetRole.
+func (mr *MockBusinessMockRecorder) SetRole(ctx, username, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRole", reflect.TypeOf((*MockBusiness)(nil).SetRole), ctx, username, role)
+}
+
+// Users mocks base method.
+func (m *MockBusiness) Users(ctx context.Context) ([]authbus.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Users", ctx)
+	ret0, _ := ret[0].([]authbus.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Users indicates an expected call of Users.
+func (mr *MockBusinessMockRecorder) Users(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Users", reflect.TypeOf((*MockBusiness)(nil).Users), ctx)
+}
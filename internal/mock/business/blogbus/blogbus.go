@@ -6,7 +6,9 @@ package mockblogbus
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
+	time "time"
 
 	blogbus "github.com/anazcodes/blogapp/internal/business/blogbus"
 	gomock "github.com/golang/mock/gomock"
@@ -35,6 +37,21 @@ func (m *MockRepo) EXPECT() *MockRepoMockRecorder {
 	return m.recorder
 }
 
+// AddAuthor mocks base method.
+func (m *MockRepo) AddAuthor(ctx context.Context, a blogbus.Author) (blogbus.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAuthor", ctx, a)
+	ret0, _ := ret[0].(blogbus.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddAuthor indicates an expected call of AddAuthor.
+func (mr *MockRepoMockRecorder) AddAuthor(ctx, a interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAuthor", reflect.TypeOf((*MockRepo)(nil).AddAuthor), ctx, a)
+}
+
 // AddBlogPost mocks base method.
 func (m *MockRepo) AddBlogPost(ctx context.Context, abp blogbus.AddBlogPost) (uint64, error) {
 	m.ctrl.T.Helper()
@@ -50,6 +67,64 @@ func (mr *MockRepoMockRecorder) AddBlogPost(ctx, abp interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBlogPost", reflect.TypeOf((*MockRepo)(nil).AddBlogPost), ctx, abp)
 }
 
+// AddCategory mocks base method.
+func (m *MockRepo) AddCategory(ctx context.Context, c blogbus.Category) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCategory", ctx, c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddCategory indicates an expected call of AddCategory.
+func (mr *MockRepoMockRecorder) AddCategory(ctx, c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCategory", reflect.TypeOf((*MockRepo)(nil).AddCategory), ctx, c)
+}
+
+// AddTag mocks base method.
+func (m *MockRepo) AddTag(ctx context.Context, t blogbus.Tag) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTag", ctx, t)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTag indicates an expected call of AddTag.
+func (mr *MockRepoMockRecorder) AddTag(ctx, t interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTag", reflect.TypeOf((*MockRepo)(nil).AddTag), ctx, t)
+}
+
+// Author mocks base method.
+func (m *MockRepo) Author(ctx context.Context, id uint64) (blogbus.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Author", ctx, id)
+	ret0, _ := ret[0].(blogbus.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Author indicates an expected call of Author.
+func (mr *MockRepoMockRecorder) Author(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Author", reflect.TypeOf((*MockRepo)(nil).Author), ctx, id)
+}
+
+// Authors mocks base method.
+func (m *MockRepo) Authors(ctx context.Context) ([]blogbus.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authors", ctx)
+	ret0, _ := ret[0].([]blogbus.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authors indicates an expected call of Authors.
+func (mr *MockRepoMockRecorder) Authors(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authors", reflect.TypeOf((*MockRepo)(nil).Authors), ctx)
+}
+
 // BlogPost mocks base method.
 func (m *MockRepo) BlogPost(ctx context.Context, id uint64) (blogbus.BlogPost, error) {
 	m.ctrl.T.Helper()
@@ -65,33 +140,316 @@ func (mr *MockRepoMockRecorder) BlogPost(ctx, id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlogPost", reflect.TypeOf((*MockRepo)(nil).BlogPost), ctx, id)
 }
 
+// BlogPostBySlug mocks base method.
+func (m *MockRepo) BlogPostBySlug(ctx context.Context, slug string) (blogbus.BlogPost, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlogPostBySlug", ctx, slug)
+	ret0, _ := ret[0].(blogbus.BlogPost)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BlogPostBySlug indicates an expected call of BlogPostBySlug.
+func (mr *MockRepoMockRecorder) BlogPostBySlug(ctx, slug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlogPostBySlug", reflect.TypeOf((*MockRepo)(nil).BlogPostBySlug), ctx, slug)
+}
+
 // BlogPosts mocks base method.
-func (m *MockRepo) BlogPosts(ctx context.Context) []blogbus.BlogPost {
+func (m *MockRepo) BlogPosts(ctx context.Context, q blogbus.Query, page blogbus.Page) (blogbus.BlogPostPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "BlogPosts", ctx)
-	ret0, _ := ret[0].([]blogbus.BlogPost)
-	return ret0
+	ret := m.ctrl.Call(m, "BlogPosts", ctx, q, page)
+	ret0, _ := ret[0].(blogbus.BlogPostPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // BlogPosts indicates an expected call of BlogPosts.
-func (mr *MockRepoMockRecorder) BlogPosts(ctx interface{}) *gomock.Call {
+func (mr *MockRepoMockRecorder) BlogPosts(ctx, q, page interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlogPosts", reflect.TypeOf((*MockRepo)(nil).BlogPosts), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlogPosts", reflect.TypeOf((*MockRepo)(nil).BlogPosts), ctx, q, page)
 }
 
-// DeleteBlogPost mocks base method.
-func (m *MockRepo) DeleteBlogPost(ctx context.Context, id uint64) (uint64, error) {
+// CacheStats mocks base method.
+func (m *MockRepo) CacheStats(ctx context.Context) (blogbus.CacheStats, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteBlogPost", ctx, id)
+	ret := m.ctrl.Call(m, "CacheStats", ctx)
+	ret0, _ := ret[0].(blogbus.CacheStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CacheStats indicates an expected call of CacheStats.
+func (mr *MockRepoMockRecorder) CacheStats(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CacheStats", reflect.TypeOf((*MockRepo)(nil).CacheStats), ctx)
+}
+
+// Categories mocks base method.
+func (m *MockRepo) Categories(ctx context.Context) ([]blogbus.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Categories", ctx)
+	ret0, _ := ret[0].([]blogbus.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Categories indicates an expected call of Categories.
+func (mr *MockRepoMockRecorder) Categories(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Categories", reflect.TypeOf((*MockRepo)(nil).Categories), ctx)
+}
+
+// Category mocks base method.
+func (m *MockRepo) Category(ctx context.Context, slug string) (blogbus.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Category", ctx, slug)
+	ret0, _ := ret[0].(blogbus.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Category indicates an expected call of Category.
+func (mr *MockRepoMockRecorder) Category(ctx, slug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Category", reflect.TypeOf((*MockRepo)(nil).Category), ctx, slug)
+}
+
+// DeleteAuthor mocks base method.
+func (m *MockRepo) DeleteAuthor(ctx context.Context, id, reassignTo uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAuthor", ctx, id, reassignTo)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAuthor indicates an expected call of DeleteAuthor.
+func (mr *MockRepoMockRecorder) DeleteAuthor(ctx, id, reassignTo interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAuthor", reflect.TypeOf((*MockRepo)(nil).DeleteAuthor), ctx, id, reassignTo)
+}
+
+// DeleteCategory mocks base method.
+func (m *MockRepo) DeleteCategory(ctx context.Context, slug string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCategory", ctx, slug)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCategory indicates an expected call of DeleteCategory.
+func (mr *MockRepoMockRecorder) DeleteCategory(ctx, slug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockRepo)(nil).DeleteCategory), ctx, slug)
+}
+
+// DeleteTag mocks base method.
+func (m *MockRepo) DeleteTag(ctx context.Context, slug string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTag", ctx, slug)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTag indicates an expected call of DeleteTag.
+func (mr *MockRepoMockRecorder) DeleteTag(ctx, slug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTag", reflect.TypeOf((*MockRepo)(nil).DeleteTag), ctx, slug)
+}
+
+// PurgeBlogPost mocks base method.
+func (m *MockRepo) PurgeBlogPost(ctx context.Context, id uint64, by time.Time) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeBlogPost", ctx, id, by)
 	ret0, _ := ret[0].(uint64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// DeleteBlogPost indicates an expected call of DeleteBlogPost.
-func (mr *MockRepoMockRecorder) DeleteBlogPost(ctx, id interface{}) *gomock.Call {
+// PurgeBlogPost indicates an expected call of PurgeBlogPost.
+func (mr *MockRepoMockRecorder) PurgeBlogPost(ctx, id, by interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeBlogPost", reflect.TypeOf((*MockRepo)(nil).PurgeBlogPost), ctx, id, by)
+}
+
+// React mocks base method.
+func (m *MockRepo) React(ctx context.Context, rr blogbus.ReaderReaction) (blogbus.ReactionCounts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "React", ctx, rr)
+	ret0, _ := ret[0].(blogbus.ReactionCounts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// React indicates an expected call of React.
+func (mr *MockRepoMockRecorder) React(ctx, rr interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "React", reflect.TypeOf((*MockRepo)(nil).React), ctx, rr)
+}
+
+// Restore mocks base method.
+func (m *MockRepo) Restore(ctx context.Context, r io.Reader) (blogbus.SnapshotInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, r)
+	ret0, _ := ret[0].(blogbus.SnapshotInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockRepoMockRecorder) Restore(ctx, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockRepo)(nil).Restore), ctx, r)
+}
+
+// RestoreBlogPost mocks base method.
+func (m *MockRepo) RestoreBlogPost(ctx context.Context, id uint64) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreBlogPost", ctx, id)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreBlogPost indicates an expected call of RestoreBlogPost.
+func (mr *MockRepoMockRecorder) RestoreBlogPost(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreBlogPost", reflect.TypeOf((*MockRepo)(nil).RestoreBlogPost), ctx, id)
+}
+
+// SearchBlogPosts mocks base method.
+func (m *MockRepo) SearchBlogPosts(ctx context.Context, q string, page blogbus.Page) (blogbus.SearchPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchBlogPosts", ctx, q, page)
+	ret0, _ := ret[0].(blogbus.SearchPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchBlogPosts indicates an expected call of SearchBlogPosts.
+func (mr *MockRepoMockRecorder) SearchBlogPosts(ctx, q, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchBlogPosts", reflect.TypeOf((*MockRepo)(nil).SearchBlogPosts), ctx, q, page)
+}
+
+// Snapshot mocks base method.
+func (m *MockRepo) Snapshot(ctx context.Context, w io.Writer) (blogbus.SnapshotInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Snapshot", ctx, w)
+	ret0, _ := ret[0].(blogbus.SnapshotInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Snapshot indicates an expected call of Snapshot.
+func (mr *MockRepoMockRecorder) Snapshot(ctx, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Snapshot", reflect.TypeOf((*MockRepo)(nil).Snapshot), ctx, w)
+}
+
+// Tag mocks base method.
+func (m *MockRepo) Tag(ctx context.Context, slug string) (blogbus.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Tag", ctx, slug)
+	ret0, _ := ret[0].(blogbus.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Tag indicates an expected call of Tag.
+func (mr *MockRepoMockRecorder) Tag(ctx, slug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Tag", reflect.TypeOf((*MockRepo)(nil).Tag), ctx, slug)
+}
+
+// TagCloud mocks base method.
+func (m *MockRepo) TagCloud(ctx context.Context) ([]blogbus.TagCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TagCloud", ctx)
+	ret0, _ := ret[0].([]blogbus.TagCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TagCloud indicates an expected call of TagCloud.
+func (mr *MockRepoMockRecorder) TagCloud(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TagCloud", reflect.TypeOf((*MockRepo)(nil).TagCloud), ctx)
+}
+
+// Tags mocks base method.
+func (m *MockRepo) Tags(ctx context.Context) ([]blogbus.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Tags", ctx)
+	ret0, _ := ret[0].([]blogbus.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Tags indicates an expected call of Tags.
+func (mr *MockRepoMockRecorder) Tags(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Tags", reflect.TypeOf((*MockRepo)(nil).Tags), ctx)
+}
+
+// TransitionBlogPost mocks base method.
+func (m *MockRepo) TransitionBlogPost(ctx context.Context, id uint64, t blogbus.Transition) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransitionBlogPost", ctx, id, t)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransitionBlogPost indicates an expected call of TransitionBlogPost.
+func (mr *MockRepoMockRecorder) TransitionBlogPost(ctx, id, t interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransitionBlogPost", reflect.TypeOf((*MockRepo)(nil).TransitionBlogPost), ctx, id, t)
+}
+
+// TrashBlogPost mocks base method.
+func (m *MockRepo) TrashBlogPost(ctx context.Context, id uint64, at time.Time) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TrashBlogPost", ctx, id, at)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TrashBlogPost indicates an expected call of TrashBlogPost.
+func (mr *MockRepoMockRecorder) TrashBlogPost(ctx, id, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrashBlogPost", reflect.TypeOf((*MockRepo)(nil).TrashBlogPost), ctx, id, at)
+}
+
+// Unreact mocks base method.
+func (m *MockRepo) Unreact(ctx context.Context, postID uint64, reader string) (blogbus.ReactionCounts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unreact", ctx, postID, reader)
+	ret0, _ := ret[0].(blogbus.ReactionCounts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Unreact indicates an expected call of Unreact.
+func (mr *MockRepoMockRecorder) Unreact(ctx, postID, reader interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBlogPost", reflect.TypeOf((*MockRepo)(nil).DeleteBlogPost), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unreact", reflect.TypeOf((*MockRepo)(nil).Unreact), ctx, postID, reader)
+}
+
+// UpdateAuthor mocks base method.
+func (m *MockRepo) UpdateAuthor(ctx context.Context, id uint64, ua blogbus.UpdateAuthor) (blogbus.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAuthor", ctx, id, ua)
+	ret0, _ := ret[0].(blogbus.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAuthor indicates an expected call of UpdateAuthor.
+func (mr *MockRepoMockRecorder) UpdateAuthor(ctx, id, ua interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAuthor", reflect.TypeOf((*MockRepo)(nil).UpdateAuthor), ctx, id, ua)
 }
 
 // UpdateBlogPost mocks base method.
@@ -109,6 +467,36 @@ func (mr *MockRepoMockRecorder) UpdateBlogPost(ctx, id, ubp interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBlogPost", reflect.TypeOf((*MockRepo)(nil).UpdateBlogPost), ctx, id, ubp)
 }
 
+// UpdateCategory mocks base method.
+func (m *MockRepo) UpdateCategory(ctx context.Context, slug string, uc blogbus.UpdateCategory) (blogbus.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCategory", ctx, slug, uc)
+	ret0, _ := ret[0].(blogbus.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCategory indicates an expected call of UpdateCategory.
+func (mr *MockRepoMockRecorder) UpdateCategory(ctx, slug, uc interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockRepo)(nil).UpdateCategory), ctx, slug, uc)
+}
+
+// UpdateTag mocks base method.
+func (m *MockRepo) UpdateTag(ctx context.Context, slug string, ut blogbus.UpdateTag) (blogbus.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTag", ctx, slug, ut)
+	ret0, _ := ret[0].(blogbus.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTag indicates an expected call of UpdateTag.
+func (mr *MockRepoMockRecorder) UpdateTag(ctx, slug, ut interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTag", reflect.TypeOf((*MockRepo)(nil).UpdateTag), ctx, slug, ut)
+}
+
 // MockBusiness is a mock of Business interface.
 type MockBusiness struct {
 	ctrl     *gomock.Controller
@@ -132,6 +520,21 @@ func (m *MockBusiness) EXPECT() *MockBusinessMockRecorder {
 	return m.recorder
 }
 
+// AddAuthor mocks base method.
+func (m *MockBusiness) AddAuthor(ctx context.Context, aa blogbus.AddAuthor) (blogbus.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAuthor", ctx, aa)
+	ret0, _ := ret[0].(blogbus.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddAuthor indicates an expected call of AddAuthor.
+func (mr *MockBusinessMockRecorder) AddAuthor(ctx, aa interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAuthor", reflect.TypeOf((*MockBusiness)(nil).AddAuthor), ctx, aa)
+}
+
 // AddBlogPost mocks base method.
 func (m *MockBusiness) AddBlogPost(ctx context.Context, abp blogbus.AddBlogPost) (blogbus.ID, error) {
 	m.ctrl.T.Helper()
@@ -147,6 +550,96 @@ func (mr *MockBusinessMockRecorder) AddBlogPost(ctx, abp interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBlogPost", reflect.TypeOf((*MockBusiness)(nil).AddBlogPost), ctx, abp)
 }
 
+// AddCategory mocks base method.
+func (m *MockBusiness) AddCategory(ctx context.Context, ac blogbus.AddCategory) (blogbus.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCategory", ctx, ac)
+	ret0, _ := ret[0].(blogbus.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddCategory indicates an expected call of AddCategory.
+func (mr *MockBusinessMockRecorder) AddCategory(ctx, ac interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCategory", reflect.TypeOf((*MockBusiness)(nil).AddCategory), ctx, ac)
+}
+
+// AddTag mocks base method.
+func (m *MockBusiness) AddTag(ctx context.Context, atg blogbus.AddTag) (blogbus.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTag", ctx, atg)
+	ret0, _ := ret[0].(blogbus.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddTag indicates an expected call of AddTag.
+func (mr *MockBusinessMockRecorder) AddTag(ctx, atg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTag", reflect.TypeOf((*MockBusiness)(nil).AddTag), ctx, atg)
+}
+
+// ArchiveBlogPost mocks base method.
+func (m *MockBusiness) ArchiveBlogPost(ctx context.Context, id uint64) (blogbus.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveBlogPost", ctx, id)
+	ret0, _ := ret[0].(blogbus.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ArchiveBlogPost indicates an expected call of ArchiveBlogPost.
+func (mr *MockBusinessMockRecorder) ArchiveBlogPost(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveBlogPost", reflect.TypeOf((*MockBusiness)(nil).ArchiveBlogPost), ctx, id)
+}
+
+// Author mocks base method.
+func (m *MockBusiness) Author(ctx context.Context, id uint64) (blogbus.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Author", ctx, id)
+	ret0, _ := ret[0].(blogbus.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Author indicates an expected call of Author.
+func (mr *MockBusinessMockRecorder) Author(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Author", reflect.TypeOf((*MockBusiness)(nil).Author), ctx, id)
+}
+
+// AuthorBlogPosts mocks base method.
+func (m *MockBusiness) AuthorBlogPosts(ctx context.Context, id uint64, q blogbus.Query, page blogbus.Page) (blogbus.BlogPostPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthorBlogPosts", ctx, id, q, page)
+	ret0, _ := ret[0].(blogbus.BlogPostPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthorBlogPosts indicates an expected call of AuthorBlogPosts.
+func (mr *MockBusinessMockRecorder) AuthorBlogPosts(ctx, id, q, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorBlogPosts", reflect.TypeOf((*MockBusiness)(nil).AuthorBlogPosts), ctx, id, q, page)
+}
+
+// Authors mocks base method.
+func (m *MockBusiness) Authors(ctx context.Context) ([]blogbus.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authors", ctx)
+	ret0, _ := ret[0].([]blogbus.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authors indicates an expected call of Authors.
+func (mr *MockBusinessMockRecorder) Authors(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authors", reflect.TypeOf((*MockBusiness)(nil).Authors), ctx)
+}
+
 // BlogPost mocks base method.
 func (m *MockBusiness) BlogPost(ctx context.Context, id uint64) (blogbus.BlogPost, error) {
 	m.ctrl.T.Helper()
@@ -162,18 +655,124 @@ func (mr *MockBusinessMockRecorder) BlogPost(ctx, id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlogPost", reflect.TypeOf((*MockBusiness)(nil).BlogPost), ctx, id)
 }
 
+// BlogPostBySlug mocks base method.
+func (m *MockBusiness) BlogPostBySlug(ctx context.Context, slug string) (blogbus.BlogPost, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlogPostBySlug", ctx, slug)
+	ret0, _ := ret[0].(blogbus.BlogPost)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BlogPostBySlug indicates an expected call of BlogPostBySlug.
+func (mr *MockBusinessMockRecorder) BlogPostBySlug(ctx, slug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlogPostBySlug", reflect.TypeOf((*MockBusiness)(nil).BlogPostBySlug), ctx, slug)
+}
+
+// BlogPostRevision mocks base method.
+func (m *MockBusiness) BlogPostRevision(ctx context.Context, id, number uint64) (blogbus.Revision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlogPostRevision", ctx, id, number)
+	ret0, _ := ret[0].(blogbus.Revision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BlogPostRevision indicates an expected call of BlogPostRevision.
+func (mr *MockBusinessMockRecorder) BlogPostRevision(ctx, id, number interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlogPostRevision", reflect.TypeOf((*MockBusiness)(nil).BlogPostRevision), ctx, id, number)
+}
+
+// BlogPostRevisions mocks base method.
+func (m *MockBusiness) BlogPostRevisions(ctx context.Context, id uint64) ([]blogbus.Revision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlogPostRevisions", ctx, id)
+	ret0, _ := ret[0].([]blogbus.Revision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BlogPostRevisions indicates an expected call of BlogPostRevisions.
+func (mr *MockBusinessMockRecorder) BlogPostRevisions(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlogPostRevisions", reflect.TypeOf((*MockBusiness)(nil).BlogPostRevisions), ctx, id)
+}
+
 // BlogPosts mocks base method.
-func (m *MockBusiness) BlogPosts(ctx context.Context) []blogbus.BlogPost {
+func (m *MockBusiness) BlogPosts(ctx context.Context, q blogbus.Query, page blogbus.Page) (blogbus.BlogPostPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "BlogPosts", ctx)
-	ret0, _ := ret[0].([]blogbus.BlogPost)
-	return ret0
+	ret := m.ctrl.Call(m, "BlogPosts", ctx, q, page)
+	ret0, _ := ret[0].(blogbus.BlogPostPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // BlogPosts indicates an expected call of BlogPosts.
-func (mr *MockBusinessMockRecorder) BlogPosts(ctx interface{}) *gomock.Call {
+func (mr *MockBusinessMockRecorder) BlogPosts(ctx, q, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlogPosts", reflect.TypeOf((*MockBusiness)(nil).BlogPosts), ctx, q, page)
+}
+
+// CacheStats mocks base method.
+func (m *MockBusiness) CacheStats(ctx context.Context) (blogbus.CacheStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CacheStats", ctx)
+	ret0, _ := ret[0].(blogbus.CacheStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CacheStats indicates an expected call of CacheStats.
+func (mr *MockBusinessMockRecorder) CacheStats(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CacheStats", reflect.TypeOf((*MockBusiness)(nil).CacheStats), ctx)
+}
+
+// Categories mocks base method.
+func (m *MockBusiness) Categories(ctx context.Context) ([]blogbus.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Categories", ctx)
+	ret0, _ := ret[0].([]blogbus.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Categories indicates an expected call of Categories.
+func (mr *MockBusinessMockRecorder) Categories(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Categories", reflect.TypeOf((*MockBusiness)(nil).Categories), ctx)
+}
+
+// Category mocks base method.
+func (m *MockBusiness) Category(ctx context.Context, slug string) (blogbus.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Category", ctx, slug)
+	ret0, _ := ret[0].(blogbus.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Category indicates an expected call of Category.
+func (mr *MockBusinessMockRecorder) Category(ctx, slug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Category", reflect.TypeOf((*MockBusiness)(nil).Category), ctx, slug)
+}
+
+// DeleteAuthor mocks base method.
+func (m *MockBusiness) DeleteAuthor(ctx context.Context, id, reassignTo uint64) (blogbus.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAuthor", ctx, id, reassignTo)
+	ret0, _ := ret[0].(blogbus.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteAuthor indicates an expected call of DeleteAuthor.
+func (mr *MockBusinessMockRecorder) DeleteAuthor(ctx, id, reassignTo interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlogPosts", reflect.TypeOf((*MockBusiness)(nil).BlogPosts), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAuthor", reflect.TypeOf((*MockBusiness)(nil).DeleteAuthor), ctx, id, reassignTo)
 }
 
 // DeleteBlogPost mocks base method.
@@ -191,6 +790,289 @@ func (mr *MockBusinessMockRecorder) DeleteBlogPost(ctx, id interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBlogPost", reflect.TypeOf((*MockBusiness)(nil).DeleteBlogPost), ctx, id)
 }
 
+// DeleteCategory mocks base method.
+func (m *MockBusiness) DeleteCategory(ctx context.Context, slug string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCategory", ctx, slug)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCategory indicates an expected call of DeleteCategory.
+func (mr *MockBusinessMockRecorder) DeleteCategory(ctx, slug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockBusiness)(nil).DeleteCategory), ctx, slug)
+}
+
+// DeleteTag mocks base method.
+func (m *MockBusiness) DeleteTag(ctx context.Context, slug string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTag", ctx, slug)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTag indicates an expected call of DeleteTag.
+func (mr *MockBusinessMockRecorder) DeleteTag(ctx, slug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTag", reflect.TypeOf((*MockBusiness)(nil).DeleteTag), ctx, slug)
+}
+
+// DiffBlogPostRevisions mocks base method.
+func (m *MockBusiness) DiffBlogPostRevisions(ctx context.Context, id, from, to uint64) ([]blogbus.FieldDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffBlogPostRevisions", ctx, id, from, to)
+	ret0, _ := ret[0].([]blogbus.FieldDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffBlogPostRevisions indicates an expected call of DiffBlogPostRevisions.
+func (mr *MockBusinessMockRecorder) DiffBlogPostRevisions(ctx, id, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffBlogPostRevisions", reflect.TypeOf((*MockBusiness)(nil).DiffBlogPostRevisions), ctx, id, from, to)
+}
+
+// PublishBlogPost mocks base method.
+func (m *MockBusiness) PublishBlogPost(ctx context.Context, id uint64) (blogbus.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishBlogPost", ctx, id)
+	ret0, _ := ret[0].(blogbus.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PublishBlogPost indicates an expected call of PublishBlogPost.
+func (mr *MockBusinessMockRecorder) PublishBlogPost(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishBlogPost", reflect.TypeOf((*MockBusiness)(nil).PublishBlogPost), ctx, id)
+}
+
+// PurgeBlogPost mocks base method.
+func (m *MockBusiness) PurgeBlogPost(ctx context.Context, id uint64) (blogbus.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeBlogPost", ctx, id)
+	ret0, _ := ret[0].(blogbus.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeBlogPost indicates an expected call of PurgeBlogPost.
+func (mr *MockBusinessMockRecorder) PurgeBlogPost(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeBlogPost", reflect.TypeOf((*MockBusiness)(nil).PurgeBlogPost), ctx, id)
+}
+
+// React mocks base method.
+func (m *MockBusiness) React(ctx context.Context, postID uint64, ar blogbus.AddReaction) (blogbus.ReactionCounts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "React", ctx, postID, ar)
+	ret0, _ := ret[0].(blogbus.ReactionCounts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// React indicates an expected call of React.
+func (mr *MockBusinessMockRecorder) React(ctx, postID, ar interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "React", reflect.TypeOf((*MockBusiness)(nil).React), ctx, postID, ar)
+}
+
+// Restore mocks base method.
+func (m *MockBusiness) Restore(ctx context.Context, r io.Reader) (blogbus.SnapshotInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, r)
+	ret0, _ := ret[0].(blogbus.SnapshotInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockBusinessMockRecorder) Restore(ctx, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockBusiness)(nil).Restore), ctx, r)
+}
+
+// RestoreBlogPost mocks base method.
+func (m *MockBusiness) RestoreBlogPost(ctx context.Context, id uint64) (blogbus.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreBlogPost", ctx, id)
+	ret0, _ := ret[0].(blogbus.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreBlogPost indicates an expected call of RestoreBlogPost.
+func (mr *MockBusinessMockRecorder) RestoreBlogPost(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreBlogPost", reflect.TypeOf((*MockBusiness)(nil).RestoreBlogPost), ctx, id)
+}
+
+// RestoreBlogPostRevision mocks base method.
+func (m *MockBusiness) RestoreBlogPostRevision(ctx context.Context, id, number uint64) (blogbus.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreBlogPostRevision", ctx, id, number)
+	ret0, _ := ret[0].(blogbus.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreBlogPostRevision indicates an expected call of RestoreBlogPostRevision.
+func (mr *MockBusinessMockRecorder) RestoreBlogPostRevision(ctx, id, number interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreBlogPostRevision", reflect.TypeOf((*MockBusiness)(nil).RestoreBlogPostRevision), ctx, id, number)
+}
+
+// ScheduleBlogPost mocks base method.
+func (m *MockBusiness) ScheduleBlogPost(ctx context.Context, id uint64, at time.Time) (blogbus.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScheduleBlogPost", ctx, id, at)
+	ret0, _ := ret[0].(blogbus.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScheduleBlogPost indicates an expected call of ScheduleBlogPost.
+func (mr *MockBusinessMockRecorder) ScheduleBlogPost(ctx, id, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleBlogPost", reflect.TypeOf((*MockBusiness)(nil).ScheduleBlogPost), ctx, id, at)
+}
+
+// SearchBlogPosts mocks base method.
+func (m *MockBusiness) SearchBlogPosts(ctx context.Context, q string, page blogbus.Page) (blogbus.SearchPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchBlogPosts", ctx, q, page)
+	ret0, _ := ret[0].(blogbus.SearchPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchBlogPosts indicates an expected call of SearchBlogPosts.
+func (mr *MockBusinessMockRecorder) SearchBlogPosts(ctx, q, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchBlogPosts", reflect.TypeOf((*MockBusiness)(nil).SearchBlogPosts), ctx, q, page)
+}
+
+// Snapshot mocks base method.
+func (m *MockBusiness) Snapshot(ctx context.Context, w io.Writer) (blogbus.SnapshotInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Snapshot", ctx, w)
+	ret0, _ := ret[0].(blogbus.SnapshotInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Snapshot indicates an expected call of Snapshot.
+func (mr *MockBusinessMockRecorder) Snapshot(ctx, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Snapshot", reflect.TypeOf((*MockBusiness)(nil).Snapshot), ctx, w)
+}
+
+// Tag mocks base method.
+func (m *MockBusiness) Tag(ctx context.Context, slug string) (blogbus.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Tag", ctx, slug)
+	ret0, _ := ret[0].(blogbus.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Tag indicates an expected call of Tag.
+func (mr *MockBusinessMockRecorder) Tag(ctx, slug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Tag", reflect.TypeOf((*MockBusiness)(nil).Tag), ctx, slug)
+}
+
+// TagCloud mocks base method.
+func (m *MockBusiness) TagCloud(ctx context.Context) ([]blogbus.TagCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TagCloud", ctx)
+	ret0, _ := ret[0].([]blogbus.TagCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TagCloud indicates an expected call of TagCloud.
+func (mr *MockBusinessMockRecorder) TagCloud(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TagCloud", reflect.TypeOf((*MockBusiness)(nil).TagCloud), ctx)
+}
+
+// Tags mocks base method.
+func (m *MockBusiness) Tags(ctx context.Context) ([]blogbus.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Tags", ctx)
+	ret0, _ := ret[0].([]blogbus.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Tags indicates an expected call of Tags.
+func (mr *MockBusinessMockRecorder) Tags(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Tags", reflect.TypeOf((*MockBusiness)(nil).Tags), ctx)
+}
+
+// UnpublishBlogPost mocks base method.
+func (m *MockBusiness) UnpublishBlogPost(ctx context.Context, id uint64) (blogbus.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnpublishBlogPost", ctx, id)
+	ret0, _ := ret[0].(blogbus.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnpublishBlogPost indicates an expected call of UnpublishBlogPost.
+func (mr *MockBusinessMockRecorder) UnpublishBlogPost(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnpublishBlogPost", reflect.TypeOf((*MockBusiness)(nil).UnpublishBlogPost), ctx, id)
+}
+
+// Unreact mocks base method.
+func (m *MockBusiness) Unreact(ctx context.Context, postID uint64) (blogbus.ReactionCounts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unreact", ctx, postID)
+	ret0, _ := ret[0].(blogbus.ReactionCounts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Unreact indicates an expected call of Unreact.
+func (mr *MockBusinessMockRecorder) Unreact(ctx, postID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unreact", reflect.TypeOf((*MockBusiness)(nil).Unreact), ctx, postID)
+}
+
+// UnscheduleBlogPost mocks base method.
+func (m *MockBusiness) UnscheduleBlogPost(ctx context.Context, id uint64) (blogbus.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnscheduleBlogPost", ctx, id)
+	ret0, _ := ret[0].(blogbus.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnscheduleBlogPost indicates an expected call of UnscheduleBlogPost.
+func (mr *MockBusinessMockRecorder) UnscheduleBlogPost(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnscheduleBlogPost", reflect.TypeOf((*MockBusiness)(nil).UnscheduleBlogPost), ctx, id)
+}
+
+// UpdateAuthor mocks base method.
+func (m *MockBusiness) UpdateAuthor(ctx context.Context, id uint64, ua blogbus.UpdateAuthor) (blogbus.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAuthor", ctx, id, ua)
+	ret0, _ := ret[0].(blogbus.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAuthor indicates an expected call of UpdateAuthor.
+func (mr *MockBusinessMockRecorder) UpdateAuthor(ctx, id, ua interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAuthor", reflect.TypeOf((*MockBusiness)(nil).UpdateAuthor), ctx, id, ua)
+}
+
 // UpdateBlogPost mocks base method.
 func (m *MockBusiness) UpdateBlogPost(ctx context.Context, id uint64, ubp blogbus.UpdateBlogPost) (blogbus.ID, error) {
 	m.ctrl.T.Helper()
@@ -205,3 +1087,33 @@ func (mr *MockBusinessMockRecorder) UpdateBlogPost(ctx, id, ubp interface{}) *go
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBlogPost", reflect.TypeOf((*MockBusiness)(nil).UpdateBlogPost), ctx, id, ubp)
 }
+
+// UpdateCategory mocks base method.
+func (m *MockBusiness) UpdateCategory(ctx context.Context, slug string, uc blogbus.UpdateCategory) (blogbus.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCategory", ctx, slug, uc)
+	ret0, _ := ret[0].(blogbus.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCategory indicates an expected call of UpdateCategory.
+func (mr *MockBusinessMockRecorder) UpdateCategory(ctx, slug, uc interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockBusiness)(nil).UpdateCategory), ctx, slug, uc)
+}
+
+// UpdateTag mocks base method.
+func (m *MockBusiness) UpdateTag(ctx context.Context, slug string, ut blogbus.UpdateTag) (blogbus.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTag", ctx, slug, ut)
+	ret0, _ := ret[0].(blogbus.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTag indicates an expected call of UpdateTag.
+func (mr *MockBusinessMockRecorder) UpdateTag(ctx, slug, ut interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTag", reflect.TypeOf((*MockBusiness)(nil).UpdateTag), ctx, slug, ut)
+}
@@ -0,0 +1,298 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/business/commentbus/commentbus.go
+
+// Package mockcommentbus is a generated GoMock package.
+package mockcommentbus
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	commentbus "github.com/anazcodes/blogapp/internal/business/commentbus"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepo is a mock of Repo interface.
+type MockRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepoMockRecorder
+}
+
+// MockRepoMockRecorder is the mock recorder for MockRepo.
+type MockRepoMockRecorder struct {
+	mock *MockRepo
+}
+
+// NewMockRepo creates a new mock instance.
+func NewMockRepo(ctrl *gomock.Controller) *MockRepo {
+	mock := &MockRepo{ctrl: ctrl}
+	mock.recorder = &MockRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepo) EXPECT() *MockRepoMockRecorder {
+	return m.recorder
+}
+
+// AddComment mocks base method.
+func (m *MockRepo) AddComment(ctx context.Context, c commentbus.Comment) (commentbus.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddComment", ctx, c)
+	ret0, _ := ret[0].(commentbus.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddComment indicates an expected call of AddComment.
+func (mr *MockRepoMockRecorder) AddComment(ctx, c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddComment", reflect.TypeOf((*MockRepo)(nil).AddComment), ctx, c)
+}
+
+// Comment mocks base method.
+func (m *MockRepo) Comment(ctx context.Context, postID, id uint64) (commentbus.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Comment", ctx, postID, id)
+	ret0, _ := ret[0].(commentbus.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Comment indicates an expected call of Comment.
+func (mr *MockRepoMockRecorder) Comment(ctx, postID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Comment", reflect.TypeOf((*MockRepo)(nil).Comment), ctx, postID, id)
+}
+
+// CommentQueue mocks base method.
+func (m *MockRepo) CommentQueue(ctx context.Context, status commentbus.Status, page commentbus.Page) (commentbus.CommentPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommentQueue", ctx, status, page)
+	ret0, _ := ret[0].(commentbus.CommentPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CommentQueue indicates an expected call of CommentQueue.
+func (mr *MockRepoMockRecorder) CommentQueue(ctx, status, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommentQueue", reflect.TypeOf((*MockRepo)(nil).CommentQueue), ctx, status, page)
+}
+
+// Comments mocks base method.
+func (m *MockRepo) Comments(ctx context.Context, postID, parentID uint64, page commentbus.Page) (commentbus.CommentPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Comments", ctx, postID, parentID, page)
+	ret0, _ := ret[0].(commentbus.CommentPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Comments indicates an expected call of Comments.
+func (mr *MockRepoMockRecorder) Comments(ctx, postID, parentID, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Comments", reflect.TypeOf((*MockRepo)(nil).Comments), ctx, postID, parentID, page)
+}
+
+// DeleteComment mocks base method.
+func (m *MockRepo) DeleteComment(ctx context.Context, postID, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteComment", ctx, postID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteComment indicates an expected call of DeleteComment.
+func (mr *MockRepoMockRecorder) DeleteComment(ctx, postID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockRepo)(nil).DeleteComment), ctx, postID, id)
+}
+
+// ModerateComments mocks base method.
+func (m_2 *MockRepo) ModerateComments(ctx context.Context, ids []uint64, m commentbus.Moderation) ([]commentbus.Comment, error) {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "ModerateComments", ctx, ids, m)
+	ret0, _ := ret[0].([]commentbus.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ModerateComments indicates an expected call of ModerateComments.
+func (mr *MockRepoMockRecorder) ModerateComments(ctx, ids, m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModerateComments", reflect.TypeOf((*MockRepo)(nil).ModerateComments), ctx, ids, m)
+}
+
+// ModeratedComments mocks base method.
+func (m *MockRepo) ModeratedComments(ctx context.Context) ([]commentbus.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModeratedComments", ctx)
+	ret0, _ := ret[0].([]commentbus.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ModeratedComments indicates an expected call of ModeratedComments.
+func (mr *MockRepoMockRecorder) ModeratedComments(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModeratedComments", reflect.TypeOf((*MockRepo)(nil).ModeratedComments), ctx)
+}
+
+// Post mocks base method.
+func (m *MockRepo) Post(ctx context.Context, id uint64) (commentbus.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Post", ctx, id)
+	ret0, _ := ret[0].(commentbus.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Post indicates an expected call of Post.
+func (mr *MockRepoMockRecorder) Post(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Post", reflect.TypeOf((*MockRepo)(nil).Post), ctx, id)
+}
+
+// UpdateComment mocks base method.
+func (m *MockRepo) UpdateComment(ctx context.Context, postID, id uint64, uc commentbus.UpdateComment, v commentbus.Verdict, at time.Time) (commentbus.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateComment", ctx, postID, id, uc, v, at)
+	ret0, _ := ret[0].(commentbus.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateComment indicates an expected call of UpdateComment.
+func (mr *MockRepoMockRecorder) UpdateComment(ctx, postID, id, uc, v, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComment", reflect.TypeOf((*MockRepo)(nil).UpdateComment), ctx, postID, id, uc, v, at)
+}
+
+// MockBusiness is a mock of Business interface.
+type MockBusiness struct {
+	ctrl     *gomock.Controller
+	recorder *MockBusinessMockRecorder
+}
+
+// MockBusinessMockRecorder is the mock recorder for MockBusiness.
+type MockBusinessMockRecorder struct {
+	mock *MockBusiness
+}
+
+// NewMockBusiness creates a new mock instance.
+func NewMockBusiness(ctrl *gomock.Controller) *MockBusiness {
+	mock := &MockBusiness{ctrl: ctrl}
+	mock.recorder = &MockBusinessMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBusiness) EXPECT() *MockBusinessMockRecorder {
+	return m.recorder
+}
+
+// AddComment mocks base method.
+func (m *MockBusiness) AddComment(ctx context.Context, postID uint64, ac commentbus.AddComment) (commentbus.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddComment", ctx, postID, ac)
+	ret0, _ := ret[0].(commentbus.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddComment indicates an expected call of AddComment.
+func (mr *MockBusinessMockRecorder) AddComment(ctx, postID, ac interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddComment", reflect.TypeOf((*MockBusiness)(nil).AddComment), ctx, postID, ac)
+}
+
+// Comment mocks base method.
+func (m *MockBusiness) Comment(ctx context.Context, postID, id uint64) (commentbus.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Comment", ctx, postID, id)
+	ret0, _ := ret[0].(commentbus.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Comment indicates an expected call of Comment.
+func (mr *MockBusinessMockRecorder) Comment(ctx, postID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Comment", reflect.TypeOf((*MockBusiness)(nil).Comment), ctx, postID, id)
+}
+
+// CommentQueue mocks base method.
+func (m *MockBusiness) CommentQueue(ctx context.Context, status commentbus.Status, page commentbus.Page) (commentbus.CommentPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommentQueue", ctx, status, page)
+	ret0, _ := ret[0].(commentbus.CommentPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CommentQueue indicates an expected call of CommentQueue.
+func (mr *MockBusinessMockRecorder) CommentQueue(ctx, status, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommentQueue", reflect.TypeOf((*MockBusiness)(nil).CommentQueue), ctx, status, page)
+}
+
+// Comments mocks base method.
+func (m *MockBusiness) Comments(ctx context.Context, postID, parentID uint64, page commentbus.Page) (commentbus.CommentPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Comments", ctx, postID, parentID, page)
+	ret0, _ := ret[0].(commentbus.CommentPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Comments indicates an expected call of Comments.
+func (mr *MockBusinessMockRecorder) Comments(ctx, postID, parentID, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Comments", reflect.TypeOf((*MockBusiness)(nil).Comments), ctx, postID, parentID, page)
+}
+
+// DeleteComment mocks base method.
+func (m *MockBusiness) DeleteComment(ctx context.Context, postID, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteComment", ctx, postID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteComment indicates an expected call of DeleteComment.
+func (mr *MockBusinessMockRecorder) DeleteComment(ctx, postID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockBusiness)(nil).DeleteComment), ctx, postID, id)
+}
+
+// ModerateComments mocks base method.
+func (m *MockBusiness) ModerateComments(ctx context.Context, ids []uint64, status commentbus.Status) ([]commentbus.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModerateComments", ctx, ids, status)
+	ret0, _ := ret[0].([]commentbus.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ModerateComments indicates an expected call of ModerateComments.
+func (mr *MockBusinessMockRecorder) ModerateComments(ctx, ids, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModerateComments", reflect.TypeOf((*MockBusiness)(nil).ModerateComments), ctx, ids, status)
+}
+
+// UpdateComment mocks base method.
+func (m *MockBusiness) UpdateComment(ctx context.Context, postID, id uint64, uc commentbus.UpdateComment) (commentbus.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateComment", ctx, postID, id, uc)
+	ret0, _ := ret[0].(commentbus.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateComment indicates an expected call of UpdateComment.
+func (mr *MockBusinessMockRecorder) UpdateComment(ctx, postID, id, uc interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComment", reflect.TypeOf((*MockBusiness)(nil).UpdateComment), ctx, postID, id, uc)
+}
@@ -0,0 +1,58 @@
+package blogrepo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/anazcodes/blogapp/internal/business/authbus"
+)
+
+func (r *repo) AddAPIKey(ctx context.Context, k authbus.APIKey) error {
+	r.userWrites.Lock()
+	defer r.userWrites.Unlock()
+
+	if _, ok := r.accounts.user(k.Owner); !ok {
+		return fmt.Errorf("query: %w", authbus.ErrUserNotFound)
+	}
+
+	if err := r.persistUsers(func(s UserStore) error { return s.PutAPIKey(ctx, k) }); err != nil {
+		return fmt.Errorf("query: %w", err)
+	}
+
+	r.accounts.putKey(k)
+
+	return nil
+}
+
+func (r *repo) APIKey(ctx context.Context, id string) (authbus.APIKey, error) {
+	k, ok := r.accounts.key(id)
+	if !ok {
+		return authbus.APIKey{}, fmt.Errorf("query: %w", authbus.ErrAPIKeyNotFound)
+	}
+
+	return k, nil
+}
+
+func (r *repo) APIKeys(ctx context.Context, owner string) ([]authbus.APIKey, error) {
+	return r.accounts.keysOf(owner), nil
+}
+
+func (r *repo) UpdateAPIKey(ctx context.Context, id string, ku authbus.APIKeyUpdate) (authbus.APIKey, error) {
+	r.userWrites.Lock()
+	defer r.userWrites.Unlock()
+
+	k, ok := r.accounts.key(id)
+	if !ok {
+		return authbus.APIKey{}, fmt.Errorf("query: %w", authbus.ErrAPIKeyNotFound)
+	}
+
+	k = ku.Apply(k)
+
+	if err := r.persistUsers(func(s UserStore) error { return s.PutAPIKey(ctx, k) }); err != nil {
+		return authbus.APIKey{}, fmt.Errorf("query: %w", err)
+	}
+
+	r.accounts.putKey(k)
+
+	return k, nil
+}
@@ -0,0 +1,168 @@
+package blogrepo
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/anazcodes/blogapp/internal/business/blogbus"
+)
+
+// authors holds the authors and indexes the posts of every author, so an author is never deleted
+// while a post still links to it. Like terms it indexes the posts of the cache and the spill.
+type authors struct {
+	mu      sync.RWMutex
+	byID    map[uint64]blogbus.Author
+	serial  uint64                         // Highest author ID issued.
+	posts   map[uint64]uint64              // Author of every post that has one.
+	written map[uint64]map[uint64]struct{} // Posts of every author.
+}
+
+func newAuthors() *authors {
+	a := &authors{}
+	a.reset(nil, nil)
+
+	return a
+}
+
+// reset replaces every author with as, and the index with the authors of posts. The author IDs
+// continue after the highest ID of as.
+func (a *authors) reset(as []blogbus.Author, posts []blogbus.BlogPost) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.byID = make(map[uint64]blogbus.Author, len(as))
+	a.serial = 0
+
+	for _, author := range as {
+		a.byID[author.ID] = author
+		a.serial = max(a.serial, author.ID)
+	}
+
+	a.posts = make(map[uint64]uint64, len(posts))
+	a.written = make(map[uint64]map[uint64]struct{})
+
+	for _, bp := range posts {
+		a.add(bp.ID, bp.AuthorID)
+	}
+}
+
+// author returns the author with id.
+func (a *authors) author(id uint64) (blogbus.Author, bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	author, ok := a.byID[id]
+
+	return author, ok
+}
+
+// list returns every author ordered by ID.
+func (a *authors) list() []blogbus.Author {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	as := slices.Collect(maps.Values(a.byID))
+	slices.SortFunc(as, func(x, y blogbus.Author) int { return cmp.Compare(x.ID, y.ID) })
+
+	return as
+}
+
+// next issues the ID of a new author.
+func (a *authors) next() uint64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.serial++
+
+	return a.serial
+}
+
+func (a *authors) put(author blogbus.Author) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.byID[author.ID] = author
+}
+
+func (a *authors) delete(id uint64) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	delete(a.byID, id)
+}
+
+// known returns blogbus.ErrUnknownAuthor unless the author with id exists, a zero id is no author.
+func (a *authors) known(id uint64) error {
+	if id == 0 {
+		return nil
+	}
+
+	if _, ok := a.author(id); !ok {
+		return fmt.Errorf("%w: %d", blogbus.ErrUnknownAuthor, id)
+	}
+
+	return nil
+}
+
+// postsOf returns the IDs of the posts of the author with id, even those in the trash, in order.
+func (a *authors) postsOf(id uint64) []uint64 {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	return slices.Sorted(maps.Keys(a.written[id]))
+}
+
+// link indexes the post with id as written by the author with authorID, a zero authorID keeps the
+// author indexed for it.
+func (a *authors) link(id, authorID uint64) {
+	if authorID == 0 {
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.remove(id)
+	a.add(id, authorID)
+}
+
+// unindex removes the post with id from the index.
+func (a *authors) unindex(id uint64) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.remove(id)
+}
+
+// add indexes the post with id under the author with authorID, the caller must hold mu.
+func (a *authors) add(id, authorID uint64) {
+	if authorID == 0 {
+		return
+	}
+
+	a.posts[id] = authorID
+
+	if a.written[authorID] == nil {
+		a.written[authorID] = make(map[uint64]struct{})
+	}
+
+	a.written[authorID][id] = struct{}{}
+}
+
+// remove drops the post with id from the index, the caller must hold mu.
+func (a *authors) remove(id uint64) {
+	authorID, ok := a.posts[id]
+	if !ok {
+		return
+	}
+
+	delete(a.posts, id)
+	delete(a.written[authorID], id)
+
+	if len(a.written[authorID]) == 0 {
+		delete(a.written, authorID)
+	}
+}
@@ -0,0 +1,161 @@
+package blogrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/anazcodes/blogapp/internal/business/blogbus"
+	"github.com/anazcodes/blogapp/internal/repository/blogrepo/cache"
+	"github.com/anazcodes/blogapp/internal/repository/blogrepo/wal"
+)
+
+// AuthorStore persists the author profiles. Which author a post links to is stored with the post, the
+// authors index rebuilds the posts of every author from both on load.
+type AuthorStore interface {
+	Authors(ctx context.Context) ([]blogbus.Author, error)
+	PutAuthor(ctx context.Context, a blogbus.Author) error
+	DeleteAuthor(ctx context.Context, id uint64) error
+}
+
+// logAuthors stores the authors of a WAL repository in its log, next to its posts.
+type logAuthors struct {
+	log *wal.Log
+}
+
+func (la logAuthors) Authors(ctx context.Context) ([]blogbus.Author, error) {
+	return la.log.Authors()
+}
+
+func (la logAuthors) PutAuthor(ctx context.Context, a blogbus.Author) error {
+	return la.log.PutAuthor(a)
+}
+
+func (la logAuthors) DeleteAuthor(ctx context.Context, id uint64) error {
+	return la.log.DeleteAuthor(id)
+}
+
+// persistAuthor applies write to the author store, when the repository has one.
+func (r *repo) persistAuthor(write func(s AuthorStore) error) error {
+	if r.authorStore == nil {
+		return nil
+	}
+
+	return write(r.authorStore)
+}
+
+func (r *repo) AddAuthor(ctx context.Context, a blogbus.Author) (blogbus.Author, error) {
+	r.authorWrites.Lock()
+	defer r.authorWrites.Unlock()
+
+	a.ID = r.authors.next()
+
+	if err := r.persistAuthor(func(s AuthorStore) error { return s.PutAuthor(ctx, a) }); err != nil {
+		return blogbus.Author{}, fmt.Errorf("query: %w", err)
+	}
+
+	r.authors.put(a)
+
+	return a, nil
+}
+
+func (r *repo) Author(ctx context.Context, id uint64) (blogbus.Author, error) {
+	a, ok := r.authors.author(id)
+	if !ok {
+		return blogbus.Author{}, fmt.Errorf("query: %w", blogbus.ErrAuthorNotFound)
+	}
+
+	return a, nil
+}
+
+func (r *repo) Authors(ctx context.Context) ([]blogbus.Author, error) {
+	return r.authors.list(), nil
+}
+
+func (r *repo) UpdateAuthor(ctx context.Context, id uint64, ua blogbus.UpdateAuthor) (blogbus.Author, error) {
+	r.authorWrites.Lock()
+	defer r.authorWrites.Unlock()
+
+	a, ok := r.authors.author(id)
+	if !ok {
+		return blogbus.Author{}, fmt.Errorf("query: %w", blogbus.ErrAuthorNotFound)
+	}
+
+	a, err := ua.Apply(a, time.Now())
+	if err != nil {
+		return blogbus.Author{}, err
+	}
+
+	if err := r.persistAuthor(func(s AuthorStore) error { return s.PutAuthor(ctx, a) }); err != nil {
+		return blogbus.Author{}, fmt.Errorf("query: %w", err)
+	}
+
+	r.authors.put(a)
+
+	return a, nil
+}
+
+// DeleteAuthor deletes the author with id once its posts are reassigned. A failure part way leaves the
+// author with the posts that were not reassigned yet, deleting it again reassigns those.
+func (r *repo) DeleteAuthor(ctx context.Context, id, reassignTo uint64) error {
+	r.authorWrites.Lock()
+	defer r.authorWrites.Unlock()
+
+	if _, ok := r.authors.author(id); !ok {
+		return fmt.Errorf("query: %w", blogbus.ErrAuthorNotFound)
+	}
+
+	if posts := r.authors.postsOf(id); len(posts) > 0 {
+		switch {
+		case reassignTo == 0:
+			return fmt.Errorf("query: %w", blogbus.ErrAuthorInUse)
+		case reassignTo == id:
+			return fmt.Errorf("query: %w", blogbus.ErrInvalidReassign)
+		}
+
+		if err := r.authors.known(reassignTo); err != nil {
+			return fmt.Errorf("query: %w", err)
+		}
+
+		for _, postID := range posts {
+			if err := r.reassign(ctx, postID, reassignTo); err != nil {
+				return fmt.Errorf("query: %w", err)
+			}
+		}
+	}
+
+	if err := r.persistAuthor(func(s AuthorStore) error { return s.DeleteAuthor(ctx, id) }); err != nil {
+		return fmt.Errorf("query: %w", err)
+	}
+
+	r.authors.delete(id)
+
+	return nil
+}
+
+// reassign moves the post with id to the author with authorID. The post is stored in place, even in
+// the trash: a new author does not edit its content, so it records no revision.
+func (r *repo) reassign(ctx context.Context, id, authorID uint64) error {
+	w := r.write(id)
+	defer w.Unlock()
+
+	bp, err := r.peek(ctx, id)
+	if errors.Is(err, cache.ErrItemNotFound) {
+		// Purged after the posts of the author were looked up.
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	bp.AuthorID = authorID
+
+	if err := r.store(ctx, bp); err != nil {
+		return err
+	}
+
+	r.authors.link(id, authorID)
+
+	return nil
+}
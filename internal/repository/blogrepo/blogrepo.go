@@ -2,59 +2,958 @@ package blogrepo
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/anazcodes/blogapp/internal/business/authbus"
 	"github.com/anazcodes/blogapp/internal/business/blogbus"
+	"github.com/anazcodes/blogapp/internal/business/commentbus"
 	"github.com/anazcodes/blogapp/internal/repository/blogrepo/cache"
+	"github.com/anazcodes/blogapp/internal/repository/blogrepo/filestore"
+	"github.com/anazcodes/blogapp/internal/repository/blogrepo/search"
+	"github.com/anazcodes/blogapp/internal/repository/blogrepo/snapshot"
+	"github.com/anazcodes/blogapp/internal/repository/blogrepo/sqlitestore"
+	"github.com/anazcodes/blogapp/internal/repository/blogrepo/wal"
 )
 
 type repo struct {
-	cache cache.Cache
+	cache  cache.Cache
+	spill  cache.Cache // Holds the posts evicted from cache, nil when evicted posts are dropped.
+	mu     sync.Mutex  // Serializes the operations that move posts between cache and spill.
+	closer io.Closer   // Releases the storage behind cache, nil when there is none.
+	index  *search.Index
+	slugs  *slugs
+	terms  *terms
+	// termStore persists the tags and categories, nil keeps them in memory only.
+	termStore TermStore
+	// termWrites is held for reading by the post writes that attach tags and categories, and for
+	// writing by the writes of tags and categories, so a post never gets a term that is being deleted.
+	termWrites sync.RWMutex
+	authors    *authors
+	// authorStore persists the authors, nil keeps them in memory only.
+	authorStore AuthorStore
+	// authorWrites is held for reading by the post writes that link an author, and for writing by the
+	// writes of authors, so a post never links to an author that is being deleted. It is taken before
+	// the write lock of a post, which DeleteAuthor takes to reassign the posts of an author.
+	authorWrites sync.RWMutex
+	comments     *comments
+	// commentStore persists the comments, nil keeps them in memory only.
+	commentStore CommentStore
+	// commentWrites is held for reading by the writes of comments and the purges of posts, and for
+	// writing by a restore, so no comment is written while every comment is replaced. It is taken after
+	// authorWrites and before the write lock of a post.
+	commentWrites sync.RWMutex
+	reactions     *reactions
+	// reactionStore persists the reactions, nil keeps them in memory only.
+	reactionStore ReactionStore
+	// reactionWrites is held for reading by the reactions and the purges of posts, and for writing by a
+	// restore, so no reaction is written while every reaction is replaced. It is taken after
+	// commentWrites and before the write lock of a post.
+	reactionWrites sync.RWMutex
+	accounts       *accounts
+	// userStore persists the users, their sessions and their API keys, nil keeps them in memory only.
+	userStore UserStore
+	// userWrites serializes the writes of users and sessions. It is never held together with the locks
+	// of the posts.
+	userWrites sync.Mutex
+	// writes orders the writes of a post to the storage and to the index, striped by post ID, so two
+	// concurrent updates of a post cannot reach the index in another order than the storage.
+	writes [64]sync.Mutex
+	// quota is the most posts the repository holds, zero when only the capacity of its storage bounds
+	// them.
+	quota atomic.Int64
+	// posts is the number of posts the repository holds, in the cache, the spill and the trash, and of
+	// the adds in flight, which the quota is checked against.
+	posts atomic.Int64
 }
 
 func NewRepository(capacity int) *repo {
 	return &repo{
-		cache: cache.NewCache(capacity),
+		cache:     cache.NewCache(capacity),
+		index:     search.New(),
+		slugs:     newSlugs(),
+		terms:     newTerms(),
+		authors:   newAuthors(),
+		comments:  newComments(),
+		reactions: newReactions(),
+		accounts:  newAccounts(),
 	}
 }
 
+// NewFileRepository returns a repository whose posts are persisted in dir and reloaded on startup.
+// The log in dir is compacted every compactEvery, a non-positive value disables compaction.
+func NewFileRepository(dir string, capacity int, compactEvery time.Duration, opts wal.Options) (*repo, error) {
+	store, err := filestore.Open(dir, capacity, compactEvery, opts)
+	if err != nil {
+		return nil, fmt.Errorf("filestore.open: %w", err)
+	}
+
+	r := &repo{
+		cache:         store,
+		closer:        store,
+		index:         search.New(),
+		slugs:         newSlugs(),
+		terms:         newTerms(),
+		termStore:     store,
+		authors:       newAuthors(),
+		authorStore:   store,
+		comments:      newComments(),
+		commentStore:  store,
+		reactions:     newReactions(),
+		reactionStore: store,
+		accounts:      newAccounts(),
+		userStore:     store,
+	}
+
+	if err := r.load(context.Background()); err != nil {
+		return nil, errors.Join(err, store.Close())
+	}
+
+	return r, nil
+}
+
+// NewWALRepository returns an in-memory repository that records every mutation to the
+// write-ahead log at path and recovers its posts and ID serial from it on startup.
+func NewWALRepository(path string, capacity int, opts wal.Options) (*repo, error) {
+	l, rec, err := wal.Open(path, opts)
+	if err != nil {
+		return nil, fmt.Errorf("wal.open: %w", err)
+	}
+
+	r := &repo{
+		cache:         cache.NewJournaledCache(capacity, rec.Serial, rec.Posts, l),
+		closer:        l,
+		index:         search.New(),
+		slugs:         newSlugs(),
+		terms:         newTerms(),
+		termStore:     logTerms{log: l},
+		authors:       newAuthors(),
+		authorStore:   logAuthors{log: l},
+		comments:      newComments(),
+		commentStore:  logComments{log: l},
+		reactions:     newReactions(),
+		reactionStore: logReactions{log: l},
+		accounts:      newAccounts(),
+		userStore:     logUsers{log: l},
+	}
+
+	if err := r.load(context.Background()); err != nil {
+		return nil, errors.Join(err, l.Close())
+	}
+
+	return r, nil
+}
+
+// Store is a durable storage evicted posts are spilled to.
+type Store interface {
+	cache.Cache
+	io.Closer
+}
+
+// NewMemoryRepository returns an in-memory repository whose cache is configured by cfg.
+// Posts evicted by the cfg.Eviction policy are moved to spill and read back from it on a cache miss,
+// a nil spill drops them. The repository takes ownership of spill and closes it on Close.
+func NewMemoryRepository(ctx context.Context, cfg cache.Config, spill Store) (*repo, error) {
+	r := &repo{
+		index:     search.New(),
+		slugs:     newSlugs(),
+		terms:     newTerms(),
+		authors:   newAuthors(),
+		comments:  newComments(),
+		reactions: newReactions(),
+		accounts:  newAccounts(),
+	}
+
+	if spill == nil {
+		// A dropped post can no longer be found, its slugs are free to be taken, its tags,
+		// categories and author to be deleted and its comments and reactions are dropped with it.
+		cfg.OnEvict = func(bp blogbus.BlogPost) error {
+			r.index.Delete(bp.ID)
+			r.slugs.release(bp.Slugs()...)
+			r.terms.unindex(bp.ID)
+			r.authors.unindex(bp.ID)
+			r.comments.delete(r.comments.ofPost(bp.ID))
+			r.reactions.drop(bp.ID)
+			r.posts.Add(-1)
+			return nil
+		}
+
+		r.cache = cache.New(cfg)
+		if err := r.load(ctx); err != nil {
+			return nil, err
+		}
+
+		return r, nil
+	}
+
+	// Continue the ID serial of the spilled posts so a new post never takes the ID of a spilled one.
+	s, err := spill.Snapshot(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("spill.snapshot: %w", err)
+	}
+
+	cfg.Serial = s.Serial
+	cfg.OnEvict = func(bp blogbus.BlogPost) error {
+		return spill.PutBlogPost(context.Background(), bp)
+	}
+
+	r.cache = cache.New(cfg)
+	r.spill = spill
+	r.closer = spill
+	// The tags, categories, authors, comments and reactions of the spilled posts are kept with them.
+	r.termStore, _ = spill.(TermStore)
+	r.authorStore, _ = spill.(AuthorStore)
+	r.commentStore, _ = spill.(CommentStore)
+	r.reactionStore, _ = spill.(ReactionStore)
+	r.userStore, _ = spill.(UserStore)
+
+	if err := r.load(ctx); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// NewTieredRepository returns a repository whose posts are stored in backend, with the recently
+// read posts kept in a hot cache of the given capacity. The hot cache makes room by policy.
+// The repository takes ownership of backend and closes it on Close.
+func NewTieredRepository(ctx context.Context, backend Store, capacity int, policy cache.Policy) (*repo, error) {
+	termStore, _ := backend.(TermStore)
+	authorStore, _ := backend.(AuthorStore)
+	commentStore, _ := backend.(CommentStore)
+	reactionStore, _ := backend.(ReactionStore)
+	userStore, _ := backend.(UserStore)
+
+	r := &repo{
+		cache:         newTier(backend, capacity, policy),
+		closer:        backend,
+		index:         search.New(),
+		slugs:         newSlugs(),
+		terms:         newTerms(),
+		termStore:     termStore,
+		authors:       newAuthors(),
+		authorStore:   authorStore,
+		comments:      newComments(),
+		commentStore:  commentStore,
+		reactions:     newReactions(),
+		reactionStore: reactionStore,
+		accounts:      newAccounts(),
+		userStore:     userStore,
+	}
+
+	if err := r.load(ctx); err != nil {
+		return nil, errors.Join(err, backend.Close())
+	}
+
+	return r, nil
+}
+
+// NewSQLiteRepository returns a repository whose posts are stored in the SQLite database at path.
+// The database schema is migrated to the latest version on open.
+func NewSQLiteRepository(ctx context.Context, path string) (*repo, error) {
+	store, err := sqlitestore.Open(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("sqlitestore.open: %w", err)
+	}
+
+	r := &repo{
+		cache:         store,
+		closer:        store,
+		index:         search.New(),
+		slugs:         newSlugs(),
+		terms:         newTerms(),
+		termStore:     store,
+		authors:       newAuthors(),
+		authorStore:   store,
+		comments:      newComments(),
+		commentStore:  store,
+		reactions:     newReactions(),
+		reactionStore: store,
+		accounts:      newAccounts(),
+		userStore:     store,
+	}
+
+	if err := r.load(ctx); err != nil {
+		return nil, errors.Join(err, store.Close())
+	}
+
+	return r, nil
+}
+
+// SetQuota bounds the posts the repository holds, the trash included, to quota, on top of the capacity
+// of its storage. A zero quota removes the bound. A restore is not bound by it, the posts of a
+// snapshot are all restored.
+func (r *repo) SetQuota(quota int) {
+	r.quota.Store(int64(quota))
+}
+
+// load builds the search index, the slugs and the indexes of the tags, categories and authors of the
+// stored posts, threads their comments, counts their reactions and loads the users and their sessions.
+// The posts stored before posts had a slug are stored again with the slug generated for them, the
+// comments and reactions left behind by posts purged before they were deleted are deleted, and so are
+// the sessions that expired.
+func (r *repo) load(ctx context.Context) error {
+	s, err := r.snapshot(ctx)
+	if err != nil {
+		return fmt.Errorf("index: %w", err)
+	}
+
+	var taxonomy blogbus.Taxonomy
+	if r.termStore != nil {
+		if taxonomy, err = r.termStore.Taxonomy(ctx); err != nil {
+			return fmt.Errorf("taxonomy: %w", err)
+		}
+	}
+
+	var as []blogbus.Author
+	if r.authorStore != nil {
+		if as, err = r.authorStore.Authors(ctx); err != nil {
+			return fmt.Errorf("authors: %w", err)
+		}
+	}
+
+	var cs []commentbus.Comment
+	if r.commentStore != nil {
+		if cs, err = r.commentStore.Comments(ctx); err != nil {
+			return fmt.Errorf("comments: %w", err)
+		}
+	}
+
+	var rrs []blogbus.ReaderReaction
+	if r.reactionStore != nil {
+		if rrs, err = r.reactionStore.Reactions(ctx); err != nil {
+			return fmt.Errorf("reactions: %w", err)
+		}
+	}
+
+	var users []authbus.User
+	var sessions []authbus.Session
+	var keys []authbus.APIKey
+	if r.userStore != nil {
+		if users, err = r.userStore.Users(ctx); err != nil {
+			return fmt.Errorf("users: %w", err)
+		}
+		if sessions, err = r.userStore.Sessions(ctx); err != nil {
+			return fmt.Errorf("sessions: %w", err)
+		}
+		if keys, err = r.userStore.APIKeys(ctx); err != nil {
+			return fmt.Errorf("api keys: %w", err)
+		}
+	}
+
+	posts, changed := r.slugs.reset(s.Posts)
+	r.posts.Store(int64(len(posts)))
+
+	for _, bp := range changed {
+		if err := r.store(ctx, bp); err != nil {
+			return fmt.Errorf("slugs: %w", err)
+		}
+	}
+
+	r.index.Reset(posts)
+	r.terms.reset(taxonomy, posts)
+	r.authors.reset(as, posts)
+
+	if dropped := r.comments.reset(cs, posts); len(dropped) > 0 {
+		if err := r.commentStore.DeleteComments(ctx, dropped); err != nil {
+			return fmt.Errorf("comments: %w", err)
+		}
+	}
+
+	if dropped := r.reactions.reset(rrs, posts); len(dropped) > 0 {
+		if err := r.reactionStore.DeletePostReactions(ctx, dropped); err != nil {
+			return fmt.Errorf("reactions: %w", err)
+		}
+	}
+
+	if dropped := r.accounts.reset(users, sessions, keys, time.Now()); len(dropped) > 0 {
+		if err := r.userStore.DeleteSessions(ctx, dropped); err != nil {
+			return fmt.Errorf("sessions: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// store replaces a post in the cache, or in the spill when that is where the post is.
+func (r *repo) store(ctx context.Context, bp blogbus.BlogPost) error {
+	if r.spill != nil {
+		// The lock keeps the post from being promoted between the lookup and the write.
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		if _, err := r.cache.BlogPost(ctx, bp.ID); errors.Is(err, cache.ErrItemNotFound) {
+			return r.spill.PutBlogPost(ctx, bp)
+		}
+	}
+
+	return r.cache.PutBlogPost(ctx, bp)
+}
+
+// Close releases the resources held by the underlying storage.
+func (r *repo) Close() error {
+	if r.closer == nil {
+		return nil
+	}
+
+	return r.closer.Close()
+}
+
+// AddBlogPost adds a post under the slug abp asks for, or else under the first free slug generated
+// from its title.
 func (r *repo) AddBlogPost(ctx context.Context, abp blogbus.AddBlogPost) (uint64, error) {
+	r.authorWrites.RLock()
+	defer r.authorWrites.RUnlock()
+
+	r.termWrites.RLock()
+	defer r.termWrites.RUnlock()
+
+	if err := r.terms.known(abp.Tags, abp.Categories); err != nil {
+		return 0, fmt.Errorf("query: %w", err)
+	}
+
+	if err := r.authors.known(abp.AuthorID); err != nil {
+		return 0, fmt.Errorf("query: %w", err)
+	}
+
+	if err := r.reserve(); err != nil {
+		return 0, fmt.Errorf("query: %w", err)
+	}
+
+	// The slug is reserved before the post is stored, its ID is not known until then.
+	if abp.Slug == "" {
+		abp.Slug = r.slugs.generate(blogbus.Slugify(abp.Title), 0)
+	} else if !r.slugs.claim(abp.Slug, 0) {
+		r.posts.Add(-1)
+		return 0, fmt.Errorf("query: %w", blogbus.ErrSlugTaken)
+	}
+
 	id, err := r.cache.AddBlogPost(ctx, abp)
 	if err != nil {
+		r.slugs.release(abp.Slug)
+		r.posts.Add(-1)
 		return 0, fmt.Errorf("query: %w", err)
 	}
 
+	r.slugs.bind(abp.Slug, id)
+	// A new post is a draft, which the tag cloud does not count.
+	r.terms.retag(id, abp.Tags, abp.Categories)
+	r.authors.link(id, abp.AuthorID)
+	r.reactions.open(id)
+
 	return id, nil
 }
 
+// reserve counts one more post, or returns ErrQuotaExceeded when the quota is taken. The adds in flight
+// are counted, so two adds cannot both take the last post of the quota.
+func (r *repo) reserve() error {
+	quota := r.quota.Load()
+	if quota == 0 {
+		r.posts.Add(1)
+		return nil
+	}
+
+	for {
+		n := r.posts.Load()
+		if n >= quota {
+			return fmt.Errorf("%w: %d posts", blogbus.ErrQuotaExceeded, quota)
+		}
+
+		if r.posts.CompareAndSwap(n, n+1) {
+			return nil
+		}
+	}
+}
+
 func (r *repo) BlogPost(ctx context.Context, id uint64) (blogbus.BlogPost, error) {
-	bp, err := r.cache.BlogPost(ctx, id)
+	bp, err := r.blogPost(ctx, id)
 	if err != nil {
 		return blogbus.BlogPost{}, fmt.Errorf("query: %w", err)
 	}
 
-	return bp, nil
+	if bp.Trashed() {
+		return blogbus.BlogPost{}, blogbus.ErrTrashed
+	}
+
+	return r.withReactions(bp), nil
 }
-func (r *repo) BlogPosts(ctx context.Context) []blogbus.BlogPost {
-	bps := r.cache.BlogPosts(ctx)
 
-	return bps
+// BlogPostBySlug returns the post slug leads to, which is either its slug or one of its old slugs.
+func (r *repo) BlogPostBySlug(ctx context.Context, slug string) (blogbus.BlogPost, error) {
+	id, ok := r.slugs.lookup(slug)
+	if !ok {
+		return blogbus.BlogPost{}, fmt.Errorf("query: %w", cache.ErrItemNotFound)
+	}
+
+	return r.BlogPost(ctx, id)
+}
+
+// blogPost reads the post with id from the cache, or from the spill on a cache miss.
+func (r *repo) blogPost(ctx context.Context, id uint64) (blogbus.BlogPost, error) {
+	bp, err := r.cache.BlogPost(ctx, id)
+	if errors.Is(err, cache.ErrItemNotFound) && r.spill != nil {
+		return r.promote(ctx, id)
+	}
+
+	return bp, err
 }
 
-func (r *repo) DeleteBlogPost(ctx context.Context, id uint64) (uint64, error) {
-	id, err := r.cache.DeleteBlogPost(ctx, id)
+// peek reads the post with id like blogPost, but leaves a spilled post in the spill.
+func (r *repo) peek(ctx context.Context, id uint64) (blogbus.BlogPost, error) {
+	bp, err := r.cache.BlogPost(ctx, id)
+	if errors.Is(err, cache.ErrItemNotFound) && r.spill != nil {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		return r.spill.BlogPost(ctx, id)
+	}
+
+	return bp, err
+}
+
+func (r *repo) BlogPosts(ctx context.Context, q blogbus.Query, page blogbus.Page) (blogbus.BlogPostPage, error) {
+	after, err := decodeCursor(q, page.Cursor)
+	if err != nil {
+		return blogbus.BlogPostPage{}, fmt.Errorf("cursor: %w", err)
+	}
+
+	l := cache.Listing{Query: q, After: after, Limit: page.Limit + 1, IDs: r.terms.ids(q.Tag, q.Category)}
+
+	bps, err := r.list(ctx, l)
+	if err != nil {
+		return blogbus.BlogPostPage{}, fmt.Errorf("query: %w", err)
+	}
+
+	bpp := paginate(q, bps, page.Limit)
+	for i, bp := range bpp.BlogPosts {
+		bpp.BlogPosts[i] = r.withReactions(bp)
+	}
+
+	return bpp, nil
+}
+
+// list returns the posts selected by l in listing order.
+func (r *repo) list(ctx context.Context, l cache.Listing) ([]blogbus.BlogPost, error) {
+	if r.spill == nil {
+		return r.cache.ListBlogPosts(ctx, l)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// As in snapshot the cache is read first so a post evicted in between is not missed.
+	cached, err := r.cache.ListBlogPosts(ctx, l)
+	if err != nil {
+		return nil, err
+	}
+
+	spilled, err := r.spill.ListBlogPosts(ctx, l)
+	if err != nil {
+		return nil, err
+	}
+
+	bps := merge(cached, spilled, l.Compare)
+
+	return bps[:min(l.Limit, len(bps))], nil
+}
+
+// SearchBlogPosts returns the page of the posts matching the full-text search q selected by page,
+// the most relevant first.
+func (r *repo) SearchBlogPosts(ctx context.Context, q string, page blogbus.Page) (blogbus.SearchPage, error) {
+	after, err := decodeSearchCursor(q, page.Cursor)
+	if err != nil {
+		return blogbus.SearchPage{}, fmt.Errorf("cursor: %w", err)
+	}
+
+	hits := r.index.Search(q, after, page.Limit+1)
+
+	sp := blogbus.SearchPage{}
+	if len(hits) > page.Limit {
+		hits = hits[:page.Limit]
+		sp.NextCursor = newSearchCursor(q, hits[len(hits)-1]).encode()
+		sp.HasMore = true
+	}
+
+	for _, h := range hits {
+		bp, err := r.blogPost(ctx, h.ID)
+		if errors.Is(err, cache.ErrItemNotFound) {
+			// Purged after it was searched.
+			continue
+		}
+		if err != nil {
+			return blogbus.SearchPage{}, fmt.Errorf("query: %w", err)
+		}
+
+		// Moved to the trash after it was searched.
+		if bp.Trashed() {
+			continue
+		}
+
+		sp.Hits = append(sp.Hits, blogbus.SearchHit{BlogPost: r.withReactions(bp), Score: h.Score, Snippets: h.Snippets})
+	}
+
+	return sp, nil
+}
+
+func (r *repo) TrashBlogPost(ctx context.Context, id uint64, at time.Time) (uint64, error) {
+	w := r.write(id)
+	defer w.Unlock()
+
+	bp, err := r.trash(ctx, id, at)
 	if err != nil {
 		return 0, fmt.Errorf("query: %w", err)
 	}
 
+	r.index.Delete(id)
+	r.terms.index(bp)
+	r.reactions.trash(id, true)
+
 	return id, nil
 }
 
-func (r *repo) UpdateBlogPost(ctx context.Context, id uint64, ubp blogbus.UpdateBlogPost) (uint64, error) {
-	id, err := r.cache.UpdateBlogPost(ctx, id, ubp)
+func (r *repo) RestoreBlogPost(ctx context.Context, id uint64) (uint64, error) {
+	w := r.write(id)
+	defer w.Unlock()
+
+	bp, err := r.trash(ctx, id, time.Time{})
 	if err != nil {
 		return 0, fmt.Errorf("query: %w", err)
 	}
 
+	r.index.Put(bp)
+	r.terms.index(bp)
+	r.reactions.trash(id, false)
+
 	return id, nil
 }
+
+// trash moves the post with id to or out of the trash in the cache, or in the spill on a cache miss.
+func (r *repo) trash(ctx context.Context, id uint64, at time.Time) (blogbus.BlogPost, error) {
+	bp, err := r.cache.TrashBlogPost(ctx, id, at)
+	if errors.Is(err, cache.ErrItemNotFound) && r.spill != nil {
+		r.mu.Lock()
+		bp, err = r.spill.TrashBlogPost(ctx, id, at)
+		r.mu.Unlock()
+	}
+
+	return bp, err
+}
+
+func (r *repo) PurgeBlogPost(ctx context.Context, id uint64, by time.Time) (uint64, error) {
+	r.commentWrites.RLock()
+	defer r.commentWrites.RUnlock()
+
+	r.reactionWrites.RLock()
+	defer r.reactionWrites.RUnlock()
+
+	w := r.write(id)
+	defer w.Unlock()
+
+	// The write lock keeps the post from being restored between the check and the delete.
+	bp, err := r.peek(ctx, id)
+	if err != nil {
+		return 0, fmt.Errorf("query: %w", err)
+	}
+
+	if !bp.Trashed() || bp.DeletedAt.After(by) {
+		return 0, blogbus.ErrNotTrashed
+	}
+
+	out, err := r.cache.DeleteBlogPost(ctx, id)
+	if errors.Is(err, cache.ErrItemNotFound) && r.spill != nil {
+		r.mu.Lock()
+		out, err = r.spill.DeleteBlogPost(ctx, id)
+		r.mu.Unlock()
+	}
+	if err != nil {
+		return 0, fmt.Errorf("query: %w", err)
+	}
+
+	r.posts.Add(-1)
+	r.index.Delete(id)
+	r.slugs.release(bp.Slugs()...)
+	r.terms.unindex(id)
+	r.authors.unindex(id)
+
+	if err := r.purgeComments(ctx, id); err != nil {
+		return 0, fmt.Errorf("query: %w", err)
+	}
+
+	if err := r.purgeReactions(ctx, id); err != nil {
+		return 0, fmt.Errorf("query: %w", err)
+	}
+
+	return out, nil
+}
+
+func (r *repo) UpdateBlogPost(ctx context.Context, id uint64, ubp blogbus.UpdateBlogPost) (uint64, error) {
+	r.authorWrites.RLock()
+	defer r.authorWrites.RUnlock()
+
+	w := r.write(id)
+	defer w.Unlock()
+
+	r.termWrites.RLock()
+	defer r.termWrites.RUnlock()
+
+	if err := r.terms.known(ubp.Tags, ubp.Categories); err != nil {
+		return 0, fmt.Errorf("query: %w", err)
+	}
+
+	if err := r.authors.known(ubp.AuthorID); err != nil {
+		return 0, fmt.Errorf("query: %w", err)
+	}
+
+	// The write lock keeps the slugs of the post from changing until the update is stored.
+	bp, err := r.peek(ctx, id)
+	if err != nil {
+		return 0, fmt.Errorf("query: %w", err)
+	}
+
+	if bp.Trashed() {
+		return 0, fmt.Errorf("query: %w", blogbus.ErrTrashed)
+	}
+
+	if ubp.Slug, err = r.reslug(bp, ubp); err != nil {
+		return 0, fmt.Errorf("query: %w", err)
+	}
+
+	out, err := r.cache.UpdateBlogPost(ctx, id, ubp)
+	if errors.Is(err, cache.ErrItemNotFound) && r.spill != nil {
+		r.mu.Lock()
+		out, err = r.spill.UpdateBlogPost(ctx, id, ubp)
+		r.mu.Unlock()
+	}
+	if err != nil {
+		if !slices.Contains(bp.Slugs(), ubp.Slug) {
+			r.slugs.release(ubp.Slug)
+		}
+		return 0, fmt.Errorf("query: %w", err)
+	}
+
+	r.index.Update(id, ubp)
+	r.terms.retag(id, ubp.Tags, ubp.Categories)
+	r.authors.link(id, ubp.AuthorID)
+
+	return out, nil
+}
+
+// reslug claims the slug bp moves to when ubp is applied: the slug ubp asks for, or a slug generated
+// from the new title when the slug of bp follows its title. It returns an empty slug when bp keeps
+// its slug.
+func (r *repo) reslug(bp blogbus.BlogPost, ubp blogbus.UpdateBlogPost) (string, error) {
+	switch {
+	case ubp.Slug != "":
+		if !r.slugs.claim(ubp.Slug, bp.ID) {
+			return "", blogbus.ErrSlugTaken
+		}
+
+		return ubp.Slug, nil
+	case ubp.Title != "" && bp.SlugFollowsTitle():
+		base := blogbus.Slugify(ubp.Title)
+		if base == blogbus.Slugify(bp.Title) {
+			return "", nil
+		}
+
+		return r.slugs.generate(base, bp.ID), nil
+	default:
+		return "", nil
+	}
+}
+
+func (r *repo) TransitionBlogPost(ctx context.Context, id uint64, t blogbus.Transition) (uint64, error) {
+	w := r.write(id)
+	defer w.Unlock()
+
+	bp, err := r.cache.TransitionBlogPost(ctx, id, t)
+	if errors.Is(err, cache.ErrItemNotFound) && r.spill != nil {
+		r.mu.Lock()
+		bp, err = r.spill.TransitionBlogPost(ctx, id, t)
+		r.mu.Unlock()
+	}
+	if err != nil {
+		return 0, fmt.Errorf("query: %w", err)
+	}
+
+	r.index.Put(bp)
+	r.terms.index(bp)
+
+	return bp.ID, nil
+}
+
+// write locks the writes of the post with id and returns the lock to unlock.
+func (r *repo) write(id uint64) *sync.Mutex {
+	w := &r.writes[id%uint64(len(r.writes))]
+	w.Lock()
+
+	return w
+}
+
+// promote moves a spilled post back into the cache, which may evict another post to the spill.
+func (r *repo) promote(ctx context.Context, id uint64) (blogbus.BlogPost, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Another reader may have promoted the post while we waited for the lock.
+	if bp, err := r.cache.BlogPost(ctx, id); err == nil {
+		return bp, nil
+	}
+
+	bp, err := r.spill.BlogPost(ctx, id)
+	if err != nil {
+		return blogbus.BlogPost{}, err
+	}
+
+	if err := r.cache.PutBlogPost(ctx, bp); err != nil {
+		return blogbus.BlogPost{}, err
+	}
+
+	if _, err := r.spill.DeleteBlogPost(ctx, id); err != nil {
+		return blogbus.BlogPost{}, err
+	}
+
+	return bp, nil
+}
+
+// snapshot returns the posts of the cache and the spill, ordered by ID.
+func (r *repo) snapshot(ctx context.Context) (blogbus.Snapshot, error) {
+	if r.spill == nil {
+		return r.cache.Snapshot(ctx)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// The cache is read first, a post evicted in between is then found in the spill instead of in neither.
+	cached, err := r.cache.Snapshot(ctx)
+	if err != nil {
+		return blogbus.Snapshot{}, err
+	}
+
+	spilled, err := r.spill.Snapshot(ctx)
+	if err != nil {
+		return blogbus.Snapshot{}, err
+	}
+
+	return blogbus.Snapshot{
+		Serial:    max(cached.Serial, spilled.Serial),
+		Posts:     merge(cached.Posts, spilled.Posts, blogbus.Query{}.Compare),
+		CreatedAt: cached.CreatedAt,
+	}, nil
+}
+
+// merge merges the cached and spilled posts, both ordered by cmp, into one list ordered by cmp.
+// A post evicted while they were read is in both, the cached copy is then kept.
+func merge(cached, spilled []blogbus.BlogPost, cmp func(a, b blogbus.BlogPost) int) []blogbus.BlogPost {
+	bps := make([]blogbus.BlogPost, 0, len(cached)+len(spilled))
+
+	for len(cached) > 0 || len(spilled) > 0 {
+		switch {
+		case len(spilled) == 0 || len(cached) > 0 && cmp(cached[0], spilled[0]) < 0:
+			bps, cached = append(bps, cached[0]), cached[1:]
+		case len(cached) == 0 || cmp(spilled[0], cached[0]) < 0:
+			bps, spilled = append(bps, spilled[0]), spilled[1:]
+		default:
+			bps, cached, spilled = append(bps, cached[0]), cached[1:], spilled[1:]
+		}
+	}
+
+	return bps
+}
+
+// CacheStats returns the counters of the in-memory cache, zero when the storage has none.
+func (r *repo) CacheStats(ctx context.Context) (blogbus.CacheStats, error) {
+	sr, ok := r.cache.(cache.StatsReporter)
+	if !ok {
+		return blogbus.CacheStats{}, nil
+	}
+
+	s := sr.Stats()
+
+	return blogbus.CacheStats{
+		Hits:      s.Hits,
+		Misses:    s.Misses,
+		Evictions: s.Evictions,
+		Posts:     s.Posts,
+		Capacity:  s.Capacity,
+	}, nil
+}
+
+func (r *repo) Snapshot(ctx context.Context, w io.Writer) (blogbus.SnapshotInfo, error) {
+	s, err := r.snapshot(ctx)
+	if err != nil {
+		return blogbus.SnapshotInfo{}, fmt.Errorf("query: %w", err)
+	}
+
+	s.Taxonomy = r.terms.taxonomy()
+	s.Authors = r.authors.list()
+	s.Comments = r.comments.list()
+	s.Reactions = r.reactions.list()
+
+	info, err := snapshot.Write(w, s)
+	if err != nil {
+		return blogbus.SnapshotInfo{}, fmt.Errorf("snapshot.write: %w", err)
+	}
+
+	return info, nil
+}
+
+func (r *repo) Restore(ctx context.Context, rd io.Reader) (blogbus.SnapshotInfo, error) {
+	s, info, err := snapshot.Read(rd)
+	if err != nil {
+		return blogbus.SnapshotInfo{}, fmt.Errorf("snapshot.read: %w", err)
+	}
+
+	// A snapshot taken before posts had a slug is restored with the slugs generated for its posts.
+	slugs := newSlugs()
+	s.Posts, _ = slugs.reset(s.Posts)
+
+	r.authorWrites.Lock()
+	defer r.authorWrites.Unlock()
+
+	r.commentWrites.Lock()
+	defer r.commentWrites.Unlock()
+
+	r.reactionWrites.Lock()
+	defer r.reactionWrites.Unlock()
+
+	r.termWrites.Lock()
+	defer r.termWrites.Unlock()
+
+	if err := r.restore(ctx, s); err != nil {
+		return blogbus.SnapshotInfo{}, fmt.Errorf("query: %w", err)
+	}
+
+	r.slugs.replace(slugs)
+	r.index.Reset(s.Posts)
+	r.terms.reset(s.Taxonomy, s.Posts)
+	r.authors.reset(s.Authors, s.Posts)
+	r.comments.reset(s.Comments, s.Posts)
+	r.reactions.reset(s.Reactions, s.Posts)
+	r.posts.Store(int64(len(s.Posts)))
+
+	return info, nil
+}
+
+// restore replaces every post with the posts of s.
+// With a spill the posts are restored to it and the cache starts empty, so the restore is not
+// bounded by the cache capacity and the posts are promoted again as they are read.
+func (r *repo) restore(ctx context.Context, s blogbus.Snapshot) error {
+	if r.spill == nil {
+		return r.cache.Restore(ctx, s)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.spill.Restore(ctx, s); err != nil {
+		return err
+	}
+
+	return r.cache.Restore(ctx, blogbus.Snapshot{Serial: s.Serial, CreatedAt: s.CreatedAt})
+}
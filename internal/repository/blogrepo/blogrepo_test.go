@@ -1,11 +1,23 @@
 package blogrepo_test
 
 import (
+	"bytes"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/anazcodes/blogapp/internal/business/authbus"
 	"github.com/anazcodes/blogapp/internal/business/blogbus"
+	"github.com/anazcodes/blogapp/internal/business/commentbus"
 	"github.com/anazcodes/blogapp/internal/repository/blogrepo"
 	"github.com/anazcodes/blogapp/internal/repository/blogrepo/cache"
+	"github.com/anazcodes/blogapp/internal/repository/blogrepo/snapshot"
+	"github.com/anazcodes/blogapp/internal/repository/blogrepo/sqlitestore"
+	"github.com/anazcodes/blogapp/internal/repository/blogrepo/wal"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -61,25 +73,35 @@ func TestBlogPosts(t *testing.T) {
 
 	testCases := []struct {
 		name        string
+		page        blogbus.Page
 		outputLen   int
 		expectedErr error
 	}{
 		{
 			name:        "Success",
+			page:        blogbus.Page{Limit: 10},
 			outputLen:   1,
 			expectedErr: nil,
 		},
+		{
+			name:        "Failure",
+			page:        blogbus.Page{Limit: 10, Cursor: "not a cursor"},
+			outputLen:   0,
+			expectedErr: blogbus.ErrInvalidCursor,
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 
-			output := repo.BlogPosts(t.Context())
-			len := len(output)
+			output, err := repo.BlogPosts(t.Context(), blogbus.Query{}, tc.page)
+			assert.ErrorIs(t, err, tc.expectedErr)
+
+			len := len(output.BlogPosts)
 			assert.Equal(t, tc.outputLen, len)
 
 			if len > 0 {
-				out := output[0]
+				out := output.BlogPosts[0]
 				assert.NotNil(t, out)
 				assert.Equal(t, input.Title, out.Title)
 				assert.Equal(t, input.Description, out.Description)
@@ -89,6 +111,195 @@ func TestBlogPosts(t *testing.T) {
 	}
 }
 
+func TestBlogPostsPagination(t *testing.T) {
+	bp := blogbus.AddBlogPost{
+		Title:       "Title",
+		Description: "Description",
+		Body:        "Body",
+	}
+
+	spill, err := sqlitestore.Open(t.Context(), filepath.Join(t.TempDir(), "spill.db"))
+	assert.Nil(t, err)
+
+	repos := map[string]interface {
+		blogbus.Repo
+		Close() error
+	}{
+		"Memory":  blogrepo.NewRepository(5),
+		"Sharded": must(blogrepo.NewMemoryRepository(t.Context(), cache.Config{Capacity: 5, Shards: 4}, nil)),
+		"Spill":   must(blogrepo.NewMemoryRepository(t.Context(), cache.Config{Capacity: 2, Eviction: cache.Oldest}, spill)),
+	}
+
+	for name, repo := range repos {
+		t.Run(name, func(t *testing.T) {
+			defer repo.Close()
+
+			for range 5 {
+				_, err := repo.AddBlogPost(t.Context(), bp)
+				assert.Nil(t, err)
+			}
+
+			_, err := repo.TrashBlogPost(t.Context(), 2, time.Now())
+			assert.Nil(t, err)
+
+			var (
+				ids   []uint64
+				pages int
+				page  = blogbus.Page{Limit: 2}
+			)
+
+			for {
+				bpp, err := repo.BlogPosts(t.Context(), blogbus.Query{}, page)
+				assert.Nil(t, err)
+
+				for _, bp := range bpp.BlogPosts {
+					ids = append(ids, bp.ID)
+				}
+
+				pages++
+				if !bpp.HasMore {
+					assert.Empty(t, bpp.NextCursor)
+					break
+				}
+
+				page.Cursor = bpp.NextCursor
+			}
+
+			assert.Equal(t, []uint64{1, 3, 4, 5}, ids)
+			assert.Equal(t, 2, pages)
+		})
+	}
+}
+
+func TestBlogPostsQuery(t *testing.T) {
+	titles := []string{"Go Basics", "Go Advanced", "Rust Basics", "Python", "Golang Tips"}
+
+	ctx := t.Context()
+	dir := t.TempDir()
+
+	spill, err := sqlitestore.Open(ctx, filepath.Join(dir, "spill.db"))
+	assert.Nil(t, err)
+
+	backend, err := sqlitestore.Open(ctx, filepath.Join(dir, "backend.db"))
+	assert.Nil(t, err)
+
+	repos := map[string]interface {
+		blogbus.Repo
+		Close() error
+	}{
+		"Memory":  blogrepo.NewRepository(len(titles)),
+		"Sharded": must(blogrepo.NewMemoryRepository(ctx, cache.Config{Capacity: len(titles), Shards: 4}, nil)),
+		"Spill":   must(blogrepo.NewMemoryRepository(ctx, cache.Config{Capacity: 2, Eviction: cache.LRU}, spill)),
+		"SQLite":  must(blogrepo.NewSQLiteRepository(ctx, filepath.Join(dir, "blogapp.db"))),
+		"Tiered":  must(blogrepo.NewTieredRepository(ctx, backend, 2, cache.LRU)),
+	}
+
+	for name, repo := range repos {
+		t.Run(name, func(t *testing.T) {
+			defer repo.Close()
+
+			var created []time.Time
+			for _, title := range titles {
+				id, err := repo.AddBlogPost(ctx, blogbus.AddBlogPost{Title: title})
+				assert.Nil(t, err)
+
+				bp, err := repo.BlogPost(ctx, id)
+				assert.Nil(t, err)
+				created = append(created, bp.CreatedAt)
+			}
+
+			for _, id := range []uint64{1, 3} {
+				_, err := repo.UpdateBlogPost(ctx, id, blogbus.UpdateBlogPost{Body: "Updated Body"})
+				assert.Nil(t, err)
+			}
+
+			testCases := []struct {
+				name  string
+				query blogbus.Query
+				ids   []uint64
+			}{
+				{
+					name:  "ID",
+					query: blogbus.Query{},
+					ids:   []uint64{1, 2, 3, 4, 5},
+				},
+				{
+					name:  "Created Desc",
+					query: blogbus.Query{SortBy: blogbus.SortByCreatedAt, Desc: true},
+					ids:   []uint64{5, 4, 3, 2, 1},
+				},
+				{
+					name:  "Updated",
+					query: blogbus.Query{SortBy: blogbus.SortByUpdatedAt},
+					ids:   []uint64{2, 4, 5, 1, 3},
+				},
+				{
+					name:  "Title",
+					query: blogbus.Query{SortBy: blogbus.SortByTitle},
+					ids:   []uint64{2, 1, 5, 4, 3},
+				},
+				{
+					name:  "Title Prefix",
+					query: blogbus.Query{TitlePrefix: "Go", SortBy: blogbus.SortByTitle, Desc: true},
+					ids:   []uint64{5, 1, 2},
+				},
+				{
+					name:  "Title Contains",
+					query: blogbus.Query{TitleContains: "Basics"},
+					ids:   []uint64{1, 3},
+				},
+				{
+					name:  "Created Range",
+					query: blogbus.Query{CreatedFrom: created[1], CreatedTo: created[3]},
+					ids:   []uint64{2, 3},
+				},
+			}
+
+			for _, tc := range testCases {
+				t.Run(tc.name, func(t *testing.T) {
+					var ids []uint64
+					page := blogbus.Page{Limit: 2}
+
+					for {
+						bpp, err := repo.BlogPosts(ctx, tc.query, page)
+						assert.Nil(t, err)
+
+						for _, bp := range bpp.BlogPosts {
+							ids = append(ids, bp.ID)
+						}
+
+						if !bpp.HasMore {
+							break
+						}
+
+						page.Cursor = bpp.NextCursor
+					}
+
+					assert.Equal(t, tc.ids, ids)
+				})
+			}
+
+			// A cursor only continues the order it was issued for.
+			bpp, err := repo.BlogPosts(ctx, blogbus.Query{}, blogbus.Page{Limit: 1})
+			assert.Nil(t, err)
+
+			_, err = repo.BlogPosts(ctx, blogbus.Query{SortBy: blogbus.SortByTitle}, blogbus.Page{Limit: 1, Cursor: bpp.NextCursor})
+			assert.ErrorIs(t, err, blogbus.ErrInvalidCursor)
+		})
+	}
+}
+
+// publish is the transition publishing a draft.
+var publish = blogbus.Transition{From: blogbus.StatusDraft, To: blogbus.StatusPublished, At: time.Now()}
+
+func must[T any](v T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+
+	return v
+}
+
 func TestBlogPost(t *testing.T) {
 	capacity := 1 // cache capacity
 
@@ -134,34 +345,147 @@ func TestBlogPost(t *testing.T) {
 	}
 }
 
-func TestDeleteBlogPost(t *testing.T) {
+func TestQuota(t *testing.T) {
+	ctx := t.Context()
+	repo := blogrepo.NewRepository(10)
+	repo.SetQuota(2)
+
+	for range 2 {
+		_, err := repo.AddBlogPost(ctx, blogbus.AddBlogPost{Title: "Title"})
+		assert.Nil(t, err)
+	}
+
+	_, err := repo.AddBlogPost(ctx, blogbus.AddBlogPost{Title: "Title"})
+	assert.ErrorIs(t, err, blogbus.ErrQuotaExceeded)
+
+	// A post in the trash still counts, a purged one no longer does.
+	trashedAt := time.Now()
+	_, err = repo.TrashBlogPost(ctx, 1, trashedAt)
+	assert.Nil(t, err)
+
+	_, err = repo.AddBlogPost(ctx, blogbus.AddBlogPost{Title: "Title"})
+	assert.ErrorIs(t, err, blogbus.ErrQuotaExceeded)
+
+	_, err = repo.PurgeBlogPost(ctx, 1, trashedAt)
+	assert.Nil(t, err)
+
+	id, err := repo.AddBlogPost(ctx, blogbus.AddBlogPost{Title: "Title"})
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(3), id)
+
+	repo.SetQuota(0)
+
+	_, err = repo.AddBlogPost(ctx, blogbus.AddBlogPost{Title: "Title"})
+	assert.Nil(t, err)
+
+	// The posts a repository loads count too.
+	dir := filepath.Join(t.TempDir(), "store")
+
+	stored, err := blogrepo.NewFileRepository(dir, 10, 0, wal.Options{})
+	assert.Nil(t, err)
+
+	for range 2 {
+		_, err := stored.AddBlogPost(ctx, blogbus.AddBlogPost{Title: "Title"})
+		assert.Nil(t, err)
+	}
+	assert.Nil(t, stored.Close())
+
+	stored, err = blogrepo.NewFileRepository(dir, 10, 0, wal.Options{})
+	assert.Nil(t, err)
+	defer stored.Close()
+
+	stored.SetQuota(2)
+
+	_, err = stored.AddBlogPost(ctx, blogbus.AddBlogPost{Title: "Title"})
+	assert.ErrorIs(t, err, blogbus.ErrQuotaExceeded)
+}
+
+func TestQuotaConcurrentAdds(t *testing.T) {
+	ctx := t.Context()
+	repo := blogrepo.NewRepository(100)
+	repo.SetQuota(5)
+
+	// An add that fails gives its slot back.
+	_, err := repo.AddBlogPost(ctx, blogbus.AddBlogPost{Title: "Title", Slug: "taken"})
+	assert.Nil(t, err)
+	_, err = repo.AddBlogPost(ctx, blogbus.AddBlogPost{Title: "Title", Slug: "taken"})
+	assert.ErrorIs(t, err, blogbus.ErrSlugTaken)
+
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		added int
+	)
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if _, err := repo.AddBlogPost(ctx, blogbus.AddBlogPost{Title: "Title"}); err == nil {
+				mu.Lock()
+				added++
+				mu.Unlock()
+			} else {
+				assert.ErrorIs(t, err, blogbus.ErrQuotaExceeded)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 4, added)
+}
+
+func TestPurgeBlogPost(t *testing.T) {
 	bp := blogbus.AddBlogPost{
 		Title:       "Title",
 		Description: "Description",
 		Body:        "Body",
 	}
 
-	capacity := 1 // cache capacity
+	capacity := 2 // cache capacity
 	repo := blogrepo.NewRepository(capacity)
-	_, err := repo.AddBlogPost(t.Context(), bp)
 
+	for range capacity {
+		_, err := repo.AddBlogPost(t.Context(), bp)
+		assert.Nil(t, err)
+	}
+
+	trashedAt := time.Now()
+	_, err := repo.TrashBlogPost(t.Context(), 1, trashedAt)
 	assert.Nil(t, err)
 
 	testCases := []struct {
 		name        string
 		id          uint64
+		by          time.Time
 		output      uint64
 		expectedErr error
 	}{
+		{
+			name:        "Trashed Later",
+			id:          1,
+			by:          trashedAt.Add(-time.Second),
+			output:      0,
+			expectedErr: blogbus.ErrNotTrashed,
+		},
+		{
+			name:        "Not Trashed",
+			id:          2,
+			by:          trashedAt,
+			output:      0,
+			expectedErr: blogbus.ErrNotTrashed,
+		},
 		{
 			name:        "Success",
 			id:          1,
+			by:          trashedAt,
 			output:      1,
 			expectedErr: nil,
 		},
 		{
 			name:        "Failure",
-			id:          2,
+			id:          1,
+			by:          trashedAt,
 			output:      0,
 			expectedErr: cache.ErrItemNotFound,
 		},
@@ -169,7 +493,7 @@ func TestDeleteBlogPost(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			output, err := repo.DeleteBlogPost(t.Context(), tc.id)
+			output, err := repo.PurgeBlogPost(t.Context(), tc.id, tc.by)
 
 			assert.ErrorIs(t, err, tc.expectedErr)
 			assert.Equal(t, tc.output, output)
@@ -177,6 +501,121 @@ func TestDeleteBlogPost(t *testing.T) {
 	}
 }
 
+func TestTrashBlogPost(t *testing.T) {
+	ctx := t.Context()
+	dir := t.TempDir()
+
+	spill, err := sqlitestore.Open(ctx, filepath.Join(dir, "spill.db"))
+	assert.Nil(t, err)
+
+	backend, err := sqlitestore.Open(ctx, filepath.Join(dir, "backend.db"))
+	assert.Nil(t, err)
+
+	repos := map[string]interface {
+		blogbus.Repo
+		Close() error
+	}{
+		"Memory":  blogrepo.NewRepository(2),
+		"Sharded": must(blogrepo.NewMemoryRepository(ctx, cache.Config{Capacity: 2, Shards: 4}, nil)),
+		"Spill":   must(blogrepo.NewMemoryRepository(ctx, cache.Config{Capacity: 1, Eviction: cache.LRU}, spill)),
+		"SQLite":  must(blogrepo.NewSQLiteRepository(ctx, filepath.Join(dir, "blogapp.db"))),
+		"Tiered":  must(blogrepo.NewTieredRepository(ctx, backend, 1, cache.LRU)),
+	}
+
+	trashed := func(t *testing.T, repo blogbus.Repo) []uint64 {
+		bpp, err := repo.BlogPosts(ctx, blogbus.Query{Trashed: true}, blogbus.Page{Limit: 10})
+		assert.Nil(t, err)
+
+		var ids []uint64
+		for _, bp := range bpp.BlogPosts {
+			ids = append(ids, bp.ID)
+		}
+
+		return ids
+	}
+
+	for name, repo := range repos {
+		t.Run(name, func(t *testing.T) {
+			defer repo.Close()
+
+			_, err := repo.AddBlogPost(ctx, blogbus.AddBlogPost{Title: "Caching", Body: "A trashed cache."})
+			assert.Nil(t, err)
+
+			_, err = repo.TransitionBlogPost(ctx, 1, publish)
+			assert.Nil(t, err)
+
+			// A second post evicts the first from the caches of one post.
+			_, err = repo.AddBlogPost(ctx, blogbus.AddBlogPost{Title: "Other"})
+			assert.Nil(t, err)
+
+			_, err = repo.TrashBlogPost(ctx, 1, time.Now())
+			assert.Nil(t, err)
+
+			_, err = repo.TrashBlogPost(ctx, 1, time.Now())
+			assert.ErrorIs(t, err, blogbus.ErrTrashed)
+
+			// A post in the trash is hidden from every read but the trash listing.
+			_, err = repo.BlogPost(ctx, 1)
+			assert.ErrorIs(t, err, blogbus.ErrTrashed)
+
+			_, err = repo.UpdateBlogPost(ctx, 1, blogbus.UpdateBlogPost{Title: "Updated Title"})
+			assert.ErrorIs(t, err, blogbus.ErrTrashed)
+
+			bpp, err := repo.BlogPosts(ctx, blogbus.Query{}, blogbus.Page{Limit: 10})
+			assert.Nil(t, err)
+			assert.Len(t, bpp.BlogPosts, 1)
+			assert.Equal(t, uint64(2), bpp.BlogPosts[0].ID)
+
+			sp, err := repo.SearchBlogPosts(ctx, "cache", blogbus.Page{Limit: 10})
+			assert.Nil(t, err)
+			assert.Empty(t, sp.Hits)
+
+			assert.Equal(t, []uint64{1}, trashed(t, repo))
+
+			_, err = repo.RestoreBlogPost(ctx, 2)
+			assert.ErrorIs(t, err, blogbus.ErrNotTrashed)
+
+			// A restored post keeps its status and is searched again.
+			_, err = repo.RestoreBlogPost(ctx, 1)
+			assert.Nil(t, err)
+
+			bp, err := repo.BlogPost(ctx, 1)
+			assert.Nil(t, err)
+			assert.Equal(t, blogbus.StatusPublished, bp.Status)
+			assert.True(t, bp.DeletedAt.IsZero())
+
+			sp, err = repo.SearchBlogPosts(ctx, "cache", blogbus.Page{Limit: 10})
+			assert.Nil(t, err)
+			assert.Len(t, sp.Hits, 1)
+
+			assert.Empty(t, trashed(t, repo))
+
+			at := time.Now()
+			_, err = repo.TrashBlogPost(ctx, 1, at)
+			assert.Nil(t, err)
+
+			_, err = repo.PurgeBlogPost(ctx, 1, at)
+			assert.Nil(t, err)
+
+			_, err = repo.BlogPost(ctx, 1)
+			assert.ErrorIs(t, err, cache.ErrItemNotFound)
+
+			assert.Empty(t, trashed(t, repo))
+		})
+	}
+}
+
+// purge moves the post with id to the trash and deletes it permanently.
+func purge(t *testing.T, repo blogbus.Repo, id uint64) {
+	at := time.Now()
+
+	_, err := repo.TrashBlogPost(t.Context(), id, at)
+	assert.Nil(t, err)
+
+	_, err = repo.PurgeBlogPost(t.Context(), id, at)
+	assert.Nil(t, err)
+}
+
 func TestUpdateBlogPost(t *testing.T) {
 	bp := blogbus.AddBlogPost{
 		Title:       "Title",
@@ -230,3 +669,1776 @@ func TestUpdateBlogPost(t *testing.T) {
 		})
 	}
 }
+
+func TestBlogPostRevisions(t *testing.T) {
+	ctx := t.Context()
+	dir := t.TempDir()
+
+	spill, err := sqlitestore.Open(ctx, filepath.Join(dir, "spill.db"))
+	assert.Nil(t, err)
+
+	backend, err := sqlitestore.Open(ctx, filepath.Join(dir, "backend.db"))
+	assert.Nil(t, err)
+
+	repos := map[string]interface {
+		blogbus.Repo
+		Close() error
+	}{
+		"Memory":  blogrepo.NewRepository(2),
+		"Sharded": must(blogrepo.NewMemoryRepository(ctx, cache.Config{Capacity: 2, Shards: 4}, nil)),
+		"Spill":   must(blogrepo.NewMemoryRepository(ctx, cache.Config{Capacity: 1, Eviction: cache.LRU}, spill)),
+		"SQLite":  must(blogrepo.NewSQLiteRepository(ctx, filepath.Join(dir, "blogapp.db"))),
+		"Tiered":  must(blogrepo.NewTieredRepository(ctx, backend, 1, cache.LRU)),
+	}
+
+	for name, repo := range repos {
+		t.Run(name, func(t *testing.T) {
+			defer repo.Close()
+
+			_, err := repo.AddBlogPost(ctx, blogbus.AddBlogPost{Title: "Title", Body: "Body", Editor: "alice"})
+			assert.Nil(t, err)
+
+			// A second post evicts the first from the caches of one post.
+			_, err = repo.AddBlogPost(ctx, blogbus.AddBlogPost{Title: "Other"})
+			assert.Nil(t, err)
+
+			_, err = repo.UpdateBlogPost(ctx, 1, blogbus.UpdateBlogPost{Body: "New Body", Editor: "bob"})
+			assert.Nil(t, err)
+
+			_, err = repo.UpdateBlogPost(ctx, 1, blogbus.UpdateBlogPost{Title: "Title", Body: "Body", Editor: "carol", RestoredFrom: 1})
+			assert.Nil(t, err)
+
+			bp, err := repo.BlogPost(ctx, 1)
+			assert.Nil(t, err)
+			assert.Equal(t, "Body", bp.Body)
+			assert.Len(t, bp.Revisions, 3)
+
+			var editors []string
+			for i, rev := range bp.Revisions {
+				assert.Equal(t, uint64(i+1), rev.Number)
+				assert.Equal(t, []string{"body"}, rev.Changed[len(rev.Changed)-1:])
+				editors = append(editors, rev.Editor)
+			}
+
+			assert.Equal(t, []string{"alice", "bob", "carol"}, editors)
+			assert.Equal(t, "New Body", bp.Revisions[1].Body)
+			assert.Equal(t, uint64(1), bp.Revisions[2].RestoredFrom)
+		})
+	}
+}
+
+func TestTransitionBlogPost(t *testing.T) {
+	ctx := t.Context()
+	dir := t.TempDir()
+
+	spill, err := sqlitestore.Open(ctx, filepath.Join(dir, "spill.db"))
+	assert.Nil(t, err)
+
+	backend, err := sqlitestore.Open(ctx, filepath.Join(dir, "backend.db"))
+	assert.Nil(t, err)
+
+	repos := map[string]interface {
+		blogbus.Repo
+		Close() error
+	}{
+		"Memory":  blogrepo.NewRepository(3),
+		"Sharded": must(blogrepo.NewMemoryRepository(ctx, cache.Config{Capacity: 3, Shards: 4}, nil)),
+		"Spill":   must(blogrepo.NewMemoryRepository(ctx, cache.Config{Capacity: 1, Eviction: cache.LRU}, spill)),
+		"SQLite":  must(blogrepo.NewSQLiteRepository(ctx, filepath.Join(dir, "blogapp.db"))),
+		"Tiered":  must(blogrepo.NewTieredRepository(ctx, backend, 1, cache.LRU)),
+	}
+
+	archive := blogbus.Transition{From: blogbus.StatusPublished, To: blogbus.StatusArchived, At: time.Now()}
+
+	for name, repo := range repos {
+		t.Run(name, func(t *testing.T) {
+			defer repo.Close()
+
+			for _, title := range []string{"Draft", "Published", "Archived"} {
+				_, err := repo.AddBlogPost(ctx, blogbus.AddBlogPost{Title: title, Editor: strings.ToLower(title)})
+				assert.Nil(t, err)
+			}
+
+			bp, err := repo.BlogPost(ctx, 1)
+			assert.Nil(t, err)
+			assert.Equal(t, blogbus.StatusDraft, bp.Status)
+			assert.True(t, bp.PublishedAt.IsZero())
+
+			for _, id := range []uint64{2, 3} {
+				_, err := repo.TransitionBlogPost(ctx, id, publish)
+				assert.Nil(t, err)
+			}
+
+			out, err := repo.TransitionBlogPost(ctx, 3, archive)
+			assert.Nil(t, err)
+			assert.Equal(t, uint64(3), out)
+
+			// Archiving keeps the time the post was published at.
+			bp, err = repo.BlogPost(ctx, 3)
+			assert.Nil(t, err)
+			assert.Equal(t, blogbus.StatusArchived, bp.Status)
+			assert.True(t, publish.At.Equal(bp.PublishedAt))
+
+			// A transition only applies to a post in its From status.
+			_, err = repo.TransitionBlogPost(ctx, 3, publish)
+			assert.ErrorIs(t, err, blogbus.ErrInvalidTransition)
+
+			_, err = repo.TransitionBlogPost(ctx, 4, publish)
+			assert.ErrorIs(t, err, cache.ErrItemNotFound)
+
+			for status, expected := range map[blogbus.Status][]uint64{
+				blogbus.StatusDraft:     {1},
+				blogbus.StatusPublished: {2},
+				blogbus.StatusArchived:  {3},
+				"":                      {1, 2, 3},
+			} {
+				bpp, err := repo.BlogPosts(ctx, blogbus.Query{Status: status}, blogbus.Page{Limit: 10})
+				assert.Nil(t, err)
+
+				var ids []uint64
+				for _, bp := range bpp.BlogPosts {
+					ids = append(ids, bp.ID)
+				}
+
+				assert.Equal(t, expected, ids, status)
+			}
+
+			// The owner narrows the posts of any status to those its user wrote.
+			bpp, err := repo.BlogPosts(ctx, blogbus.Query{Owner: "archived"}, blogbus.Page{Limit: 10})
+			assert.Nil(t, err)
+			assert.Len(t, bpp.BlogPosts, 1)
+			assert.Equal(t, uint64(3), bpp.BlogPosts[0].ID)
+		})
+	}
+}
+
+func TestScheduledBlogPosts(t *testing.T) {
+	ctx := t.Context()
+	dir := t.TempDir()
+
+	spill, err := sqlitestore.Open(ctx, filepath.Join(dir, "spill.db"))
+	assert.Nil(t, err)
+
+	backend, err := sqlitestore.Open(ctx, filepath.Join(dir, "backend.db"))
+	assert.Nil(t, err)
+
+	repos := map[string]interface {
+		blogbus.Repo
+		Close() error
+	}{
+		"Memory":  blogrepo.NewRepository(3),
+		"Sharded": must(blogrepo.NewMemoryRepository(ctx, cache.Config{Capacity: 3, Shards: 4}, nil)),
+		"Spill":   must(blogrepo.NewMemoryRepository(ctx, cache.Config{Capacity: 1, Eviction: cache.LRU}, spill)),
+		"SQLite":  must(blogrepo.NewSQLiteRepository(ctx, filepath.Join(dir, "blogapp.db"))),
+		"Tiered":  must(blogrepo.NewTieredRepository(ctx, backend, 1, cache.LRU)),
+	}
+
+	now := time.Now()
+	schedule := func(at time.Time) blogbus.Transition {
+		return blogbus.Transition{From: blogbus.StatusDraft, To: blogbus.StatusDraft, At: now, PublishAt: at}
+	}
+
+	for name, repo := range repos {
+		t.Run(name, func(t *testing.T) {
+			defer repo.Close()
+
+			for _, title := range []string{"Soon", "Later", "Unscheduled"} {
+				_, err := repo.AddBlogPost(ctx, blogbus.AddBlogPost{Title: title})
+				assert.Nil(t, err)
+			}
+
+			_, err := repo.TransitionBlogPost(ctx, 1, schedule(now.Add(time.Hour)))
+			assert.Nil(t, err)
+			_, err = repo.TransitionBlogPost(ctx, 2, schedule(now.Add(2*time.Hour)))
+			assert.Nil(t, err)
+
+			bp, err := repo.BlogPost(ctx, 1)
+			assert.Nil(t, err)
+			assert.True(t, now.Add(time.Hour).Equal(bp.PublishAt))
+
+			for by, expected := range map[time.Duration][]uint64{
+				30 * time.Minute: nil,
+				time.Hour:        {1},
+				3 * time.Hour:    {1, 2},
+			} {
+				q := blogbus.Query{Status: blogbus.StatusDraft, ScheduledBy: now.Add(by)}
+				bpp, err := repo.BlogPosts(ctx, q, blogbus.Page{Limit: 10})
+				assert.Nil(t, err)
+
+				var ids []uint64
+				for _, bp := range bpp.BlogPosts {
+					ids = append(ids, bp.ID)
+				}
+
+				assert.Equal(t, expected, ids, by)
+			}
+
+			// A due transition only applies to a post scheduled by then.
+			due := publish
+			due.DueBy = now.Add(90 * time.Minute)
+
+			_, err = repo.TransitionBlogPost(ctx, 2, due)
+			assert.ErrorIs(t, err, blogbus.ErrInvalidTransition)
+
+			_, err = repo.TransitionBlogPost(ctx, 1, due)
+			assert.Nil(t, err)
+
+			bp, err = repo.BlogPost(ctx, 1)
+			assert.Nil(t, err)
+			assert.Equal(t, blogbus.StatusPublished, bp.Status)
+			assert.True(t, bp.PublishAt.IsZero())
+		})
+	}
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	bp := blogbus.AddBlogPost{
+		Title:       "Title",
+		Description: "Description",
+		Body:        "Body",
+	}
+
+	capacity := 2 // cache capacity
+	repo := blogrepo.NewRepository(capacity)
+	_, err := repo.AddBlogPost(t.Context(), bp)
+	assert.Nil(t, err)
+
+	buf := new(bytes.Buffer)
+	info, err := repo.Snapshot(t.Context(), buf)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, info.Posts)
+	assert.Equal(t, uint64(1), info.Serial)
+
+	_, err = repo.UpdateBlogPost(t.Context(), 1, blogbus.UpdateBlogPost{Title: "Risky Title"})
+	assert.Nil(t, err)
+	_, err = repo.AddBlogPost(t.Context(), bp)
+	assert.Nil(t, err)
+
+	testCases := []struct {
+		name        string
+		input       []byte
+		nextID      uint64
+		expectedErr error
+	}{
+		{
+			name:        "Success",
+			input:       buf.Bytes(),
+			nextID:      3,
+			expectedErr: nil,
+		},
+		{
+			name:        "Failure",
+			input:       []byte("not a snapshot"),
+			nextID:      4,
+			expectedErr: snapshot.ErrBadMagic,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := repo.Restore(t.Context(), bytes.NewReader(tc.input))
+			assert.ErrorIs(t, err, tc.expectedErr)
+
+			bpp, err := repo.BlogPosts(t.Context(), blogbus.Query{}, blogbus.Page{Limit: 10})
+			assert.Nil(t, err)
+			assert.Len(t, bpp.BlogPosts, 1)
+			assert.Equal(t, bp.Title, bpp.BlogPosts[0].Title)
+
+			// IDs issued after the snapshot are not reused.
+			id, err := repo.AddBlogPost(t.Context(), bp)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.nextID, id)
+
+			purge(t, repo, id)
+		})
+	}
+}
+
+func TestSpill(t *testing.T) {
+	bp := blogbus.AddBlogPost{
+		Title:       "Title",
+		Description: "Description",
+		Body:        "Body",
+	}
+
+	ctx := t.Context()
+	path := filepath.Join(t.TempDir(), "spill.db")
+
+	spill, err := sqlitestore.Open(ctx, path)
+	assert.Nil(t, err)
+
+	capacity := 2 // cache capacity
+	repo, err := blogrepo.NewMemoryRepository(ctx, cache.Config{Capacity: capacity, Eviction: cache.LRU}, spill)
+	assert.Nil(t, err)
+
+	for range 3 {
+		_, err := repo.AddBlogPost(ctx, bp)
+		assert.Nil(t, err)
+	}
+
+	// Post 1 was evicted to the spill and is still listed and readable.
+	bpp, err := repo.BlogPosts(ctx, blogbus.Query{}, blogbus.Page{Limit: 10})
+	assert.Nil(t, err)
+	assert.Len(t, bpp.BlogPosts, 3)
+
+	_, err = repo.UpdateBlogPost(ctx, 1, blogbus.UpdateBlogPost{Title: "Updated Title"})
+	assert.Nil(t, err)
+
+	out, err := repo.BlogPost(ctx, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, "Updated Title", out.Title)
+
+	// Reading post 1 promoted it and evicted post 2 in its place.
+	purge(t, repo, 2)
+
+	_, err = repo.BlogPost(ctx, 2)
+	assert.ErrorIs(t, err, cache.ErrItemNotFound)
+	assert.Nil(t, repo.Close())
+
+	// The cached posts are gone with the process, a new repository continues after the spilled ones.
+	spill, err = sqlitestore.Open(ctx, path)
+	assert.Nil(t, err)
+
+	repo, err = blogrepo.NewMemoryRepository(ctx, cache.Config{Capacity: capacity, Eviction: cache.LRU}, spill)
+	assert.Nil(t, err)
+	defer repo.Close()
+
+	id, err := repo.AddBlogPost(ctx, bp)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(3), id)
+}
+
+func TestTieredRepository(t *testing.T) {
+	bp := blogbus.AddBlogPost{
+		Title:       "Title",
+		Description: "Description",
+		Body:        "Body",
+	}
+
+	ctx := t.Context()
+
+	backend, err := sqlitestore.Open(ctx, filepath.Join(t.TempDir(), "blogapp.db"))
+	assert.Nil(t, err)
+
+	capacity := 1 // hot cache capacity
+	repo, err := blogrepo.NewTieredRepository(t.Context(), backend, capacity, cache.LRU)
+	assert.Nil(t, err)
+	defer repo.Close()
+
+	// The hot cache does not bound the backend.
+	for range 2 {
+		_, err := repo.AddBlogPost(ctx, bp)
+		assert.Nil(t, err)
+	}
+
+	for _, id := range []uint64{1, 1, 2} {
+		_, err := repo.BlogPost(ctx, id)
+		assert.Nil(t, err)
+	}
+
+	// An update reads the hot copy for the slug of the post, then reaches the backend and drops it.
+	_, err = repo.UpdateBlogPost(ctx, 2, blogbus.UpdateBlogPost{Title: "Updated Title"})
+	assert.Nil(t, err)
+
+	out, err := repo.BlogPost(ctx, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, "Updated Title", out.Title)
+
+	// Trashing drops the hot copy, purging reads the trashed post from the backend.
+	purge(t, repo, 2)
+
+	_, err = repo.BlogPost(ctx, 2)
+	assert.ErrorIs(t, err, cache.ErrItemNotFound)
+
+	stats, err := repo.CacheStats(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, blogbus.CacheStats{Hits: 2, Misses: 5, Evictions: 1, Posts: 0, Capacity: capacity}, stats)
+}
+
+func TestSearchBlogPosts(t *testing.T) {
+	posts := []blogbus.AddBlogPost{
+		{Title: "Caching in Go", Description: "Eviction policies", Body: "A cache keeps the hot posts."},
+		{Title: "Testing in Go", Description: "Table driven tests", Body: "Tests that share a cache run serially."},
+		{Title: "Rust Basics", Description: "Ownership", Body: "Borrowing rules."},
+		{Title: "Deleted", Description: "Gone", Body: "A cached post that is deleted."},
+	}
+
+	ctx := t.Context()
+	dir := t.TempDir()
+
+	spill, err := sqlitestore.Open(ctx, filepath.Join(dir, "spill.db"))
+	assert.Nil(t, err)
+
+	repos := map[string]interface {
+		blogbus.Repo
+		Close() error
+	}{
+		"Memory": blogrepo.NewRepository(len(posts)),
+		"Spill":  must(blogrepo.NewMemoryRepository(ctx, cache.Config{Capacity: 2, Eviction: cache.LRU}, spill)),
+		"SQLite": must(blogrepo.NewSQLiteRepository(ctx, filepath.Join(dir, "blogapp.db"))),
+	}
+
+	for name, repo := range repos {
+		t.Run(name, func(t *testing.T) {
+			defer repo.Close()
+
+			for _, abp := range posts {
+				id, err := repo.AddBlogPost(ctx, abp)
+				assert.Nil(t, err)
+
+				// Drafts are not searched.
+				sp, err := repo.SearchBlogPosts(ctx, abp.Description, blogbus.Page{Limit: 10})
+				assert.Nil(t, err)
+				assert.Empty(t, sp.Hits)
+
+				_, err = repo.TransitionBlogPost(ctx, id, publish)
+				assert.Nil(t, err)
+			}
+
+			_, err := repo.UpdateBlogPost(ctx, 3, blogbus.UpdateBlogPost{Body: "A cache for Rust."})
+			assert.Nil(t, err)
+
+			_, err = repo.TrashBlogPost(ctx, 4, time.Now())
+			assert.Nil(t, err)
+
+			var hits []blogbus.SearchHit
+			page := blogbus.Page{Limit: 2}
+
+			for {
+				sp, err := repo.SearchBlogPosts(ctx, "caches", page)
+				assert.Nil(t, err)
+
+				hits = append(hits, sp.Hits...)
+
+				if !sp.HasMore {
+					break
+				}
+
+				page.Cursor = sp.NextCursor
+			}
+
+			// The post with the term in its title ranks first.
+			assert.Len(t, hits, 3)
+			assert.Equal(t, uint64(1), hits[0].ID)
+			assert.Equal(t, "Caching in Go", hits[0].Title)
+			assert.Equal(t, []blogbus.Snippet{
+				{Field: "title", Text: "<mark>Caching</mark> in Go"},
+				{Field: "body", Text: "A <mark>cache</mark> keeps the hot posts."},
+			}, hits[0].Snippets)
+			assert.ElementsMatch(t, []uint64{2, 3}, []uint64{hits[1].ID, hits[2].ID})
+
+			// A cursor only continues the search it was issued for.
+			sp, err := repo.SearchBlogPosts(ctx, "caches", blogbus.Page{Limit: 1})
+			assert.Nil(t, err)
+
+			_, err = repo.SearchBlogPosts(ctx, "rust", blogbus.Page{Limit: 1, Cursor: sp.NextCursor})
+			assert.ErrorIs(t, err, blogbus.ErrInvalidCursor)
+		})
+	}
+}
+
+func TestSearchIndexRebuild(t *testing.T) {
+	ctx := t.Context()
+	path := filepath.Join(t.TempDir(), "blogapp.db")
+
+	repo, err := blogrepo.NewSQLiteRepository(ctx, path)
+	assert.Nil(t, err)
+
+	id, err := repo.AddBlogPost(ctx, blogbus.AddBlogPost{Title: "Persistent Search"})
+	assert.Nil(t, err)
+
+	_, err = repo.TransitionBlogPost(ctx, id, publish)
+	assert.Nil(t, err)
+
+	buf := new(bytes.Buffer)
+	_, err = repo.Snapshot(ctx, buf)
+	assert.Nil(t, err)
+	assert.Nil(t, repo.Close())
+
+	// The index is rebuilt from the stored posts on open.
+	repo, err = blogrepo.NewSQLiteRepository(ctx, path)
+	assert.Nil(t, err)
+	defer repo.Close()
+
+	sp, err := repo.SearchBlogPosts(ctx, "persistent", blogbus.Page{Limit: 10})
+	assert.Nil(t, err)
+	assert.Len(t, sp.Hits, 1)
+
+	id, err = repo.AddBlogPost(ctx, blogbus.AddBlogPost{Title: "Persistent Cache"})
+	assert.Nil(t, err)
+
+	_, err = repo.TransitionBlogPost(ctx, id, publish)
+	assert.Nil(t, err)
+
+	// And replaced by the posts of a restored snapshot.
+	_, err = repo.Restore(ctx, buf)
+	assert.Nil(t, err)
+
+	sp, err = repo.SearchBlogPosts(ctx, "persistent", blogbus.Page{Limit: 10})
+	assert.Nil(t, err)
+	assert.Len(t, sp.Hits, 1)
+	assert.Equal(t, "Persistent Search", sp.Hits[0].Title)
+
+	// Posts dropped by eviction can no longer be found.
+	memory, err := blogrepo.NewMemoryRepository(ctx, cache.Config{Capacity: 1, Eviction: cache.LRU}, nil)
+	assert.Nil(t, err)
+
+	for _, title := range []string{"Evicted Post", "Kept Post"} {
+		id, err := memory.AddBlogPost(ctx, blogbus.AddBlogPost{Title: title})
+		assert.Nil(t, err)
+
+		_, err = memory.TransitionBlogPost(ctx, id, publish)
+		assert.Nil(t, err)
+	}
+
+	sp, err = memory.SearchBlogPosts(ctx, "post", blogbus.Page{Limit: 10})
+	assert.Nil(t, err)
+	assert.Len(t, sp.Hits, 1)
+	assert.Equal(t, "Kept Post", sp.Hits[0].Title)
+}
+
+func TestSlugs(t *testing.T) {
+	ctx := t.Context()
+	dir := t.TempDir()
+
+	spill, err := sqlitestore.Open(ctx, filepath.Join(dir, "spill.db"))
+	assert.Nil(t, err)
+
+	backend, err := sqlitestore.Open(ctx, filepath.Join(dir, "backend.db"))
+	assert.Nil(t, err)
+
+	repos := map[string]interface {
+		blogbus.Repo
+		Close() error
+	}{
+		"Memory":  blogrepo.NewRepository(5),
+		"Sharded": must(blogrepo.NewMemoryRepository(ctx, cache.Config{Capacity: 5, Shards: 4}, nil)),
+		"Spill":   must(blogrepo.NewMemoryRepository(ctx, cache.Config{Capacity: 1, Eviction: cache.LRU}, spill)),
+		"SQLite":  must(blogrepo.NewSQLiteRepository(ctx, filepath.Join(dir, "blogapp.db"))),
+		"Tiered":  must(blogrepo.NewTieredRepository(ctx, backend, 1, cache.LRU)),
+	}
+
+	slug := func(t *testing.T, repo blogbus.Repo, id uint64) string {
+		bp, err := repo.BlogPost(ctx, id)
+		assert.Nil(t, err)
+		return bp.Slug
+	}
+
+	for name, repo := range repos {
+		t.Run(name, func(t *testing.T) {
+			defer repo.Close()
+
+			// Posts with the same title get suffixed slugs.
+			for range 2 {
+				_, err := repo.AddBlogPost(ctx, blogbus.AddBlogPost{Title: "Hello, World"})
+				assert.Nil(t, err)
+			}
+
+			_, err := repo.AddBlogPost(ctx, blogbus.AddBlogPost{Title: "Other", Slug: "hello-world-3"})
+			assert.Nil(t, err)
+
+			id, err := repo.AddBlogPost(ctx, blogbus.AddBlogPost{Title: "Hello, World"})
+			assert.Nil(t, err)
+
+			assert.Equal(t, "hello-world", slug(t, repo, 1))
+			assert.Equal(t, "hello-world-2", slug(t, repo, 2))
+			assert.Equal(t, "hello-world-3", slug(t, repo, 3))
+			assert.Equal(t, "hello-world-4", slug(t, repo, id))
+
+			_, err = repo.AddBlogPost(ctx, blogbus.AddBlogPost{Title: "Taken", Slug: "hello-world"})
+			assert.ErrorIs(t, err, blogbus.ErrSlugTaken)
+
+			// A generated slug follows the title, the old slug still leads to the post.
+			_, err = repo.UpdateBlogPost(ctx, 1, blogbus.UpdateBlogPost{Title: "Goodbye, World"})
+			assert.Nil(t, err)
+			assert.Equal(t, "goodbye-world", slug(t, repo, 1))
+
+			bp, err := repo.BlogPostBySlug(ctx, "hello-world")
+			assert.Nil(t, err)
+			assert.Equal(t, uint64(1), bp.ID)
+			assert.Equal(t, "goodbye-world", bp.Slug)
+
+			// A chosen slug stays when the title changes.
+			_, err = repo.UpdateBlogPost(ctx, 3, blogbus.UpdateBlogPost{Title: "Renamed"})
+			assert.Nil(t, err)
+			assert.Equal(t, "hello-world-3", slug(t, repo, 3))
+
+			_, err = repo.UpdateBlogPost(ctx, 2, blogbus.UpdateBlogPost{Slug: "hello-world"})
+			assert.ErrorIs(t, err, blogbus.ErrSlugTaken)
+
+			_, err = repo.UpdateBlogPost(ctx, 2, blogbus.UpdateBlogPost{Slug: "second"})
+			assert.Nil(t, err)
+
+			bp, err = repo.BlogPostBySlug(ctx, "second")
+			assert.Nil(t, err)
+			assert.Equal(t, uint64(2), bp.ID)
+
+			// A trashed post keeps its slugs, a purged post frees them.
+			at := time.Now()
+			_, err = repo.TrashBlogPost(ctx, 1, at)
+			assert.Nil(t, err)
+
+			_, err = repo.BlogPostBySlug(ctx, "goodbye-world")
+			assert.ErrorIs(t, err, blogbus.ErrTrashed)
+
+			_, err = repo.AddBlogPost(ctx, blogbus.AddBlogPost{Title: "Goodbye, World"})
+			assert.Nil(t, err)
+			assert.Equal(t, "goodbye-world-2", slug(t, repo, id+1))
+
+			_, err = repo.PurgeBlogPost(ctx, 1, at)
+			assert.Nil(t, err)
+
+			for _, s := range []string{"goodbye-world", "hello-world"} {
+				_, err = repo.BlogPostBySlug(ctx, s)
+				assert.ErrorIs(t, err, cache.ErrItemNotFound)
+			}
+
+			_, err = repo.AddBlogPost(ctx, blogbus.AddBlogPost{Title: "New", Slug: "hello-world"})
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func TestSlugsReload(t *testing.T) {
+	ctx := t.Context()
+	path := filepath.Join(t.TempDir(), "blogapp.db")
+
+	repo, err := blogrepo.NewSQLiteRepository(ctx, path)
+	assert.Nil(t, err)
+
+	_, err = repo.AddBlogPost(ctx, blogbus.AddBlogPost{Title: "First Title"})
+	assert.Nil(t, err)
+
+	_, err = repo.UpdateBlogPost(ctx, 1, blogbus.UpdateBlogPost{Title: "Second Title"})
+	assert.Nil(t, err)
+	assert.Nil(t, repo.Close())
+
+	// The slugs are claimed again from the stored posts on open.
+	repo, err = blogrepo.NewSQLiteRepository(ctx, path)
+	assert.Nil(t, err)
+	defer repo.Close()
+
+	bp, err := repo.BlogPostBySlug(ctx, "first-title")
+	assert.Nil(t, err)
+	assert.Equal(t, "second-title", bp.Slug)
+
+	_, err = repo.AddBlogPost(ctx, blogbus.AddBlogPost{Title: "First Title"})
+	assert.Nil(t, err)
+
+	bp, err = repo.BlogPost(ctx, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, "first-title-2", bp.Slug)
+
+	// Posts of a snapshot taken before posts had a slug are given one, a clash goes to the lowest ID.
+	now := time.Now()
+	buf := new(bytes.Buffer)
+	_, err = snapshot.Write(buf, blogbus.Snapshot{
+		Serial: 3,
+		Posts: []blogbus.BlogPost{
+			{ID: 1, Title: "Legacy", Status: blogbus.StatusPublished, CreatedAt: now, UpdatedAt: now},
+			{ID: 2, Title: "Clash", Status: blogbus.StatusPublished, CreatedAt: now, UpdatedAt: now, Slug: "legacy"},
+			{ID: 3, Title: "Legacy", Status: blogbus.StatusPublished, CreatedAt: now, UpdatedAt: now, Slug: "legacy-2"},
+		},
+	})
+	assert.Nil(t, err)
+
+	_, err = repo.Restore(ctx, buf)
+	assert.Nil(t, err)
+
+	var slugs []string
+	for id := range uint64(3) {
+		bp, err := repo.BlogPost(ctx, id+1)
+		assert.Nil(t, err)
+		slugs = append(slugs, bp.Slug)
+	}
+
+	assert.Equal(t, []string{"legacy-3", "legacy", "legacy-2"}, slugs)
+
+	// Posts dropped by eviction free their slugs.
+	memory, err := blogrepo.NewMemoryRepository(ctx, cache.Config{Capacity: 1, Eviction: cache.LRU}, nil)
+	assert.Nil(t, err)
+
+	for range 2 {
+		_, err := memory.AddBlogPost(ctx, blogbus.AddBlogPost{Title: "Dropped"})
+		assert.Nil(t, err)
+	}
+
+	_, err = memory.BlogPostBySlug(ctx, "dropped")
+	assert.ErrorIs(t, err, cache.ErrItemNotFound)
+
+	_, err = memory.AddBlogPost(ctx, blogbus.AddBlogPost{Title: "Dropped Again", Slug: "dropped"})
+	assert.Nil(t, err)
+}
+
+func TestTaxonomy(t *testing.T) {
+	ctx := t.Context()
+	dir := t.TempDir()
+
+	spill, err := sqlitestore.Open(ctx, filepath.Join(dir, "spill.db"))
+	assert.Nil(t, err)
+
+	backend, err := sqlitestore.Open(ctx, filepath.Join(dir, "backend.db"))
+	assert.Nil(t, err)
+
+	repos := map[string]interface {
+		blogbus.Repo
+		Close() error
+	}{
+		"Memory":  blogrepo.NewRepository(5),
+		"Sharded": must(blogrepo.NewMemoryRepository(ctx, cache.Config{Capacity: 5, Shards: 4}, nil)),
+		"Spill":   must(blogrepo.NewMemoryRepository(ctx, cache.Config{Capacity: 1, Eviction: cache.LRU}, spill)),
+		"SQLite":  must(blogrepo.NewSQLiteRepository(ctx, filepath.Join(dir, "blogapp.db"))),
+		"Tiered":  must(blogrepo.NewTieredRepository(ctx, backend, 1, cache.LRU)),
+	}
+
+	listed := func(t *testing.T, repo blogbus.Repo, q blogbus.Query) []uint64 {
+		bpp, err := repo.BlogPosts(ctx, q, blogbus.Page{Limit: 10})
+		assert.Nil(t, err)
+
+		var ids []uint64
+		for _, bp := range bpp.BlogPosts {
+			ids = append(ids, bp.ID)
+		}
+
+		return ids
+	}
+
+	now := time.Now()
+
+	for name, repo := range repos {
+		t.Run(name, func(t *testing.T) {
+			defer repo.Close()
+
+			for _, slug := range []string{"go", "rust"} {
+				assert.Nil(t, repo.AddTag(ctx, blogbus.Tag{Slug: slug, Name: slug, CreatedAt: now}))
+			}
+
+			for _, c := range []blogbus.Category{
+				{Slug: "programming", Name: "Programming"},
+				{Slug: "languages", Name: "Languages", Parent: "programming"},
+				{Slug: "travel", Name: "Travel"},
+			} {
+				c.CreatedAt = now
+				assert.Nil(t, repo.AddCategory(ctx, c))
+			}
+
+			assert.ErrorIs(t, repo.AddTag(ctx, blogbus.Tag{Slug: "go", Name: "Go"}), blogbus.ErrTagExists)
+			assert.ErrorIs(t, repo.AddCategory(ctx, blogbus.Category{Slug: "orphan", Name: "Orphan", Parent: "missing"}), blogbus.ErrUnknownTerm)
+
+			_, err := repo.AddBlogPost(ctx, blogbus.AddBlogPost{Title: "Unknown", Tags: []string{"missing"}})
+			assert.ErrorIs(t, err, blogbus.ErrUnknownTerm)
+
+			first, err := repo.AddBlogPost(ctx, blogbus.AddBlogPost{Title: "Go", Tags: []string{"go"}, Categories: []string{"languages"}})
+			assert.Nil(t, err)
+
+			second, err := repo.AddBlogPost(ctx, blogbus.AddBlogPost{Title: "Rust", Tags: []string{"go", "rust"}, Categories: []string{"programming"}})
+			assert.Nil(t, err)
+
+			third, err := repo.AddBlogPost(ctx, blogbus.AddBlogPost{Title: "Trip", Categories: []string{"travel"}})
+			assert.Nil(t, err)
+
+			bp, err := repo.BlogPost(ctx, second)
+			assert.Nil(t, err)
+			assert.Equal(t, []string{"go", "rust"}, bp.Tags)
+			assert.Equal(t, []string{"programming"}, bp.Categories)
+
+			// A category lists the posts of its subcategories too.
+			assert.Equal(t, []uint64{first, second}, listed(t, repo, blogbus.Query{Tag: "go"}))
+			assert.Equal(t, []uint64{first, second}, listed(t, repo, blogbus.Query{Category: "programming"}))
+			assert.Equal(t, []uint64{first}, listed(t, repo, blogbus.Query{Category: "languages"}))
+			assert.Equal(t, []uint64{second}, listed(t, repo, blogbus.Query{Tag: "rust", Category: "programming"}))
+			assert.Empty(t, listed(t, repo, blogbus.Query{Tag: "missing"}))
+
+			// An update without terms keeps them, an empty list removes them.
+			_, err = repo.UpdateBlogPost(ctx, first, blogbus.UpdateBlogPost{Title: "Go Again"})
+			assert.Nil(t, err)
+			assert.Equal(t, []uint64{first, second}, listed(t, repo, blogbus.Query{Tag: "go"}))
+
+			_, err = repo.UpdateBlogPost(ctx, third, blogbus.UpdateBlogPost{Tags: []string{"rust"}, Categories: []string{}})
+			assert.Nil(t, err)
+			assert.Empty(t, listed(t, repo, blogbus.Query{Category: "travel"}))
+			assert.Equal(t, []uint64{second, third}, listed(t, repo, blogbus.Query{Tag: "rust"}))
+
+			// The cloud only counts published posts.
+			for _, id := range []uint64{first, second} {
+				_, err = repo.TransitionBlogPost(ctx, id, publish)
+				assert.Nil(t, err)
+			}
+
+			cloud, err := repo.TagCloud(ctx)
+			assert.Nil(t, err)
+			assert.Len(t, cloud, 2)
+			assert.Equal(t, "go", cloud[0].Slug)
+			assert.Equal(t, 2, cloud[0].Posts)
+			assert.Equal(t, 1, cloud[1].Posts)
+
+			// Terms in use, even by a trashed post, or with subcategories cannot be deleted.
+			_, err = repo.TrashBlogPost(ctx, third, now)
+			assert.Nil(t, err)
+
+			assert.ErrorIs(t, repo.DeleteTag(ctx, "rust"), blogbus.ErrTermInUse)
+			assert.ErrorIs(t, repo.DeleteCategory(ctx, "programming"), blogbus.ErrTermInUse)
+			assert.Nil(t, repo.DeleteCategory(ctx, "travel"))
+			assert.ErrorIs(t, repo.DeleteTag(ctx, "missing"), blogbus.ErrTagNotFound)
+
+			_, err = repo.PurgeBlogPost(ctx, third, now)
+			assert.Nil(t, err)
+
+			_, err = repo.UpdateBlogPost(ctx, second, blogbus.UpdateBlogPost{Tags: []string{"go"}})
+			assert.Nil(t, err)
+			assert.Nil(t, repo.DeleteTag(ctx, "rust"))
+
+			_, err = repo.Tag(ctx, "rust")
+			assert.ErrorIs(t, err, blogbus.ErrTagNotFound)
+
+			// A category cannot move under itself or its subcategories.
+			languages := "languages"
+			_, err = repo.UpdateCategory(ctx, "programming", blogbus.UpdateCategory{Parent: &languages})
+			assert.ErrorIs(t, err, blogbus.ErrCategoryCycle)
+
+			top := ""
+			c, err := repo.UpdateCategory(ctx, "languages", blogbus.UpdateCategory{Name: "Langs", Parent: &top})
+			assert.Nil(t, err)
+			assert.Equal(t, blogbus.Category{Slug: "languages", Name: "Langs", CreatedAt: c.CreatedAt}, c)
+			assert.Equal(t, []uint64{second}, listed(t, repo, blogbus.Query{Category: "programming"}))
+
+			tg, err := repo.UpdateTag(ctx, "go", blogbus.UpdateTag{Name: "Golang"})
+			assert.Nil(t, err)
+			assert.Equal(t, "Golang", tg.Name)
+
+			cs, err := repo.Categories(ctx)
+			assert.Nil(t, err)
+			assert.Len(t, cs, 2)
+		})
+	}
+}
+
+func TestTaxonomyReload(t *testing.T) {
+	ctx := t.Context()
+	dir := t.TempDir()
+	now := time.Now().UTC()
+
+	open := map[string]func() (interface {
+		blogbus.Repo
+		Close() error
+	}, error){
+		"SQLite": func() (interface {
+			blogbus.Repo
+			Close() error
+		}, error) {
+			return blogrepo.NewSQLiteRepository(ctx, filepath.Join(dir, "blogapp.db"))
+		},
+		"WAL": func() (interface {
+			blogbus.Repo
+			Close() error
+		}, error) {
+			return blogrepo.NewWALRepository(filepath.Join(dir, "blogapp.wal"), 5, wal.Options{})
+		},
+		"File": func() (interface {
+			blogbus.Repo
+			Close() error
+		}, error) {
+			return blogrepo.NewFileRepository(filepath.Join(dir, "store"), 5, 0, wal.Options{})
+		},
+	}
+
+	for name, open := range open {
+		t.Run(name, func(t *testing.T) {
+			repo, err := open()
+			assert.Nil(t, err)
+
+			assert.Nil(t, repo.AddTag(ctx, blogbus.Tag{Slug: "go", Name: "Go", CreatedAt: now}))
+			assert.Nil(t, repo.AddTag(ctx, blogbus.Tag{Slug: "old", Name: "Old", CreatedAt: now}))
+			assert.Nil(t, repo.AddCategory(ctx, blogbus.Category{Slug: "dev", Name: "Dev", CreatedAt: now}))
+			assert.Nil(t, repo.AddCategory(ctx, blogbus.Category{Slug: "go", Name: "Go", Parent: "dev", CreatedAt: now}))
+			assert.Nil(t, repo.DeleteTag(ctx, "old"))
+
+			id, err := repo.AddBlogPost(ctx, blogbus.AddBlogPost{Title: "Go", Tags: []string{"go"}, Categories: []string{"go"}})
+			assert.Nil(t, err)
+			assert.Nil(t, repo.Close())
+
+			// The terms and the index are loaded again from the storage on open.
+			repo, err = open()
+			assert.Nil(t, err)
+			defer repo.Close()
+
+			tags, err := repo.Tags(ctx)
+			assert.Nil(t, err)
+			assert.Len(t, tags, 1)
+			assert.Equal(t, "go", tags[0].Slug)
+			assert.True(t, now.Equal(tags[0].CreatedAt))
+
+			cs, err := repo.Categories(ctx)
+			assert.Nil(t, err)
+			assert.Len(t, cs, 2)
+			assert.Equal(t, "dev", cs[1].Parent)
+
+			bpp, err := repo.BlogPosts(ctx, blogbus.Query{Category: "dev"}, blogbus.Page{Limit: 10})
+			assert.Nil(t, err)
+			assert.Len(t, bpp.BlogPosts, 1)
+			assert.Equal(t, id, bpp.BlogPosts[0].ID)
+
+			assert.ErrorIs(t, repo.DeleteCategory(ctx, "go"), blogbus.ErrTermInUse)
+		})
+	}
+}
+
+func TestAuthors(t *testing.T) {
+	ctx := t.Context()
+	dir := t.TempDir()
+
+	spill, err := sqlitestore.Open(ctx, filepath.Join(dir, "spill.db"))
+	assert.Nil(t, err)
+
+	backend, err := sqlitestore.Open(ctx, filepath.Join(dir, "backend.db"))
+	assert.Nil(t, err)
+
+	repos := map[string]interface {
+		blogbus.Repo
+		Close() error
+	}{
+		"Memory":  blogrepo.NewRepository(5),
+		"Sharded": must(blogrepo.NewMemoryRepository(ctx, cache.Config{Capacity: 5, Shards: 4}, nil)),
+		"Spill":   must(blogrepo.NewMemoryRepository(ctx, cache.Config{Capacity: 1, Eviction: cache.LRU}, spill)),
+		"SQLite":  must(blogrepo.NewSQLiteRepository(ctx, filepath.Join(dir, "blogapp.db"))),
+		"Tiered":  must(blogrepo.NewTieredRepository(ctx, backend, 1, cache.LRU)),
+	}
+
+	listed := func(t *testing.T, repo blogbus.Repo, authorID uint64) []uint64 {
+		bpp, err := repo.BlogPosts(ctx, blogbus.Query{AuthorID: authorID}, blogbus.Page{Limit: 10})
+		assert.Nil(t, err)
+
+		var ids []uint64
+		for _, bp := range bpp.BlogPosts {
+			ids = append(ids, bp.ID)
+		}
+
+		return ids
+	}
+
+	now := time.Now()
+
+	for name, repo := range repos {
+		t.Run(name, func(t *testing.T) {
+			defer repo.Close()
+
+			ann, err := repo.AddAuthor(ctx, blogbus.Author{Name: "Ann", Email: "ann@example.com", CreatedAt: now, UpdatedAt: now})
+			assert.Nil(t, err)
+			assert.Equal(t, uint64(1), ann.ID)
+
+			bob, err := repo.AddAuthor(ctx, blogbus.Author{Name: "Bob", CreatedAt: now, UpdatedAt: now})
+			assert.Nil(t, err)
+			assert.Equal(t, uint64(2), bob.ID)
+
+			as, err := repo.Authors(ctx)
+			assert.Nil(t, err)
+			assert.Len(t, as, 2)
+			assert.Equal(t, "Ann", as[0].Name)
+
+			a, err := repo.UpdateAuthor(ctx, ann.ID, blogbus.UpdateAuthor{Bio: "Writes about Go"})
+			assert.Nil(t, err)
+			assert.Equal(t, "Writes about Go", a.Bio)
+			assert.Equal(t, "ann@example.com", a.Email)
+
+			_, err = repo.UpdateAuthor(ctx, 9, blogbus.UpdateAuthor{Name: "Nobody"})
+			assert.ErrorIs(t, err, blogbus.ErrAuthorNotFound)
+
+			_, err = repo.AddBlogPost(ctx, blogbus.AddBlogPost{Title: "Unknown", AuthorID: 9})
+			assert.ErrorIs(t, err, blogbus.ErrUnknownAuthor)
+
+			first, err := repo.AddBlogPost(ctx, blogbus.AddBlogPost{Title: "First", AuthorID: ann.ID})
+			assert.Nil(t, err)
+
+			second, err := repo.AddBlogPost(ctx, blogbus.AddBlogPost{Title: "Second", AuthorID: ann.ID})
+			assert.Nil(t, err)
+
+			third, err := repo.AddBlogPost(ctx, blogbus.AddBlogPost{Title: "Third"})
+			assert.Nil(t, err)
+
+			bp, err := repo.BlogPost(ctx, first)
+			assert.Nil(t, err)
+			assert.Equal(t, ann.ID, bp.AuthorID)
+
+			assert.Equal(t, []uint64{first, second}, listed(t, repo, ann.ID))
+
+			// An update without an author keeps it.
+			_, err = repo.UpdateBlogPost(ctx, first, blogbus.UpdateBlogPost{Title: "First Again"})
+			assert.Nil(t, err)
+			assert.Equal(t, []uint64{first, second}, listed(t, repo, ann.ID))
+
+			_, err = repo.UpdateBlogPost(ctx, third, blogbus.UpdateBlogPost{AuthorID: bob.ID})
+			assert.Nil(t, err)
+			assert.Equal(t, []uint64{third}, listed(t, repo, bob.ID))
+
+			_, err = repo.UpdateBlogPost(ctx, third, blogbus.UpdateBlogPost{AuthorID: 9})
+			assert.ErrorIs(t, err, blogbus.ErrUnknownAuthor)
+
+			// An author with posts, even trashed ones, is only deleted by reassigning them.
+			_, err = repo.TrashBlogPost(ctx, second, now)
+			assert.Nil(t, err)
+
+			assert.ErrorIs(t, repo.DeleteAuthor(ctx, ann.ID, 0), blogbus.ErrAuthorInUse)
+			assert.ErrorIs(t, repo.DeleteAuthor(ctx, ann.ID, ann.ID), blogbus.ErrInvalidReassign)
+			assert.ErrorIs(t, repo.DeleteAuthor(ctx, ann.ID, 9), blogbus.ErrUnknownAuthor)
+			assert.ErrorIs(t, repo.DeleteAuthor(ctx, 9, 0), blogbus.ErrAuthorNotFound)
+
+			assert.Nil(t, repo.DeleteAuthor(ctx, ann.ID, bob.ID))
+
+			_, err = repo.Author(ctx, ann.ID)
+			assert.ErrorIs(t, err, blogbus.ErrAuthorNotFound)
+
+			assert.Equal(t, []uint64{first, third}, listed(t, repo, bob.ID))
+
+			bpp, err := repo.BlogPosts(ctx, blogbus.Query{AuthorID: bob.ID, Trashed: true}, blogbus.Page{Limit: 10})
+			assert.Nil(t, err)
+			assert.Len(t, bpp.BlogPosts, 1)
+			assert.Equal(t, second, bpp.BlogPosts[0].ID)
+
+			// Once its posts are purged an author is deleted without reassigning.
+			for _, id := range []uint64{first, third} {
+				purge(t, repo, id)
+			}
+
+			_, err = repo.PurgeBlogPost(ctx, second, now)
+			assert.Nil(t, err)
+
+			assert.Nil(t, repo.DeleteAuthor(ctx, bob.ID, 0))
+
+			as, err = repo.Authors(ctx)
+			assert.Nil(t, err)
+			assert.Empty(t, as)
+		})
+	}
+}
+
+func TestAuthorsReload(t *testing.T) {
+	ctx := t.Context()
+	dir := t.TempDir()
+	now := time.Now().UTC()
+
+	open := map[string]func() (interface {
+		blogbus.Repo
+		Close() error
+	}, error){
+		"SQLite": func() (interface {
+			blogbus.Repo
+			Close() error
+		}, error) {
+			return blogrepo.NewSQLiteRepository(ctx, filepath.Join(dir, "blogapp.db"))
+		},
+		"WAL": func() (interface {
+			blogbus.Repo
+			Close() error
+		}, error) {
+			return blogrepo.NewWALRepository(filepath.Join(dir, "blogapp.wal"), 5, wal.Options{})
+		},
+		"File": func() (interface {
+			blogbus.Repo
+			Close() error
+		}, error) {
+			return blogrepo.NewFileRepository(filepath.Join(dir, "store"), 5, 0, wal.Options{})
+		},
+	}
+
+	for name, open := range open {
+		t.Run(name, func(t *testing.T) {
+			repo, err := open()
+			assert.Nil(t, err)
+
+			ann, err := repo.AddAuthor(ctx, blogbus.Author{Name: "Ann", CreatedAt: now, UpdatedAt: now})
+			assert.Nil(t, err)
+
+			old, err := repo.AddAuthor(ctx, blogbus.Author{Name: "Old", CreatedAt: now, UpdatedAt: now})
+			assert.Nil(t, err)
+			assert.Nil(t, repo.DeleteAuthor(ctx, old.ID, 0))
+
+			id, err := repo.AddBlogPost(ctx, blogbus.AddBlogPost{Title: "Go", AuthorID: ann.ID})
+			assert.Nil(t, err)
+			assert.Nil(t, repo.Close())
+
+			// The authors and the posts of every author are loaded again from the storage on open.
+			repo, err = open()
+			assert.Nil(t, err)
+			defer repo.Close()
+
+			as, err := repo.Authors(ctx)
+			assert.Nil(t, err)
+			assert.Len(t, as, 1)
+			assert.Equal(t, "Ann", as[0].Name)
+			assert.True(t, now.Equal(as[0].CreatedAt))
+
+			bp, err := repo.BlogPost(ctx, id)
+			assert.Nil(t, err)
+			assert.Equal(t, ann.ID, bp.AuthorID)
+
+			assert.ErrorIs(t, repo.DeleteAuthor(ctx, ann.ID, 0), blogbus.ErrAuthorInUse)
+		})
+	}
+}
+
+func TestComments(t *testing.T) {
+	ctx := t.Context()
+	dir := t.TempDir()
+
+	spill, err := sqlitestore.Open(ctx, filepath.Join(dir, "spill.db"))
+	assert.Nil(t, err)
+
+	backend, err := sqlitestore.Open(ctx, filepath.Join(dir, "backend.db"))
+	assert.Nil(t, err)
+
+	repos := map[string]interface {
+		blogbus.Repo
+		commentbus.Repo
+		Close() error
+	}{
+		"Memory":  blogrepo.NewRepository(5),
+		"Sharded": must(blogrepo.NewMemoryRepository(ctx, cache.Config{Capacity: 5, Shards: 4}, nil)),
+		"Spill":   must(blogrepo.NewMemoryRepository(ctx, cache.Config{Capacity: 1, Eviction: cache.LRU}, spill)),
+		"SQLite":  must(blogrepo.NewSQLiteRepository(ctx, filepath.Join(dir, "blogapp.db"))),
+		"Tiered":  must(blogrepo.NewTieredRepository(ctx, backend, 1, cache.LRU)),
+	}
+
+	now := time.Now()
+
+	listed := func(t *testing.T, repo commentbus.Repo, postID, parentID uint64, page commentbus.Page) ([]uint64, string) {
+		cp, err := repo.Comments(ctx, postID, parentID, page)
+		assert.Nil(t, err)
+
+		var ids []uint64
+		for _, c := range cp.Comments {
+			ids = append(ids, c.ID)
+		}
+
+		return ids, cp.NextCursor
+	}
+
+	for name, repo := range repos {
+		t.Run(name, func(t *testing.T) {
+			defer repo.Close()
+
+			post, err := repo.AddBlogPost(ctx, blogbus.AddBlogPost{Title: "Post"})
+			assert.Nil(t, err)
+
+			other, err := repo.AddBlogPost(ctx, blogbus.AddBlogPost{Title: "Other"})
+			assert.Nil(t, err)
+
+			add := func(postID, parentID uint64) uint64 {
+				c, err := repo.AddComment(ctx, commentbus.Comment{PostID: postID, ParentID: parentID, Author: "Ann", Body: "Body", Status: commentbus.StatusApproved, CreatedAt: now, UpdatedAt: now})
+				assert.Nil(t, err)
+				return c.ID
+			}
+
+			first := add(post, 0)
+			reply := add(post, first)
+			nested := add(post, reply)
+			second := add(post, 0)
+			elsewhere := add(other, 0)
+
+			_, err = repo.AddComment(ctx, commentbus.Comment{PostID: 99, Author: "Ann", Body: "Body"})
+			assert.ErrorIs(t, err, cache.ErrItemNotFound)
+
+			// A reply must be to a comment on the same post.
+			_, err = repo.AddComment(ctx, commentbus.Comment{PostID: post, ParentID: elsewhere, Author: "Ann", Body: "Body"})
+			assert.ErrorIs(t, err, commentbus.ErrUnknownParent)
+
+			c, err := repo.Comment(ctx, post, first)
+			assert.Nil(t, err)
+			assert.Equal(t, 1, c.Replies)
+
+			_, err = repo.Comment(ctx, other, first)
+			assert.ErrorIs(t, err, commentbus.ErrCommentNotFound)
+
+			// The thread of a post pages through its comments, oldest first.
+			ids, cursor := listed(t, repo, post, 0, commentbus.Page{Limit: 1})
+			assert.Equal(t, []uint64{first}, ids)
+
+			ids, cursor = listed(t, repo, post, 0, commentbus.Page{Limit: 1, Cursor: cursor})
+			assert.Equal(t, []uint64{second}, ids)
+			assert.Empty(t, cursor)
+
+			ids, _ = listed(t, repo, post, first, commentbus.Page{Limit: 10})
+			assert.Equal(t, []uint64{reply}, ids)
+
+			_, err = repo.Comments(ctx, other, 0, commentbus.Page{Limit: 1, Cursor: must(repo.Comments(ctx, post, 0, commentbus.Page{Limit: 1})).NextCursor})
+			assert.ErrorIs(t, err, commentbus.ErrInvalidCursor)
+
+			// An edited comment is moderated again.
+			c, err = repo.UpdateComment(ctx, post, second, commentbus.UpdateComment{Body: " Edited "}, commentbus.Verdict{}, now.Add(time.Minute))
+			assert.Nil(t, err)
+			assert.Equal(t, "Edited", c.Body)
+			assert.Equal(t, commentbus.StatusPending, c.Status)
+			assert.True(t, now.Add(time.Minute).Equal(c.UpdatedAt))
+
+			_, err = repo.UpdateComment(ctx, post, second, commentbus.UpdateComment{}, commentbus.Verdict{}, now)
+			assert.ErrorIs(t, err, commentbus.ErrInvalidComment)
+
+			_, err = repo.ModerateComments(ctx, []uint64{second}, commentbus.Moderation{Status: commentbus.StatusApproved, At: now})
+			assert.Nil(t, err)
+
+			// Deleting a comment deletes the replies in its thread.
+			assert.Nil(t, repo.DeleteComment(ctx, post, first))
+
+			_, err = repo.Comment(ctx, post, nested)
+			assert.ErrorIs(t, err, commentbus.ErrCommentNotFound)
+
+			ids, _ = listed(t, repo, post, 0, commentbus.Page{Limit: 10})
+			assert.Equal(t, []uint64{second}, ids)
+
+			// The comments of a trashed post are hidden until it is restored, and purged with it.
+			_, err = repo.TrashBlogPost(ctx, post, now)
+			assert.Nil(t, err)
+
+			_, err = repo.Comments(ctx, post, 0, commentbus.Page{Limit: 10})
+			assert.ErrorIs(t, err, blogbus.ErrTrashed)
+
+			_, err = repo.RestoreBlogPost(ctx, post)
+			assert.Nil(t, err)
+
+			ids, _ = listed(t, repo, post, 0, commentbus.Page{Limit: 10})
+			assert.Equal(t, []uint64{second}, ids)
+
+			purge(t, repo, post)
+
+			_, err = repo.Comments(ctx, post, 0, commentbus.Page{Limit: 10})
+			assert.ErrorIs(t, err, cache.ErrItemNotFound)
+
+			s := new(bytes.Buffer)
+			_, err = repo.Snapshot(ctx, s)
+			assert.Nil(t, err)
+
+			restored, _, err := snapshot.Read(s)
+			assert.Nil(t, err)
+			assert.Len(t, restored.Comments, 1)
+			assert.Equal(t, elsewhere, restored.Comments[0].ID)
+		})
+	}
+}
+
+func TestCommentsReload(t *testing.T) {
+	ctx := t.Context()
+	dir := t.TempDir()
+	now := time.Now().UTC()
+
+	type repository interface {
+		blogbus.Repo
+		commentbus.Repo
+		Close() error
+	}
+
+	open := map[string]func() (repository, error){
+		"SQLite": func() (repository, error) {
+			return blogrepo.NewSQLiteRepository(ctx, filepath.Join(dir, "blogapp.db"))
+		},
+		"WAL": func() (repository, error) {
+			return blogrepo.NewWALRepository(filepath.Join(dir, "blogapp.wal"), 5, wal.Options{})
+		},
+		"File": func() (repository, error) {
+			return blogrepo.NewFileRepository(filepath.Join(dir, "store"), 5, 0, wal.Options{})
+		},
+	}
+
+	for name, open := range open {
+		t.Run(name, func(t *testing.T) {
+			repo, err := open()
+			assert.Nil(t, err)
+
+			post, err := repo.AddBlogPost(ctx, blogbus.AddBlogPost{Title: "Post"})
+			assert.Nil(t, err)
+
+			c, err := repo.AddComment(ctx, commentbus.Comment{PostID: post, Author: "Ann", Body: "Body", Status: commentbus.StatusApproved, CreatedAt: now, UpdatedAt: now})
+			assert.Nil(t, err)
+
+			reply, err := repo.AddComment(ctx, commentbus.Comment{PostID: post, ParentID: c.ID, Author: "Bob", Body: "Reply", Status: commentbus.StatusPending, CreatedAt: now, UpdatedAt: now})
+			assert.Nil(t, err)
+
+			_, err = repo.ModerateComments(ctx, []uint64{reply.ID}, commentbus.Moderation{Status: commentbus.StatusApproved, At: now})
+			assert.Nil(t, err)
+			assert.Nil(t, repo.Close())
+
+			// The comments are threaded again from the storage on open, and issued IDs are not reused.
+			repo, err = open()
+			assert.Nil(t, err)
+			defer repo.Close()
+
+			got, err := repo.Comment(ctx, post, c.ID)
+			assert.Nil(t, err)
+			assert.Equal(t, 1, got.Replies)
+			assert.True(t, now.Equal(got.CreatedAt))
+
+			// Moderator decisions are kept, for the classifiers to learn them again.
+			got, err = repo.Comment(ctx, post, reply.ID)
+			assert.Nil(t, err)
+			assert.Equal(t, "Reply", got.Body)
+			assert.Equal(t, commentbus.StatusApproved, got.Status)
+			assert.True(t, now.Equal(got.ModeratedAt))
+
+			moderated, err := repo.ModeratedComments(ctx)
+			assert.Nil(t, err)
+			assert.Len(t, moderated, 1)
+
+			next, err := repo.AddComment(ctx, commentbus.Comment{PostID: post, Author: "Ann", Body: "Again", Status: commentbus.StatusApproved, CreatedAt: now, UpdatedAt: now})
+			assert.Nil(t, err)
+			assert.Equal(t, reply.ID+1, next.ID)
+		})
+	}
+}
+
+func TestCommentModeration(t *testing.T) {
+	ctx := t.Context()
+	dir := t.TempDir()
+
+	spill, err := sqlitestore.Open(ctx, filepath.Join(dir, "spill.db"))
+	assert.Nil(t, err)
+
+	backend, err := sqlitestore.Open(ctx, filepath.Join(dir, "backend.db"))
+	assert.Nil(t, err)
+
+	repos := map[string]interface {
+		blogbus.Repo
+		commentbus.Repo
+		Close() error
+	}{
+		"Memory":  blogrepo.NewRepository(5),
+		"Sharded": must(blogrepo.NewMemoryRepository(ctx, cache.Config{Capacity: 5, Shards: 4}, nil)),
+		"Spill":   must(blogrepo.NewMemoryRepository(ctx, cache.Config{Capacity: 1, Eviction: cache.LRU}, spill)),
+		"SQLite":  must(blogrepo.NewSQLiteRepository(ctx, filepath.Join(dir, "blogapp.db"))),
+		"Tiered":  must(blogrepo.NewTieredRepository(ctx, backend, 1, cache.LRU)),
+	}
+
+	now := time.Now()
+
+	queued := func(t *testing.T, repo commentbus.Repo, status commentbus.Status) []uint64 {
+		cp, err := repo.CommentQueue(ctx, status, commentbus.Page{Limit: 10})
+		assert.Nil(t, err)
+
+		var ids []uint64
+		for _, c := range cp.Comments {
+			ids = append(ids, c.ID)
+		}
+
+		return ids
+	}
+
+	for name, repo := range repos {
+		t.Run(name, func(t *testing.T) {
+			defer repo.Close()
+
+			post, err := repo.AddBlogPost(ctx, blogbus.AddBlogPost{Title: "Post"})
+			assert.Nil(t, err)
+
+			trashed, err := repo.AddBlogPost(ctx, blogbus.AddBlogPost{Title: "Trashed"})
+			assert.Nil(t, err)
+
+			add := func(postID, parentID uint64, status commentbus.Status) uint64 {
+				c, err := repo.AddComment(ctx, commentbus.Comment{PostID: postID, ParentID: parentID, Author: "Ann", Body: "Body", Status: status, CreatedAt: now, UpdatedAt: now})
+				assert.Nil(t, err)
+				return c.ID
+			}
+
+			approved := add(post, 0, commentbus.StatusApproved)
+			pending := add(post, 0, commentbus.StatusPending)
+			reply := add(post, approved, commentbus.StatusPending)
+			rejected := add(post, 0, commentbus.StatusRejected)
+			hidden := add(trashed, 0, commentbus.StatusPending)
+
+			_, err = repo.TrashBlogPost(ctx, trashed, now)
+			assert.Nil(t, err)
+
+			// Only approved comments are listed, counted as replies and replied to.
+			cp, err := repo.Comments(ctx, post, 0, commentbus.Page{Limit: 10})
+			assert.Nil(t, err)
+			assert.Len(t, cp.Comments, 1)
+			assert.Equal(t, approved, cp.Comments[0].ID)
+			assert.Equal(t, 0, cp.Comments[0].Replies)
+
+			_, err = repo.AddComment(ctx, commentbus.Comment{PostID: post, ParentID: pending, Author: "Ann", Body: "Body", Status: commentbus.StatusApproved})
+			assert.ErrorIs(t, err, commentbus.ErrUnknownParent)
+
+			// The queue leaves out the comments of the posts in the trash.
+			assert.Equal(t, []uint64{pending, reply}, queued(t, repo, commentbus.StatusPending))
+			assert.Equal(t, []uint64{rejected}, queued(t, repo, commentbus.StatusRejected))
+
+			page, err := repo.CommentQueue(ctx, commentbus.StatusPending, commentbus.Page{Limit: 1})
+			assert.Nil(t, err)
+			assert.True(t, page.HasMore)
+
+			_, err = repo.CommentQueue(ctx, commentbus.StatusRejected, commentbus.Page{Limit: 1, Cursor: page.NextCursor})
+			assert.ErrorIs(t, err, commentbus.ErrInvalidCursor)
+
+			page, err = repo.CommentQueue(ctx, commentbus.StatusPending, commentbus.Page{Limit: 1, Cursor: page.NextCursor})
+			assert.Nil(t, err)
+			assert.Equal(t, reply, page.Comments[0].ID)
+			assert.False(t, page.HasMore)
+
+			// A moderation naming an unknown comment moderates none.
+			approve := commentbus.Moderation{Status: commentbus.StatusApproved, At: now}
+
+			_, err = repo.ModerateComments(ctx, []uint64{pending, 99}, approve)
+			assert.ErrorIs(t, err, commentbus.ErrCommentNotFound)
+			assert.Equal(t, []uint64{pending, reply}, queued(t, repo, commentbus.StatusPending))
+
+			before, err := repo.ModerateComments(ctx, []uint64{pending, reply, hidden}, approve)
+			assert.Nil(t, err)
+			assert.Len(t, before, 3)
+			assert.Equal(t, commentbus.StatusPending, before[0].Status)
+
+			assert.Empty(t, queued(t, repo, commentbus.StatusPending))
+
+			c, err := repo.Comment(ctx, post, approved)
+			assert.Nil(t, err)
+			assert.Equal(t, 1, c.Replies)
+
+			moderated, err := repo.ModeratedComments(ctx)
+			assert.Nil(t, err)
+			assert.Len(t, moderated, 3)
+		})
+	}
+}
+
+func TestReactions(t *testing.T) {
+	ctx := t.Context()
+	dir := t.TempDir()
+
+	spill, err := sqlitestore.Open(ctx, filepath.Join(dir, "spill.db"))
+	assert.Nil(t, err)
+
+	backend, err := sqlitestore.Open(ctx, filepath.Join(dir, "backend.db"))
+	assert.Nil(t, err)
+
+	repos := map[string]interface {
+		blogbus.Repo
+		Close() error
+	}{
+		"Memory":  blogrepo.NewRepository(5),
+		"Sharded": must(blogrepo.NewMemoryRepository(ctx, cache.Config{Capacity: 5, Shards: 4}, nil)),
+		"Spill":   must(blogrepo.NewMemoryRepository(ctx, cache.Config{Capacity: 1, Eviction: cache.LRU}, spill)),
+		"SQLite":  must(blogrepo.NewSQLiteRepository(ctx, filepath.Join(dir, "blogapp.db"))),
+		"Tiered":  must(blogrepo.NewTieredRepository(ctx, backend, 1, cache.LRU)),
+	}
+
+	now := time.Now()
+
+	for name, repo := range repos {
+		t.Run(name, func(t *testing.T) {
+			defer repo.Close()
+
+			post, err := repo.AddBlogPost(ctx, blogbus.AddBlogPost{Title: "Post"})
+			assert.Nil(t, err)
+
+			other, err := repo.AddBlogPost(ctx, blogbus.AddBlogPost{Title: "Other"})
+			assert.Nil(t, err)
+
+			react := func(postID uint64, reader string, r blogbus.Reaction) blogbus.ReactionCounts {
+				counts, err := repo.React(ctx, blogbus.ReaderReaction{PostID: postID, Reader: reader, Reaction: r, At: now})
+				assert.Nil(t, err)
+				return counts
+			}
+
+			assert.Equal(t, blogbus.ReactionCounts{blogbus.ReactionLike: 1}, react(post, "ann", blogbus.ReactionLike))
+			assert.Equal(t, blogbus.ReactionCounts{blogbus.ReactionLike: 2}, react(post, "bob", blogbus.ReactionLike))
+
+			// A reader has one reaction to a post, reacting again replaces it.
+			assert.Equal(t, blogbus.ReactionCounts{blogbus.ReactionLike: 2}, react(post, "bob", blogbus.ReactionLike))
+			assert.Equal(t, blogbus.ReactionCounts{blogbus.ReactionLike: 1, blogbus.ReactionWow: 1}, react(post, "bob", blogbus.ReactionWow))
+			assert.Equal(t, blogbus.ReactionCounts{blogbus.ReactionSad: 1}, react(other, "ann", blogbus.ReactionSad))
+
+			_, err = repo.React(ctx, blogbus.ReaderReaction{PostID: 99, Reader: "ann", Reaction: blogbus.ReactionLike, At: now})
+			assert.ErrorIs(t, err, cache.ErrItemNotFound)
+
+			// The counts are read with the post.
+			bp, err := repo.BlogPost(ctx, post)
+			assert.Nil(t, err)
+			assert.Equal(t, blogbus.ReactionCounts{blogbus.ReactionLike: 1, blogbus.ReactionWow: 1}, bp.Reactions)
+
+			bpp, err := repo.BlogPosts(ctx, blogbus.Query{}, blogbus.Page{Limit: 10})
+			assert.Nil(t, err)
+			assert.Len(t, bpp.BlogPosts, 2)
+			for _, bp := range bpp.BlogPosts {
+				assert.NotEmpty(t, bp.Reactions)
+			}
+
+			counts, err := repo.Unreact(ctx, post, "ann")
+			assert.Nil(t, err)
+			assert.Equal(t, blogbus.ReactionCounts{blogbus.ReactionWow: 1}, counts)
+
+			counts, err = repo.Unreact(ctx, post, "ann")
+			assert.Nil(t, err)
+			assert.Equal(t, blogbus.ReactionCounts{blogbus.ReactionWow: 1}, counts)
+
+			// A trashed post takes no reactions until it is restored, and its reactions are purged with it.
+			_, err = repo.TrashBlogPost(ctx, post, now)
+			assert.Nil(t, err)
+
+			_, err = repo.React(ctx, blogbus.ReaderReaction{PostID: post, Reader: "ann", Reaction: blogbus.ReactionLike, At: now})
+			assert.ErrorIs(t, err, blogbus.ErrTrashed)
+
+			_, err = repo.RestoreBlogPost(ctx, post)
+			assert.Nil(t, err)
+
+			assert.Equal(t, blogbus.ReactionCounts{blogbus.ReactionWow: 1, blogbus.ReactionLove: 1}, react(post, "ann", blogbus.ReactionLove))
+
+			purge(t, repo, post)
+
+			_, err = repo.Unreact(ctx, post, "ann")
+			assert.ErrorIs(t, err, cache.ErrItemNotFound)
+
+			s := new(bytes.Buffer)
+			_, err = repo.Snapshot(ctx, s)
+			assert.Nil(t, err)
+
+			restored, _, err := snapshot.Read(s)
+			assert.Nil(t, err)
+			assert.Len(t, restored.Reactions, 1)
+			assert.Equal(t, other, restored.Reactions[0].PostID)
+		})
+	}
+}
+
+func TestReactionsConcurrent(t *testing.T) {
+	ctx := t.Context()
+	repo := blogrepo.NewRepository(5)
+
+	post, err := repo.AddBlogPost(ctx, blogbus.AddBlogPost{Title: "Post"})
+	assert.Nil(t, err)
+
+	const readers = 200
+
+	// Every reader likes the post twice and then switches to love, some of them twice over.
+	var wg sync.WaitGroup
+
+	for i := range readers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			reader := fmt.Sprintf("reader-%d", i)
+			for _, r := range []blogbus.Reaction{blogbus.ReactionLike, blogbus.ReactionLike, blogbus.ReactionLove} {
+				_, err := repo.React(ctx, blogbus.ReaderReaction{PostID: post, Reader: reader, Reaction: r})
+				assert.Nil(t, err)
+			}
+
+			if i%2 == 0 {
+				_, err := repo.Unreact(ctx, post, reader)
+				assert.Nil(t, err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	bp, err := repo.BlogPost(ctx, post)
+	assert.Nil(t, err)
+	assert.Equal(t, blogbus.ReactionCounts{blogbus.ReactionLove: readers / 2}, bp.Reactions)
+}
+
+func TestReactionsReload(t *testing.T) {
+	ctx := t.Context()
+	dir := t.TempDir()
+	now := time.Now().UTC()
+
+	open := map[string]func() (blogbus.Repo, io.Closer, error){
+		"SQLite": func() (blogbus.Repo, io.Closer, error) {
+			r, err := blogrepo.NewSQLiteRepository(ctx, filepath.Join(dir, "blogapp.db"))
+			return r, r, err
+		},
+		"WAL": func() (blogbus.Repo, io.Closer, error) {
+			r, err := blogrepo.NewWALRepository(filepath.Join(dir, "blogapp.wal"), 5, wal.Options{})
+			return r, r, err
+		},
+		"File": func() (blogbus.Repo, io.Closer, error) {
+			r, err := blogrepo.NewFileRepository(filepath.Join(dir, "store"), 5, 0, wal.Options{})
+			return r, r, err
+		},
+	}
+
+	for name, open := range open {
+		t.Run(name, func(t *testing.T) {
+			repo, closer, err := open()
+			assert.Nil(t, err)
+
+			post, err := repo.AddBlogPost(ctx, blogbus.AddBlogPost{Title: "Post"})
+			assert.Nil(t, err)
+
+			trashed, err := repo.AddBlogPost(ctx, blogbus.AddBlogPost{Title: "Trashed"})
+			assert.Nil(t, err)
+
+			for _, rr := range []blogbus.ReaderReaction{
+				{PostID: post, Reader: "ann", Reaction: blogbus.ReactionLike, At: now},
+				{PostID: post, Reader: "bob", Reaction: blogbus.ReactionLike, At: now},
+				{PostID: post, Reader: "bob", Reaction: blogbus.ReactionLaugh, At: now},
+				{PostID: trashed, Reader: "ann", Reaction: blogbus.ReactionAngry, At: now},
+			} {
+				_, err = repo.React(ctx, rr)
+				assert.Nil(t, err)
+			}
+
+			_, err = repo.TrashBlogPost(ctx, trashed, now)
+			assert.Nil(t, err)
+			assert.Nil(t, closer.Close())
+
+			// The reactions are counted again from the storage on open, a trashed post keeps its own.
+			repo, closer, err = open()
+			assert.Nil(t, err)
+			defer closer.Close()
+
+			bp, err := repo.BlogPost(ctx, post)
+			assert.Nil(t, err)
+			assert.Equal(t, blogbus.ReactionCounts{blogbus.ReactionLike: 1, blogbus.ReactionLaugh: 1}, bp.Reactions)
+
+			_, err = repo.React(ctx, blogbus.ReaderReaction{PostID: trashed, Reader: "bob", Reaction: blogbus.ReactionLike, At: now})
+			assert.ErrorIs(t, err, blogbus.ErrTrashed)
+
+			_, err = repo.RestoreBlogPost(ctx, trashed)
+			assert.Nil(t, err)
+
+			bp, err = repo.BlogPost(ctx, trashed)
+			assert.Nil(t, err)
+			assert.Equal(t, blogbus.ReactionCounts{blogbus.ReactionAngry: 1}, bp.Reactions)
+		})
+	}
+}
+
+func TestUsers(t *testing.T) {
+	ctx := t.Context()
+	repo := blogrepo.NewRepository(5)
+	now := time.Now()
+
+	ann := authbus.User{Username: "ann", PasswordHash: "hash", CreatedAt: now}
+
+	assert.Nil(t, repo.AddUser(ctx, ann))
+	assert.ErrorIs(t, repo.AddUser(ctx, ann), authbus.ErrUsernameTaken)
+
+	_, err := repo.User(ctx, "bob")
+	assert.ErrorIs(t, err, authbus.ErrUserNotFound)
+
+	u, err := repo.UpdateUser(ctx, "ann", authbus.PasswordChange{Hash: "new", At: now})
+	assert.Nil(t, err)
+	assert.Equal(t, "new", u.PasswordHash)
+
+	u, err = repo.User(ctx, "ann")
+	assert.Nil(t, err)
+	assert.Equal(t, "new", u.PasswordHash)
+
+	_, err = repo.UpdateUser(ctx, "bob", authbus.PasswordChange{Hash: "new", At: now})
+	assert.ErrorIs(t, err, authbus.ErrUserNotFound)
+
+	assert.Nil(t, repo.AddAPIKey(ctx, authbus.APIKey{ID: "k2", Owner: "ann", CreatedAt: now}))
+	assert.Nil(t, repo.AddAPIKey(ctx, authbus.APIKey{ID: "k1", Owner: "ann", CreatedAt: now.Add(time.Second)}))
+	assert.ErrorIs(t, repo.AddAPIKey(ctx, authbus.APIKey{ID: "k3", Owner: "bob"}), authbus.ErrUserNotFound)
+
+	keys, err := repo.APIKeys(ctx, "ann")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"k2", "k1"}, []string{keys[0].ID, keys[1].ID})
+
+	keys, err = repo.APIKeys(ctx, "bob")
+	assert.Nil(t, err)
+	assert.Empty(t, keys)
+
+	k, err := repo.UpdateAPIKey(ctx, "k1", authbus.KeyRevocation{At: now})
+	assert.Nil(t, err)
+	assert.True(t, k.Revoked())
+
+	_, err = repo.UpdateAPIKey(ctx, "k9", authbus.KeyRevocation{At: now})
+	assert.ErrorIs(t, err, authbus.ErrAPIKeyNotFound)
+
+	session := func(id string, created time.Time) authbus.Session {
+		return authbus.Session{ID: id, Username: "ann", CreatedAt: created, ExpiresAt: created.Add(time.Hour)}
+	}
+
+	assert.Nil(t, repo.AddSession(ctx, session("old", now.Add(-2*time.Hour))))
+	assert.Nil(t, repo.AddSession(ctx, session("s1", now)))
+	assert.Nil(t, repo.AddSession(ctx, session("s2", now)))
+	assert.Nil(t, repo.AddSession(ctx, session("s3", now)))
+	assert.ErrorIs(t, repo.AddSession(ctx, authbus.Session{ID: "s4", Username: "bob"}), authbus.ErrUserNotFound)
+
+	// Adding s1 deleted the session that had expired by then.
+	_, err = repo.Session(ctx, "old")
+	assert.ErrorIs(t, err, authbus.ErrSessionNotFound)
+
+	assert.Nil(t, repo.DeleteSession(ctx, "s1"))
+	assert.Nil(t, repo.DeleteSession(ctx, "s1"))
+	assert.Nil(t, repo.DeleteSessions(ctx, "ann", "s3"))
+
+	for id, live := range map[string]bool{"s1": false, "s2": false, "s3": true} {
+		_, err := repo.Session(ctx, id)
+		assert.Equal(t, live, err == nil, id)
+	}
+}
+
+func TestUsersReload(t *testing.T) {
+	ctx := t.Context()
+	dir := t.TempDir()
+	now := time.Now().UTC()
+
+	type repository interface {
+		authbus.Repo
+		blogbus.Repo
+		io.Closer
+	}
+
+	open := map[string]func() (repository, error){
+		"SQLite": func() (repository, error) {
+			return blogrepo.NewSQLiteRepository(ctx, filepath.Join(dir, "blogapp.db"))
+		},
+		"WAL": func() (repository, error) {
+			return blogrepo.NewWALRepository(filepath.Join(dir, "blogapp.wal"), 5, wal.Options{})
+		},
+		"File": func() (repository, error) {
+			return blogrepo.NewFileRepository(filepath.Join(dir, "store"), 5, 0, wal.Options{})
+		},
+	}
+
+	for name, open := range open {
+		t.Run(name, func(t *testing.T) {
+			repo, err := open()
+			assert.Nil(t, err)
+
+			ann := authbus.User{Username: "ann", PasswordHash: "hash", Role: authbus.RoleAuthor, CreatedAt: now, PasswordChangedAt: now}
+			assert.Nil(t, repo.AddUser(ctx, ann))
+
+			lockout := authbus.LoginAttempt{Failed: true, At: now, Lockout: authbus.Lockout{MaxFailures: 1, Duration: time.Hour}}
+			ann, err = repo.UpdateUser(ctx, "ann", lockout)
+			assert.Nil(t, err)
+
+			live := authbus.Session{ID: "live", Username: "ann", CreatedAt: now, ExpiresAt: now.Add(time.Hour)}
+			assert.Nil(t, repo.AddSession(ctx, live))
+			assert.Nil(t, repo.AddSession(ctx, authbus.Session{ID: "ending", Username: "ann", CreatedAt: now, ExpiresAt: now.Add(time.Millisecond)}))
+
+			key := authbus.APIKey{ID: "k1", Name: "ci", Owner: "ann", Scopes: []authbus.Scope{authbus.ScopePostsWrite}, Hash: "hash", CreatedAt: now}
+			assert.Nil(t, repo.AddAPIKey(ctx, key))
+			key, err = repo.UpdateAPIKey(ctx, "k1", authbus.KeyUse{At: now})
+			assert.Nil(t, err)
+
+			// A restore replaces the posts, the users, their sessions and their keys are kept.
+			var buf bytes.Buffer
+			_, err = repo.Snapshot(ctx, &buf)
+			assert.Nil(t, err)
+			_, err = repo.Restore(ctx, &buf)
+			assert.Nil(t, err)
+			assert.Nil(t, repo.Close())
+
+			time.Sleep(2 * time.Millisecond)
+
+			// The users and the sessions that did not expire are loaded again from the storage.
+			repo, err = open()
+			assert.Nil(t, err)
+			defer repo.Close()
+
+			u, err := repo.User(ctx, "ann")
+			assert.Nil(t, err)
+			assert.Equal(t, ann, u)
+			assert.True(t, u.Locked(now))
+
+			s, err := repo.Session(ctx, "live")
+			assert.Nil(t, err)
+			assert.Equal(t, live, s)
+
+			_, err = repo.Session(ctx, "ending")
+			assert.ErrorIs(t, err, authbus.ErrSessionNotFound)
+
+			k, err := repo.APIKey(ctx, "k1")
+			assert.Nil(t, err)
+			assert.Equal(t, key, k)
+		})
+	}
+}
@@ -1,9 +1,13 @@
 package cache
 
 import (
+	"cmp"
 	"context"
 	"errors"
+	"fmt"
+	"slices"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/anazcodes/blogapp/internal/business/blogbus"
@@ -12,14 +16,74 @@ import (
 var (
 	ErrCacheInMaxCap = errors.New("cache capacity is full")
 	ErrItemNotFound  = errors.New("item  not found")
+	ErrItemConflict  = errors.New("item conflicts with a stored item")
 )
 
 func NewCache(capacity int) Cache {
-	return &cache{
-		blogs:    make(map[uint64]blogbus.BlogPost, capacity),
+	return New(Config{Capacity: capacity})
+}
+
+// NewJournaledCache returns a cache seeded with posts whose ID serial continues from last.
+// Every mutation is recorded to j before it is applied, a failed record aborts the mutation.
+func NewJournaledCache(capacity int, last uint64, posts []blogbus.BlogPost, j Journal) Cache {
+	return New(Config{
+		Capacity: capacity,
+		Journal:  j,
+		Serial:   last,
+		Posts:    posts,
+	})
+}
+
+// Config configures a cache created by New.
+type Config struct {
+	Capacity int    // Maximum number of posts held at once.
+	Eviction Policy // Decides which post makes room when adding to a full cache.
+	// OnEvict is called with every evicted post before it is removed, an error aborts the eviction.
+	// It is called while the cache holds its write lock.
+	OnEvict func(bp blogbus.BlogPost) error
+	Journal Journal            // Records every mutation, nil records nothing.
+	Serial  uint64             // ID serial to continue from.
+	Posts   []blogbus.BlogPost // Posts the cache starts with.
+	// Shards splits the cache into independently locked shards keyed by post ID.
+	// Values below two create a cache behind a single lock.
+	Shards int
+}
+
+// New returns a cache configured by cfg.
+func New(cfg Config) Cache {
+	if cfg.Shards > 1 {
+		return newSharded(cfg)
+	}
+
+	return newCache(cfg)
+}
+
+func newCache(cfg Config) *cache {
+	c := &cache{
+		serial:   serial(cfg.Serial),
+		blogs:    make(map[uint64]blogbus.BlogPost, len(cfg.Posts)),
+		usage:    make(map[uint64]*usage, len(cfg.Posts)),
+		clock:    new(atomic.Uint64),
 		RWMutex:  &sync.RWMutex{},
-		capacity: capacity,
+		capacity: cfg.Capacity,
+		eviction: cfg.Eviction,
+		onEvict:  cfg.OnEvict,
+		journal:  cfg.Journal,
+	}
+
+	if c.journal == nil {
+		c.journal = nopJournal{}
+	}
+
+	if c.onEvict == nil {
+		c.onEvict = func(blogbus.BlogPost) error { return nil }
+	}
+
+	for _, bp := range cfg.Posts {
+		c.store(bp)
 	}
+
+	return c
 }
 
 type serial uint64
@@ -32,44 +96,125 @@ func (s *serial) ID() uint64 {
 	return uint64(*s)
 }
 
+// store inserts bp, tracks its usage and raises the serial to its ID.
+// The caller must hold the write lock.
+func (c *cache) store(bp blogbus.BlogPost) {
+	if _, ok := c.blogs[bp.ID]; !ok {
+		u := &usage{}
+		u.last.Store(c.clock.Add(1))
+		c.usage[bp.ID] = u
+	}
+
+	c.blogs[bp.ID] = bp
+
+	if bp.ID > c.ID() {
+		c.serial = serial(bp.ID)
+	}
+}
+
+// remove deletes the post with the given ID, the caller must hold the write lock.
+func (c *cache) remove(id uint64) {
+	delete(c.blogs, id)
+	delete(c.usage, id)
+}
+
 type cache struct {
 	serial
 	blogs    map[uint64]blogbus.BlogPost
-	capacity int // Maximum number of items can store.
+	usage    map[uint64]*usage // Access statistics of every post in blogs.
+	clock    *atomic.Uint64    // Ticks on every access to order them, shared by the shards of a sharded cache.
+	capacity int               // Maximum number of items can store.
+	eviction Policy
+	onEvict  func(bp blogbus.BlogPost) error
+	journal  Journal
+	hits     atomic.Uint64
+	misses   atomic.Uint64
+	evicted  atomic.Uint64
 	*sync.RWMutex
 }
 
+// Stats are the counters of a cache since it was created.
+type Stats struct {
+	Hits      uint64 // Reads that found the post.
+	Misses    uint64 // Reads that did not find the post.
+	Evictions uint64 // Posts evicted to make room.
+	Posts     int    // Posts held now.
+	Capacity  int
+}
+
+// StatsReporter is implemented by caches that count their hits, misses and evictions.
+type StatsReporter interface {
+	Stats() Stats
+}
+
+// Stats returns the counters of the cache.
+func (c *cache) Stats() Stats {
+	c.RLock()
+	defer c.RUnlock()
+
+	return Stats{
+		Hits:      c.hits.Load(),
+		Misses:    c.misses.Load(),
+		Evictions: c.evicted.Load(),
+		Posts:     len(c.blogs),
+		Capacity:  c.capacity,
+	}
+}
+
+// Journal records the mutations applied to a cache so they can be persisted and replayed.
+// Calls are made while the cache holds its write lock.
+type Journal interface {
+	// Put records the full state of a post that was added or updated.
+	Put(bp blogbus.BlogPost) error
+	// Delete records the removal of the post with the given ID.
+	Delete(id uint64) error
+	// Restore records that every post was replaced by the snapshot.
+	Restore(s blogbus.Snapshot) error
+}
+
+type nopJournal struct{}
+
+func (nopJournal) Put(blogbus.BlogPost) error     { return nil }
+func (nopJournal) Delete(uint64) error            { return nil }
+func (nopJournal) Restore(blogbus.Snapshot) error { return nil }
+
 type Cache interface {
 	AddBlogPost(ctx context.Context, abp blogbus.AddBlogPost) (uint64, error)
 	BlogPost(ctx context.Context, id uint64) (blogbus.BlogPost, error)
-	BlogPosts(ctx context.Context) []blogbus.BlogPost
+	BlogPosts(ctx context.Context) ([]blogbus.BlogPost, error)
+	// ListBlogPosts returns the posts selected by l in listing order.
+	ListBlogPosts(ctx context.Context, l Listing) ([]blogbus.BlogPost, error)
 	DeleteBlogPost(ctx context.Context, id uint64) (uint64, error)
 	UpdateBlogPost(ctx context.Context, id uint64, ubp blogbus.UpdateBlogPost) (uint64, error)
+	// TransitionBlogPost applies t to the post with id and returns the post, or returns
+	// blogbus.ErrInvalidTransition when t.Check rejects the post.
+	TransitionBlogPost(ctx context.Context, id uint64, t blogbus.Transition) (blogbus.BlogPost, error)
+	// TrashBlogPost moves the post with id to the trash at at, or restores it from the trash when at is
+	// zero, and returns the post. See blogbus.BlogPost.Trash for the errors.
+	TrashBlogPost(ctx context.Context, id uint64, at time.Time) (blogbus.BlogPost, error)
+	// PutBlogPost stores bp under its own ID, replacing any post with that ID.
+	PutBlogPost(ctx context.Context, bp blogbus.BlogPost) error
+	Snapshot(ctx context.Context) (blogbus.Snapshot, error)
+	Restore(ctx context.Context, s blogbus.Snapshot) error
 }
 
 func (c *cache) AddBlogPost(ctx context.Context, abp blogbus.AddBlogPost) (uint64, error) {
 	c.Lock()
 	defer c.Unlock()
 
-	if len(c.blogs) == c.capacity {
-		return 0, ErrCacheInMaxCap
+	if err := c.makeRoom(); err != nil {
+		return 0, err
 	}
 
-	c.Inc()
-
-	id := c.ID()
-	now := time.Now()
+	id := c.ID() + 1
+	blog := blogbus.NewBlogPost(id, abp, time.Now())
 
-	blog := blogbus.BlogPost{
-		ID:          id,
-		Title:       abp.Title,
-		Description: abp.Description,
-		Body:        abp.Body,
-		CreatedAt:   now,
-		UpdatedAt:   now,
+	if err := c.journal.Put(blog); err != nil {
+		return 0, fmt.Errorf("journal: %w", err)
 	}
 
-	c.blogs[id] = blog
+	c.Inc()
+	c.store(blog)
 
 	return id, nil
 }
@@ -80,13 +225,17 @@ func (c *cache) BlogPost(ctx context.Context, id uint64) (blogbus.BlogPost, erro
 
 	bp, ok := c.blogs[id]
 	if !ok {
+		c.misses.Add(1)
 		return blogbus.BlogPost{}, ErrItemNotFound
 	}
 
+	c.hits.Add(1)
+	c.touch(id)
+
 	return bp, nil
 }
 
-func (c *cache) BlogPosts(ctx context.Context) []blogbus.BlogPost {
+func (c *cache) BlogPosts(ctx context.Context) ([]blogbus.BlogPost, error) {
 	c.RLock()
 	defer c.RUnlock()
 
@@ -96,7 +245,14 @@ func (c *cache) BlogPosts(ctx context.Context) []blogbus.BlogPost {
 		bps = append(bps, bp)
 	}
 
-	return bps
+	return bps, nil
+}
+
+func (c *cache) ListBlogPosts(ctx context.Context, l Listing) ([]blogbus.BlogPost, error) {
+	c.RLock()
+	defer c.RUnlock()
+
+	return list(c.blogs, l), nil
 }
 
 func (c *cache) DeleteBlogPost(ctx context.Context, id uint64) (uint64, error) {
@@ -108,12 +264,16 @@ func (c *cache) DeleteBlogPost(ctx context.Context, id uint64) (uint64, error) {
 		return 0, ErrItemNotFound
 	}
 
-	delete(c.blogs, id)
+	if err := c.journal.Delete(id); err != nil {
+		return 0, fmt.Errorf("journal: %w", err)
+	}
+
+	c.remove(id)
 
 	return id, nil
 }
 
-func (c *cache) UpdateBlogPost(ctx context.Context, id uint64, abp blogbus.UpdateBlogPost) (uint64, error) {
+func (c *cache) UpdateBlogPost(ctx context.Context, id uint64, ubp blogbus.UpdateBlogPost) (uint64, error) {
 	c.Lock()
 	defer c.Unlock()
 
@@ -122,18 +282,137 @@ func (c *cache) UpdateBlogPost(ctx context.Context, id uint64, abp blogbus.Updat
 		return 0, ErrItemNotFound
 	}
 
-	if abp.Title != "" {
-		bp.Title = abp.Title
+	if bp.Trashed() {
+		return 0, blogbus.ErrTrashed
 	}
-	if abp.Description != "" {
-		bp.Description = abp.Description
-	}
-	if abp.Body != "" {
-		bp.Body = abp.Body
+
+	bp = ubp.Apply(bp, time.Now())
+
+	if err := c.journal.Put(bp); err != nil {
+		return 0, fmt.Errorf("journal: %w", err)
 	}
 
-	bp.UpdatedAt = time.Now()
 	c.blogs[id] = bp
 
 	return id, nil
 }
+
+func (c *cache) TransitionBlogPost(ctx context.Context, id uint64, t blogbus.Transition) (blogbus.BlogPost, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	bp, ok := c.blogs[id]
+	if !ok {
+		return blogbus.BlogPost{}, ErrItemNotFound
+	}
+
+	if err := t.Check(bp); err != nil {
+		return blogbus.BlogPost{}, err
+	}
+
+	bp = t.Apply(bp)
+
+	if err := c.journal.Put(bp); err != nil {
+		return blogbus.BlogPost{}, fmt.Errorf("journal: %w", err)
+	}
+
+	c.blogs[id] = bp
+
+	return bp, nil
+}
+
+func (c *cache) TrashBlogPost(ctx context.Context, id uint64, at time.Time) (blogbus.BlogPost, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	bp, ok := c.blogs[id]
+	if !ok {
+		return blogbus.BlogPost{}, ErrItemNotFound
+	}
+
+	bp, err := bp.Trash(at)
+	if err != nil {
+		return blogbus.BlogPost{}, err
+	}
+
+	if err := c.journal.Put(bp); err != nil {
+		return blogbus.BlogPost{}, fmt.Errorf("journal: %w", err)
+	}
+
+	c.blogs[id] = bp
+
+	return bp, nil
+}
+
+func (c *cache) PutBlogPost(ctx context.Context, bp blogbus.BlogPost) error {
+	c.Lock()
+	defer c.Unlock()
+
+	if _, ok := c.blogs[bp.ID]; !ok {
+		if err := c.makeRoom(); err != nil {
+			return err
+		}
+	}
+
+	if err := c.journal.Put(bp); err != nil {
+		return fmt.Errorf("journal: %w", err)
+	}
+
+	c.store(bp)
+
+	return nil
+}
+
+// Snapshot returns a consistent copy of every post ordered by ID.
+func (c *cache) Snapshot(ctx context.Context) (blogbus.Snapshot, error) {
+	c.RLock()
+	defer c.RUnlock()
+
+	posts := make([]blogbus.BlogPost, 0, len(c.blogs))
+	for _, bp := range c.blogs {
+		posts = append(posts, bp)
+	}
+
+	slices.SortFunc(posts, func(a, b blogbus.BlogPost) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return blogbus.Snapshot{
+		Serial:    c.ID(),
+		Posts:     posts,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// Restore replaces every post with the posts of s.
+// The ID serial never moves backwards so IDs issued after the snapshot are not reused.
+func (c *cache) Restore(ctx context.Context, s blogbus.Snapshot) error {
+	c.Lock()
+	defer c.Unlock()
+
+	if len(s.Posts) > c.capacity {
+		return ErrCacheInMaxCap
+	}
+
+	last := max(c.ID(), s.Serial)
+
+	for _, bp := range s.Posts {
+		last = max(last, bp.ID)
+	}
+
+	s.Serial = last
+
+	if err := c.journal.Restore(s); err != nil {
+		return fmt.Errorf("journal: %w", err)
+	}
+
+	c.blogs = make(map[uint64]blogbus.BlogPost, len(s.Posts))
+	c.usage = make(map[uint64]*usage, len(s.Posts))
+	c.serial = serial(last)
+
+	for _, bp := range s.Posts {
+		c.store(bp)
+	}
+
+	return nil
+}
@@ -0,0 +1,156 @@
+package cache
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// Policy decides which post is evicted to make room in a full cache.
+type Policy int
+
+const (
+	// Reject refuses new posts with ErrCacheInMaxCap once the cache is full.
+	Reject Policy = iota
+	// LRU evicts the least recently read post.
+	LRU
+	// LFU evicts the least frequently read post, ties go to the least recently read.
+	LFU
+	// Oldest evicts the post created first.
+	Oldest
+)
+
+var policies = map[string]Policy{
+	"reject": Reject,
+	"lru":    LRU,
+	"lfu":    LFU,
+	"oldest": Oldest,
+}
+
+// ParsePolicy parses reject, lru, lfu or oldest into a Policy.
+func ParsePolicy(s string) (Policy, error) {
+	p, ok := policies[s]
+	if !ok {
+		return 0, fmt.Errorf("unknown eviction policy %q", s)
+	}
+
+	return p, nil
+}
+
+func (p Policy) String() string {
+	for s, policy := range policies {
+		if policy == p {
+			return s
+		}
+	}
+
+	return fmt.Sprintf("Policy(%d)", int(p))
+}
+
+// usage holds the access statistics of a post.
+// It is updated atomically so reads only need the read lock.
+type usage struct {
+	last atomic.Uint64 // Clock tick of the latest access.
+	hits atomic.Uint64 // Number of reads.
+}
+
+// touch records a read of the post with the given ID, the caller must hold a lock.
+func (c *cache) touch(id uint64) {
+	u, ok := c.usage[id]
+	if !ok {
+		return
+	}
+
+	u.last.Store(c.clock.Add(1))
+	u.hits.Add(1)
+}
+
+// makeRoom evicts a post when the cache is full so one more post fits.
+// The caller must hold the write lock.
+func (c *cache) makeRoom() error {
+	if len(c.blogs) < c.capacity {
+		return nil
+	}
+
+	if c.eviction == Reject {
+		return ErrCacheInMaxCap
+	}
+
+	id, _, ok := c.victim()
+	if !ok {
+		return ErrCacheInMaxCap
+	}
+
+	return c.evict(id)
+}
+
+// evict hands the post with the given ID to onEvict and removes it.
+// The caller must hold the write lock.
+func (c *cache) evict(id uint64) error {
+	if err := c.onEvict(c.blogs[id]); err != nil {
+		return fmt.Errorf("evict: %w", err)
+	}
+
+	if err := c.journal.Delete(id); err != nil {
+		return fmt.Errorf("journal: %w", err)
+	}
+
+	c.remove(id)
+	c.evicted.Add(1)
+
+	return nil
+}
+
+// rank orders posts for eviction, the lowest rank is evicted first.
+type rank struct {
+	primary   uint64
+	secondary uint64
+	created   time.Time
+	id        uint64
+}
+
+// rank returns the eviction rank of the post with the given ID under the cache policy.
+// The caller must hold a lock.
+func (c *cache) rank(id uint64) rank {
+	u := c.usage[id]
+
+	switch c.eviction {
+	case LFU:
+		return rank{primary: u.hits.Load(), secondary: u.last.Load(), id: id}
+	case Oldest:
+		return rank{created: c.blogs[id].CreatedAt, id: id}
+	default:
+		return rank{primary: u.last.Load(), id: id}
+	}
+}
+
+func (r rank) less(o rank) bool {
+	if r.primary != o.primary {
+		return r.primary < o.primary
+	}
+	if r.secondary != o.secondary {
+		return r.secondary < o.secondary
+	}
+	if !r.created.Equal(o.created) {
+		return r.created.Before(o.created)
+	}
+	return r.id < o.id
+}
+
+// victim returns the ID and rank of the post the eviction policy removes first.
+// It scans every post, which keeps reads free of bookkeeping beyond two atomic updates.
+// The caller must hold a lock.
+func (c *cache) victim() (uint64, rank, bool) {
+	var (
+		victim rank
+		found  bool
+	)
+
+	for id := range c.blogs {
+		if r := c.rank(id); !found || r.less(victim) {
+			victim, found = r, true
+		}
+	}
+
+	return victim.id, victim, found
+}
@@ -0,0 +1,126 @@
+package cache_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/anazcodes/blogapp/internal/business/blogbus"
+	"github.com/anazcodes/blogapp/internal/repository/blogrepo/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEviction(t *testing.T) {
+	now := time.Now()
+
+	// Post 1 is read the most but the longest ago, post 2 is created first and post 3 is never read.
+	posts := []blogbus.BlogPost{
+		{ID: 1, Title: "One", CreatedAt: now},
+		{ID: 2, Title: "Two", CreatedAt: now.Add(-time.Hour)},
+		{ID: 3, Title: "Three", CreatedAt: now},
+	}
+	reads := []uint64{1, 1, 1, 2}
+
+	testCases := []struct {
+		name        string
+		policy      cache.Policy
+		evicted     uint64
+		expectedErr error
+	}{
+		{
+			name:        "Reject",
+			policy:      cache.Reject,
+			expectedErr: cache.ErrCacheInMaxCap,
+		},
+		{
+			name:    "LRU",
+			policy:  cache.LRU,
+			evicted: 3,
+		},
+		{
+			name:    "LFU",
+			policy:  cache.LFU,
+			evicted: 3,
+		},
+		{
+			name:    "Oldest",
+			policy:  cache.Oldest,
+			evicted: 2,
+		},
+	}
+
+	for _, shards := range []int{1, 4} {
+		for _, tc := range testCases {
+			t.Run(fmt.Sprintf("%s/Shards-%d", tc.name, shards), func(t *testing.T) {
+				var evicted []uint64
+
+				c := cache.New(cache.Config{
+					Capacity: len(posts),
+					Eviction: tc.policy,
+					Posts:    posts,
+					Shards:   shards,
+					OnEvict: func(bp blogbus.BlogPost) error {
+						evicted = append(evicted, bp.ID)
+						return nil
+					},
+				})
+
+				for _, id := range reads {
+					_, err := c.BlogPost(t.Context(), id)
+					assert.Nil(t, err)
+				}
+
+				id, err := c.AddBlogPost(t.Context(), blogbus.AddBlogPost{Title: "Four"})
+				assert.ErrorIs(t, err, tc.expectedErr)
+
+				if tc.expectedErr != nil {
+					assert.Empty(t, evicted)
+					return
+				}
+
+				assert.Equal(t, uint64(4), id)
+				assert.Equal(t, []uint64{tc.evicted}, evicted)
+
+				_, err = c.BlogPost(t.Context(), tc.evicted)
+				assert.ErrorIs(t, err, cache.ErrItemNotFound)
+
+				bps, err := c.BlogPosts(t.Context())
+				assert.Nil(t, err)
+				assert.Len(t, bps, len(posts))
+			})
+		}
+	}
+}
+
+func TestLRURead(t *testing.T) {
+	c := cache.New(cache.Config{Capacity: 2, Eviction: cache.LRU})
+
+	for range 2 {
+		_, err := c.AddBlogPost(t.Context(), blogbus.AddBlogPost{Title: "Title"})
+		assert.Nil(t, err)
+	}
+
+	// Reading post 1 makes post 2 the least recently used.
+	_, err := c.BlogPost(t.Context(), 1)
+	assert.Nil(t, err)
+
+	_, err = c.AddBlogPost(t.Context(), blogbus.AddBlogPost{Title: "Title"})
+	assert.Nil(t, err)
+
+	_, err = c.BlogPost(t.Context(), 1)
+	assert.Nil(t, err)
+
+	_, err = c.BlogPost(t.Context(), 2)
+	assert.ErrorIs(t, err, cache.ErrItemNotFound)
+}
+
+func TestParsePolicy(t *testing.T) {
+	for _, s := range []string{"reject", "lru", "lfu", "oldest"} {
+		p, err := cache.ParsePolicy(s)
+		assert.Nil(t, err)
+		assert.Equal(t, s, p.String())
+	}
+
+	_, err := cache.ParsePolicy("random")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,102 @@
+package cache
+
+import (
+	"container/heap"
+	"iter"
+	"maps"
+	"slices"
+
+	"github.com/anazcodes/blogapp/internal/business/blogbus"
+)
+
+// Listing selects the posts returned by ListBlogPosts.
+type Listing struct {
+	blogbus.Query
+	// After lists only the posts ordered after it, nil lists from the first post.
+	// Only its ID and the field the query sorts by are compared.
+	After *blogbus.BlogPost
+	Limit int
+	// IDs lists only the posts with these IDs, nil lists every post. The repository resolves the tag
+	// and category of the query into it.
+	IDs map[uint64]struct{}
+}
+
+// Include reports whether bp belongs to the listing regardless of its limit.
+func (l Listing) Include(bp blogbus.BlogPost) bool {
+	return l.Match(bp) && (l.After == nil || l.Compare(bp, *l.After) > 0) && l.Has(bp.ID)
+}
+
+// Has reports whether the post with id is among the IDs of the listing.
+func (l Listing) Has(id uint64) bool {
+	if l.IDs == nil {
+		return true
+	}
+
+	_, ok := l.IDs[id]
+
+	return ok
+}
+
+// list returns the first l.Limit posts of blogs in listing order.
+// It keeps the best posts seen so far in a bounded heap, so a page costs O(n log limit) instead of
+// sorting every post.
+func list(blogs map[uint64]blogbus.BlogPost, l Listing) []blogbus.BlogPost {
+	if l.Limit <= 0 {
+		return []blogbus.BlogPost{}
+	}
+
+	h := &worst{listing: l, posts: make([]blogbus.BlogPost, 0, min(l.Limit, len(blogs)))}
+
+	for bp := range l.candidates(blogs) {
+		if !l.Include(bp) {
+			continue
+		}
+
+		if h.Len() < l.Limit {
+			heap.Push(h, bp)
+			continue
+		}
+
+		if l.Compare(bp, h.posts[0]) < 0 {
+			h.posts[0] = bp
+			heap.Fix(h, 0)
+		}
+	}
+
+	slices.SortFunc(h.posts, l.Compare)
+
+	return h.posts
+}
+
+// candidates returns the posts of blogs the listing may include. A listing by IDs only looks those up,
+// unless it has more of them than blogs has posts.
+func (l Listing) candidates(blogs map[uint64]blogbus.BlogPost) iter.Seq[blogbus.BlogPost] {
+	if l.IDs == nil || len(l.IDs) >= len(blogs) {
+		return maps.Values(blogs)
+	}
+
+	return func(yield func(blogbus.BlogPost) bool) {
+		for id := range l.IDs {
+			if bp, ok := blogs[id]; ok && !yield(bp) {
+				return
+			}
+		}
+	}
+}
+
+// worst is a heap whose root is the post ordered last by its listing.
+type worst struct {
+	listing Listing
+	posts   []blogbus.BlogPost
+}
+
+func (w *worst) Len() int           { return len(w.posts) }
+func (w *worst) Less(i, j int) bool { return w.listing.Compare(w.posts[i], w.posts[j]) > 0 }
+func (w *worst) Swap(i, j int)      { w.posts[i], w.posts[j] = w.posts[j], w.posts[i] }
+func (w *worst) Push(x any)         { w.posts = append(w.posts, x.(blogbus.BlogPost)) }
+
+func (w *worst) Pop() any {
+	bp := w.posts[len(w.posts)-1]
+	w.posts = w.posts[:len(w.posts)-1]
+	return bp
+}
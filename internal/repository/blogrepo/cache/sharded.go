@@ -0,0 +1,337 @@
+package cache
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/anazcodes/blogapp/internal/business/blogbus"
+)
+
+// sharded is a cache split into shards keyed by post ID, each behind its own lock, so writes to
+// different posts do not serialize against each other or against readers.
+//
+// IDs are issued by a shared atomic allocator and the capacity is enforced across every shard by
+// reserving a slot before a post is stored. Operations on every post, such as Snapshot and Restore,
+// lock the shards in order.
+type sharded struct {
+	shards   []*cache
+	next     atomic.Uint64 // Highest ID issued.
+	size     atomic.Int64  // Posts stored plus slots reserved for posts being stored.
+	capacity int
+	eviction Policy
+	journal  Journal
+}
+
+func newSharded(cfg Config) *sharded {
+	s := &sharded{
+		shards:   make([]*cache, cfg.Shards),
+		capacity: cfg.Capacity,
+		eviction: cfg.Eviction,
+		journal:  cfg.Journal,
+	}
+
+	if s.journal == nil {
+		s.journal = nopJournal{}
+	}
+
+	// Capacity and eviction are enforced across shards, a shard never makes room on its own.
+	clock := new(atomic.Uint64)
+	for i := range s.shards {
+		s.shards[i] = newCache(Config{
+			Capacity: math.MaxInt,
+			Eviction: cfg.Eviction,
+			OnEvict:  cfg.OnEvict,
+			Journal:  s.journal,
+		})
+		s.shards[i].clock = clock
+	}
+
+	s.next.Store(cfg.Serial)
+
+	for _, bp := range cfg.Posts {
+		s.shard(bp.ID).store(bp)
+		s.raise(bp.ID)
+	}
+
+	s.size.Store(int64(len(cfg.Posts)))
+
+	return s
+}
+
+func (s *sharded) shard(id uint64) *cache {
+	return s.shards[id%uint64(len(s.shards))]
+}
+
+// reserve takes a slot for one more post, evicting a post when every slot is taken.
+func (s *sharded) reserve() error {
+	for {
+		n := s.size.Load()
+		if n < int64(s.capacity) {
+			if s.size.CompareAndSwap(n, n+1) {
+				return nil
+			}
+			continue
+		}
+
+		if s.eviction == Reject {
+			return ErrCacheInMaxCap
+		}
+
+		if err := s.evict(); err != nil {
+			return err
+		}
+	}
+}
+
+// evict removes the post the eviction policy ranks lowest across every shard.
+func (s *sharded) evict() error {
+	var (
+		victim *cache
+		lowest rank
+	)
+
+	for _, sh := range s.shards {
+		sh.RLock()
+		_, r, ok := sh.victim()
+		sh.RUnlock()
+
+		if ok && (victim == nil || r.less(lowest)) {
+			victim, lowest = sh, r
+		}
+	}
+
+	if victim == nil {
+		return ErrCacheInMaxCap
+	}
+
+	victim.Lock()
+	defer victim.Unlock()
+
+	// A concurrent delete or eviction already freed the slot.
+	if _, ok := victim.blogs[lowest.id]; !ok {
+		return nil
+	}
+
+	if err := victim.evict(lowest.id); err != nil {
+		return err
+	}
+
+	s.size.Add(-1)
+
+	return nil
+}
+
+func (s *sharded) AddBlogPost(ctx context.Context, abp blogbus.AddBlogPost) (uint64, error) {
+	if err := s.reserve(); err != nil {
+		return 0, err
+	}
+
+	id := s.next.Add(1)
+	blog := blogbus.NewBlogPost(id, abp, time.Now())
+
+	sh := s.shard(id)
+
+	sh.Lock()
+	defer sh.Unlock()
+
+	if err := s.journal.Put(blog); err != nil {
+		s.size.Add(-1)
+		return 0, fmt.Errorf("journal: %w", err)
+	}
+
+	sh.store(blog)
+
+	return id, nil
+}
+
+func (s *sharded) BlogPost(ctx context.Context, id uint64) (blogbus.BlogPost, error) {
+	return s.shard(id).BlogPost(ctx, id)
+}
+
+func (s *sharded) BlogPosts(ctx context.Context) ([]blogbus.BlogPost, error) {
+	bps := make([]blogbus.BlogPost, 0, s.size.Load())
+
+	for _, sh := range s.shards {
+		sh.RLock()
+		for _, bp := range sh.blogs {
+			bps = append(bps, bp)
+		}
+		sh.RUnlock()
+	}
+
+	return bps, nil
+}
+
+func (s *sharded) ListBlogPosts(ctx context.Context, l Listing) ([]blogbus.BlogPost, error) {
+	bps := make([]blogbus.BlogPost, 0, l.Limit)
+
+	// Every shard lists its own first page, the page of the whole cache is the first of their union.
+	for _, sh := range s.shards {
+		sh.RLock()
+		bps = append(bps, list(sh.blogs, l)...)
+		sh.RUnlock()
+	}
+
+	slices.SortFunc(bps, l.Compare)
+
+	return bps[:min(l.Limit, len(bps))], nil
+}
+
+func (s *sharded) DeleteBlogPost(ctx context.Context, id uint64) (uint64, error) {
+	id, err := s.shard(id).DeleteBlogPost(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+
+	s.size.Add(-1)
+
+	return id, nil
+}
+
+func (s *sharded) UpdateBlogPost(ctx context.Context, id uint64, ubp blogbus.UpdateBlogPost) (uint64, error) {
+	return s.shard(id).UpdateBlogPost(ctx, id, ubp)
+}
+
+func (s *sharded) TransitionBlogPost(ctx context.Context, id uint64, t blogbus.Transition) (blogbus.BlogPost, error) {
+	return s.shard(id).TransitionBlogPost(ctx, id, t)
+}
+
+func (s *sharded) TrashBlogPost(ctx context.Context, id uint64, at time.Time) (blogbus.BlogPost, error) {
+	return s.shard(id).TrashBlogPost(ctx, id, at)
+}
+
+func (s *sharded) PutBlogPost(ctx context.Context, bp blogbus.BlogPost) error {
+	sh := s.shard(bp.ID)
+
+	// A slot must be reserved before taking the shard lock, an eviction may need that lock.
+	reserved := false
+	for {
+		sh.Lock()
+		if _, ok := sh.blogs[bp.ID]; ok || reserved {
+			break
+		}
+		sh.Unlock()
+
+		if err := s.reserve(); err != nil {
+			return err
+		}
+		reserved = true
+	}
+	defer sh.Unlock()
+
+	// Replacing a post that was stored while the slot was reserved does not take the slot.
+	_, replace := sh.blogs[bp.ID]
+	if reserved && replace {
+		s.size.Add(-1)
+		reserved = false
+	}
+
+	if err := s.journal.Put(bp); err != nil {
+		if reserved {
+			s.size.Add(-1)
+		}
+		return fmt.Errorf("journal: %w", err)
+	}
+
+	sh.store(bp)
+	s.raise(bp.ID)
+
+	return nil
+}
+
+// raise moves the ID allocator up to id so it is never issued again.
+func (s *sharded) raise(id uint64) {
+	for {
+		last := s.next.Load()
+		if id <= last || s.next.CompareAndSwap(last, id) {
+			return
+		}
+	}
+}
+
+// Snapshot returns a consistent copy of every post ordered by ID.
+func (s *sharded) Snapshot(ctx context.Context) (blogbus.Snapshot, error) {
+	for _, sh := range s.shards {
+		sh.RLock()
+		defer sh.RUnlock()
+	}
+
+	posts := make([]blogbus.BlogPost, 0, s.size.Load())
+	for _, sh := range s.shards {
+		for _, bp := range sh.blogs {
+			posts = append(posts, bp)
+		}
+	}
+
+	slices.SortFunc(posts, func(a, b blogbus.BlogPost) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return blogbus.Snapshot{
+		Serial:    s.next.Load(),
+		Posts:     posts,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// Restore replaces every post with the posts of snap.
+// The ID serial never moves backwards so IDs issued after the snapshot are not reused.
+func (s *sharded) Restore(ctx context.Context, snap blogbus.Snapshot) error {
+	for _, sh := range s.shards {
+		sh.Lock()
+		defer sh.Unlock()
+	}
+
+	if len(snap.Posts) > s.capacity {
+		return ErrCacheInMaxCap
+	}
+
+	last := max(s.next.Load(), snap.Serial)
+
+	for _, bp := range snap.Posts {
+		last = max(last, bp.ID)
+	}
+
+	snap.Serial = last
+
+	if err := s.journal.Restore(snap); err != nil {
+		return fmt.Errorf("journal: %w", err)
+	}
+
+	var stored int64
+	for _, sh := range s.shards {
+		stored += int64(len(sh.blogs))
+		sh.blogs = make(map[uint64]blogbus.BlogPost)
+		sh.usage = make(map[uint64]*usage)
+	}
+
+	for _, bp := range snap.Posts {
+		s.shard(bp.ID).store(bp)
+	}
+
+	// Adds waiting on a shard lock already hold a reservation, keep it.
+	s.size.Add(int64(len(snap.Posts)) - stored)
+	s.next.Store(last)
+
+	return nil
+}
+
+// Stats returns the counters summed over every shard.
+func (s *sharded) Stats() Stats {
+	stats := Stats{Capacity: s.capacity}
+
+	for _, sh := range s.shards {
+		ss := sh.Stats()
+		stats.Hits += ss.Hits
+		stats.Misses += ss.Misses
+		stats.Evictions += ss.Evictions
+		stats.Posts += ss.Posts
+	}
+
+	return stats
+}
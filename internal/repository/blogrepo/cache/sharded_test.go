@@ -0,0 +1,166 @@
+package cache_test
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/anazcodes/blogapp/internal/business/blogbus"
+	"github.com/anazcodes/blogapp/internal/repository/blogrepo/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShardedCapacity(t *testing.T) {
+	testCases := []struct {
+		name   string
+		policy cache.Policy
+		added  int
+	}{
+		{
+			name:   "Reject",
+			policy: cache.Reject,
+			added:  100,
+		},
+		{
+			name:   "LRU",
+			policy: cache.LRU,
+			added:  400,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			capacity := 100
+			c := cache.New(cache.Config{Capacity: capacity, Eviction: tc.policy, Shards: 8})
+
+			var (
+				wg    sync.WaitGroup
+				added atomic.Int64
+				ids   sync.Map
+			)
+
+			for range 8 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+
+					for range 50 {
+						id, err := c.AddBlogPost(t.Context(), blogbus.AddBlogPost{Title: "Title"})
+						if err != nil {
+							assert.ErrorIs(t, err, cache.ErrCacheInMaxCap)
+							continue
+						}
+
+						_, dup := ids.LoadOrStore(id, struct{}{})
+						assert.False(t, dup, "id %d issued twice", id)
+						added.Add(1)
+					}
+				}()
+			}
+
+			wg.Wait()
+
+			assert.Equal(t, int64(tc.added), added.Load())
+
+			bps, err := c.BlogPosts(t.Context())
+			assert.Nil(t, err)
+			assert.Len(t, bps, capacity)
+
+			stats := c.(cache.StatsReporter).Stats()
+			assert.Equal(t, capacity, stats.Posts)
+			assert.Equal(t, uint64(tc.added-capacity), stats.Evictions)
+		})
+	}
+}
+
+func TestShardedSnapshotRestore(t *testing.T) {
+	c := cache.New(cache.Config{Capacity: 10, Shards: 4})
+
+	for range 5 {
+		_, err := c.AddBlogPost(t.Context(), blogbus.AddBlogPost{Title: "Title"})
+		assert.Nil(t, err)
+	}
+
+	s, err := c.Snapshot(t.Context())
+	assert.Nil(t, err)
+	assert.Len(t, s.Posts, 5)
+	assert.Equal(t, uint64(5), s.Serial)
+
+	for i, bp := range s.Posts {
+		assert.Equal(t, uint64(i+1), bp.ID)
+	}
+
+	_, err = c.DeleteBlogPost(t.Context(), 1)
+	assert.Nil(t, err)
+
+	_, err = c.AddBlogPost(t.Context(), blogbus.AddBlogPost{Title: "Title"})
+	assert.Nil(t, err)
+
+	assert.Nil(t, c.Restore(t.Context(), s))
+
+	bps, err := c.BlogPosts(t.Context())
+	assert.Nil(t, err)
+	assert.Len(t, bps, 5)
+
+	// IDs issued after the snapshot are not reused.
+	id, err := c.AddBlogPost(t.Context(), blogbus.AddBlogPost{Title: "Title"})
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(7), id)
+
+	assert.ErrorIs(t, c.Restore(t.Context(), blogbus.Snapshot{Posts: make([]blogbus.BlogPost, 11)}), cache.ErrCacheInMaxCap)
+}
+
+// BenchmarkMixed compares the single lock cache against the sharded cache under concurrent
+// workloads mixing reads, updates, adds and deletes. Run it with -cpu to vary the contention.
+func BenchmarkMixed(b *testing.B) {
+	workloads := []struct {
+		name   string
+		writes int // Out of every 10 operations.
+	}{
+		{name: "Read-Heavy", writes: 1},
+		{name: "Balanced", writes: 5},
+		{name: "Write-Heavy", writes: 9},
+	}
+
+	const posts = 10_000
+
+	for _, w := range workloads {
+		for _, shards := range []int{1, 16, 64} {
+			b.Run(fmt.Sprintf("%s/Shards-%d", w.name, shards), func(b *testing.B) {
+				c := cache.New(cache.Config{Capacity: 2 * posts, Shards: shards})
+
+				for range posts {
+					if _, err := c.AddBlogPost(b.Context(), blogbus.AddBlogPost{Title: "Title"}); err != nil {
+						b.Fatal(err)
+					}
+				}
+
+				var seed atomic.Uint64
+
+				b.ResetTimer()
+				b.RunParallel(func(pb *testing.PB) {
+					ctx := b.Context()
+					n := seed.Add(1) * 7919
+
+					for pb.Next() {
+						n++
+						id := n%posts + 1
+
+						switch op := int(n % 10); {
+						case op >= w.writes:
+							_, _ = c.BlogPost(ctx, id)
+						case op%2 == 0:
+							_, _ = c.UpdateBlogPost(ctx, id, blogbus.UpdateBlogPost{Title: "Updated Title"})
+						default:
+							// Adds and deletes keep the number of posts steady.
+							if id, err := c.AddBlogPost(ctx, blogbus.AddBlogPost{Title: "Title"}); err == nil {
+								_, _ = c.DeleteBlogPost(ctx, id)
+							}
+						}
+					}
+				})
+			})
+		}
+	}
+}
@@ -0,0 +1,244 @@
+package blogrepo
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/anazcodes/blogapp/internal/business/blogbus"
+	"github.com/anazcodes/blogapp/internal/business/commentbus"
+)
+
+// comments holds the comments of every post, threaded by the comment each replies to. Like authors it
+// holds the comments of the posts of the cache and the spill.
+type comments struct {
+	mu      sync.RWMutex
+	byID    map[uint64]commentbus.Comment
+	serial  uint64              // Highest comment ID issued.
+	threads map[thread][]uint64 // IDs of the comments of every thread, in order.
+}
+
+// thread names the replies to the comment parent on the post, a zero parent names the comments on the
+// post itself.
+type thread struct {
+	post   uint64
+	parent uint64
+}
+
+func newComments() *comments {
+	c := &comments{}
+	c.reset(nil, nil)
+
+	return c
+}
+
+// reset replaces every comment with the comments of cs on posts, and returns the IDs of the comments
+// of cs left out: those on a post that is not in posts or replying to a comment that is left out. The
+// comment IDs continue after the highest ID of cs.
+func (c *comments) reset(cs []commentbus.Comment, posts []blogbus.BlogPost) []uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	live := make(map[uint64]bool, len(posts))
+	for _, bp := range posts {
+		live[bp.ID] = true
+	}
+
+	// A reply is always issued a higher ID than the comment it replies to, so in ID order the
+	// parent of a comment is kept or left out before the comment is.
+	cs = slices.SortedFunc(slices.Values(cs), func(x, y commentbus.Comment) int { return cmp.Compare(x.ID, y.ID) })
+
+	c.byID = make(map[uint64]commentbus.Comment, len(cs))
+	c.threads = make(map[thread][]uint64)
+	c.serial = 0
+
+	var dropped []uint64
+
+	for _, cm := range cs {
+		c.serial = max(c.serial, cm.ID)
+
+		if parent, ok := c.byID[cm.ParentID]; !live[cm.PostID] || cm.ParentID != 0 && (!ok || parent.PostID != cm.PostID) {
+			dropped = append(dropped, cm.ID)
+			continue
+		}
+
+		c.add(cm)
+	}
+
+	return dropped
+}
+
+// comment returns the comment with id with the number of its approved replies.
+func (c *comments) comment(id uint64) (commentbus.Comment, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	cm, ok := c.byID[id]
+	if !ok {
+		return commentbus.Comment{}, false
+	}
+
+	cm.Replies = c.approved(thread{post: cm.PostID, parent: id})
+
+	return cm, true
+}
+
+// page returns up to limit approved comments of the thread of parent on post after the comment with
+// after, in order.
+func (c *comments) page(post, parent, after uint64, limit int) []commentbus.Comment {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	ids := c.threads[thread{post: post, parent: parent}]
+	i, _ := slices.BinarySearch(ids, after+1)
+
+	var cs []commentbus.Comment
+
+	for _, id := range ids[i:] {
+		if len(cs) == limit {
+			break
+		}
+
+		if cm := c.byID[id]; cm.Status == commentbus.StatusApproved {
+			cm.Replies = c.approved(thread{post: post, parent: id})
+			cs = append(cs, cm)
+		}
+	}
+
+	return cs
+}
+
+// withStatus returns every comment with status after the comment with after, ordered by ID.
+func (c *comments) withStatus(status commentbus.Status, after uint64) []commentbus.Comment {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var cs []commentbus.Comment
+
+	for _, cm := range c.byID {
+		if cm.Status == status && cm.ID > after {
+			cm.Replies = c.approved(thread{post: cm.PostID, parent: cm.ID})
+			cs = append(cs, cm)
+		}
+	}
+
+	slices.SortFunc(cs, func(x, y commentbus.Comment) int { return cmp.Compare(x.ID, y.ID) })
+
+	return cs
+}
+
+// list returns every comment ordered by ID.
+func (c *comments) list() []commentbus.Comment {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	cs := slices.Collect(maps.Values(c.byID))
+	slices.SortFunc(cs, func(x, y commentbus.Comment) int { return cmp.Compare(x.ID, y.ID) })
+
+	return cs
+}
+
+// next issues the ID of a new comment.
+func (c *comments) next() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.serial++
+
+	return c.serial
+}
+
+// put adds cm to its thread, or replaces the comment stored with its ID.
+func (c *comments) put(cm commentbus.Comment) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.byID[cm.ID]; ok {
+		cm.Replies = 0
+		c.byID[cm.ID] = cm
+		return
+	}
+
+	c.add(cm)
+}
+
+// thread returns the ID of the comment with id followed by the IDs of every reply in its thread.
+func (c *comments) thread(id uint64) []uint64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	cm, ok := c.byID[id]
+	if !ok {
+		return nil
+	}
+
+	return append([]uint64{id}, c.replies(thread{post: cm.PostID, parent: id})...)
+}
+
+// ofPost returns the IDs of every comment on the post with id.
+func (c *comments) ofPost(id uint64) []uint64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.replies(thread{post: id})
+}
+
+// delete removes the comments with ids, which must hold every reply of the comments they hold.
+func (c *comments) delete(ids []uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, id := range ids {
+		cm, ok := c.byID[id]
+		if !ok {
+			continue
+		}
+
+		delete(c.byID, id)
+		delete(c.threads, thread{post: cm.PostID, parent: id})
+
+		t := thread{post: cm.PostID, parent: cm.ParentID}
+		if siblings := slices.DeleteFunc(c.threads[t], func(sibling uint64) bool { return sibling == id }); len(siblings) > 0 {
+			c.threads[t] = siblings
+		} else {
+			delete(c.threads, t)
+		}
+	}
+}
+
+// add adds cm to its thread, the caller must hold mu. The IDs of a thread stay in order as new
+// comments are issued higher IDs.
+func (c *comments) add(cm commentbus.Comment) {
+	cm.Replies = 0
+	c.byID[cm.ID] = cm
+
+	t := thread{post: cm.PostID, parent: cm.ParentID}
+	c.threads[t] = append(c.threads[t], cm.ID)
+}
+
+// approved returns the number of approved comments in t, the caller must hold mu.
+func (c *comments) approved(t thread) int {
+	n := 0
+
+	for _, id := range c.threads[t] {
+		if c.byID[id].Status == commentbus.StatusApproved {
+			n++
+		}
+	}
+
+	return n
+}
+
+// replies returns the IDs of every comment in t and in the threads of its replies, the caller must
+// hold mu.
+func (c *comments) replies(t thread) []uint64 {
+	var ids []uint64
+
+	for _, id := range c.threads[t] {
+		ids = append(ids, id)
+		ids = append(ids, c.replies(thread{post: t.post, parent: id})...)
+	}
+
+	return ids
+}
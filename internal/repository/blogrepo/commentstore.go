@@ -0,0 +1,313 @@
+package blogrepo
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/anazcodes/blogapp/internal/business/blogbus"
+	"github.com/anazcodes/blogapp/internal/business/commentbus"
+	"github.com/anazcodes/blogapp/internal/repository/blogrepo/wal"
+)
+
+// CommentStore persists the comments with their moderation state. The threads and reply counts are
+// rebuilt from it on load, when the comments of posts that no longer exist are deleted from it.
+type CommentStore interface {
+	Comments(ctx context.Context) ([]commentbus.Comment, error)
+	PutComment(ctx context.Context, c commentbus.Comment) error
+	DeleteComments(ctx context.Context, ids []uint64) error
+}
+
+// logComments stores the comments of a WAL repository in its log, next to its posts.
+type logComments struct {
+	log *wal.Log
+}
+
+func (lc logComments) Comments(ctx context.Context) ([]commentbus.Comment, error) {
+	return lc.log.Comments()
+}
+
+func (lc logComments) PutComment(ctx context.Context, c commentbus.Comment) error {
+	return lc.log.PutComment(c)
+}
+
+func (lc logComments) DeleteComments(ctx context.Context, ids []uint64) error {
+	return lc.log.DeleteComments(ids)
+}
+
+// persistComments applies write to the comment store, when the repository has one.
+func (r *repo) persistComments(write func(s CommentStore) error) error {
+	if r.commentStore == nil {
+		return nil
+	}
+
+	return write(r.commentStore)
+}
+
+// commentable returns cache.ErrItemNotFound unless the post with id exists, and blogbus.ErrTrashed
+// while it is in the trash, which hides its comments until it is restored.
+func (r *repo) commentable(ctx context.Context, id uint64) error {
+	bp, err := r.peek(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if bp.Trashed() {
+		return blogbus.ErrTrashed
+	}
+
+	return nil
+}
+
+func (r *repo) Post(ctx context.Context, id uint64) (commentbus.Post, error) {
+	bp, err := r.peek(ctx, id)
+	if err != nil {
+		return commentbus.Post{}, fmt.Errorf("query: %w", err)
+	}
+
+	if bp.Trashed() {
+		return commentbus.Post{}, fmt.Errorf("query: %w", blogbus.ErrTrashed)
+	}
+
+	return commentbus.Post{ID: bp.ID, Published: bp.Status == blogbus.StatusPublished, Owner: bp.Owner}, nil
+}
+
+// comment returns the comment with id on the post with postID.
+func (r *repo) comment(postID, id uint64) (commentbus.Comment, error) {
+	c, ok := r.comments.comment(id)
+	if !ok || c.PostID != postID {
+		return commentbus.Comment{}, commentbus.ErrCommentNotFound
+	}
+
+	return c, nil
+}
+
+// approved returns the comment with id on the post with postID when it is approved, the others are
+// not public.
+func (r *repo) approved(postID, id uint64) (commentbus.Comment, error) {
+	c, err := r.comment(postID, id)
+	if err != nil {
+		return commentbus.Comment{}, err
+	}
+
+	if c.Status != commentbus.StatusApproved {
+		return commentbus.Comment{}, fmt.Errorf("%w: comment is %s", commentbus.ErrCommentNotFound, c.Status)
+	}
+
+	return c, nil
+}
+
+func (r *repo) AddComment(ctx context.Context, c commentbus.Comment) (commentbus.Comment, error) {
+	r.commentWrites.RLock()
+	defer r.commentWrites.RUnlock()
+
+	// The write lock keeps the post from being purged before its comment is stored.
+	w := r.write(c.PostID)
+	defer w.Unlock()
+
+	if err := r.commentable(ctx, c.PostID); err != nil {
+		return commentbus.Comment{}, fmt.Errorf("query: %w", err)
+	}
+
+	if c.ParentID != 0 {
+		if _, err := r.approved(c.PostID, c.ParentID); err != nil {
+			return commentbus.Comment{}, fmt.Errorf("query: %w: %d", commentbus.ErrUnknownParent, c.ParentID)
+		}
+	}
+
+	c.ID = r.comments.next()
+
+	if err := r.persistComments(func(s CommentStore) error { return s.PutComment(ctx, c) }); err != nil {
+		return commentbus.Comment{}, fmt.Errorf("query: %w", err)
+	}
+
+	r.comments.put(c)
+
+	return c, nil
+}
+
+func (r *repo) Comment(ctx context.Context, postID, id uint64) (commentbus.Comment, error) {
+	if err := r.commentable(ctx, postID); err != nil {
+		return commentbus.Comment{}, fmt.Errorf("query: %w", err)
+	}
+
+	c, err := r.comment(postID, id)
+	if err != nil {
+		return commentbus.Comment{}, fmt.Errorf("query: %w", err)
+	}
+
+	return c, nil
+}
+
+func (r *repo) Comments(ctx context.Context, postID, parentID uint64, page commentbus.Page) (commentbus.CommentPage, error) {
+	after, err := decodeCommentCursor(postID, parentID, page.Cursor)
+	if err != nil {
+		return commentbus.CommentPage{}, fmt.Errorf("cursor: %w", err)
+	}
+
+	if err := r.commentable(ctx, postID); err != nil {
+		return commentbus.CommentPage{}, fmt.Errorf("query: %w", err)
+	}
+
+	if parentID != 0 {
+		if _, err := r.approved(postID, parentID); err != nil {
+			return commentbus.CommentPage{}, fmt.Errorf("query: %w", err)
+		}
+	}
+
+	// The extra comment only tells whether a following page exists.
+	cs := r.comments.page(postID, parentID, after, page.Limit+1)
+	if len(cs) <= page.Limit {
+		return commentbus.CommentPage{Comments: cs}, nil
+	}
+
+	cs = cs[:page.Limit]
+
+	return commentbus.CommentPage{
+		Comments:   cs,
+		NextCursor: commentCursor{Post: postID, Parent: parentID, ID: cs[len(cs)-1].ID}.encode(),
+		HasMore:    true,
+	}, nil
+}
+
+func (r *repo) UpdateComment(ctx context.Context, postID, id uint64, uc commentbus.UpdateComment, v commentbus.Verdict, at time.Time) (commentbus.Comment, error) {
+	r.commentWrites.RLock()
+	defer r.commentWrites.RUnlock()
+
+	w := r.write(postID)
+	defer w.Unlock()
+
+	if err := r.commentable(ctx, postID); err != nil {
+		return commentbus.Comment{}, fmt.Errorf("query: %w", err)
+	}
+
+	c, err := r.comment(postID, id)
+	if err != nil {
+		return commentbus.Comment{}, fmt.Errorf("query: %w", err)
+	}
+
+	if c, err = uc.Apply(c, at); err != nil {
+		return commentbus.Comment{}, err
+	}
+
+	c = v.Apply(c)
+
+	if err := r.persistComments(func(s CommentStore) error { return s.PutComment(ctx, c) }); err != nil {
+		return commentbus.Comment{}, fmt.Errorf("query: %w", err)
+	}
+
+	r.comments.put(c)
+
+	return c, nil
+}
+
+// DeleteComment deletes the comment with id and every reply in its thread, so no reply is left to a
+// comment that no longer exists.
+func (r *repo) DeleteComment(ctx context.Context, postID, id uint64) error {
+	r.commentWrites.RLock()
+	defer r.commentWrites.RUnlock()
+
+	w := r.write(postID)
+	defer w.Unlock()
+
+	if err := r.commentable(ctx, postID); err != nil {
+		return fmt.Errorf("query: %w", err)
+	}
+
+	if _, err := r.comment(postID, id); err != nil {
+		return fmt.Errorf("query: %w", err)
+	}
+
+	ids := r.comments.thread(id)
+
+	if err := r.persistComments(func(s CommentStore) error { return s.DeleteComments(ctx, ids) }); err != nil {
+		return fmt.Errorf("query: %w", err)
+	}
+
+	r.comments.delete(ids)
+
+	return nil
+}
+
+func (r *repo) CommentQueue(ctx context.Context, status commentbus.Status, page commentbus.Page) (commentbus.CommentPage, error) {
+	after, err := decodeQueueCursor(status, page.Cursor)
+	if err != nil {
+		return commentbus.CommentPage{}, fmt.Errorf("cursor: %w", err)
+	}
+
+	// The comments are read out of the registry before their posts, as evicting a post deletes its
+	// comments from the registry while the cache is locked.
+	var cs []commentbus.Comment
+
+	for _, c := range r.comments.withStatus(status, after) {
+		if len(cs) > page.Limit {
+			break
+		}
+
+		if err := r.commentable(ctx, c.PostID); err != nil {
+			continue
+		}
+
+		cs = append(cs, c)
+	}
+
+	if len(cs) <= page.Limit {
+		return commentbus.CommentPage{Comments: cs}, nil
+	}
+
+	cs = cs[:page.Limit]
+
+	return commentbus.CommentPage{
+		Comments:   cs,
+		NextCursor: queueCursor{Status: status, ID: cs[len(cs)-1].ID}.encode(),
+		HasMore:    true,
+	}, nil
+}
+
+// ModerateComments holds every comment write, so the comments cannot be edited, deleted or purged with
+// their post while they are moderated. A failure to store one leaves the ones before it moderated.
+func (r *repo) ModerateComments(ctx context.Context, ids []uint64, m commentbus.Moderation) ([]commentbus.Comment, error) {
+	r.commentWrites.Lock()
+	defer r.commentWrites.Unlock()
+
+	before := make([]commentbus.Comment, len(ids))
+
+	for i, id := range ids {
+		c, ok := r.comments.comment(id)
+		if !ok {
+			return nil, fmt.Errorf("query: %w: %d", commentbus.ErrCommentNotFound, id)
+		}
+
+		before[i] = c
+	}
+
+	for _, c := range before {
+		c = m.Apply(c)
+
+		if err := r.persistComments(func(s CommentStore) error { return s.PutComment(ctx, c) }); err != nil {
+			return nil, fmt.Errorf("query: %w", err)
+		}
+
+		r.comments.put(c)
+	}
+
+	return before, nil
+}
+
+func (r *repo) ModeratedComments(ctx context.Context) ([]commentbus.Comment, error) {
+	return slices.DeleteFunc(r.comments.list(), func(c commentbus.Comment) bool { return !c.Moderated() }), nil
+}
+
+// purgeComments deletes every comment on the post with id, which was purged. The comments a failure
+// leaves in the store are dropped on the next load.
+func (r *repo) purgeComments(ctx context.Context, id uint64) error {
+	ids := r.comments.ofPost(id)
+	if len(ids) == 0 {
+		return nil
+	}
+
+	r.comments.delete(ids)
+
+	return r.persistComments(func(s CommentStore) error { return s.DeleteComments(ctx, ids) })
+}
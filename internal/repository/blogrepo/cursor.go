@@ -0,0 +1,213 @@
+package blogrepo
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/anazcodes/blogapp/internal/business/blogbus"
+	"github.com/anazcodes/blogapp/internal/business/commentbus"
+	"github.com/anazcodes/blogapp/internal/repository/blogrepo/search"
+)
+
+// cursor is the position of a page in a listing, clients receive it encoded and opaque.
+// It holds the sort key and ID of the last post of the previous page, so pages stay stable while
+// posts are added or deleted, and the order it was issued for so it cannot be reused with another.
+type cursor struct {
+	ID     uint64            `json:"id"`
+	Key    string            `json:"key,omitempty"`
+	SortBy blogbus.SortField `json:"sort_by,omitempty"`
+	Desc   bool              `json:"desc,omitempty"`
+}
+
+// newCursor returns the cursor of the page following bp, the last post of a page listed by q.
+func newCursor(q blogbus.Query, bp blogbus.BlogPost) cursor {
+	c := cursor{ID: bp.ID, SortBy: q.SortBy, Desc: q.Desc}
+
+	switch q.SortBy {
+	case blogbus.SortByCreatedAt:
+		c.Key = bp.CreatedAt.Format(time.RFC3339Nano)
+	case blogbus.SortByUpdatedAt:
+		c.Key = bp.UpdatedAt.Format(time.RFC3339Nano)
+	case blogbus.SortByTitle:
+		c.Key = bp.Title
+	}
+
+	return c
+}
+
+func (c cursor) encode() string {
+	return encode(c)
+}
+
+// decodeCursor decodes the cursor of a listing by q into the position it continues after.
+// An empty string is the cursor of the first page, which has no position.
+func decodeCursor(q blogbus.Query, s string) (*blogbus.BlogPost, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	var c cursor
+	if err := decode(s, &c, blogbus.ErrInvalidCursor); err != nil {
+		return nil, err
+	}
+
+	if c.SortBy != q.SortBy || c.Desc != q.Desc {
+		return nil, fmt.Errorf("%w: issued for another order", blogbus.ErrInvalidCursor)
+	}
+
+	after := &blogbus.BlogPost{ID: c.ID}
+
+	var err error
+
+	switch q.SortBy {
+	case blogbus.SortByCreatedAt:
+		after.CreatedAt, err = time.Parse(time.RFC3339Nano, c.Key)
+	case blogbus.SortByUpdatedAt:
+		after.UpdatedAt, err = time.Parse(time.RFC3339Nano, c.Key)
+	case blogbus.SortByTitle:
+		after.Title = c.Key
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", blogbus.ErrInvalidCursor, err)
+	}
+
+	return after, nil
+}
+
+// paginate turns up to limit+1 posts listed by q into a page of at most limit posts.
+// The extra post only tells whether a following page exists.
+func paginate(q blogbus.Query, bps []blogbus.BlogPost, limit int) blogbus.BlogPostPage {
+	if len(bps) <= limit {
+		return blogbus.BlogPostPage{BlogPosts: bps}
+	}
+
+	bps = bps[:limit]
+
+	return blogbus.BlogPostPage{
+		BlogPosts:  bps,
+		NextCursor: newCursor(q, bps[len(bps)-1]).encode(),
+		HasMore:    true,
+	}
+}
+
+// searchCursor is the position of a page in the hits of a search, clients receive it encoded and opaque.
+// It holds the score and ID of the last hit of the previous page. Scores move as posts are added,
+// updated or deleted, so a following page may then repeat or skip a hit.
+type searchCursor struct {
+	Query string  `json:"q"`
+	Score float64 `json:"score"`
+	ID    uint64  `json:"id"`
+}
+
+// newSearchCursor returns the cursor of the page following h, the last hit of a page of the search q.
+func newSearchCursor(q string, h search.Hit) searchCursor {
+	return searchCursor{Query: q, Score: h.Score, ID: h.ID}
+}
+
+func (c searchCursor) encode() string {
+	return encode(c)
+}
+
+// decodeSearchCursor decodes the cursor of the search q into the hit it continues after.
+// An empty string is the cursor of the first page, which has no position.
+func decodeSearchCursor(q, s string) (*search.Hit, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	var c searchCursor
+	if err := decode(s, &c, blogbus.ErrInvalidCursor); err != nil {
+		return nil, err
+	}
+
+	if c.Query != q {
+		return nil, fmt.Errorf("%w: issued for another search", blogbus.ErrInvalidCursor)
+	}
+
+	return &search.Hit{ID: c.ID, Score: c.Score}, nil
+}
+
+// commentCursor is the position of a page in a thread of comments, clients receive it encoded and
+// opaque. It holds the ID of the last comment of the previous page, and the thread it was issued for so
+// it cannot be reused with another.
+type commentCursor struct {
+	Post   uint64 `json:"post"`
+	Parent uint64 `json:"parent,omitempty"`
+	ID     uint64 `json:"id"`
+}
+
+func (c commentCursor) encode() string {
+	return encode(c)
+}
+
+// decodeCommentCursor decodes the cursor of the thread of parent on post into the ID of the comment it
+// continues after. An empty string is the cursor of the first page, which continues after zero.
+func decodeCommentCursor(post, parent uint64, s string) (uint64, error) {
+	if s == "" {
+		return 0, nil
+	}
+
+	var c commentCursor
+	if err := decode(s, &c, commentbus.ErrInvalidCursor); err != nil {
+		return 0, err
+	}
+
+	if c.Post != post || c.Parent != parent {
+		return 0, fmt.Errorf("%w: issued for another thread", commentbus.ErrInvalidCursor)
+	}
+
+	return c.ID, nil
+}
+
+// queueCursor is the position of a page in the comments with a status across the posts, clients
+// receive it encoded and opaque. It holds the ID of the last comment of the previous page, and the
+// status it was issued for so it cannot be reused with another.
+type queueCursor struct {
+	Status commentbus.Status `json:"status"`
+	ID     uint64            `json:"id"`
+}
+
+func (c queueCursor) encode() string {
+	return encode(c)
+}
+
+// decodeQueueCursor decodes the cursor of the comments with status into the ID of the comment it
+// continues after. An empty string is the cursor of the first page, which continues after zero.
+func decodeQueueCursor(status commentbus.Status, s string) (uint64, error) {
+	if s == "" {
+		return 0, nil
+	}
+
+	var c queueCursor
+	if err := decode(s, &c, commentbus.ErrInvalidCursor); err != nil {
+		return 0, err
+	}
+
+	if c.Status != status {
+		return 0, fmt.Errorf("%w: issued for another status", commentbus.ErrInvalidCursor)
+	}
+
+	return c.ID, nil
+}
+
+func encode(c any) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decode decodes s into c, or returns invalid when s is not an encoded cursor.
+func decode(s string, c any, invalid error) error {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return fmt.Errorf("%w: %v", invalid, err)
+	}
+
+	if err := json.Unmarshal(b, c); err != nil {
+		return fmt.Errorf("%w: %v", invalid, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,210 @@
+// Package filestore implements a durable cache.Cache that persists blog posts, with their tags,
+// categories, authors, comments and reactions, and the users and their sessions to a local data
+// directory.
+//
+// Every mutation is appended to a write-ahead log before it is applied in memory. The log is replayed
+// on startup and periodically compacted so it only holds the live posts.
+package filestore
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/anazcodes/blogapp/internal/business/authbus"
+	"github.com/anazcodes/blogapp/internal/business/blogbus"
+	"github.com/anazcodes/blogapp/internal/business/commentbus"
+	"github.com/anazcodes/blogapp/internal/repository/blogrepo/cache"
+	"github.com/anazcodes/blogapp/internal/repository/blogrepo/wal"
+)
+
+const logName = "blogposts.wal"
+
+// Store is a cache.Cache whose contents survive process restarts.
+type Store struct {
+	cache.Cache
+	log  *wal.Log
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// Open loads the posts stored in dir, creating the directory when it does not exist. The posts of a
+// directory written before the store logged to a write-ahead log are migrated to one first.
+// When compactEvery is positive the log is compacted in the background at that interval.
+func Open(dir string, capacity int, compactEvery time.Duration, opts wal.Options) (*Store, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("mkdir: %w", err)
+	}
+
+	if err := migrate(dir, opts); err != nil {
+		return nil, fmt.Errorf("migrate: %w", err)
+	}
+
+	l, rec, err := wal.Open(filepath.Join(dir, logName), opts)
+	if err != nil {
+		return nil, fmt.Errorf("wal.open: %w", err)
+	}
+
+	s := &Store{
+		Cache: cache.NewJournaledCache(capacity, rec.Serial, rec.Posts, l),
+		log:   l,
+		done:  make(chan struct{}),
+	}
+
+	if compactEvery > 0 {
+		s.wg.Add(1)
+		go s.compactLoop(compactEvery)
+	}
+
+	return s, nil
+}
+
+// Taxonomy returns the tags and categories stored in the log.
+func (s *Store) Taxonomy(ctx context.Context) (blogbus.Taxonomy, error) {
+	return s.log.Taxonomy()
+}
+
+// PutTag records a tag that was added or renamed.
+func (s *Store) PutTag(ctx context.Context, t blogbus.Tag) error {
+	return s.log.PutTag(t)
+}
+
+// DeleteTag records the removal of the tag with slug.
+func (s *Store) DeleteTag(ctx context.Context, slug string) error {
+	return s.log.DeleteTag(slug)
+}
+
+// PutCategory records a category that was added, renamed or moved.
+func (s *Store) PutCategory(ctx context.Context, c blogbus.Category) error {
+	return s.log.PutCategory(c)
+}
+
+// DeleteCategory records the removal of the category with slug.
+func (s *Store) DeleteCategory(ctx context.Context, slug string) error {
+	return s.log.DeleteCategory(slug)
+}
+
+// Authors returns the authors stored in the log.
+func (s *Store) Authors(ctx context.Context) ([]blogbus.Author, error) {
+	return s.log.Authors()
+}
+
+// PutAuthor records an author that was added or edited.
+func (s *Store) PutAuthor(ctx context.Context, a blogbus.Author) error {
+	return s.log.PutAuthor(a)
+}
+
+// DeleteAuthor records the removal of the author with id.
+func (s *Store) DeleteAuthor(ctx context.Context, id uint64) error {
+	return s.log.DeleteAuthor(id)
+}
+
+// Comments returns the comments stored in the log.
+func (s *Store) Comments(ctx context.Context) ([]commentbus.Comment, error) {
+	return s.log.Comments()
+}
+
+// PutComment records a comment that was added or edited.
+func (s *Store) PutComment(ctx context.Context, c commentbus.Comment) error {
+	return s.log.PutComment(c)
+}
+
+// DeleteComments records the removal of the comments with ids.
+func (s *Store) DeleteComments(ctx context.Context, ids []uint64) error {
+	return s.log.DeleteComments(ids)
+}
+
+// Reactions returns the reactions stored in the log.
+func (s *Store) Reactions(ctx context.Context) ([]blogbus.ReaderReaction, error) {
+	return s.log.Reactions()
+}
+
+// PutReaction records a reaction that was added or changed.
+func (s *Store) PutReaction(ctx context.Context, rr blogbus.ReaderReaction) error {
+	return s.log.PutReaction(rr)
+}
+
+// DeleteReaction records the removal of the reaction of reader to the post with postID.
+func (s *Store) DeleteReaction(ctx context.Context, postID uint64, reader string) error {
+	return s.log.DeleteReaction(postID, reader)
+}
+
+// DeletePostReactions records the removal of every reaction to the posts with postIDs.
+func (s *Store) DeletePostReactions(ctx context.Context, postIDs []uint64) error {
+	return s.log.DeletePostReactions(postIDs)
+}
+
+// Users returns the users stored in the log.
+func (s *Store) Users(ctx context.Context) ([]authbus.User, error) {
+	return s.log.Users()
+}
+
+// PutUser records a user that was added or changed.
+func (s *Store) PutUser(ctx context.Context, u authbus.User) error {
+	return s.log.PutUser(u)
+}
+
+// Sessions returns the sessions stored in the log.
+func (s *Store) Sessions(ctx context.Context) ([]authbus.Session, error) {
+	return s.log.Sessions()
+}
+
+// PutSession records a session that was started.
+func (s *Store) PutSession(ctx context.Context, ss authbus.Session) error {
+	return s.log.PutSession(ss)
+}
+
+// DeleteSessions records the end of the sessions with ids.
+func (s *Store) DeleteSessions(ctx context.Context, ids []string) error {
+	return s.log.DeleteSessions(ids)
+}
+
+// APIKeys returns the API keys stored in the log.
+func (s *Store) APIKeys(ctx context.Context) ([]authbus.APIKey, error) {
+	return s.log.APIKeys()
+}
+
+// PutAPIKey records an API key that was created or changed.
+func (s *Store) PutAPIKey(ctx context.Context, k authbus.APIKey) error {
+	return s.log.PutAPIKey(k)
+}
+
+// Compact rewrites the log so it only holds the live posts, tags, categories, authors, comments,
+// reactions, users, sessions and API keys and the current ID serial.
+func (s *Store) Compact() error {
+	return s.log.Compact()
+}
+
+// Close stops background compaction and closes the log.
+func (s *Store) Close() error {
+	close(s.done)
+	s.wg.Wait()
+
+	return s.log.Close()
+}
+
+func (s *Store) compactLoop(every time.Duration) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			if !s.log.Dirty() {
+				continue
+			}
+
+			if err := s.log.Compact(); err != nil {
+				log.Println("filestore: compaction failed: ", err)
+			}
+		}
+	}
+}
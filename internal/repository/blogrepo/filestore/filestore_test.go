@@ -0,0 +1,169 @@
+package filestore_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/anazcodes/blogapp/internal/business/blogbus"
+	"github.com/anazcodes/blogapp/internal/repository/blogrepo/cache"
+	"github.com/anazcodes/blogapp/internal/repository/blogrepo/filestore"
+	"github.com/anazcodes/blogapp/internal/repository/blogrepo/wal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReopen(t *testing.T) {
+	input := blogbus.AddBlogPost{
+		Title:       "Title",
+		Description: "Description",
+		Body:        "Body",
+	}
+
+	testCases := []struct {
+		name    string
+		compact bool
+	}{
+		{
+			name:    "Log",
+			compact: false,
+		},
+		{
+			name:    "Compacted",
+			compact: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+
+			store, err := filestore.Open(dir, 10, 0, wal.Options{})
+			assert.Nil(t, err)
+
+			for range 3 {
+				_, err := store.AddBlogPost(t.Context(), input)
+				assert.Nil(t, err)
+			}
+
+			_, err = store.UpdateBlogPost(t.Context(), 1, blogbus.UpdateBlogPost{Title: "Updated Title"})
+			assert.Nil(t, err)
+
+			_, err = store.DeleteBlogPost(t.Context(), 3)
+			assert.Nil(t, err)
+
+			if tc.compact {
+				assert.Nil(t, store.Compact())
+			}
+
+			assert.Nil(t, store.Close())
+
+			store, err = filestore.Open(dir, 10, 0, wal.Options{})
+			assert.Nil(t, err)
+			defer store.Close()
+
+			bps, err := store.BlogPosts(t.Context())
+			assert.Nil(t, err)
+			assert.Len(t, bps, 2)
+
+			bp, err := store.BlogPost(t.Context(), 1)
+			assert.Nil(t, err)
+			assert.Equal(t, "Updated Title", bp.Title)
+			assert.Equal(t, input.Body, bp.Body)
+
+			_, err = store.BlogPost(t.Context(), 3)
+			assert.ErrorIs(t, err, cache.ErrItemNotFound)
+
+			// IDs of deleted posts are never issued again.
+			id, err := store.AddBlogPost(t.Context(), input)
+			assert.Nil(t, err)
+			assert.Equal(t, uint64(4), id)
+		})
+	}
+}
+
+func TestTornTail(t *testing.T) {
+	dir := t.TempDir()
+
+	store, err := filestore.Open(dir, 10, 0, wal.Options{})
+	assert.Nil(t, err)
+
+	_, err = store.AddBlogPost(t.Context(), blogbus.AddBlogPost{Title: "Title"})
+	assert.Nil(t, err)
+	assert.Nil(t, store.Close())
+
+	f, err := os.OpenFile(filepath.Join(dir, "blogposts.wal"), os.O_WRONLY|os.O_APPEND, 0o644)
+	assert.Nil(t, err)
+	_, err = f.Write([]byte{0x40, 0x00, 0x00, 0x00, 0xde, 0xad})
+	assert.Nil(t, err)
+	assert.Nil(t, f.Close())
+
+	store, err = filestore.Open(dir, 10, 0, wal.Options{})
+	assert.Nil(t, err)
+
+	id, err := store.AddBlogPost(t.Context(), blogbus.AddBlogPost{Title: "Title"})
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(2), id)
+	assert.Nil(t, store.Close())
+
+	store, err = filestore.Open(dir, 10, 0, wal.Options{})
+	assert.Nil(t, err)
+	defer store.Close()
+
+	bps, err := store.BlogPosts(t.Context())
+	assert.Nil(t, err)
+	assert.Len(t, bps, 2)
+}
+
+func TestLegacyLog(t *testing.T) {
+	legacy := `{"op":"put","id":1,"post":{"ID":1,"Title":"First"}}
+{"op":"put","id":2,"post":{"ID":2,"Title":"Second"}}
+{"op":"delete","id":2}
+{"op":"serial","serial":3}
+{"op":"put","id":4,"post":{"ID":4,"Title":"Torn"`
+
+	t.Run("Migrated", func(t *testing.T) {
+		dir := t.TempDir()
+		assert.Nil(t, os.WriteFile(filepath.Join(dir, "blogposts.log"), []byte(legacy), 0o644))
+
+		store, err := filestore.Open(dir, 10, 0, wal.Options{})
+		assert.Nil(t, err)
+
+		bps, err := store.BlogPosts(t.Context())
+		assert.Nil(t, err)
+		assert.Len(t, bps, 1)
+		assert.Equal(t, "First", bps[0].Title)
+
+		// The serial of the legacy log carries over.
+		id, err := store.AddBlogPost(t.Context(), blogbus.AddBlogPost{Title: "Title"})
+		assert.Nil(t, err)
+		assert.Equal(t, uint64(4), id)
+		assert.Nil(t, store.Close())
+
+		_, err = os.Stat(filepath.Join(dir, "blogposts.log"))
+		assert.ErrorIs(t, err, os.ErrNotExist)
+		_, err = os.Stat(filepath.Join(dir, "blogposts.log.migrated"))
+		assert.Nil(t, err)
+
+		// Opened again, the store reads its write-ahead log only.
+		store, err = filestore.Open(dir, 10, 0, wal.Options{})
+		assert.Nil(t, err)
+		defer store.Close()
+
+		bps, err = store.BlogPosts(t.Context())
+		assert.Nil(t, err)
+		assert.Len(t, bps, 2)
+	})
+
+	t.Run("Both Logs", func(t *testing.T) {
+		dir := t.TempDir()
+
+		store, err := filestore.Open(dir, 10, 0, wal.Options{})
+		assert.Nil(t, err)
+		assert.Nil(t, store.Close())
+
+		assert.Nil(t, os.WriteFile(filepath.Join(dir, "blogposts.log"), []byte(legacy), 0o644))
+
+		_, err = filestore.Open(dir, 10, 0, wal.Options{})
+		assert.ErrorContains(t, err, "blogposts.log")
+	})
+}
@@ -0,0 +1,134 @@
+package filestore
+
+import (
+	"bufio"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/anazcodes/blogapp/internal/business/blogbus"
+	"github.com/anazcodes/blogapp/internal/repository/blogrepo/wal"
+)
+
+// legacyLogName is the log of the data directories written before the store logged to a write-ahead
+// log, one JSON record per line.
+const legacyLogName = "blogposts.log"
+
+// legacyRecord is a line of the legacy log.
+type legacyRecord struct {
+	Op     string            `json:"op"`
+	ID     uint64            `json:"id,omitempty"`
+	Serial uint64            `json:"serial,omitempty"`
+	Post   *blogbus.BlogPost `json:"post,omitempty"`
+}
+
+// migrate moves the posts of the legacy log in dir to a new write-ahead log and renames the legacy log
+// aside, so a data directory written before keeps its posts. It does nothing without a legacy log, and
+// fails when both logs exist since neither can be trusted to hold every post.
+func migrate(dir string, opts wal.Options) error {
+	legacy := filepath.Join(dir, legacyLogName)
+
+	if _, err := os.Stat(legacy); errors.Is(err, fs.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("stat: %w", err)
+	}
+
+	path := filepath.Join(dir, logName)
+
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("both %s and %s exist in %s, move aside the one that is out of date", legacyLogName, logName, dir)
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("stat: %w", err)
+	}
+
+	posts, serial, err := readLegacy(legacy)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", legacyLogName, err)
+	}
+
+	l, _, err := wal.Open(path, opts)
+	if err != nil {
+		return fmt.Errorf("wal.open: %w", err)
+	}
+
+	if err := l.Restore(blogbus.Snapshot{Posts: posts, Serial: serial}); err != nil {
+		l.Close()
+		return fmt.Errorf("wal.restore: %w", err)
+	}
+
+	if err := l.Close(); err != nil {
+		return fmt.Errorf("wal.close: %w", err)
+	}
+
+	if err := os.Rename(legacy, legacy+".migrated"); err != nil {
+		return fmt.Errorf("rename: %w", err)
+	}
+
+	log.Printf("filestore: migrated %d posts from %s to %s", len(posts), legacy, path)
+
+	return nil
+}
+
+// readLegacy returns the live posts of the legacy log at path, ordered by ID, and its ID serial.
+func readLegacy(path string) ([]blogbus.BlogPost, uint64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer f.Close()
+
+	live := make(map[uint64]blogbus.BlogPost)
+	var serial uint64
+
+	r := bufio.NewReader(f)
+	for line := 1; ; line++ {
+		b, err := r.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			// A trailing line without a newline is an append that never completed.
+			break
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+
+		var rec legacyRecord
+		if err := json.Unmarshal(b, &rec); err != nil {
+			return nil, 0, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		switch rec.Op {
+		case "put":
+			if rec.Post == nil {
+				return nil, 0, fmt.Errorf("line %d: put without post", line)
+			}
+			live[rec.Post.ID] = *rec.Post
+			serial = max(serial, rec.Post.ID)
+		case "delete":
+			delete(live, rec.ID)
+			serial = max(serial, rec.ID)
+		case "serial":
+			serial = max(serial, rec.Serial)
+		default:
+			return nil, 0, fmt.Errorf("line %d: unknown op %q", line, rec.Op)
+		}
+	}
+
+	posts := make([]blogbus.BlogPost, 0, len(live))
+	for _, bp := range live {
+		posts = append(posts, bp)
+	}
+
+	slices.SortFunc(posts, func(a, b blogbus.BlogPost) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return posts, serial, nil
+}
@@ -0,0 +1,230 @@
+package blogrepo
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/anazcodes/blogapp/internal/business/blogbus"
+	"github.com/anazcodes/blogapp/internal/repository/blogrepo/cache"
+)
+
+// reactions holds the reactions to every post with their counts. It is striped by post ID, so the
+// reactions to posts of different stripes never wait on each other, nor on the cache. Like authors it
+// holds the reactions to the posts of the cache and the spill, and knows which of them are in the trash
+// so a reaction never has to read its post.
+type reactions struct {
+	stripes [64]reactionStripe
+}
+
+type reactionStripe struct {
+	mu      sync.RWMutex
+	tallies map[uint64]*tally
+}
+
+// tally is the reactions to a post.
+type tally struct {
+	trashed bool
+	readers map[string]blogbus.ReaderReaction
+	counts  blogbus.ReactionCounts
+}
+
+func newTally(trashed bool) *tally {
+	return &tally{
+		trashed: trashed,
+		readers: make(map[string]blogbus.ReaderReaction),
+		counts:  make(blogbus.ReactionCounts),
+	}
+}
+
+// add counts rr, replacing the reaction its reader had.
+func (t *tally) add(rr blogbus.ReaderReaction) {
+	t.remove(rr.Reader)
+	t.readers[rr.Reader] = rr
+	t.counts[rr.Reaction]++
+}
+
+// remove uncounts the reaction of reader.
+func (t *tally) remove(reader string) {
+	old, ok := t.readers[reader]
+	if !ok {
+		return
+	}
+
+	delete(t.readers, reader)
+
+	if t.counts[old.Reaction]--; t.counts[old.Reaction] == 0 {
+		delete(t.counts, old.Reaction)
+	}
+}
+
+func newReactions() *reactions {
+	r := &reactions{}
+	r.reset(nil, nil)
+
+	return r
+}
+
+// stripe returns the stripe of the post with id.
+func (r *reactions) stripe(id uint64) *reactionStripe {
+	return &r.stripes[id%uint64(len(r.stripes))]
+}
+
+// reset replaces every reaction with the reactions of rrs to posts, and returns the IDs of the posts of
+// rrs left out as they are not in posts.
+func (r *reactions) reset(rrs []blogbus.ReaderReaction, posts []blogbus.BlogPost) []uint64 {
+	for i := range r.stripes {
+		r.stripes[i].mu.Lock()
+		defer r.stripes[i].mu.Unlock()
+
+		r.stripes[i].tallies = make(map[uint64]*tally)
+	}
+
+	for _, bp := range posts {
+		r.stripe(bp.ID).tallies[bp.ID] = newTally(bp.Trashed())
+	}
+
+	dropped := make(map[uint64]bool)
+
+	for _, rr := range rrs {
+		t, ok := r.stripe(rr.PostID).tallies[rr.PostID]
+		if !ok {
+			dropped[rr.PostID] = true
+			continue
+		}
+
+		t.add(rr)
+	}
+
+	return slices.Sorted(maps.Keys(dropped))
+}
+
+// open adds the post with id, which was added, with no reactions.
+func (r *reactions) open(id uint64) {
+	s := r.stripe(id)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tallies[id] = newTally(false)
+}
+
+// trash marks the post with id as moved to or out of the trash, its reactions are kept for when it is
+// restored.
+func (r *reactions) trash(id uint64, trashed bool) {
+	s := r.stripe(id)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if t, ok := s.tallies[id]; ok {
+		t.trashed = trashed
+	}
+}
+
+// drop removes the post with id, which was purged or evicted, with its reactions.
+func (r *reactions) drop(id uint64) {
+	s := r.stripe(id)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.tallies, id)
+}
+
+// react counts rr once persist stored it and returns the counts of the post after. Reacting again with
+// the same reaction changes nothing and skips persist.
+func (r *reactions) react(rr blogbus.ReaderReaction, persist func() error) (blogbus.ReactionCounts, error) {
+	s := r.stripe(rr.PostID)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, err := s.reactable(rr.PostID)
+	if err != nil {
+		return nil, err
+	}
+
+	if old, ok := t.readers[rr.Reader]; !ok || old.Reaction != rr.Reaction {
+		if err := persist(); err != nil {
+			return nil, err
+		}
+
+		t.add(rr)
+	}
+
+	return maps.Clone(t.counts), nil
+}
+
+// unreact uncounts the reaction of reader to the post with postID once persist removed it and returns
+// the counts of the post after. A reader with no reaction changes nothing and skips persist.
+func (r *reactions) unreact(postID uint64, reader string, persist func() error) (blogbus.ReactionCounts, error) {
+	s := r.stripe(postID)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, err := s.reactable(postID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := t.readers[reader]; ok {
+		if err := persist(); err != nil {
+			return nil, err
+		}
+
+		t.remove(reader)
+	}
+
+	return maps.Clone(t.counts), nil
+}
+
+// reactable returns the tally of the post with id, cache.ErrItemNotFound when there is no such post and
+// blogbus.ErrTrashed while it is in the trash. The caller must hold mu.
+func (s *reactionStripe) reactable(id uint64) (*tally, error) {
+	t, ok := s.tallies[id]
+	switch {
+	case !ok:
+		return nil, cache.ErrItemNotFound
+	case t.trashed:
+		return nil, blogbus.ErrTrashed
+	}
+
+	return t, nil
+}
+
+// counts returns the counts of the reactions to the post with id.
+func (r *reactions) counts(id uint64) blogbus.ReactionCounts {
+	s := r.stripe(id)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	t, ok := s.tallies[id]
+	if !ok {
+		return blogbus.ReactionCounts{}
+	}
+
+	return maps.Clone(t.counts)
+}
+
+// list returns every reaction, ordered by post ID and reader.
+func (r *reactions) list() []blogbus.ReaderReaction {
+	var rrs []blogbus.ReaderReaction
+
+	for i := range r.stripes {
+		r.stripes[i].mu.RLock()
+		for _, t := range r.stripes[i].tallies {
+			rrs = slices.AppendSeq(rrs, maps.Values(t.readers))
+		}
+		r.stripes[i].mu.RUnlock()
+	}
+
+	slices.SortFunc(rrs, func(a, b blogbus.ReaderReaction) int {
+		return cmp.Or(cmp.Compare(a.PostID, b.PostID), cmp.Compare(a.Reader, b.Reader))
+	})
+
+	return rrs
+}
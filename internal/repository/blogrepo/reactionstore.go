@@ -0,0 +1,93 @@
+package blogrepo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/anazcodes/blogapp/internal/business/blogbus"
+	"github.com/anazcodes/blogapp/internal/repository/blogrepo/wal"
+)
+
+// ReactionStore persists the reaction of every reader to every post, never the counts, which are
+// tallied from it on load.
+type ReactionStore interface {
+	Reactions(ctx context.Context) ([]blogbus.ReaderReaction, error)
+	PutReaction(ctx context.Context, rr blogbus.ReaderReaction) error
+	DeleteReaction(ctx context.Context, postID uint64, reader string) error
+	DeletePostReactions(ctx context.Context, postIDs []uint64) error
+}
+
+// logReactions stores the reactions of a WAL repository in its log, next to its posts.
+type logReactions struct {
+	log *wal.Log
+}
+
+func (lr logReactions) Reactions(ctx context.Context) ([]blogbus.ReaderReaction, error) {
+	return lr.log.Reactions()
+}
+
+func (lr logReactions) PutReaction(ctx context.Context, rr blogbus.ReaderReaction) error {
+	return lr.log.PutReaction(rr)
+}
+
+func (lr logReactions) DeleteReaction(ctx context.Context, postID uint64, reader string) error {
+	return lr.log.DeleteReaction(postID, reader)
+}
+
+func (lr logReactions) DeletePostReactions(ctx context.Context, postIDs []uint64) error {
+	return lr.log.DeletePostReactions(postIDs)
+}
+
+// persistReactions applies write to the reaction store, when the repository has one.
+func (r *repo) persistReactions(write func(s ReactionStore) error) error {
+	if r.reactionStore == nil {
+		return nil
+	}
+
+	return write(r.reactionStore)
+}
+
+// React neither reads nor locks the post, the reactions registry knows the posts that take reactions.
+// Only the reactions to the posts of the same stripe wait on each other.
+func (r *repo) React(ctx context.Context, rr blogbus.ReaderReaction) (blogbus.ReactionCounts, error) {
+	r.reactionWrites.RLock()
+	defer r.reactionWrites.RUnlock()
+
+	counts, err := r.reactions.react(rr, func() error {
+		return r.persistReactions(func(s ReactionStore) error { return s.PutReaction(ctx, rr) })
+	})
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+
+	return counts, nil
+}
+
+func (r *repo) Unreact(ctx context.Context, postID uint64, reader string) (blogbus.ReactionCounts, error) {
+	r.reactionWrites.RLock()
+	defer r.reactionWrites.RUnlock()
+
+	counts, err := r.reactions.unreact(postID, reader, func() error {
+		return r.persistReactions(func(s ReactionStore) error { return s.DeleteReaction(ctx, postID, reader) })
+	})
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+
+	return counts, nil
+}
+
+// withReactions returns bp with the counts of its reactions.
+func (r *repo) withReactions(bp blogbus.BlogPost) blogbus.BlogPost {
+	bp.Reactions = r.reactions.counts(bp.ID)
+
+	return bp
+}
+
+// purgeReactions deletes every reaction to the post with id, which was purged. The reactions a failure
+// leaves in the store are dropped on the next load.
+func (r *repo) purgeReactions(ctx context.Context, id uint64) error {
+	r.reactions.drop(id)
+
+	return r.persistReactions(func(s ReactionStore) error { return s.DeletePostReactions(ctx, []uint64{id}) })
+}
@@ -0,0 +1,73 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+// stopWords are the English words too common to tell posts apart, they are neither indexed nor searched.
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true, "but": true,
+	"by": true, "for": true, "if": true, "in": true, "into": true, "is": true, "it": true, "no": true,
+	"not": true, "of": true, "on": true, "or": true, "such": true, "that": true, "the": true,
+	"their": true, "then": true, "there": true, "these": true, "they": true, "this": true, "to": true,
+	"was": true, "will": true, "with": true,
+}
+
+// token is a word of a text.
+type token struct {
+	term       string // Stemmed lower-case form of the word, empty for a stop word.
+	start, end int    // Byte offsets of the word in the text.
+}
+
+// tokenize splits text into its words, the runs of letters and digits.
+func tokenize(text string) []token {
+	var toks []token
+
+	start := -1
+
+	for i, r := range text {
+		word := unicode.IsLetter(r) || unicode.IsDigit(r)
+
+		switch {
+		case word && start < 0:
+			start = i
+		case !word && start >= 0:
+			toks = append(toks, newToken(text, start, i))
+			start = -1
+		}
+	}
+
+	if start >= 0 {
+		toks = append(toks, newToken(text, start, len(text)))
+	}
+
+	return toks
+}
+
+func newToken(text string, start, end int) token {
+	w := strings.ToLower(text[start:end])
+	if stopWords[w] {
+		return token{start: start, end: end}
+	}
+
+	return token{term: stem(w), start: start, end: end}
+}
+
+// terms returns the distinct terms of text, in order of first appearance.
+func terms(text string) []string {
+	var out []string
+
+	seen := map[string]bool{}
+
+	for _, t := range tokenize(text) {
+		if t.term == "" || seen[t.term] {
+			continue
+		}
+
+		seen[t.term] = true
+		out = append(out, t.term)
+	}
+
+	return out
+}
@@ -0,0 +1,249 @@
+// Package search implements an in-memory full-text index of blog posts ranked by BM25.
+package search
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/anazcodes/blogapp/internal/business/blogbus"
+)
+
+// BM25 parameters, k1 saturates the term frequency and bm normalizes it by document length.
+const (
+	k1 = 1.2
+	bm = 0.75
+)
+
+// fields are the indexed post fields with their BM25F weights, a title word counts as much as three
+// body words.
+var fields = [...]struct {
+	name   string
+	weight float64
+	text   func(bp blogbus.BlogPost) string
+}{
+	{"title", 3, func(bp blogbus.BlogPost) string { return bp.Title }},
+	{"description", 2, func(bp blogbus.BlogPost) string { return bp.Description }},
+	{"body", 1, func(bp blogbus.BlogPost) string { return bp.Body }},
+}
+
+// document is an indexed post.
+type document struct {
+	post   blogbus.BlogPost   // The indexed fields of the post, kept to update it and cut snippets.
+	freqs  map[string]float64 // Weighted frequency of each term of the post.
+	length float64            // Weighted number of terms of the post.
+}
+
+// Hit is a post matching a search.
+type Hit struct {
+	ID       uint64
+	Score    float64
+	Snippets []blogbus.Snippet
+}
+
+// Compare orders hits by descending score and then by ID.
+func (h Hit) Compare(o Hit) int {
+	if c := cmp.Compare(o.Score, h.Score); c != 0 {
+		return c
+	}
+
+	return cmp.Compare(h.ID, o.ID)
+}
+
+// Index is an inverted index of the published posts, safe for concurrent use.
+// Search is public, so a post that is not published or is in the trash is never indexed.
+type Index struct {
+	mu       sync.RWMutex
+	docs     map[uint64]*document
+	postings map[string]map[uint64]float64 // Weighted frequency of a term in each post containing it.
+	length   float64                       // Weighted number of terms of every post.
+}
+
+func New() *Index {
+	return &Index{
+		docs:     map[uint64]*document{},
+		postings: map[string]map[uint64]float64{},
+	}
+}
+
+// Put indexes bp when it is published and not in the trash, replacing the post with its ID.
+func (x *Index) Put(bp blogbus.BlogPost) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	x.remove(bp.ID)
+
+	if public(bp) {
+		x.add(bp)
+	}
+}
+
+// public reports whether bp can be found by a search.
+func public(bp blogbus.BlogPost) bool {
+	return bp.Status == blogbus.StatusPublished && !bp.Trashed()
+}
+
+// Update applies ubp to the indexed post with id the way the storage does. A post that is not indexed
+// is left out.
+func (x *Index) Update(id uint64, ubp blogbus.UpdateBlogPost) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	doc, ok := x.docs[id]
+	if !ok {
+		return
+	}
+
+	bp := ubp.Apply(doc.post, time.Time{})
+
+	x.remove(id)
+	x.add(bp)
+}
+
+// Delete removes the post with id from the index.
+func (x *Index) Delete(id uint64) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	x.remove(id)
+}
+
+// Reset replaces every indexed post with the published posts of bps that are not in the trash.
+func (x *Index) Reset(bps []blogbus.BlogPost) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	x.docs = make(map[uint64]*document, len(bps))
+	x.postings = map[string]map[uint64]float64{}
+	x.length = 0
+
+	for _, bp := range bps {
+		if public(bp) {
+			x.add(bp)
+		}
+	}
+}
+
+// Search returns up to limit posts matching any term of q that rank after after, the most relevant
+// first. A nil after returns the most relevant posts. Each hit has a snippet of every field that
+// matches.
+func (x *Index) Search(q string, after *Hit, limit int) []Hit {
+	qterms := terms(q)
+
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	if len(qterms) == 0 || len(x.docs) == 0 {
+		return nil
+	}
+
+	n := float64(len(x.docs))
+	avg := x.length / n
+	scores := map[uint64]float64{}
+
+	for _, t := range qterms {
+		posting := x.postings[t]
+		if len(posting) == 0 {
+			continue
+		}
+
+		df := float64(len(posting))
+		idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+
+		for id, tf := range posting {
+			norm := 1 - bm + bm*x.docs[id].length/avg
+			scores[id] += idf * tf * (k1 + 1) / (tf + k1*norm)
+		}
+	}
+
+	hits := make([]Hit, 0, len(scores))
+
+	for id, score := range scores {
+		h := Hit{ID: id, Score: score}
+		if after == nil || after.Compare(h) < 0 {
+			hits = append(hits, h)
+		}
+	}
+
+	slices.SortFunc(hits, Hit.Compare)
+
+	hits = hits[:min(limit, len(hits))]
+
+	matching := make(map[string]bool, len(qterms))
+	for _, t := range qterms {
+		matching[t] = true
+	}
+
+	for i := range hits {
+		hits[i].Snippets = x.snippets(hits[i].ID, matching)
+	}
+
+	return hits
+}
+
+// snippets returns the snippet of every field of the post with id that has a word matching terms.
+func (x *Index) snippets(id uint64, terms map[string]bool) []blogbus.Snippet {
+	var out []blogbus.Snippet
+
+	for _, f := range fields {
+		if text, ok := snippet(f.text(x.docs[id].post), terms); ok {
+			out = append(out, blogbus.Snippet{Field: f.name, Text: text})
+		}
+	}
+
+	return out
+}
+
+// add indexes bp, the caller must hold mu and have removed any post with its ID.
+func (x *Index) add(bp blogbus.BlogPost) {
+	doc := &document{
+		post:  blogbus.BlogPost{ID: bp.ID, Title: bp.Title, Description: bp.Description, Body: bp.Body},
+		freqs: map[string]float64{},
+	}
+
+	for _, f := range fields {
+		for _, t := range tokenize(f.text(bp)) {
+			if t.term == "" {
+				continue
+			}
+
+			doc.freqs[t.term] += f.weight
+			doc.length += f.weight
+		}
+	}
+
+	for term, tf := range doc.freqs {
+		posting, ok := x.postings[term]
+		if !ok {
+			posting = map[uint64]float64{}
+			x.postings[term] = posting
+		}
+
+		posting[bp.ID] = tf
+	}
+
+	x.docs[bp.ID] = doc
+	x.length += doc.length
+}
+
+// remove drops the post with id from the index, the caller must hold mu.
+func (x *Index) remove(id uint64) {
+	doc, ok := x.docs[id]
+	if !ok {
+		return
+	}
+
+	for term := range doc.freqs {
+		posting := x.postings[term]
+		delete(posting, id)
+
+		if len(posting) == 0 {
+			delete(x.postings, term)
+		}
+	}
+
+	delete(x.docs, id)
+	x.length -= doc.length
+}
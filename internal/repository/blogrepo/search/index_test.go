@@ -0,0 +1,149 @@
+package search_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/anazcodes/blogapp/internal/business/blogbus"
+	"github.com/anazcodes/blogapp/internal/repository/blogrepo/search"
+	"github.com/stretchr/testify/assert"
+)
+
+func newIndex() *search.Index {
+	x := search.New()
+	x.Reset([]blogbus.BlogPost{
+		{ID: 1, Title: "Connecting to Databases", Description: "Pooling and retries", Body: "Open a pool of connections.", Status: blogbus.StatusPublished},
+		{ID: 2, Title: "Caching", Description: "Eviction policies", Body: "A database is slower than a cache.", Status: blogbus.StatusPublished},
+		{ID: 3, Title: "Testing", Description: "Table driven tests", Body: "Run the tests in parallel.", Status: blogbus.StatusPublished},
+		{ID: 4, Title: "Draft about Caching", Status: blogbus.StatusDraft},
+	})
+
+	return x
+}
+
+func ids(hits []search.Hit) []uint64 {
+	out := make([]uint64, len(hits))
+	for i, h := range hits {
+		out[i] = h.ID
+	}
+
+	return out
+}
+
+func TestSearch(t *testing.T) {
+	testCases := []struct {
+		name     string
+		query    string
+		expected []uint64
+	}{
+		{
+			name:     "Stemmed",
+			query:    "connections",
+			expected: []uint64{1},
+		},
+		{
+			name:     "Case Insensitive",
+			query:    "CACHING",
+			expected: []uint64{2},
+		},
+		{
+			name:     "Title Ranks First",
+			query:    "databases",
+			expected: []uint64{1, 2},
+		},
+		{
+			name:     "Any Term",
+			query:    "pool tested",
+			expected: []uint64{3, 1},
+		},
+		{
+			name:  "Stop Words",
+			query: "the a is",
+		},
+		{
+			name:  "No Match",
+			query: "kubernetes",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hits := newIndex().Search(tc.query, nil, 10)
+			assert.Equal(t, len(tc.expected), len(hits))
+			if len(tc.expected) > 0 {
+				assert.Equal(t, tc.expected, ids(hits))
+			}
+
+			for _, h := range hits {
+				assert.Greater(t, h.Score, 0.0)
+			}
+		})
+	}
+}
+
+func TestSearchPages(t *testing.T) {
+	x := newIndex()
+
+	all := x.Search("database tests", nil, 10)
+	assert.Len(t, all, 3)
+
+	var paged []search.Hit
+	var after *search.Hit
+
+	for {
+		hits := x.Search("database tests", after, 1)
+		if len(hits) == 0 {
+			break
+		}
+
+		paged = append(paged, hits...)
+		after = &hits[len(hits)-1]
+	}
+
+	assert.Equal(t, ids(all), ids(paged))
+}
+
+func TestIndexMutations(t *testing.T) {
+	x := newIndex()
+
+	x.Update(2, blogbus.UpdateBlogPost{Description: "Kubernetes"})
+	assert.Empty(t, x.Search("eviction", nil, 10))
+	assert.Equal(t, []uint64{2}, ids(x.Search("kubernetes", nil, 10)))
+	// The fields the update leaves empty are still indexed.
+	assert.Equal(t, []uint64{2}, ids(x.Search("caching", nil, 10)))
+
+	x.Delete(2)
+	assert.Empty(t, x.Search("kubernetes", nil, 10))
+
+	x.Put(blogbus.BlogPost{ID: 5, Title: "Kubernetes Operators", Status: blogbus.StatusPublished})
+	assert.Equal(t, []uint64{5}, ids(x.Search("operator", nil, 10)))
+
+	// Updating a post that is not indexed does not index it.
+	x.Update(6, blogbus.UpdateBlogPost{Title: "Kubernetes"})
+	assert.Equal(t, []uint64{5}, ids(x.Search("kubernetes", nil, 10)))
+
+	// A post that is no longer published is removed.
+	x.Put(blogbus.BlogPost{ID: 5, Title: "Kubernetes Operators", Status: blogbus.StatusArchived})
+	assert.Empty(t, x.Search("kubernetes", nil, 10))
+}
+
+func TestSnippets(t *testing.T) {
+	x := search.New()
+	x.Put(blogbus.BlogPost{
+		ID:     1,
+		Status: blogbus.StatusPublished,
+		Title:  "Caching <basics>",
+		Body:   strings.Repeat("filler ", 10) + "the cache is fast, caches are everywhere " + strings.Repeat("filler ", 30),
+	})
+
+	hits := x.Search("cache", nil, 10)
+	assert.Len(t, hits, 1)
+	assert.Equal(t, []blogbus.Snippet{
+		{Field: "title", Text: "<mark>Caching</mark> &lt;basics&gt;"},
+		{
+			Field: "body",
+			Text: "…filler filler filler filler filler the <mark>cache</mark> is fast, <mark>caches</mark> are everywhere " +
+				strings.TrimSpace(strings.Repeat("filler ", 12)) + "…",
+		},
+	}, hits[0].Snippets)
+}
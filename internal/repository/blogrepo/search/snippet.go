@@ -0,0 +1,71 @@
+package search
+
+import (
+	"html"
+	"strings"
+)
+
+const (
+	snippetWords  = 24 // Words of a snippet at most.
+	snippetBefore = 6  // Words of a snippet kept before its first match.
+)
+
+// snippet returns the excerpt of text around its first word matching terms, with the matching words
+// of the excerpt wrapped in <mark> tags and the rest HTML escaped. An excerpt that does not reach the
+// start or the end of text is marked with an ellipsis there. It reports false when no word matches.
+func snippet(text string, terms map[string]bool) (string, bool) {
+	toks := tokenize(text)
+
+	first := -1
+
+	for i, t := range toks {
+		if terms[t.term] {
+			first = i
+			break
+		}
+	}
+
+	if first < 0 {
+		return "", false
+	}
+
+	from := max(first-snippetBefore, 0)
+	to := min(from+snippetWords, len(toks))
+
+	start, end := toks[from].start, toks[to-1].end
+	if from == 0 {
+		start = 0
+	}
+	if to == len(toks) {
+		end = len(text)
+	}
+
+	var sb strings.Builder
+
+	if from > 0 {
+		sb.WriteString("…")
+	}
+
+	pos := start
+
+	for _, t := range toks[from:to] {
+		if !terms[t.term] {
+			continue
+		}
+
+		sb.WriteString(html.EscapeString(text[pos:t.start]))
+		sb.WriteString("<mark>")
+		sb.WriteString(html.EscapeString(text[t.start:t.end]))
+		sb.WriteString("</mark>")
+
+		pos = t.end
+	}
+
+	sb.WriteString(html.EscapeString(text[pos:end]))
+
+	if to < len(toks) {
+		sb.WriteString("…")
+	}
+
+	return sb.String(), true
+}
@@ -0,0 +1,227 @@
+package search
+
+import "bytes"
+
+// stem reduces an English word to its stem with the Porter stemming algorithm, so the inflections
+// of a word, like "connected", "connecting" and "connections", are indexed as one term.
+// Words that are not made of lower-case ASCII letters, or are shorter than three, are returned as is.
+func stem(w string) string {
+	if len(w) < 3 {
+		return w
+	}
+
+	for i := 0; i < len(w); i++ {
+		if w[i] < 'a' || w[i] > 'z' {
+			return w
+		}
+	}
+
+	b := []byte(w)
+	b = step1a(b)
+	b = step1b(b)
+	b = step1c(b)
+	b = replaceSuffix(b, step2Rules)
+	b = replaceSuffix(b, step3Rules)
+	b = step4(b)
+	b = step5(b)
+
+	return string(b)
+}
+
+// The suffix rules of steps 2 to 4. Of the suffixes a word ends with only the first one listed is
+// considered, so a suffix is listed before the shorter suffixes it ends with.
+var (
+	step2Rules = [][2]string{
+		{"ational", "ate"}, {"tional", "tion"}, {"enci", "ence"}, {"anci", "ance"}, {"izer", "ize"},
+		{"abli", "able"}, {"alli", "al"}, {"entli", "ent"}, {"eli", "e"}, {"ousli", "ous"},
+		{"ization", "ize"}, {"ation", "ate"}, {"ator", "ate"}, {"alism", "al"}, {"iveness", "ive"},
+		{"fulness", "ful"}, {"ousness", "ous"}, {"aliti", "al"}, {"iviti", "ive"}, {"biliti", "ble"},
+	}
+	step3Rules = [][2]string{
+		{"icate", "ic"}, {"ative", ""}, {"alize", "al"}, {"iciti", "ic"}, {"ical", "ic"}, {"ful", ""},
+		{"ness", ""},
+	}
+	step4Suffixes = []string{
+		"al", "ance", "ence", "er", "ic", "able", "ible", "ant", "ement", "ment", "ent", "ion", "ou",
+		"ism", "ate", "iti", "ous", "ive", "ize",
+	}
+)
+
+func step1a(b []byte) []byte {
+	switch {
+	case bytes.HasSuffix(b, []byte("sses")), bytes.HasSuffix(b, []byte("ies")):
+		return b[:len(b)-2]
+	case bytes.HasSuffix(b, []byte("ss")):
+		return b
+	case bytes.HasSuffix(b, []byte("s")):
+		return b[:len(b)-1]
+	}
+
+	return b
+}
+
+func step1b(b []byte) []byte {
+	if bytes.HasSuffix(b, []byte("eed")) {
+		if measure(b[:len(b)-3]) > 0 {
+			return b[:len(b)-1]
+		}
+
+		return b
+	}
+
+	var cut int
+
+	switch {
+	case bytes.HasSuffix(b, []byte("ed")):
+		cut = 2
+	case bytes.HasSuffix(b, []byte("ing")):
+		cut = 3
+	default:
+		return b
+	}
+
+	if !hasVowel(b[:len(b)-cut]) {
+		return b
+	}
+
+	b = b[:len(b)-cut]
+
+	switch {
+	case bytes.HasSuffix(b, []byte("at")), bytes.HasSuffix(b, []byte("bl")), bytes.HasSuffix(b, []byte("iz")):
+		return append(b, 'e')
+	case doubleConsonant(b) && bytes.IndexByte([]byte("lsz"), b[len(b)-1]) < 0:
+		return b[:len(b)-1]
+	case measure(b) == 1 && cvc(b):
+		return append(b, 'e')
+	}
+
+	return b
+}
+
+func step1c(b []byte) []byte {
+	if bytes.HasSuffix(b, []byte("y")) && hasVowel(b[:len(b)-1]) {
+		b[len(b)-1] = 'i'
+	}
+
+	return b
+}
+
+func step4(b []byte) []byte {
+	for _, suffix := range step4Suffixes {
+		if !bytes.HasSuffix(b, []byte(suffix)) {
+			continue
+		}
+
+		stem := b[:len(b)-len(suffix)]
+		if measure(stem) <= 1 {
+			return b
+		}
+
+		if suffix == "ion" && !bytes.HasSuffix(stem, []byte("s")) && !bytes.HasSuffix(stem, []byte("t")) {
+			return b
+		}
+
+		return stem
+	}
+
+	return b
+}
+
+func step5(b []byte) []byte {
+	if bytes.HasSuffix(b, []byte("e")) {
+		stem := b[:len(b)-1]
+		if m := measure(stem); m > 1 || m == 1 && !cvc(stem) {
+			b = stem
+		}
+	}
+
+	if bytes.HasSuffix(b, []byte("l")) && doubleConsonant(b) && measure(b) > 1 {
+		b = b[:len(b)-1]
+	}
+
+	return b
+}
+
+// replaceSuffix replaces the first suffix of rules b ends with, when the stem before it has a
+// positive measure.
+func replaceSuffix(b []byte, rules [][2]string) []byte {
+	for _, r := range rules {
+		if !bytes.HasSuffix(b, []byte(r[0])) {
+			continue
+		}
+
+		stem := b[:len(b)-len(r[0])]
+		if measure(stem) == 0 {
+			return b
+		}
+
+		return append(stem, r[1]...)
+	}
+
+	return b
+}
+
+// consonant reports whether b[i] is a consonant, y is one unless it follows a consonant.
+func consonant(b []byte, i int) bool {
+	switch b[i] {
+	case 'a', 'e', 'i', 'o', 'u':
+		return false
+	case 'y':
+		return i == 0 || !consonant(b, i-1)
+	}
+
+	return true
+}
+
+// measure returns the number of vowel-consonant sequences in b, the m of the algorithm.
+func measure(b []byte) int {
+	var m, i int
+
+	for i < len(b) && consonant(b, i) {
+		i++
+	}
+
+	for i < len(b) {
+		for i < len(b) && !consonant(b, i) {
+			i++
+		}
+
+		if i == len(b) {
+			break
+		}
+
+		for i < len(b) && consonant(b, i) {
+			i++
+		}
+
+		m++
+	}
+
+	return m
+}
+
+func hasVowel(b []byte) bool {
+	for i := range b {
+		if !consonant(b, i) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// doubleConsonant reports whether b ends with two identical consonants.
+func doubleConsonant(b []byte) bool {
+	n := len(b)
+	return n >= 2 && b[n-1] == b[n-2] && consonant(b, n-1)
+}
+
+// cvc reports whether b ends consonant-vowel-consonant where the last consonant is not w, x or y.
+func cvc(b []byte) bool {
+	n := len(b)
+	if n < 3 || !consonant(b, n-3) || consonant(b, n-2) || !consonant(b, n-1) {
+		return false
+	}
+
+	return b[n-1] != 'w' && b[n-1] != 'x' && b[n-1] != 'y'
+}
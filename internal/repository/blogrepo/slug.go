@@ -0,0 +1,128 @@
+package blogrepo
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+
+	"github.com/anazcodes/blogapp/internal/business/blogbus"
+)
+
+// slugs maps every slug, current or old, to the post it leads to. It is the one place slugs are
+// claimed, so a slug is unique across the cache and the spill of every repository.
+type slugs struct {
+	mu  sync.Mutex
+	ids map[string]uint64 // Zero while the post taking the slug is being added.
+}
+
+func newSlugs() *slugs {
+	return &slugs{ids: make(map[string]uint64)}
+}
+
+// lookup returns the ID of the post slug leads to.
+func (s *slugs) lookup(slug string) (uint64, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := s.ids[slug]
+
+	return id, id != 0
+}
+
+// claim takes slug for the post with id, or reports that another post holds it. A zero id reserves
+// the slug for a post being added, bind then hands it to the ID the post was added with.
+func (s *slugs) claim(slug string, id uint64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.take(slug, id)
+}
+
+// take is claim for a caller holding mu.
+func (s *slugs) take(slug string, id uint64) bool {
+	owner, ok := s.ids[slug]
+	if ok && (owner != id || id == 0) {
+		return false
+	}
+
+	s.ids[slug] = id
+
+	return true
+}
+
+// generate claims the first free candidate of base for the post with id and returns it.
+func (s *slugs) generate(base string, id uint64) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.next(base, id)
+}
+
+// next is generate for a caller holding mu.
+func (s *slugs) next(base string, id uint64) string {
+	for n := 1; ; n++ {
+		if slug := blogbus.SlugCandidate(base, n); s.take(slug, id) {
+			return slug
+		}
+	}
+}
+
+// bind hands a slug reserved for a post being added to the ID it was added with.
+func (s *slugs) bind(slug string, id uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.ids[slug] = id
+}
+
+// release frees the slugs, held by a post that is gone or failed to take them.
+func (s *slugs) release(slugs ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, slug := range slugs {
+		delete(s.ids, slug)
+	}
+}
+
+// reset replaces every slug with the slugs of posts and returns posts, ordered by ID, with the slugs
+// they hold. A post without a slug, stored before posts had one, gets one generated from
+// its title, and so does a post whose slug clashes with a post of a lower ID. Those posts are also
+// returned in changed so the caller can store their slug.
+func (s *slugs) reset(posts []blogbus.BlogPost) (out, changed []blogbus.BlogPost) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.ids = make(map[string]uint64, len(posts))
+
+	// The lowest ID keeps a clashing slug, as it most likely held it first.
+	out = slices.Clone(posts)
+	slices.SortFunc(out, func(a, b blogbus.BlogPost) int { return cmp.Compare(a.ID, b.ID) })
+
+	// The slugs that are stored are claimed first, so a generated slug never takes one of them.
+	var missing []int
+	for i, bp := range out {
+		if bp.Slug == "" || !s.take(bp.Slug, bp.ID) {
+			missing = append(missing, i)
+		}
+
+		for _, old := range bp.OldSlugs {
+			s.take(old, bp.ID)
+		}
+	}
+
+	for _, i := range missing {
+		out[i].Slug = s.next(blogbus.Slugify(out[i].Title), out[i].ID)
+		changed = append(changed, out[i])
+	}
+
+	return out, changed
+}
+
+// replace takes the slugs of o, built by reset before the posts it was reset with were stored.
+func (s *slugs) replace(o *slugs) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.ids = o.ids
+}
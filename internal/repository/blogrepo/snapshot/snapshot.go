@@ -0,0 +1,345 @@
+// Package snapshot implements the versioned file format of blog store snapshots.
+//
+// A snapshot file starts with a magic string and a format version, followed by the payload
+// length, a CRC-32C checksum of the payload and the JSON encoded payload.
+package snapshot
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"time"
+
+	"github.com/anazcodes/blogapp/internal/business/blogbus"
+	"github.com/anazcodes/blogapp/internal/business/commentbus"
+)
+
+var (
+	ErrBadMagic           = errors.New("not a snapshot file")
+	ErrUnsupportedVersion = errors.New("unsupported snapshot version")
+	ErrChecksum           = errors.New("snapshot checksum mismatch")
+)
+
+// Version is the format version written by Write.
+// Version 2 added the status of posts, a version 1 snapshot is read with every post published.
+// Version 3 added the time drafts are scheduled to be published at, version 4 the revisions of posts
+// and version 5 the time posts were moved to the trash. Version 6 added slugs, the posts of an older
+// snapshot are given one when it is restored. Version 7 added tags and categories, version 8 authors
+// and version 9 comments. Version 10 added the moderation of comments, the comments of an older
+// snapshot are read approved. Version 11 added the reactions of readers and version 12 the users who
+// posted comments. Version 13 added the users who wrote posts, the posts of an older snapshot have none.
+const Version uint32 = 13
+
+var magic = []byte("BLOGSNAP")
+
+const headerSize = 24 // Magic, version, payload length and checksum.
+
+var table = crc32.MakeTable(crc32.Castagnoli)
+
+// payload is the JSON encoded body of a snapshot.
+type payload struct {
+	Serial     uint64     `json:"serial"`
+	CreatedAt  time.Time  `json:"created_at"`
+	Posts      []post     `json:"posts"`
+	Tags       []tag      `json:"tags,omitempty"`
+	Categories []category `json:"categories,omitempty"`
+	Authors    []author   `json:"authors,omitempty"`
+	Comments   []comment  `json:"comments,omitempty"`
+	Reactions  []reaction `json:"reactions,omitempty"`
+}
+
+// post decouples the file format from blogbus.BlogPost so the model can change without breaking old files.
+type post struct {
+	ID          uint64     `json:"id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Body        string     `json:"body"`
+	Status      string     `json:"status"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	PublishedAt time.Time  `json:"published_at,omitzero"`
+	PublishAt   time.Time  `json:"publish_at,omitzero"`
+	Revisions   []revision `json:"revisions,omitempty"`
+	DeletedAt   time.Time  `json:"deleted_at,omitzero"`
+	Slug        string     `json:"slug,omitempty"`
+	OldSlugs    []string   `json:"old_slugs,omitempty"`
+	Tags        []string   `json:"tags,omitempty"`
+	Categories  []string   `json:"categories,omitempty"`
+	AuthorID    uint64     `json:"author_id,omitempty"`
+	Owner       string     `json:"owner,omitempty"`
+}
+
+type revision struct {
+	Number       uint64    `json:"number"`
+	Editor       string    `json:"editor,omitempty"`
+	Changed      []string  `json:"changed,omitempty"`
+	RestoredFrom uint64    `json:"restored_from,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	Title        string    `json:"title"`
+	Description  string    `json:"description"`
+	Body         string    `json:"body"`
+}
+
+type tag struct {
+	Slug      string    `json:"slug"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type category struct {
+	Slug      string    `json:"slug"`
+	Name      string    `json:"name"`
+	Parent    string    `json:"parent,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type author struct {
+	ID        uint64    `json:"id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email,omitempty"`
+	Bio       string    `json:"bio,omitempty"`
+	AvatarURL string    `json:"avatar_url,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type comment struct {
+	ID          uint64    `json:"id"`
+	PostID      uint64    `json:"post_id"`
+	ParentID    uint64    `json:"parent_id,omitempty"`
+	Author      string    `json:"author"`
+	Commenter   string    `json:"commenter,omitempty"`
+	Body        string    `json:"body"`
+	Status      string    `json:"status"`
+	Flag        string    `json:"flag,omitempty"`
+	ModeratedAt time.Time `json:"moderated_at,omitzero"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type reaction struct {
+	PostID    uint64    `json:"post_id"`
+	Reader    string    `json:"reader"`
+	Reaction  string    `json:"reaction"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Write encodes s to w in the current format version.
+func Write(w io.Writer, s blogbus.Snapshot) (blogbus.SnapshotInfo, error) {
+	p := payload{
+		Serial:    s.Serial,
+		CreatedAt: s.CreatedAt,
+		Posts:     make([]post, len(s.Posts)),
+	}
+
+	for _, t := range s.Taxonomy.Tags {
+		p.Tags = append(p.Tags, tag(t))
+	}
+
+	for _, c := range s.Taxonomy.Categories {
+		p.Categories = append(p.Categories, category(c))
+	}
+
+	for _, a := range s.Authors {
+		p.Authors = append(p.Authors, author(a))
+	}
+
+	for _, c := range s.Comments {
+		p.Comments = append(p.Comments, comment{
+			ID:          c.ID,
+			PostID:      c.PostID,
+			ParentID:    c.ParentID,
+			Author:      c.Author,
+			Commenter:   c.Commenter,
+			Body:        c.Body,
+			Status:      string(c.Status),
+			Flag:        c.Flag,
+			ModeratedAt: c.ModeratedAt,
+			CreatedAt:   c.CreatedAt,
+			UpdatedAt:   c.UpdatedAt,
+		})
+	}
+
+	for _, rr := range s.Reactions {
+		p.Reactions = append(p.Reactions, reaction{
+			PostID:    rr.PostID,
+			Reader:    rr.Reader,
+			Reaction:  string(rr.Reaction),
+			CreatedAt: rr.At,
+		})
+	}
+
+	for i, bp := range s.Posts {
+		p.Posts[i] = post{
+			ID:          bp.ID,
+			Title:       bp.Title,
+			Description: bp.Description,
+			Body:        bp.Body,
+			Status:      string(bp.Status),
+			CreatedAt:   bp.CreatedAt,
+			UpdatedAt:   bp.UpdatedAt,
+			PublishedAt: bp.PublishedAt,
+			PublishAt:   bp.PublishAt,
+			DeletedAt:   bp.DeletedAt,
+			Slug:        bp.Slug,
+			OldSlugs:    bp.OldSlugs,
+			Tags:        bp.Tags,
+			Categories:  bp.Categories,
+			AuthorID:    bp.AuthorID,
+			Owner:       bp.Owner,
+		}
+
+		for _, r := range bp.Revisions {
+			p.Posts[i].Revisions = append(p.Posts[i].Revisions, revision(r))
+		}
+	}
+
+	body, err := json.Marshal(p)
+	if err != nil {
+		return blogbus.SnapshotInfo{}, fmt.Errorf("marshal: %w", err)
+	}
+
+	header := make([]byte, headerSize)
+	copy(header, magic)
+	binary.LittleEndian.PutUint32(header[8:12], Version)
+	binary.LittleEndian.PutUint64(header[12:20], uint64(len(body)))
+	binary.LittleEndian.PutUint32(header[20:24], crc32.Checksum(body, table))
+
+	if _, err := w.Write(header); err != nil {
+		return blogbus.SnapshotInfo{}, fmt.Errorf("write header: %w", err)
+	}
+
+	if _, err := w.Write(body); err != nil {
+		return blogbus.SnapshotInfo{}, fmt.Errorf("write payload: %w", err)
+	}
+
+	return info(s, Version), nil
+}
+
+// Read decodes a snapshot from r and verifies its checksum.
+func Read(r io.Reader) (blogbus.Snapshot, blogbus.SnapshotInfo, error) {
+	header := make([]byte, headerSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return blogbus.Snapshot{}, blogbus.SnapshotInfo{}, fmt.Errorf("read header: %w", ErrBadMagic)
+	}
+
+	if !bytes.Equal(header[:8], magic) {
+		return blogbus.Snapshot{}, blogbus.SnapshotInfo{}, ErrBadMagic
+	}
+
+	version := binary.LittleEndian.Uint32(header[8:12])
+	if version < 1 || version > Version {
+		return blogbus.Snapshot{}, blogbus.SnapshotInfo{}, fmt.Errorf("%w: %d", ErrUnsupportedVersion, version)
+	}
+
+	length := binary.LittleEndian.Uint64(header[12:20])
+	sum := binary.LittleEndian.Uint32(header[20:24])
+
+	body, err := io.ReadAll(io.LimitReader(r, int64(length)))
+	if err != nil {
+		return blogbus.Snapshot{}, blogbus.SnapshotInfo{}, fmt.Errorf("read payload: %w", err)
+	}
+
+	if uint64(len(body)) != length || crc32.Checksum(body, table) != sum {
+		return blogbus.Snapshot{}, blogbus.SnapshotInfo{}, ErrChecksum
+	}
+
+	var p payload
+	if err := json.Unmarshal(body, &p); err != nil {
+		return blogbus.Snapshot{}, blogbus.SnapshotInfo{}, fmt.Errorf("unmarshal: %w", err)
+	}
+
+	s := blogbus.Snapshot{
+		Serial:    p.Serial,
+		CreatedAt: p.CreatedAt,
+		Posts:     make([]blogbus.BlogPost, len(p.Posts)),
+	}
+
+	for _, t := range p.Tags {
+		s.Taxonomy.Tags = append(s.Taxonomy.Tags, blogbus.Tag(t))
+	}
+
+	for _, c := range p.Categories {
+		s.Taxonomy.Categories = append(s.Taxonomy.Categories, blogbus.Category(c))
+	}
+
+	for _, a := range p.Authors {
+		s.Authors = append(s.Authors, blogbus.Author(a))
+	}
+
+	for _, c := range p.Comments {
+		cm := commentbus.Comment{
+			ID:          c.ID,
+			PostID:      c.PostID,
+			ParentID:    c.ParentID,
+			Author:      c.Author,
+			Commenter:   c.Commenter,
+			Body:        c.Body,
+			Status:      commentbus.Status(c.Status),
+			Flag:        c.Flag,
+			ModeratedAt: c.ModeratedAt,
+			CreatedAt:   c.CreatedAt,
+			UpdatedAt:   c.UpdatedAt,
+		}
+
+		if version < 10 {
+			cm.Status = commentbus.StatusApproved
+		}
+
+		s.Comments = append(s.Comments, cm)
+	}
+
+	for _, rr := range p.Reactions {
+		s.Reactions = append(s.Reactions, blogbus.ReaderReaction{
+			PostID:   rr.PostID,
+			Reader:   rr.Reader,
+			Reaction: blogbus.Reaction(rr.Reaction),
+			At:       rr.CreatedAt,
+		})
+	}
+
+	for i, bp := range p.Posts {
+		s.Posts[i] = blogbus.BlogPost{
+			ID:          bp.ID,
+			Title:       bp.Title,
+			Description: bp.Description,
+			Body:        bp.Body,
+			Status:      blogbus.Status(bp.Status),
+			CreatedAt:   bp.CreatedAt,
+			UpdatedAt:   bp.UpdatedAt,
+			PublishedAt: bp.PublishedAt,
+			PublishAt:   bp.PublishAt,
+			DeletedAt:   bp.DeletedAt,
+			Slug:        bp.Slug,
+			OldSlugs:    bp.OldSlugs,
+			Tags:        bp.Tags,
+			Categories:  bp.Categories,
+			AuthorID:    bp.AuthorID,
+			Owner:       bp.Owner,
+		}
+
+		for _, r := range bp.Revisions {
+			s.Posts[i].Revisions = append(s.Posts[i].Revisions, blogbus.Revision(r))
+		}
+
+		if version == 1 {
+			s.Posts[i].Status = blogbus.StatusPublished
+			s.Posts[i].PublishedAt = bp.CreatedAt
+		}
+	}
+
+	return s, info(s, version), nil
+}
+
+func info(s blogbus.Snapshot, version uint32) blogbus.SnapshotInfo {
+	return blogbus.SnapshotInfo{
+		Version:   version,
+		Serial:    s.Serial,
+		Posts:     len(s.Posts),
+		CreatedAt: s.CreatedAt,
+	}
+}
@@ -0,0 +1,162 @@
+package snapshot_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"testing"
+	"time"
+
+	"github.com/anazcodes/blogapp/internal/business/blogbus"
+	"github.com/anazcodes/blogapp/internal/business/commentbus"
+	"github.com/anazcodes/blogapp/internal/repository/blogrepo/snapshot"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoundTrip(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+
+	input := blogbus.Snapshot{
+		Serial: 5,
+		Posts: []blogbus.BlogPost{
+			{
+				ID: 1, Title: "Title", Description: "Description", Body: "Body", CreatedAt: now, UpdatedAt: now,
+				Revisions: []blogbus.Revision{
+					{Number: 1, Changed: []string{"title"}, CreatedAt: now, Title: "Old Title", Description: "Description", Body: "Body"},
+					{Number: 2, Editor: "editor", Changed: []string{"title"}, CreatedAt: now, Title: "Title", Description: "Description", Body: "Body"},
+				},
+			},
+			{ID: 4, Title: "Other Title", Status: blogbus.StatusDraft, CreatedAt: now, UpdatedAt: now, PublishAt: now.Add(time.Hour), Owner: "ann"},
+			{ID: 5, Title: "Trashed", CreatedAt: now, UpdatedAt: now, DeletedAt: now, Slug: "trashed", OldSlugs: []string{"old-title"}},
+			{ID: 6, Title: "Filed", CreatedAt: now, UpdatedAt: now, Tags: []string{"go"}, Categories: []string{"backend"}, AuthorID: 2},
+		},
+		Taxonomy: blogbus.Taxonomy{
+			Tags: []blogbus.Tag{{Slug: "go", Name: "Go", CreatedAt: now}},
+			Categories: []blogbus.Category{
+				{Slug: "engineering", Name: "Engineering", CreatedAt: now},
+				{Slug: "backend", Name: "Backend", Parent: "engineering", CreatedAt: now},
+			},
+		},
+		Authors: []blogbus.Author{
+			{ID: 2, Name: "Ada", Email: "ada@example.com", Bio: "Writes about Go.", CreatedAt: now, UpdatedAt: now},
+		},
+		Comments: []commentbus.Comment{
+			{ID: 1, PostID: 6, Author: "Bob", Body: "Nice post.", Status: commentbus.StatusApproved, ModeratedAt: now, CreatedAt: now, UpdatedAt: now},
+			{ID: 2, PostID: 6, ParentID: 1, Author: "Ada", Commenter: "ada", Body: "Thanks!", Status: commentbus.StatusPending, CreatedAt: now, UpdatedAt: now},
+			{ID: 3, PostID: 6, Author: "Eve", Body: "Buy now", Status: commentbus.StatusRejected, Flag: "uses the blocked word \"buy\"", CreatedAt: now, UpdatedAt: now},
+		},
+		Reactions: []blogbus.ReaderReaction{
+			{PostID: 1, Reader: "bob", Reaction: blogbus.ReactionLike, At: now},
+			{PostID: 6, Reader: "ada", Reaction: blogbus.ReactionLove, At: now},
+		},
+		CreatedAt: now,
+	}
+
+	buf := new(bytes.Buffer)
+
+	info, err := snapshot.Write(buf, input)
+	assert.Nil(t, err)
+	assert.Equal(t, snapshot.Version, info.Version)
+	assert.Equal(t, 4, info.Posts)
+
+	output, readInfo, err := snapshot.Read(buf)
+	assert.Nil(t, err)
+	assert.Equal(t, info, readInfo)
+	assert.Equal(t, input.Serial, output.Serial)
+	assert.Equal(t, input.Posts, output.Posts)
+	assert.Equal(t, input.Taxonomy, output.Taxonomy)
+	assert.Equal(t, input.Authors, output.Authors)
+	assert.Equal(t, input.Comments, output.Comments)
+	assert.Equal(t, input.Reactions, output.Reactions)
+	assert.True(t, input.CreatedAt.Equal(output.CreatedAt))
+}
+
+func TestReadInvalid(t *testing.T) {
+	valid := new(bytes.Buffer)
+	_, err := snapshot.Write(valid, blogbus.Snapshot{Serial: 1, Posts: []blogbus.BlogPost{{ID: 1}}})
+	assert.Nil(t, err)
+
+	corrupt := bytes.Clone(valid.Bytes())
+	corrupt[len(corrupt)-2] ^= 0xff
+
+	future := bytes.Clone(valid.Bytes())
+	binary.LittleEndian.PutUint32(future[8:12], snapshot.Version+1)
+
+	testCases := []struct {
+		name        string
+		input       []byte
+		expectedErr error
+	}{
+		{
+			name:        "Empty",
+			input:       nil,
+			expectedErr: snapshot.ErrBadMagic,
+		},
+		{
+			name:        "Bad Magic",
+			input:       []byte("NOTASNAPSHOT-NOTASNAPSHOT"),
+			expectedErr: snapshot.ErrBadMagic,
+		},
+		{
+			name:        "Unsupported Version",
+			input:       future,
+			expectedErr: snapshot.ErrUnsupportedVersion,
+		},
+		{
+			name:        "Corrupt Payload",
+			input:       corrupt,
+			expectedErr: snapshot.ErrChecksum,
+		},
+		{
+			name:        "Truncated Payload",
+			input:       valid.Bytes()[:valid.Len()-1],
+			expectedErr: snapshot.ErrChecksum,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := snapshot.Read(bytes.NewReader(tc.input))
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestReadVersion1(t *testing.T) {
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Version 1 posts had no status, they were all public.
+	body := []byte(`{"serial":1,"created_at":"2025-01-02T00:00:00Z","posts":[` +
+		`{"id":1,"title":"Title","description":"","body":"","created_at":"2025-01-01T00:00:00Z","updated_at":"2025-01-01T00:00:00Z"}]}`)
+
+	header := make([]byte, 24)
+	copy(header, "BLOGSNAP")
+	binary.LittleEndian.PutUint32(header[8:12], 1)
+	binary.LittleEndian.PutUint64(header[12:20], uint64(len(body)))
+	binary.LittleEndian.PutUint32(header[20:24], crc32.Checksum(body, crc32.MakeTable(crc32.Castagnoli)))
+
+	s, info, err := snapshot.Read(bytes.NewReader(append(header, body...)))
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(1), info.Version)
+	assert.Len(t, s.Posts, 1)
+	assert.Equal(t, blogbus.StatusPublished, s.Posts[0].Status)
+	assert.Equal(t, created, s.Posts[0].PublishedAt)
+}
+
+func TestReadVersion9(t *testing.T) {
+	// Version 9 comments were not moderated, they were all public.
+	body := []byte(`{"serial":1,"created_at":"2025-01-02T00:00:00Z","posts":[],"comments":[` +
+		`{"id":1,"post_id":1,"author":"Bob","body":"Nice post.","created_at":"2025-01-01T00:00:00Z","updated_at":"2025-01-01T00:00:00Z"}]}`)
+
+	header := make([]byte, 24)
+	copy(header, "BLOGSNAP")
+	binary.LittleEndian.PutUint32(header[8:12], 9)
+	binary.LittleEndian.PutUint64(header[12:20], uint64(len(body)))
+	binary.LittleEndian.PutUint32(header[20:24], crc32.Checksum(body, crc32.MakeTable(crc32.Castagnoli)))
+
+	s, _, err := snapshot.Read(bytes.NewReader(append(header, body...)))
+	assert.Nil(t, err)
+	assert.Len(t, s.Comments, 1)
+	assert.Equal(t, commentbus.StatusApproved, s.Comments[0].Status)
+}
@@ -0,0 +1,96 @@
+package sqlitestore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/anazcodes/blogapp/internal/business/authbus"
+)
+
+// APIKeys returns every API key ordered by ID.
+func (s *Store) APIKeys(ctx context.Context) ([]authbus.APIKey, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, name, owner, scopes, hash, created_at, rotated_at, last_used_at, revoked_at
+		FROM api_keys
+		ORDER BY id`)
+	if err != nil {
+		return nil, mapErr(err)
+	}
+
+	var out []authbus.APIKey
+
+	err = scanRows(rows, func(sc scanner) error {
+		var k authbus.APIKey
+		var scopes, createdAt string
+		var rotatedAt, lastUsedAt, revokedAt sql.NullString
+
+		if err := sc.Scan(&k.ID, &k.Name, &k.Owner, &scopes, &k.Hash, &createdAt, &rotatedAt, &lastUsedAt,
+			&revokedAt); err != nil {
+			return err
+		}
+
+		ss, err := unmarshalSlugs(scopes)
+		if err != nil {
+			return fmt.Errorf("scopes: %w", err)
+		}
+
+		for _, sc := range ss {
+			k.Scopes = append(k.Scopes, authbus.Scope(sc))
+		}
+
+		if k.CreatedAt, err = time.Parse(time.RFC3339Nano, createdAt); err != nil {
+			return fmt.Errorf("created_at: %w", err)
+		}
+
+		if rotatedAt.Valid {
+			if k.RotatedAt, err = time.Parse(time.RFC3339Nano, rotatedAt.String); err != nil {
+				return fmt.Errorf("rotated_at: %w", err)
+			}
+		}
+
+		if lastUsedAt.Valid {
+			if k.LastUsedAt, err = time.Parse(time.RFC3339Nano, lastUsedAt.String); err != nil {
+				return fmt.Errorf("last_used_at: %w", err)
+			}
+		}
+
+		if revokedAt.Valid {
+			if k.RevokedAt, err = time.Parse(time.RFC3339Nano, revokedAt.String); err != nil {
+				return fmt.Errorf("revoked_at: %w", err)
+			}
+		}
+
+		out = append(out, k)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// PutAPIKey inserts k or replaces the key stored with its ID.
+func (s *Store) PutAPIKey(ctx context.Context, k authbus.APIKey) error {
+	scopes := make([]string, len(k.Scopes))
+	for i, sc := range k.Scopes {
+		scopes[i] = string(sc)
+	}
+
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO api_keys (id, name, owner, scopes, hash, created_at, rotated_at, last_used_at, revoked_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE
+		SET hash         = excluded.hash,
+		    rotated_at   = excluded.rotated_at,
+		    last_used_at = excluded.last_used_at,
+		    revoked_at   = excluded.revoked_at`,
+		k.ID, k.Name, k.Owner, marshalSlugs(scopes), k.Hash, formatTime(k.CreatedAt), nullTime(k.RotatedAt),
+		nullTime(k.LastUsedAt), nullTime(k.RevokedAt),
+	)
+
+	return mapErr(err)
+}
@@ -0,0 +1,91 @@
+package sqlitestore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/anazcodes/blogapp/internal/business/blogbus"
+)
+
+// Authors returns every author ordered by ID.
+func (s *Store) Authors(ctx context.Context) ([]blogbus.Author, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, name, email, bio, avatar_url, created_at, updated_at
+		FROM authors
+		ORDER BY id`)
+	if err != nil {
+		return nil, mapErr(err)
+	}
+
+	var out []blogbus.Author
+
+	err = scanRows(rows, func(sc scanner) error {
+		var a blogbus.Author
+		var createdAt, updatedAt string
+
+		if err := sc.Scan(&a.ID, &a.Name, &a.Email, &a.Bio, &a.AvatarURL, &createdAt, &updatedAt); err != nil {
+			return err
+		}
+
+		if a.CreatedAt, err = time.Parse(time.RFC3339Nano, createdAt); err != nil {
+			return fmt.Errorf("created_at: %w", err)
+		}
+
+		if a.UpdatedAt, err = time.Parse(time.RFC3339Nano, updatedAt); err != nil {
+			return fmt.Errorf("updated_at: %w", err)
+		}
+
+		out = append(out, a)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// PutAuthor inserts a or replaces the author stored with its ID.
+func (s *Store) PutAuthor(ctx context.Context, a blogbus.Author) error {
+	return putAuthor(ctx, s.db, a)
+}
+
+// DeleteAuthor deletes the author with id, deleting an author that is not stored is not an error.
+func (s *Store) DeleteAuthor(ctx context.Context, id uint64) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM authors WHERE id = ?`, id)
+	return mapErr(err)
+}
+
+func putAuthor(ctx context.Context, e execer, a blogbus.Author) error {
+	_, err := e.ExecContext(ctx, `
+		INSERT INTO authors (id, name, email, bio, avatar_url, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE
+		SET name       = excluded.name,
+		    email      = excluded.email,
+		    bio        = excluded.bio,
+		    avatar_url = excluded.avatar_url,
+		    updated_at = excluded.updated_at`,
+		a.ID, a.Name, a.Email, a.Bio, a.AvatarURL, formatTime(a.CreatedAt), formatTime(a.UpdatedAt),
+	)
+
+	return mapErr(err)
+}
+
+// restoreAuthors replaces every author with as within tx, the transaction of a restore.
+func restoreAuthors(ctx context.Context, tx *sql.Tx, as []blogbus.Author) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM authors`); err != nil {
+		return mapErr(err)
+	}
+
+	for _, a := range as {
+		if err := putAuthor(ctx, tx, a); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package sqlitestore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/anazcodes/blogapp/internal/business/commentbus"
+)
+
+// Comments returns every comment ordered by ID.
+func (s *Store) Comments(ctx context.Context) ([]commentbus.Comment, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, post_id, parent_id, author, commenter, body, status, flag, moderated_at, created_at, updated_at
+		FROM comments
+		ORDER BY id`)
+	if err != nil {
+		return nil, mapErr(err)
+	}
+
+	var out []commentbus.Comment
+
+	err = scanRows(rows, func(sc scanner) error {
+		var c commentbus.Comment
+		var createdAt, updatedAt string
+		var moderatedAt sql.NullString
+
+		if err := sc.Scan(&c.ID, &c.PostID, &c.ParentID, &c.Author, &c.Commenter, &c.Body, &c.Status, &c.Flag,
+			&moderatedAt, &createdAt, &updatedAt); err != nil {
+			return err
+		}
+
+		if moderatedAt.Valid {
+			if c.ModeratedAt, err = time.Parse(time.RFC3339Nano, moderatedAt.String); err != nil {
+				return fmt.Errorf("moderated_at: %w", err)
+			}
+		}
+
+		if c.CreatedAt, err = time.Parse(time.RFC3339Nano, createdAt); err != nil {
+			return fmt.Errorf("created_at: %w", err)
+		}
+
+		if c.UpdatedAt, err = time.Parse(time.RFC3339Nano, updatedAt); err != nil {
+			return fmt.Errorf("updated_at: %w", err)
+		}
+
+		out = append(out, c)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// PutComment inserts c or replaces the comment stored with its ID.
+func (s *Store) PutComment(ctx context.Context, c commentbus.Comment) error {
+	return putComment(ctx, s.db, c)
+}
+
+// DeleteComments deletes the comments with ids at once, deleting a comment that is not stored is not
+// an error.
+func (s *Store) DeleteComments(ctx context.Context, ids []uint64) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return mapErr(err)
+	}
+	defer tx.Rollback()
+
+	for _, id := range ids {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM comments WHERE id = ?`, id); err != nil {
+			return mapErr(err)
+		}
+	}
+
+	return mapErr(tx.Commit())
+}
+
+func putComment(ctx context.Context, e execer, c commentbus.Comment) error {
+	_, err := e.ExecContext(ctx, `
+		INSERT INTO comments (id, post_id, parent_id, author, commenter, body, status, flag, moderated_at, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE
+		SET body         = excluded.body,
+		    status       = excluded.status,
+		    flag         = excluded.flag,
+		    moderated_at = excluded.moderated_at,
+		    updated_at   = excluded.updated_at`,
+		c.ID, c.PostID, c.ParentID, c.Author, c.Commenter, c.Body, c.Status, c.Flag, nullTime(c.ModeratedAt),
+		formatTime(c.CreatedAt), formatTime(c.UpdatedAt),
+	)
+
+	return mapErr(err)
+}
+
+// restoreComments replaces every comment with cs within tx, the transaction of a restore.
+func restoreComments(ctx context.Context, tx *sql.Tx, cs []commentbus.Comment) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM comments`); err != nil {
+		return mapErr(err)
+	}
+
+	for _, c := range cs {
+		if err := putComment(ctx, tx, c); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package sqlitestore
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+// migration is a schema change named NNNN_description.sql, applied in version order.
+type migration struct {
+	version int
+	name    string
+	sql     string
+}
+
+// migrate applies every migration newer than the schema version recorded in the database.
+// Each migration runs in its own transaction together with the bump of the recorded version.
+func migrate(ctx context.Context, db *sql.DB) error {
+	_, err := db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version    INTEGER PRIMARY KEY,
+			applied_at TEXT NOT NULL
+		)`)
+	if err != nil {
+		return fmt.Errorf("create schema_migrations: %w", err)
+	}
+
+	var current int
+	err = db.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&current)
+	if err != nil {
+		return fmt.Errorf("schema version: %w", err)
+	}
+
+	ms, err := load()
+	if err != nil {
+		return fmt.Errorf("load: %w", err)
+	}
+
+	for _, m := range ms {
+		if m.version <= current {
+			continue
+		}
+
+		if err := apply(ctx, db, m); err != nil {
+			return fmt.Errorf("apply %s: %w", m.name, err)
+		}
+	}
+
+	return nil
+}
+
+func apply(ctx context.Context, db *sql.DB, m migration) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, m.sql); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO schema_migrations (version, applied_at) VALUES (?, ?)`,
+		m.version, time.Now().UTC().Format(time.RFC3339Nano))
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// load reads the embedded migrations ordered by version.
+func load() ([]migration, error) {
+	names, err := fs.Glob(migrations, "migrations/*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	ms := make([]migration, 0, len(names))
+
+	for _, name := range names {
+		base := strings.TrimPrefix(name, "migrations/")
+
+		prefix, _, ok := strings.Cut(base, "_")
+		if !ok {
+			return nil, fmt.Errorf("%s: missing version prefix", base)
+		}
+
+		version, err := strconv.Atoi(prefix)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", base, err)
+		}
+
+		b, err := migrations.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+
+		ms = append(ms, migration{version: version, name: base, sql: string(b)})
+	}
+
+	slices.SortFunc(ms, func(a, b migration) int {
+		return a.version - b.version
+	})
+
+	for i := 1; i < len(ms); i++ {
+		if ms[i].version == ms[i-1].version {
+			return nil, fmt.Errorf("%s and %s share version %d", ms[i-1].name, ms[i].name, ms[i].version)
+		}
+	}
+
+	return ms, nil
+}
@@ -0,0 +1,8 @@
+CREATE TABLE blog_posts (
+	id          INTEGER PRIMARY KEY AUTOINCREMENT,
+	title       TEXT NOT NULL,
+	description TEXT NOT NULL,
+	body        TEXT NOT NULL,
+	created_at  TEXT NOT NULL,
+	updated_at  TEXT NOT NULL
+);
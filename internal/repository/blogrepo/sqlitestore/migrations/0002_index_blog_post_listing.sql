@@ -0,0 +1,12 @@
+-- Timestamps were written as RFC 3339 with a variable length fraction, which does not sort
+-- chronologically as text. Rewrite them with a fixed nine digit fraction so listings can order and
+-- filter by them in SQL, and index the sortable columns.
+UPDATE blog_posts
+SET created_at = substr(created_at, 1, 19) || '.' ||
+                 substr(CASE WHEN instr(created_at, '.') > 0 THEN substr(created_at, 21, length(created_at) - 21) ELSE '' END || '000000000', 1, 9) || 'Z',
+    updated_at = substr(updated_at, 1, 19) || '.' ||
+                 substr(CASE WHEN instr(updated_at, '.') > 0 THEN substr(updated_at, 21, length(updated_at) - 21) ELSE '' END || '000000000', 1, 9) || 'Z';
+
+CREATE INDEX blog_posts_created_at ON blog_posts (created_at, id);
+CREATE INDEX blog_posts_updated_at ON blog_posts (updated_at, id);
+CREATE INDEX blog_posts_title ON blog_posts (title, id);
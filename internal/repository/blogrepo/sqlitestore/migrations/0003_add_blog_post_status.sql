@@ -0,0 +1,8 @@
+-- Posts gained a draft, published and archived lifecycle. Every post stored before it was public, so
+-- it is published as of its creation. A post that was never published has no published_at.
+ALTER TABLE blog_posts ADD COLUMN status TEXT NOT NULL DEFAULT 'published';
+ALTER TABLE blog_posts ADD COLUMN published_at TEXT;
+
+UPDATE blog_posts SET published_at = created_at;
+
+CREATE INDEX blog_posts_status ON blog_posts (status, id);
@@ -0,0 +1,5 @@
+-- Drafts can be scheduled to be published at a later time. The partial index keeps the scan for the
+-- due drafts small, most posts are never scheduled.
+ALTER TABLE blog_posts ADD COLUMN publish_at TEXT;
+
+CREATE INDEX blog_posts_publish_at ON blog_posts (publish_at) WHERE publish_at IS NOT NULL;
@@ -0,0 +1,4 @@
+-- Every edit of a post records a revision of its content. The revisions of a post are read and
+-- written with it, as a JSON array. Posts stored before have none, their current content stands for
+-- their first revision.
+ALTER TABLE blog_posts ADD COLUMN revisions TEXT NOT NULL DEFAULT '[]';
@@ -0,0 +1,5 @@
+-- Deleting a post moves it to the trash, which hides it until it is restored or purged. The partial
+-- index serves the listing of the trash and the purge of the posts trashed the longest.
+ALTER TABLE blog_posts ADD COLUMN deleted_at TEXT;
+
+CREATE INDEX blog_posts_deleted_at ON blog_posts (deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,7 @@
+-- Posts are also found by slug. The unique index keeps two posts from holding the same slug, posts
+-- stored before have none until the repository generates theirs. The old slugs that still lead to a
+-- post are stored with it as a JSON array.
+ALTER TABLE blog_posts ADD COLUMN slug TEXT;
+ALTER TABLE blog_posts ADD COLUMN old_slugs TEXT NOT NULL DEFAULT '[]';
+
+CREATE UNIQUE INDEX blog_posts_slug ON blog_posts (slug);
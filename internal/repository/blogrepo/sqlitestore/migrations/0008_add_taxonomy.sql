@@ -0,0 +1,19 @@
+-- Tags and categories are stored in their own tables, keyed by the slug posts refer to them by. The
+-- repository keeps the parent of a category existing, it is not a foreign key so a restore can insert
+-- the categories in any order. The slugs of the tags and categories of a post are stored with it as
+-- JSON arrays, the repository indexes them on startup.
+CREATE TABLE tags (
+	slug       TEXT PRIMARY KEY,
+	name       TEXT NOT NULL,
+	created_at TEXT NOT NULL
+);
+
+CREATE TABLE categories (
+	slug       TEXT PRIMARY KEY,
+	name       TEXT NOT NULL,
+	parent     TEXT,
+	created_at TEXT NOT NULL
+);
+
+ALTER TABLE blog_posts ADD COLUMN tags TEXT NOT NULL DEFAULT '[]';
+ALTER TABLE blog_posts ADD COLUMN categories TEXT NOT NULL DEFAULT '[]';
@@ -0,0 +1,16 @@
+-- Authors are stored in their own table under the ID the repository issues them. A post links to its
+-- author by ID, NULL for a post without one. The repository keeps the author of a post existing, it is
+-- not a foreign key so a restore can insert the posts and authors in any order.
+CREATE TABLE authors (
+	id         INTEGER PRIMARY KEY,
+	name       TEXT NOT NULL,
+	email      TEXT NOT NULL DEFAULT '',
+	bio        TEXT NOT NULL DEFAULT '',
+	avatar_url TEXT NOT NULL DEFAULT '',
+	created_at TEXT NOT NULL,
+	updated_at TEXT NOT NULL
+);
+
+ALTER TABLE blog_posts ADD COLUMN author_id INTEGER;
+
+CREATE INDEX blog_posts_author_id ON blog_posts (author_id);
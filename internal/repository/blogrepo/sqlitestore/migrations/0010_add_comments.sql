@@ -0,0 +1,14 @@
+-- Comments are stored in their own table under the ID the repository issues them. A comment on the
+-- post itself has a zero parent_id. Like authors the post and parent are not foreign keys, the
+-- repository deletes the comments of a post with it.
+CREATE TABLE comments (
+	id         INTEGER PRIMARY KEY,
+	post_id    INTEGER NOT NULL,
+	parent_id  INTEGER NOT NULL DEFAULT 0,
+	author     TEXT NOT NULL,
+	body       TEXT NOT NULL,
+	created_at TEXT NOT NULL,
+	updated_at TEXT NOT NULL
+);
+
+CREATE INDEX comments_post_id ON comments (post_id);
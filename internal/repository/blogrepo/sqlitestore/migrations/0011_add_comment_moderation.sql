@@ -0,0 +1,8 @@
+-- Comments are held for moderation until a moderator approves them. Every comment stored before it was
+-- public, so it is approved. The flag is the reason a classifier took a comment for spam, and a
+-- comment no moderator decided on has no moderated_at. The index serves the moderation queue.
+ALTER TABLE comments ADD COLUMN status TEXT NOT NULL DEFAULT 'approved';
+ALTER TABLE comments ADD COLUMN flag TEXT NOT NULL DEFAULT '';
+ALTER TABLE comments ADD COLUMN moderated_at TEXT;
+
+CREATE INDEX comments_status ON comments (status, id);
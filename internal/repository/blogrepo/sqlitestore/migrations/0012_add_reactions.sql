@@ -0,0 +1,10 @@
+-- A reader has at most one reaction to a post, so the post and reader are the key and reacting again
+-- replaces the reaction. Like comments the post is not a foreign key, the repository deletes the
+-- reactions to a post with it.
+CREATE TABLE reactions (
+	post_id    INTEGER NOT NULL,
+	reader     TEXT NOT NULL,
+	reaction   TEXT NOT NULL,
+	created_at TEXT NOT NULL,
+	PRIMARY KEY (post_id, reader)
+) WITHOUT ROWID;
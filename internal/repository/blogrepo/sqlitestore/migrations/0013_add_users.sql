@@ -0,0 +1,19 @@
+-- Users are keyed by their normalized username. Like the sessions they are not part of a snapshot, so a
+-- restore leaves both tables alone.
+CREATE TABLE users (
+	username            TEXT PRIMARY KEY,
+	password_hash       TEXT NOT NULL,
+	created_at          TEXT NOT NULL,
+	password_changed_at TEXT NOT NULL,
+	failed_logins       INTEGER NOT NULL DEFAULT 0,
+	locked_until        TEXT
+) WITHOUT ROWID;
+
+CREATE TABLE sessions (
+	id         TEXT PRIMARY KEY,
+	username   TEXT NOT NULL REFERENCES users (username),
+	created_at TEXT NOT NULL,
+	expires_at TEXT NOT NULL
+) WITHOUT ROWID;
+
+CREATE INDEX sessions_username ON sessions (username);
@@ -0,0 +1,2 @@
+-- Users stored before roles existed become readers, an admin gives them their roles.
+ALTER TABLE users ADD COLUMN role TEXT NOT NULL DEFAULT 'reader';
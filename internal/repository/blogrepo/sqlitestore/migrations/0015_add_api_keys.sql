@@ -0,0 +1,15 @@
+-- Only the hash of the secret of a key is stored. Revoked keys are kept, so the keys of a user show what
+-- was issued and when it stopped working.
+CREATE TABLE api_keys (
+	id           TEXT PRIMARY KEY,
+	name         TEXT NOT NULL,
+	owner        TEXT NOT NULL REFERENCES users (username),
+	scopes       TEXT NOT NULL DEFAULT '[]',
+	hash         TEXT NOT NULL,
+	created_at   TEXT NOT NULL,
+	rotated_at   TEXT,
+	last_used_at TEXT,
+	revoked_at   TEXT
+) WITHOUT ROWID;
+
+CREATE INDEX api_keys_owner ON api_keys (owner);
@@ -0,0 +1,3 @@
+-- The commenter is the user who posted a comment, who may edit it. The comments stored before users
+-- posted them have none, only moderators edit those.
+ALTER TABLE comments ADD COLUMN commenter TEXT NOT NULL DEFAULT '';
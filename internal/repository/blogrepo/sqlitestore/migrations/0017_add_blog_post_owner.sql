@@ -0,0 +1,6 @@
+-- The owner is the user who wrote a post, who may read and change it before it is published. The
+-- posts stored before users wrote them have none: the editors of their revisions were named by the
+-- clients, so only editors reach their drafts.
+ALTER TABLE blog_posts ADD COLUMN owner TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX blog_posts_owner ON blog_posts (owner);
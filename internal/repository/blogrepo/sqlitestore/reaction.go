@@ -0,0 +1,103 @@
+package sqlitestore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/anazcodes/blogapp/internal/business/blogbus"
+)
+
+// Reactions returns every reaction ordered by post ID and reader.
+func (s *Store) Reactions(ctx context.Context) ([]blogbus.ReaderReaction, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT post_id, reader, reaction, created_at
+		FROM reactions
+		ORDER BY post_id, reader`)
+	if err != nil {
+		return nil, mapErr(err)
+	}
+
+	var out []blogbus.ReaderReaction
+
+	err = scanRows(rows, func(sc scanner) error {
+		var rr blogbus.ReaderReaction
+		var createdAt string
+
+		if err := sc.Scan(&rr.PostID, &rr.Reader, &rr.Reaction, &createdAt); err != nil {
+			return err
+		}
+
+		if rr.At, err = time.Parse(time.RFC3339Nano, createdAt); err != nil {
+			return fmt.Errorf("created_at: %w", err)
+		}
+
+		out = append(out, rr)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// PutReaction inserts rr or replaces the reaction its reader had to the post.
+func (s *Store) PutReaction(ctx context.Context, rr blogbus.ReaderReaction) error {
+	return putReaction(ctx, s.db, rr)
+}
+
+// DeleteReaction deletes the reaction of reader to the post with postID, deleting a reaction that is
+// not stored is not an error.
+func (s *Store) DeleteReaction(ctx context.Context, postID uint64, reader string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM reactions WHERE post_id = ? AND reader = ?`, postID, reader)
+
+	return mapErr(err)
+}
+
+// DeletePostReactions deletes every reaction to the posts with postIDs at once.
+func (s *Store) DeletePostReactions(ctx context.Context, postIDs []uint64) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return mapErr(err)
+	}
+	defer tx.Rollback()
+
+	for _, id := range postIDs {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM reactions WHERE post_id = ?`, id); err != nil {
+			return mapErr(err)
+		}
+	}
+
+	return mapErr(tx.Commit())
+}
+
+func putReaction(ctx context.Context, e execer, rr blogbus.ReaderReaction) error {
+	_, err := e.ExecContext(ctx, `
+		INSERT INTO reactions (post_id, reader, reaction, created_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT (post_id, reader) DO UPDATE
+		SET reaction   = excluded.reaction,
+		    created_at = excluded.created_at`,
+		rr.PostID, rr.Reader, rr.Reaction, formatTime(rr.At),
+	)
+
+	return mapErr(err)
+}
+
+// restoreReactions replaces every reaction with rrs within tx, the transaction of a restore.
+func restoreReactions(ctx context.Context, tx *sql.Tx, rrs []blogbus.ReaderReaction) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM reactions`); err != nil {
+		return mapErr(err)
+	}
+
+	for _, rr := range rrs {
+		if err := putReaction(ctx, tx, rr); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package sqlitestore
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/anazcodes/blogapp/internal/business/blogbus"
+)
+
+// revision is a revision as stored in the revisions column, a JSON array of the revisions of a post.
+// It decouples the column from blogbus.Revision so the model can change without a migration.
+type revision struct {
+	Number       uint64    `json:"number"`
+	Editor       string    `json:"editor,omitempty"`
+	Changed      []string  `json:"changed,omitempty"`
+	RestoredFrom uint64    `json:"restored_from,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	Title        string    `json:"title"`
+	Description  string    `json:"description"`
+	Body         string    `json:"body"`
+}
+
+// marshalRevisions encodes revs as the value of the revisions column.
+func marshalRevisions(revs []blogbus.Revision) string {
+	out := make([]revision, len(revs))
+
+	for i, r := range revs {
+		out[i] = revision(r)
+	}
+
+	// A revision only holds strings, numbers and times, encoding it cannot fail.
+	b, _ := json.Marshal(out)
+
+	return string(b)
+}
+
+func unmarshalRevisions(s string) ([]blogbus.Revision, error) {
+	var in []revision
+	if err := json.Unmarshal([]byte(s), &in); err != nil {
+		return nil, err
+	}
+
+	if len(in) == 0 {
+		return nil, nil
+	}
+
+	revs := make([]blogbus.Revision, len(in))
+	for i, r := range in {
+		revs[i] = blogbus.Revision(r)
+	}
+
+	return revs, nil
+}
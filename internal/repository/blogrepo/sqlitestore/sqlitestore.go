@@ -0,0 +1,664 @@
+// Package sqlitestore implements a cache.Cache backed by an embedded SQLite database.
+//
+// The schema is created and upgraded through the versioned migrations embedded in the package.
+// SQL errors are mapped onto the cache sentinel errors so callers handle every storage alike.
+package sqlitestore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/anazcodes/blogapp/internal/business/blogbus"
+	"github.com/anazcodes/blogapp/internal/repository/blogrepo/cache"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// columns are the columns of a post, in the order scanBlogPost reads them.
+const columns = "id, title, description, body, status, created_at, updated_at, published_at, publish_at, revisions, deleted_at, slug, old_slugs, tags, categories, author_id, owner"
+
+// Store is a cache.Cache whose posts live in a SQLite database.
+// Unlike the in-memory cache it does not enforce a capacity.
+type Store struct {
+	db *sql.DB
+}
+
+// Open opens the database at path, creating it when it does not exist, and migrates its schema.
+func Open(ctx context.Context, path string) (*Store, error) {
+	dsn := fmt.Sprintf("file:%s?_pragma=journal_mode(WAL)&_pragma=busy_timeout(5000)&_pragma=foreign_keys(1)", path)
+
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("open: %w", err)
+	}
+
+	// SQLite serializes writers, a single connection avoids SQLITE_BUSY between our own queries.
+	db.SetMaxOpenConns(1)
+
+	if err := migrate(ctx, db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("migrate: %w", err)
+	}
+
+	return &Store{db: db}, nil
+}
+
+// Close closes the database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+func (s *Store) AddBlogPost(ctx context.Context, abp blogbus.AddBlogPost) (uint64, error) {
+	// The ID is issued by the database, the other columns are the values of the new post.
+	v := values(blogbus.NewBlogPost(0, abp, time.Now()))
+
+	var id uint64
+	err := s.db.QueryRowContext(ctx, `
+		INSERT INTO blog_posts (`+strings.TrimPrefix(columns, "id, ")+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING id`,
+		v[1:]...,
+	).Scan(&id)
+	if err != nil {
+		return 0, mapErr(err)
+	}
+
+	return id, nil
+}
+
+func (s *Store) BlogPost(ctx context.Context, id uint64) (blogbus.BlogPost, error) {
+	row := s.db.QueryRowContext(ctx, `
+		SELECT `+columns+`
+		FROM blog_posts
+		WHERE id = ?`, id)
+
+	bp, err := scanBlogPost(row)
+	if err != nil {
+		return blogbus.BlogPost{}, mapErr(err)
+	}
+
+	return bp, nil
+}
+
+func (s *Store) BlogPosts(ctx context.Context) ([]blogbus.BlogPost, error) {
+	return blogPosts(ctx, s.db)
+}
+
+func (s *Store) ListBlogPosts(ctx context.Context, l cache.Listing) ([]blogbus.BlogPost, error) {
+	where, args := listingWhere(l)
+
+	column := sortColumn(l.SortBy)
+	order := "ASC"
+	if l.Desc {
+		order = "DESC"
+	}
+
+	rows, err := s.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT `+columns+`
+		FROM blog_posts
+		WHERE %s
+		ORDER BY %s %s, id %s
+		LIMIT ?`, where, column, order, order), append(args, l.Limit)...)
+	if err != nil {
+		return nil, mapErr(err)
+	}
+
+	return scanBlogPosts(rows)
+}
+
+func (s *Store) DeleteBlogPost(ctx context.Context, id uint64) (uint64, error) {
+	err := s.db.QueryRowContext(ctx, `DELETE FROM blog_posts WHERE id = ? RETURNING id`, id).Scan(&id)
+	if err != nil {
+		return 0, mapErr(err)
+	}
+
+	return id, nil
+}
+
+// UpdateBlogPost applies ubp in a single transaction, so the revision it records follows the
+// revision the post was read at.
+func (s *Store) UpdateBlogPost(ctx context.Context, id uint64, ubp blogbus.UpdateBlogPost) (uint64, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, mapErr(err)
+	}
+	defer tx.Rollback()
+
+	bp, err := scanBlogPost(tx.QueryRowContext(ctx, `SELECT `+columns+` FROM blog_posts WHERE id = ?`, id))
+	if err != nil {
+		return 0, mapErr(err)
+	}
+
+	if bp.Trashed() {
+		return 0, blogbus.ErrTrashed
+	}
+
+	bp = ubp.Apply(bp, time.Now())
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE blog_posts
+		SET title = ?, description = ?, body = ?, updated_at = ?, revisions = ?, slug = ?, old_slugs = ?, tags = ?,
+		    categories = ?, author_id = ?
+		WHERE id = ?`,
+		bp.Title, bp.Description, bp.Body, formatTime(bp.UpdatedAt), marshalRevisions(bp.Revisions), nullString(bp.Slug),
+		marshalSlugs(bp.OldSlugs), marshalSlugs(bp.Tags), marshalSlugs(bp.Categories), nullID(bp.AuthorID), id,
+	)
+	if err != nil {
+		return 0, mapErr(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, mapErr(err)
+	}
+
+	return id, nil
+}
+
+// TransitionBlogPost checks and applies t in a single transaction, so the post cannot change in
+// between.
+func (s *Store) TransitionBlogPost(ctx context.Context, id uint64, t blogbus.Transition) (blogbus.BlogPost, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return blogbus.BlogPost{}, mapErr(err)
+	}
+	defer tx.Rollback()
+
+	bp, err := scanBlogPost(tx.QueryRowContext(ctx, `SELECT `+columns+` FROM blog_posts WHERE id = ?`, id))
+	if err != nil {
+		return blogbus.BlogPost{}, mapErr(err)
+	}
+
+	if err := t.Check(bp); err != nil {
+		return blogbus.BlogPost{}, err
+	}
+
+	bp = t.Apply(bp)
+
+	_, err = tx.ExecContext(ctx, `UPDATE blog_posts SET status = ?, published_at = ?, publish_at = ? WHERE id = ?`,
+		bp.Status, nullTime(bp.PublishedAt), nullTime(bp.PublishAt), id)
+	if err != nil {
+		return blogbus.BlogPost{}, mapErr(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return blogbus.BlogPost{}, mapErr(err)
+	}
+
+	return bp, nil
+}
+
+// TrashBlogPost moves the post to or out of the trash in a single transaction.
+func (s *Store) TrashBlogPost(ctx context.Context, id uint64, at time.Time) (blogbus.BlogPost, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return blogbus.BlogPost{}, mapErr(err)
+	}
+	defer tx.Rollback()
+
+	bp, err := scanBlogPost(tx.QueryRowContext(ctx, `SELECT `+columns+` FROM blog_posts WHERE id = ?`, id))
+	if err != nil {
+		return blogbus.BlogPost{}, mapErr(err)
+	}
+
+	if bp, err = bp.Trash(at); err != nil {
+		return blogbus.BlogPost{}, err
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE blog_posts SET deleted_at = ? WHERE id = ?`, nullTime(bp.DeletedAt), id)
+	if err != nil {
+		return blogbus.BlogPost{}, mapErr(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return blogbus.BlogPost{}, mapErr(err)
+	}
+
+	return bp, nil
+}
+
+// PutBlogPost inserts bp under its own ID or replaces the post stored with that ID.
+func (s *Store) PutBlogPost(ctx context.Context, bp blogbus.BlogPost) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO blog_posts (`+columns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE
+		SET title        = excluded.title,
+		    description  = excluded.description,
+		    body         = excluded.body,
+		    status       = excluded.status,
+		    created_at   = excluded.created_at,
+		    updated_at   = excluded.updated_at,
+		    published_at = excluded.published_at,
+		    publish_at   = excluded.publish_at,
+		    revisions    = excluded.revisions,
+		    deleted_at   = excluded.deleted_at,
+		    slug         = excluded.slug,
+		    old_slugs    = excluded.old_slugs,
+		    tags         = excluded.tags,
+		    categories   = excluded.categories,
+		    author_id    = excluded.author_id,
+		    owner        = excluded.owner`,
+		values(bp)...,
+	)
+
+	return mapErr(err)
+}
+
+// Snapshot returns a consistent copy of every post read within a single transaction.
+func (s *Store) Snapshot(ctx context.Context) (blogbus.Snapshot, error) {
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return blogbus.Snapshot{}, mapErr(err)
+	}
+	defer tx.Rollback()
+
+	posts, err := blogPosts(ctx, tx)
+	if err != nil {
+		return blogbus.Snapshot{}, err
+	}
+
+	var serial uint64
+	err = tx.QueryRowContext(ctx, `SELECT COALESCE(MAX(seq), 0) FROM sqlite_sequence WHERE name = 'blog_posts'`).Scan(&serial)
+	if err != nil {
+		return blogbus.Snapshot{}, mapErr(err)
+	}
+
+	return blogbus.Snapshot{
+		Serial:    serial,
+		Posts:     posts,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// Restore replaces every post, tag, category, author, comment and reaction with those of snap in a
+// single transaction.
+// The ID sequence never moves backwards so IDs issued after the snapshot are not reused.
+func (s *Store) Restore(ctx context.Context, snap blogbus.Snapshot) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return mapErr(err)
+	}
+	defer tx.Rollback()
+
+	var serial uint64
+	err = tx.QueryRowContext(ctx, `SELECT COALESCE(MAX(seq), 0) FROM sqlite_sequence WHERE name = 'blog_posts'`).Scan(&serial)
+	if err != nil {
+		return mapErr(err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM blog_posts`); err != nil {
+		return mapErr(err)
+	}
+
+	if err := restoreTaxonomy(ctx, tx, snap.Taxonomy); err != nil {
+		return err
+	}
+
+	if err := restoreAuthors(ctx, tx, snap.Authors); err != nil {
+		return err
+	}
+
+	if err := restoreComments(ctx, tx, snap.Comments); err != nil {
+		return err
+	}
+
+	if err := restoreReactions(ctx, tx, snap.Reactions); err != nil {
+		return err
+	}
+
+	serial = max(serial, snap.Serial)
+
+	for _, bp := range snap.Posts {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO blog_posts (`+columns+`)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			values(bp)...,
+		)
+		if err != nil {
+			return mapErr(err)
+		}
+	}
+
+	// Inserting explicit IDs already raised the sequence to the highest restored ID.
+	_, err = tx.ExecContext(ctx, `UPDATE sqlite_sequence SET seq = MAX(seq, ?) WHERE name = 'blog_posts'`, serial)
+	if err != nil {
+		return mapErr(err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO sqlite_sequence (name, seq)
+		SELECT 'blog_posts', ?
+		WHERE NOT EXISTS (SELECT 1 FROM sqlite_sequence WHERE name = 'blog_posts')`, serial)
+	if err != nil {
+		return mapErr(err)
+	}
+
+	return mapErr(tx.Commit())
+}
+
+// querier is implemented by *sql.DB and *sql.Tx.
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+func blogPosts(ctx context.Context, q querier) ([]blogbus.BlogPost, error) {
+	rows, err := q.QueryContext(ctx, `
+		SELECT `+columns+`
+		FROM blog_posts
+		ORDER BY id`)
+	if err != nil {
+		return nil, mapErr(err)
+	}
+
+	return scanBlogPosts(rows)
+}
+
+// scanBlogPosts reads every row of rows and closes it.
+func scanBlogPosts(rows *sql.Rows) ([]blogbus.BlogPost, error) {
+	defer rows.Close()
+
+	bps := make([]blogbus.BlogPost, 0)
+
+	for rows.Next() {
+		bp, err := scanBlogPost(rows)
+		if err != nil {
+			return nil, mapErr(err)
+		}
+
+		bps = append(bps, bp)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, mapErr(err)
+	}
+
+	return bps, nil
+}
+
+// scanner is implemented by *sql.Row and *sql.Rows.
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanBlogPost(row scanner) (blogbus.BlogPost, error) {
+	var bp blogbus.BlogPost
+	var createdAt, updatedAt, revisions, oldSlugs, tags, categories string
+	var publishedAt, publishAt, deletedAt, slug sql.NullString
+	var authorID sql.NullInt64
+
+	err := row.Scan(&bp.ID, &bp.Title, &bp.Description, &bp.Body, &bp.Status, &createdAt, &updatedAt, &publishedAt, &publishAt,
+		&revisions, &deletedAt, &slug, &oldSlugs, &tags, &categories, &authorID, &bp.Owner)
+	if err != nil {
+		return blogbus.BlogPost{}, err
+	}
+
+	if bp.CreatedAt, err = time.Parse(time.RFC3339Nano, createdAt); err != nil {
+		return blogbus.BlogPost{}, fmt.Errorf("created_at: %w", err)
+	}
+
+	if bp.UpdatedAt, err = time.Parse(time.RFC3339Nano, updatedAt); err != nil {
+		return blogbus.BlogPost{}, fmt.Errorf("updated_at: %w", err)
+	}
+
+	if publishedAt.Valid {
+		if bp.PublishedAt, err = time.Parse(time.RFC3339Nano, publishedAt.String); err != nil {
+			return blogbus.BlogPost{}, fmt.Errorf("published_at: %w", err)
+		}
+	}
+
+	if publishAt.Valid {
+		if bp.PublishAt, err = time.Parse(time.RFC3339Nano, publishAt.String); err != nil {
+			return blogbus.BlogPost{}, fmt.Errorf("publish_at: %w", err)
+		}
+	}
+
+	if deletedAt.Valid {
+		if bp.DeletedAt, err = time.Parse(time.RFC3339Nano, deletedAt.String); err != nil {
+			return blogbus.BlogPost{}, fmt.Errorf("deleted_at: %w", err)
+		}
+	}
+
+	if bp.Revisions, err = unmarshalRevisions(revisions); err != nil {
+		return blogbus.BlogPost{}, fmt.Errorf("revisions: %w", err)
+	}
+
+	bp.Slug = slug.String
+
+	if bp.OldSlugs, err = unmarshalSlugs(oldSlugs); err != nil {
+		return blogbus.BlogPost{}, fmt.Errorf("old_slugs: %w", err)
+	}
+
+	if bp.Tags, err = unmarshalSlugs(tags); err != nil {
+		return blogbus.BlogPost{}, fmt.Errorf("tags: %w", err)
+	}
+
+	if bp.Categories, err = unmarshalSlugs(categories); err != nil {
+		return blogbus.BlogPost{}, fmt.Errorf("categories: %w", err)
+	}
+
+	bp.AuthorID = uint64(authorID.Int64)
+
+	return bp, nil
+}
+
+// values returns the values of the columns of bp.
+func values(bp blogbus.BlogPost) []any {
+	return []any{
+		bp.ID, bp.Title, bp.Description, bp.Body, bp.Status,
+		formatTime(bp.CreatedAt), formatTime(bp.UpdatedAt), nullTime(bp.PublishedAt), nullTime(bp.PublishAt),
+		marshalRevisions(bp.Revisions), nullTime(bp.DeletedAt), nullString(bp.Slug), marshalSlugs(bp.OldSlugs),
+		marshalSlugs(bp.Tags), marshalSlugs(bp.Categories), nullID(bp.AuthorID), bp.Owner,
+	}
+}
+
+// timeLayout has a fixed width fraction so formatted times sort chronologically as text.
+const timeLayout = "2006-01-02T15:04:05.000000000Z07:00"
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(timeLayout)
+}
+
+// nullTime formats t, or returns NULL for the zero time.
+func nullTime(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+
+	return formatTime(t)
+}
+
+// nullString returns s, or NULL for the empty string.
+func nullString(s string) any {
+	if s == "" {
+		return nil
+	}
+
+	return s
+}
+
+// nullID returns id, or NULL for the zero ID.
+func nullID(id uint64) any {
+	if id == 0 {
+		return nil
+	}
+
+	return int64(id)
+}
+
+// marshalSlugs encodes slugs as the value of the old_slugs, tags or categories column.
+func marshalSlugs(slugs []string) string {
+	if slugs == nil {
+		slugs = []string{}
+	}
+
+	// Encoding strings cannot fail.
+	b, _ := json.Marshal(slugs)
+
+	return string(b)
+}
+
+func unmarshalSlugs(s string) ([]string, error) {
+	var slugs []string
+	if err := json.Unmarshal([]byte(s), &slugs); err != nil {
+		return nil, err
+	}
+
+	if len(slugs) == 0 {
+		return nil, nil
+	}
+
+	return slugs, nil
+}
+
+// marshalIDs encodes ids as a JSON array.
+func marshalIDs(ids map[uint64]struct{}) string {
+	out := make([]uint64, 0, len(ids))
+	for id := range ids {
+		out = append(out, id)
+	}
+
+	// Encoding numbers cannot fail.
+	b, _ := json.Marshal(out)
+
+	return string(b)
+}
+
+// sortColumn returns the column a listing sorted by field is ordered by.
+func sortColumn(field blogbus.SortField) string {
+	switch field {
+	case blogbus.SortByCreatedAt:
+		return "created_at"
+	case blogbus.SortByUpdatedAt:
+		return "updated_at"
+	case blogbus.SortByTitle:
+		return "title"
+	default:
+		return "id"
+	}
+}
+
+// listingWhere builds the WHERE clause selecting the posts of l and its arguments.
+func listingWhere(l cache.Listing) (string, []any) {
+	conds := []string{"1 = 1"}
+	var args []any
+
+	timeRange := func(column string, from, to time.Time) {
+		if !from.IsZero() {
+			conds = append(conds, column+" >= ?")
+			args = append(args, formatTime(from))
+		}
+		if !to.IsZero() {
+			conds = append(conds, column+" < ?")
+			args = append(args, formatTime(to))
+		}
+	}
+
+	timeRange("created_at", l.CreatedFrom, l.CreatedTo)
+	timeRange("updated_at", l.UpdatedFrom, l.UpdatedTo)
+
+	if l.TitlePrefix != "" {
+		conds = append(conds, "substr(title, 1, length(?)) = ?")
+		args = append(args, l.TitlePrefix, l.TitlePrefix)
+	}
+
+	if l.TitleContains != "" {
+		conds = append(conds, "instr(title, ?) > 0")
+		args = append(args, l.TitleContains)
+	}
+
+	if l.Status != "" {
+		conds = append(conds, "status = ?")
+		args = append(args, l.Status)
+	}
+
+	if l.Trashed {
+		conds = append(conds, "deleted_at IS NOT NULL")
+	} else {
+		conds = append(conds, "deleted_at IS NULL")
+	}
+
+	if !l.TrashedBy.IsZero() {
+		conds = append(conds, "deleted_at <= ?")
+		args = append(args, formatTime(l.TrashedBy))
+	}
+
+	if l.IDs != nil {
+		// The IDs the repository looked up in its index of tags and categories.
+		conds = append(conds, "id IN (SELECT value FROM json_each(?))")
+		args = append(args, marshalIDs(l.IDs))
+	}
+
+	if l.AuthorID != 0 {
+		conds = append(conds, "author_id = ?")
+		args = append(args, int64(l.AuthorID))
+	}
+
+	if l.Owner != "" {
+		conds = append(conds, "owner = ?")
+		args = append(args, l.Owner)
+	}
+
+	if !l.ScheduledBy.IsZero() {
+		conds = append(conds, "publish_at <= ?")
+		args = append(args, formatTime(l.ScheduledBy))
+	}
+
+	if l.After != nil {
+		// Keyset pagination, the posts ordered after the (key, id) position of the previous page.
+		op := ">"
+		if l.Desc {
+			op = "<"
+		}
+
+		column := sortColumn(l.SortBy)
+		if column == "id" {
+			conds = append(conds, "id "+op+" ?")
+			args = append(args, l.After.ID)
+		} else {
+			key := sortKey(l.SortBy, *l.After)
+			conds = append(conds, fmt.Sprintf("(%[1]s %[2]s ? OR (%[1]s = ? AND id %[2]s ?))", column, op))
+			args = append(args, key, key, l.After.ID)
+		}
+	}
+
+	return strings.Join(conds, " AND "), args
+}
+
+// sortKey returns the value of the column bp is ordered by.
+func sortKey(field blogbus.SortField, bp blogbus.BlogPost) any {
+	switch field {
+	case blogbus.SortByCreatedAt:
+		return formatTime(bp.CreatedAt)
+	case blogbus.SortByUpdatedAt:
+		return formatTime(bp.UpdatedAt)
+	default:
+		return bp.Title
+	}
+}
+
+// mapErr translates SQL errors into the cache sentinel errors.
+func mapErr(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return cache.ErrItemNotFound
+	}
+
+	var serr *sqlite.Error
+	if errors.As(err, &serr) && serr.Code()&0xff == sqlite3.SQLITE_CONSTRAINT {
+		if strings.Contains(serr.Error(), "blog_posts.slug") {
+			return fmt.Errorf("%w: %s", blogbus.ErrSlugTaken, serr.Error())
+		}
+
+		return fmt.Errorf("%w: %s", cache.ErrItemConflict, serr.Error())
+	}
+
+	return err
+}
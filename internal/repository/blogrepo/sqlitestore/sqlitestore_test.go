@@ -0,0 +1,286 @@
+package sqlitestore_test
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/anazcodes/blogapp/internal/business/authbus"
+	"github.com/anazcodes/blogapp/internal/business/blogbus"
+	"github.com/anazcodes/blogapp/internal/business/commentbus"
+	"github.com/anazcodes/blogapp/internal/repository/blogrepo/cache"
+	"github.com/anazcodes/blogapp/internal/repository/blogrepo/sqlitestore"
+	"github.com/stretchr/testify/assert"
+)
+
+func open(t *testing.T, path string) *sqlitestore.Store {
+	t.Helper()
+
+	store, err := sqlitestore.Open(t.Context(), path)
+	assert.Nil(t, err)
+
+	t.Cleanup(func() { store.Close() })
+
+	return store
+}
+
+func TestBlogPostLifecycle(t *testing.T) {
+	input := blogbus.AddBlogPost{
+		Title:       "Title",
+		Description: "Description",
+		Body:        "Body",
+	}
+
+	store := open(t, filepath.Join(t.TempDir(), "blogapp.db"))
+
+	id, err := store.AddBlogPost(t.Context(), input)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), id)
+
+	testCases := []struct {
+		name        string
+		id          uint64
+		input       blogbus.UpdateBlogPost
+		expectedErr error
+	}{
+		{
+			name:        "Success",
+			id:          1,
+			input:       blogbus.UpdateBlogPost{Title: "Updated Title"},
+			expectedErr: nil,
+		},
+		{
+			name:        "Failure",
+			id:          2,
+			input:       blogbus.UpdateBlogPost{Title: "Updated Title"},
+			expectedErr: cache.ErrItemNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := store.UpdateBlogPost(t.Context(), tc.id, tc.input)
+			assert.ErrorIs(t, err, tc.expectedErr)
+
+			bp, err := store.BlogPost(t.Context(), tc.id)
+			assert.ErrorIs(t, err, tc.expectedErr)
+
+			if err == nil {
+				assert.Equal(t, tc.input.Title, bp.Title)
+				assert.Equal(t, input.Description, bp.Description)
+				assert.Equal(t, input.Body, bp.Body)
+				assert.False(t, bp.UpdatedAt.Before(bp.CreatedAt))
+			}
+
+			_, err = store.DeleteBlogPost(t.Context(), tc.id)
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+
+	bps, err := store.BlogPosts(t.Context())
+	assert.Nil(t, err)
+	assert.Empty(t, bps)
+
+	// AUTOINCREMENT never reuses the ID of a deleted post.
+	id, err = store.AddBlogPost(t.Context(), input)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(2), id)
+}
+
+func TestReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "blogapp.db")
+
+	store, err := sqlitestore.Open(t.Context(), path)
+	assert.Nil(t, err)
+
+	_, err = store.AddBlogPost(t.Context(), blogbus.AddBlogPost{Title: "Title"})
+	assert.Nil(t, err)
+	assert.Nil(t, store.Close())
+
+	// Migrations already applied are skipped on the second open.
+	store = open(t, path)
+
+	bps, err := store.BlogPosts(t.Context())
+	assert.Nil(t, err)
+	assert.Len(t, bps, 1)
+	assert.Equal(t, "Title", bps[0].Title)
+}
+
+func TestComments(t *testing.T) {
+	store := open(t, filepath.Join(t.TempDir(), "blogapp.db"))
+
+	at := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	c := commentbus.Comment{ID: 1, PostID: 1, Author: "Ann", Commenter: "ann", Body: "Body", Status: commentbus.StatusPending, CreatedAt: at, UpdatedAt: at}
+
+	assert.Nil(t, store.PutComment(t.Context(), c))
+
+	// An edit replaces the body, the commenter stays who posted the comment.
+	c.Body = "Edited"
+	c.UpdatedAt = at.Add(time.Minute)
+	assert.Nil(t, store.PutComment(t.Context(), c))
+
+	cs, err := store.Comments(t.Context())
+	assert.Nil(t, err)
+	assert.Equal(t, []commentbus.Comment{c}, cs)
+}
+
+func TestUsers(t *testing.T) {
+	store := open(t, filepath.Join(t.TempDir(), "blogapp.db"))
+
+	at := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	ann := authbus.User{Username: "ann", PasswordHash: "hash", CreatedAt: at, PasswordChangedAt: at}
+
+	assert.Nil(t, store.PutUser(t.Context(), ann))
+
+	ann = authbus.LoginAttempt{Failed: true, At: at, Lockout: authbus.Lockout{MaxFailures: 1, Duration: time.Hour}}.Apply(ann)
+	assert.Nil(t, store.PutUser(t.Context(), ann))
+	ann = authbus.RoleChange{Role: authbus.RoleEditor}.Apply(ann)
+	assert.Nil(t, store.PutUser(t.Context(), ann))
+
+	users, err := store.Users(t.Context())
+	assert.Nil(t, err)
+	assert.Equal(t, []authbus.User{ann}, users)
+
+	assert.Nil(t, store.PutSession(t.Context(), authbus.Session{ID: "s1", Username: "ann", CreatedAt: at, ExpiresAt: at.Add(time.Hour)}))
+	assert.Nil(t, store.PutSession(t.Context(), authbus.Session{ID: "s2", Username: "ann", CreatedAt: at, ExpiresAt: at.Add(time.Hour)}))
+	assert.NotNil(t, store.PutSession(t.Context(), authbus.Session{ID: "s3", Username: "bob", CreatedAt: at, ExpiresAt: at}))
+	assert.Nil(t, store.DeleteSessions(t.Context(), []string{"s1", "s9"}))
+
+	sessions, err := store.Sessions(t.Context())
+	assert.Nil(t, err)
+	assert.Equal(t, []authbus.Session{{ID: "s2", Username: "ann", CreatedAt: at, ExpiresAt: at.Add(time.Hour)}}, sessions)
+
+	k := authbus.APIKey{ID: "k1", Name: "ci", Owner: "ann", Scopes: []authbus.Scope{authbus.ScopePostsWrite}, Hash: "hash", CreatedAt: at}
+	assert.Nil(t, store.PutAPIKey(t.Context(), k))
+	assert.NotNil(t, store.PutAPIKey(t.Context(), authbus.APIKey{ID: "k2", Name: "ci", Owner: "bob", Hash: "hash", CreatedAt: at}))
+
+	k = authbus.KeyUse{At: at.Add(time.Minute)}.Apply(k)
+	k = authbus.KeyRotation{Hash: "new", At: at.Add(time.Hour)}.Apply(k)
+	k = authbus.KeyRevocation{At: at.Add(2 * time.Hour)}.Apply(k)
+	assert.Nil(t, store.PutAPIKey(t.Context(), k))
+
+	keys, err := store.APIKeys(t.Context())
+	assert.Nil(t, err)
+	assert.Equal(t, []authbus.APIKey{k}, keys)
+}
+
+func TestUniqueSlug(t *testing.T) {
+	store := open(t, filepath.Join(t.TempDir(), "blogapp.db"))
+
+	_, err := store.AddBlogPost(t.Context(), blogbus.AddBlogPost{Title: "Title", Slug: "title"})
+	assert.Nil(t, err)
+
+	_, err = store.AddBlogPost(t.Context(), blogbus.AddBlogPost{Title: "Title", Slug: "title"})
+	assert.ErrorIs(t, err, blogbus.ErrSlugTaken)
+
+	// Posts stored before slugs have none, any number of them may be stored.
+	for id := range uint64(2) {
+		assert.Nil(t, store.PutBlogPost(t.Context(), blogbus.BlogPost{ID: id + 10, Title: "Legacy"}))
+	}
+
+	id, err := store.AddBlogPost(t.Context(), blogbus.AddBlogPost{Title: "Other", Slug: "other"})
+	assert.Nil(t, err)
+
+	_, err = store.UpdateBlogPost(t.Context(), id, blogbus.UpdateBlogPost{Slug: "title"})
+	assert.ErrorIs(t, err, blogbus.ErrSlugTaken)
+
+	_, err = store.UpdateBlogPost(t.Context(), id, blogbus.UpdateBlogPost{Slug: "renamed"})
+	assert.Nil(t, err)
+
+	bp, err := store.BlogPost(t.Context(), id)
+	assert.Nil(t, err)
+	assert.Equal(t, "renamed", bp.Slug)
+	assert.Equal(t, []string{"other"}, bp.OldSlugs)
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	store := open(t, filepath.Join(t.TempDir(), "blogapp.db"))
+
+	for range 2 {
+		_, err := store.AddBlogPost(t.Context(), blogbus.AddBlogPost{Title: "Title"})
+		assert.Nil(t, err)
+	}
+
+	snap, err := store.Snapshot(t.Context())
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(2), snap.Serial)
+	assert.Len(t, snap.Posts, 2)
+
+	testCases := []struct {
+		name        string
+		input       blogbus.Snapshot
+		expectedLen int
+		expectedErr error
+	}{
+		{
+			name:        "Success",
+			input:       blogbus.Snapshot{Serial: 9, Posts: snap.Posts[:1]},
+			expectedLen: 1,
+			expectedErr: nil,
+		},
+		{
+			name:        "Duplicate ID",
+			input:       blogbus.Snapshot{Posts: []blogbus.BlogPost{snap.Posts[0], snap.Posts[0]}},
+			expectedLen: 1,
+			expectedErr: cache.ErrItemConflict,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := store.Restore(t.Context(), tc.input)
+			assert.ErrorIs(t, err, tc.expectedErr)
+
+			bps, err := store.BlogPosts(t.Context())
+			assert.Nil(t, err)
+			assert.Len(t, bps, tc.expectedLen)
+		})
+	}
+
+	id, err := store.AddBlogPost(t.Context(), blogbus.AddBlogPost{Title: "Title"})
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(10), id)
+}
+
+func TestTimestampMigration(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "blogapp.db")
+
+	// A database at schema version 1, whose timestamps have variable length fractions.
+	db, err := sql.Open("sqlite", path)
+	assert.Nil(t, err)
+
+	_, err = db.ExecContext(t.Context(), `
+		CREATE TABLE schema_migrations (version INTEGER PRIMARY KEY, applied_at TEXT NOT NULL);
+		INSERT INTO schema_migrations VALUES (1, '2025-01-01T00:00:00Z');
+		CREATE TABLE blog_posts (
+			id          INTEGER PRIMARY KEY AUTOINCREMENT,
+			title       TEXT NOT NULL,
+			description TEXT NOT NULL,
+			body        TEXT NOT NULL,
+			created_at  TEXT NOT NULL,
+			updated_at  TEXT NOT NULL
+		);
+		INSERT INTO blog_posts VALUES (1, 'Later', '', '', '2025-01-01T00:00:00.5Z', '2025-01-01T00:00:00.5Z');
+		INSERT INTO blog_posts VALUES (2, 'Earlier', '', '', '2025-01-01T00:00:00Z', '2025-01-01T00:00:00Z')`)
+	assert.Nil(t, err)
+	assert.Nil(t, db.Close())
+
+	store := open(t, path)
+
+	bps, err := store.ListBlogPosts(t.Context(), cache.Listing{
+		Query: blogbus.Query{SortBy: blogbus.SortByCreatedAt},
+		Limit: 10,
+	})
+	assert.Nil(t, err)
+	assert.Len(t, bps, 2)
+	assert.Equal(t, "Earlier", bps[0].Title)
+	assert.Equal(t, "Later", bps[1].Title)
+	assert.Equal(t, 500*time.Millisecond, bps[1].CreatedAt.Sub(bps[0].CreatedAt))
+
+	// Posts stored before the lifecycle were public.
+	for _, bp := range bps {
+		assert.Equal(t, blogbus.StatusPublished, bp.Status)
+		assert.Equal(t, bp.CreatedAt, bp.PublishedAt)
+	}
+}
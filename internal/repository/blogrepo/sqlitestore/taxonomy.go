@@ -0,0 +1,166 @@
+package sqlitestore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/anazcodes/blogapp/internal/business/blogbus"
+)
+
+// Taxonomy returns every tag and category, in no particular order.
+func (s *Store) Taxonomy(ctx context.Context) (blogbus.Taxonomy, error) {
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return blogbus.Taxonomy{}, mapErr(err)
+	}
+	defer tx.Rollback()
+
+	var out blogbus.Taxonomy
+
+	rows, err := tx.QueryContext(ctx, `SELECT slug, name, created_at FROM tags`)
+	if err != nil {
+		return blogbus.Taxonomy{}, mapErr(err)
+	}
+
+	err = scanRows(rows, func(sc scanner) error {
+		var t blogbus.Tag
+		var createdAt string
+
+		if err := sc.Scan(&t.Slug, &t.Name, &createdAt); err != nil {
+			return err
+		}
+
+		if t.CreatedAt, err = time.Parse(time.RFC3339Nano, createdAt); err != nil {
+			return fmt.Errorf("created_at: %w", err)
+		}
+
+		out.Tags = append(out.Tags, t)
+
+		return nil
+	})
+	if err != nil {
+		return blogbus.Taxonomy{}, err
+	}
+
+	rows, err = tx.QueryContext(ctx, `SELECT slug, name, parent, created_at FROM categories`)
+	if err != nil {
+		return blogbus.Taxonomy{}, mapErr(err)
+	}
+
+	err = scanRows(rows, func(sc scanner) error {
+		var c blogbus.Category
+		var parent sql.NullString
+		var createdAt string
+
+		if err := sc.Scan(&c.Slug, &c.Name, &parent, &createdAt); err != nil {
+			return err
+		}
+
+		c.Parent = parent.String
+
+		if c.CreatedAt, err = time.Parse(time.RFC3339Nano, createdAt); err != nil {
+			return fmt.Errorf("created_at: %w", err)
+		}
+
+		out.Categories = append(out.Categories, c)
+
+		return nil
+	})
+	if err != nil {
+		return blogbus.Taxonomy{}, err
+	}
+
+	return out, nil
+}
+
+// PutTag inserts t or replaces the tag stored with its slug.
+func (s *Store) PutTag(ctx context.Context, t blogbus.Tag) error {
+	return putTag(ctx, s.db, t)
+}
+
+// DeleteTag deletes the tag with slug, deleting a tag that is not stored is not an error.
+func (s *Store) DeleteTag(ctx context.Context, slug string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM tags WHERE slug = ?`, slug)
+	return mapErr(err)
+}
+
+// PutCategory inserts c or replaces the category stored with its slug.
+func (s *Store) PutCategory(ctx context.Context, c blogbus.Category) error {
+	return putCategory(ctx, s.db, c)
+}
+
+// DeleteCategory deletes the category with slug, deleting a category that is not stored is not an
+// error.
+func (s *Store) DeleteCategory(ctx context.Context, slug string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM categories WHERE slug = ?`, slug)
+	return mapErr(err)
+}
+
+// execer is implemented by *sql.DB and *sql.Tx.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+func putTag(ctx context.Context, e execer, t blogbus.Tag) error {
+	_, err := e.ExecContext(ctx, `
+		INSERT INTO tags (slug, name, created_at)
+		VALUES (?, ?, ?)
+		ON CONFLICT (slug) DO UPDATE
+		SET name = excluded.name`,
+		t.Slug, t.Name, formatTime(t.CreatedAt),
+	)
+
+	return mapErr(err)
+}
+
+func putCategory(ctx context.Context, e execer, c blogbus.Category) error {
+	_, err := e.ExecContext(ctx, `
+		INSERT INTO categories (slug, name, parent, created_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT (slug) DO UPDATE
+		SET name   = excluded.name,
+		    parent = excluded.parent`,
+		c.Slug, c.Name, nullString(c.Parent), formatTime(c.CreatedAt),
+	)
+
+	return mapErr(err)
+}
+
+// restoreTaxonomy replaces every tag and category with those of taxonomy within tx, the transaction of
+// a restore.
+func restoreTaxonomy(ctx context.Context, tx *sql.Tx, taxonomy blogbus.Taxonomy) error {
+	for _, table := range []string{"tags", "categories"} {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM `+table); err != nil {
+			return mapErr(err)
+		}
+	}
+
+	for _, t := range taxonomy.Tags {
+		if err := putTag(ctx, tx, t); err != nil {
+			return err
+		}
+	}
+
+	for _, c := range taxonomy.Categories {
+		if err := putCategory(ctx, tx, c); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// scanRows calls scan with every row of rows and closes it.
+func scanRows(rows *sql.Rows, scan func(sc scanner) error) error {
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return mapErr(err)
+		}
+	}
+
+	return mapErr(rows.Err())
+}
@@ -0,0 +1,143 @@
+package sqlitestore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/anazcodes/blogapp/internal/business/authbus"
+)
+
+// Users returns every user ordered by username.
+func (s *Store) Users(ctx context.Context) ([]authbus.User, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT username, password_hash, role, created_at, password_changed_at, failed_logins, locked_until
+		FROM users
+		ORDER BY username`)
+	if err != nil {
+		return nil, mapErr(err)
+	}
+
+	var out []authbus.User
+
+	err = scanRows(rows, func(sc scanner) error {
+		var u authbus.User
+		var createdAt, passwordChangedAt string
+		var lockedUntil sql.NullString
+
+		if err := sc.Scan(&u.Username, &u.PasswordHash, &u.Role, &createdAt, &passwordChangedAt, &u.FailedLogins,
+			&lockedUntil); err != nil {
+			return err
+		}
+
+		if u.CreatedAt, err = time.Parse(time.RFC3339Nano, createdAt); err != nil {
+			return fmt.Errorf("created_at: %w", err)
+		}
+
+		if u.PasswordChangedAt, err = time.Parse(time.RFC3339Nano, passwordChangedAt); err != nil {
+			return fmt.Errorf("password_changed_at: %w", err)
+		}
+
+		if lockedUntil.Valid {
+			if u.LockedUntil, err = time.Parse(time.RFC3339Nano, lockedUntil.String); err != nil {
+				return fmt.Errorf("locked_until: %w", err)
+			}
+		}
+
+		out = append(out, u)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// PutUser inserts u or replaces the user stored with its username.
+func (s *Store) PutUser(ctx context.Context, u authbus.User) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO users (username, password_hash, role, created_at, password_changed_at, failed_logins, locked_until)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (username) DO UPDATE
+		SET password_hash       = excluded.password_hash,
+		    role                = excluded.role,
+		    password_changed_at = excluded.password_changed_at,
+		    failed_logins       = excluded.failed_logins,
+		    locked_until        = excluded.locked_until`,
+		u.Username, u.PasswordHash, u.Role, formatTime(u.CreatedAt), formatTime(u.PasswordChangedAt), u.FailedLogins,
+		nullTime(u.LockedUntil),
+	)
+
+	return mapErr(err)
+}
+
+// Sessions returns every session ordered by ID.
+func (s *Store) Sessions(ctx context.Context) ([]authbus.Session, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, username, created_at, expires_at
+		FROM sessions
+		ORDER BY id`)
+	if err != nil {
+		return nil, mapErr(err)
+	}
+
+	var out []authbus.Session
+
+	err = scanRows(rows, func(sc scanner) error {
+		var ss authbus.Session
+		var createdAt, expiresAt string
+
+		if err := sc.Scan(&ss.ID, &ss.Username, &createdAt, &expiresAt); err != nil {
+			return err
+		}
+
+		if ss.CreatedAt, err = time.Parse(time.RFC3339Nano, createdAt); err != nil {
+			return fmt.Errorf("created_at: %w", err)
+		}
+
+		if ss.ExpiresAt, err = time.Parse(time.RFC3339Nano, expiresAt); err != nil {
+			return fmt.Errorf("expires_at: %w", err)
+		}
+
+		out = append(out, ss)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// PutSession inserts ss.
+func (s *Store) PutSession(ctx context.Context, ss authbus.Session) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO sessions (id, username, created_at, expires_at)
+		VALUES (?, ?, ?, ?)`,
+		ss.ID, ss.Username, formatTime(ss.CreatedAt), formatTime(ss.ExpiresAt),
+	)
+
+	return mapErr(err)
+}
+
+// DeleteSessions deletes the sessions with ids at once, deleting a session that is not stored is not an
+// error.
+func (s *Store) DeleteSessions(ctx context.Context, ids []string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return mapErr(err)
+	}
+	defer tx.Rollback()
+
+	for _, id := range ids {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM sessions WHERE id = ?`, id); err != nil {
+			return mapErr(err)
+		}
+	}
+
+	return mapErr(tx.Commit())
+}
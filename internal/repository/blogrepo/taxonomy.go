@@ -0,0 +1,223 @@
+package blogrepo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/anazcodes/blogapp/internal/business/blogbus"
+	"github.com/anazcodes/blogapp/internal/repository/blogrepo/wal"
+)
+
+// TermStore persists the tags and categories, which terms is loaded from on startup. A memory
+// repository has none and forgets its taxonomy when the process exits.
+type TermStore interface {
+	Taxonomy(ctx context.Context) (blogbus.Taxonomy, error)
+	PutTag(ctx context.Context, t blogbus.Tag) error
+	DeleteTag(ctx context.Context, slug string) error
+	PutCategory(ctx context.Context, c blogbus.Category) error
+	DeleteCategory(ctx context.Context, slug string) error
+}
+
+// logTerms stores the tags and categories of a WAL repository in its log, next to its posts.
+type logTerms struct {
+	log *wal.Log
+}
+
+func (lt logTerms) Taxonomy(ctx context.Context) (blogbus.Taxonomy, error) {
+	return lt.log.Taxonomy()
+}
+
+func (lt logTerms) PutTag(ctx context.Context, t blogbus.Tag) error {
+	return lt.log.PutTag(t)
+}
+
+func (lt logTerms) DeleteTag(ctx context.Context, slug string) error {
+	return lt.log.DeleteTag(slug)
+}
+
+func (lt logTerms) PutCategory(ctx context.Context, c blogbus.Category) error {
+	return lt.log.PutCategory(c)
+}
+
+func (lt logTerms) DeleteCategory(ctx context.Context, slug string) error {
+	return lt.log.DeleteCategory(slug)
+}
+
+// persist applies write to the term store, when the repository has one.
+func (r *repo) persist(write func(s TermStore) error) error {
+	if r.termStore == nil {
+		return nil
+	}
+
+	return write(r.termStore)
+}
+
+func (r *repo) AddTag(ctx context.Context, t blogbus.Tag) error {
+	r.termWrites.Lock()
+	defer r.termWrites.Unlock()
+
+	if _, ok := r.terms.tag(t.Slug); ok {
+		return fmt.Errorf("query: %w", blogbus.ErrTagExists)
+	}
+
+	if err := r.persist(func(s TermStore) error { return s.PutTag(ctx, t) }); err != nil {
+		return fmt.Errorf("query: %w", err)
+	}
+
+	r.terms.putTag(t)
+
+	return nil
+}
+
+func (r *repo) Tag(ctx context.Context, slug string) (blogbus.Tag, error) {
+	t, ok := r.terms.tag(slug)
+	if !ok {
+		return blogbus.Tag{}, fmt.Errorf("query: %w", blogbus.ErrTagNotFound)
+	}
+
+	return t, nil
+}
+
+func (r *repo) Tags(ctx context.Context) ([]blogbus.Tag, error) {
+	return r.terms.taxonomy().Tags, nil
+}
+
+func (r *repo) TagCloud(ctx context.Context) ([]blogbus.TagCount, error) {
+	return r.terms.cloud(), nil
+}
+
+func (r *repo) UpdateTag(ctx context.Context, slug string, ut blogbus.UpdateTag) (blogbus.Tag, error) {
+	r.termWrites.Lock()
+	defer r.termWrites.Unlock()
+
+	t, ok := r.terms.tag(slug)
+	if !ok {
+		return blogbus.Tag{}, fmt.Errorf("query: %w", blogbus.ErrTagNotFound)
+	}
+
+	t, err := ut.Apply(t)
+	if err != nil {
+		return blogbus.Tag{}, err
+	}
+
+	if err := r.persist(func(s TermStore) error { return s.PutTag(ctx, t) }); err != nil {
+		return blogbus.Tag{}, fmt.Errorf("query: %w", err)
+	}
+
+	r.terms.putTag(t)
+
+	return t, nil
+}
+
+func (r *repo) DeleteTag(ctx context.Context, slug string) error {
+	r.termWrites.Lock()
+	defer r.termWrites.Unlock()
+
+	if _, ok := r.terms.tag(slug); !ok {
+		return fmt.Errorf("query: %w", blogbus.ErrTagNotFound)
+	}
+
+	if r.terms.tagUsed(slug) {
+		return fmt.Errorf("query: %w", blogbus.ErrTermInUse)
+	}
+
+	if err := r.persist(func(s TermStore) error { return s.DeleteTag(ctx, slug) }); err != nil {
+		return fmt.Errorf("query: %w", err)
+	}
+
+	r.terms.deleteTag(slug)
+
+	return nil
+}
+
+func (r *repo) AddCategory(ctx context.Context, c blogbus.Category) error {
+	r.termWrites.Lock()
+	defer r.termWrites.Unlock()
+
+	if _, ok := r.terms.category(c.Slug); ok {
+		return fmt.Errorf("query: %w", blogbus.ErrCategoryExists)
+	}
+
+	if err := r.terms.known(nil, parents(c)); err != nil {
+		return fmt.Errorf("query: %w", err)
+	}
+
+	if err := r.persist(func(s TermStore) error { return s.PutCategory(ctx, c) }); err != nil {
+		return fmt.Errorf("query: %w", err)
+	}
+
+	r.terms.putCategory(c)
+
+	return nil
+}
+
+func (r *repo) Category(ctx context.Context, slug string) (blogbus.Category, error) {
+	c, ok := r.terms.category(slug)
+	if !ok {
+		return blogbus.Category{}, fmt.Errorf("query: %w", blogbus.ErrCategoryNotFound)
+	}
+
+	return c, nil
+}
+
+func (r *repo) Categories(ctx context.Context) ([]blogbus.Category, error) {
+	return r.terms.taxonomy().Categories, nil
+}
+
+func (r *repo) UpdateCategory(ctx context.Context, slug string, uc blogbus.UpdateCategory) (blogbus.Category, error) {
+	r.termWrites.Lock()
+	defer r.termWrites.Unlock()
+
+	c, ok := r.terms.category(slug)
+	if !ok {
+		return blogbus.Category{}, fmt.Errorf("query: %w", blogbus.ErrCategoryNotFound)
+	}
+
+	c = uc.Apply(c)
+
+	if err := r.terms.known(nil, parents(c)); err != nil {
+		return blogbus.Category{}, fmt.Errorf("query: %w", err)
+	}
+
+	if c.Parent != "" && r.terms.descends(c.Parent, slug) {
+		return blogbus.Category{}, fmt.Errorf("query: %w", blogbus.ErrCategoryCycle)
+	}
+
+	if err := r.persist(func(s TermStore) error { return s.PutCategory(ctx, c) }); err != nil {
+		return blogbus.Category{}, fmt.Errorf("query: %w", err)
+	}
+
+	r.terms.putCategory(c)
+
+	return c, nil
+}
+
+func (r *repo) DeleteCategory(ctx context.Context, slug string) error {
+	r.termWrites.Lock()
+	defer r.termWrites.Unlock()
+
+	if _, ok := r.terms.category(slug); !ok {
+		return fmt.Errorf("query: %w", blogbus.ErrCategoryNotFound)
+	}
+
+	if r.terms.categoryUsed(slug) {
+		return fmt.Errorf("query: %w", blogbus.ErrTermInUse)
+	}
+
+	if err := r.persist(func(s TermStore) error { return s.DeleteCategory(ctx, slug) }); err != nil {
+		return fmt.Errorf("query: %w", err)
+	}
+
+	r.terms.deleteCategory(slug)
+
+	return nil
+}
+
+// parents returns the parent of c as the categories it refers to, none for a top-level category.
+func parents(c blogbus.Category) []string {
+	if c.Parent == "" {
+		return nil
+	}
+
+	return []string{c.Parent}
+}
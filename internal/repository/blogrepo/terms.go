@@ -0,0 +1,387 @@
+package blogrepo
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/anazcodes/blogapp/internal/business/blogbus"
+)
+
+// terms holds the tags and categories, and indexes the posts of every tag and category so a listing
+// filtered by them does not scan every post. Like slugs it indexes the posts of the cache and the spill.
+type terms struct {
+	mu         sync.RWMutex
+	tags       map[string]blogbus.Tag
+	categories map[string]blogbus.Category
+	posts      map[uint64]termed              // Terms of every post.
+	tagged     map[string]map[uint64]struct{} // Posts with every tag.
+	filed      map[string]map[uint64]struct{} // Posts filed directly under every category.
+}
+
+// termed is what the index keeps of a post.
+type termed struct {
+	tags       []string
+	categories []string
+	public     bool // Published and not in the trash, the tag cloud only counts those.
+}
+
+func newTerms() *terms {
+	t := &terms{}
+	t.reset(blogbus.Taxonomy{}, nil)
+
+	return t
+}
+
+// reset replaces every tag and category with those of tx, and the index with the terms of posts.
+func (t *terms) reset(tx blogbus.Taxonomy, posts []blogbus.BlogPost) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.tags = make(map[string]blogbus.Tag, len(tx.Tags))
+	for _, tag := range tx.Tags {
+		t.tags[tag.Slug] = tag
+	}
+
+	t.categories = make(map[string]blogbus.Category, len(tx.Categories))
+	for _, c := range tx.Categories {
+		t.categories[c.Slug] = c
+	}
+
+	t.posts = make(map[uint64]termed, len(posts))
+	t.tagged = make(map[string]map[uint64]struct{})
+	t.filed = make(map[string]map[uint64]struct{})
+
+	for _, bp := range posts {
+		t.add(bp.ID, termed{tags: bp.Tags, categories: bp.Categories, public: public(bp)})
+	}
+}
+
+// taxonomy returns every tag, ordered by slug, and every category, each after its parent.
+func (t *terms) taxonomy() blogbus.Taxonomy {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return blogbus.Taxonomy{Tags: t.tagList(), Categories: t.categoryList()}
+}
+
+// tag returns the tag with slug.
+func (t *terms) tag(slug string) (blogbus.Tag, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	tag, ok := t.tags[slug]
+
+	return tag, ok
+}
+
+// category returns the category with slug.
+func (t *terms) category(slug string) (blogbus.Category, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	c, ok := t.categories[slug]
+
+	return c, ok
+}
+
+// tagList returns every tag ordered by slug, the caller must hold mu.
+func (t *terms) tagList() []blogbus.Tag {
+	tags := slices.Collect(maps.Values(t.tags))
+	slices.SortFunc(tags, func(a, b blogbus.Tag) int { return cmp.Compare(a.Slug, b.Slug) })
+
+	return tags
+}
+
+// categoryList returns every category depth first, each after its parent and siblings ordered by
+// slug. The caller must hold mu.
+func (t *terms) categoryList() []blogbus.Category {
+	children := t.children()
+	out := make([]blogbus.Category, 0, len(t.categories))
+
+	var walk func(parent string)
+	walk = func(parent string) {
+		for _, c := range children[parent] {
+			out = append(out, c)
+			walk(c.Slug)
+		}
+	}
+
+	walk("")
+
+	return out
+}
+
+// children returns the subcategories of every category ordered by slug, under "" for the top level.
+// The caller must hold mu.
+func (t *terms) children() map[string][]blogbus.Category {
+	children := make(map[string][]blogbus.Category)
+	for _, c := range t.categories {
+		children[c.Parent] = append(children[c.Parent], c)
+	}
+
+	for _, cs := range children {
+		slices.SortFunc(cs, func(a, b blogbus.Category) int { return cmp.Compare(a.Slug, b.Slug) })
+	}
+
+	return children
+}
+
+func (t *terms) putTag(tag blogbus.Tag) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.tags[tag.Slug] = tag
+}
+
+func (t *terms) putCategory(c blogbus.Category) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.categories[c.Slug] = c
+}
+
+func (t *terms) deleteTag(slug string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.tags, slug)
+}
+
+func (t *terms) deleteCategory(slug string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.categories, slug)
+}
+
+// tagUsed reports whether a post has the tag with slug, even a post in the trash.
+func (t *terms) tagUsed(slug string) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return len(t.tagged[slug]) > 0
+}
+
+// categoryUsed reports whether a post, even one in the trash, or a subcategory is filed under the
+// category with slug.
+func (t *terms) categoryUsed(slug string) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if len(t.filed[slug]) > 0 {
+		return true
+	}
+
+	for _, c := range t.categories {
+		if c.Parent == slug {
+			return true
+		}
+	}
+
+	return false
+}
+
+// descends reports whether the category with slug is the category ancestor or one of its descendants.
+func (t *terms) descends(slug, ancestor string) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	// The depth is bounded by the number of categories, even were the tree to hold a cycle.
+	for range len(t.categories) + 1 {
+		if slug == ancestor {
+			return true
+		}
+
+		c, ok := t.categories[slug]
+		if !ok || c.Parent == "" {
+			return false
+		}
+
+		slug = c.Parent
+	}
+
+	return false
+}
+
+// known returns blogbus.ErrUnknownTerm unless every tag of tags and category of categories exists.
+func (t *terms) known(tags, categories []string) error {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	for _, slug := range tags {
+		if _, ok := t.tags[slug]; !ok {
+			return fmt.Errorf("%w: tag %q", blogbus.ErrUnknownTerm, slug)
+		}
+	}
+
+	for _, slug := range categories {
+		if _, ok := t.categories[slug]; !ok {
+			return fmt.Errorf("%w: category %q", blogbus.ErrUnknownTerm, slug)
+		}
+	}
+
+	return nil
+}
+
+// index indexes the terms of bp, replacing those indexed for it before.
+func (t *terms) index(bp blogbus.BlogPost) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.remove(bp.ID)
+	t.add(bp.ID, termed{tags: bp.Tags, categories: bp.Categories, public: public(bp)})
+}
+
+// retag replaces the indexed tags and categories of the post with id, a nil slice keeps them.
+func (t *terms) retag(id uint64, tags, categories []string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	tp := t.posts[id]
+	if tags != nil {
+		tp.tags = tags
+	}
+	if categories != nil {
+		tp.categories = categories
+	}
+
+	t.remove(id)
+	t.add(id, tp)
+}
+
+// unindex removes the post with id from the index.
+func (t *terms) unindex(id uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.remove(id)
+}
+
+// add indexes tp as the terms of the post with id, the caller must hold mu.
+func (t *terms) add(id uint64, tp termed) {
+	t.posts[id] = tp
+
+	for _, slug := range tp.tags {
+		link(t.tagged, slug, id)
+	}
+
+	for _, slug := range tp.categories {
+		link(t.filed, slug, id)
+	}
+}
+
+// remove drops the post with id from the index, the caller must hold mu.
+func (t *terms) remove(id uint64) {
+	tp, ok := t.posts[id]
+	if !ok {
+		return
+	}
+
+	delete(t.posts, id)
+
+	for _, slug := range tp.tags {
+		unlink(t.tagged, slug, id)
+	}
+
+	for _, slug := range tp.categories {
+		unlink(t.filed, slug, id)
+	}
+}
+
+func link(index map[string]map[uint64]struct{}, slug string, id uint64) {
+	if index[slug] == nil {
+		index[slug] = make(map[uint64]struct{})
+	}
+
+	index[slug][id] = struct{}{}
+}
+
+func unlink(index map[string]map[uint64]struct{}, slug string, id uint64) {
+	delete(index[slug], id)
+
+	if len(index[slug]) == 0 {
+		delete(index, slug)
+	}
+}
+
+// ids returns the IDs of the posts with the tag and filed under the category or its subcategories.
+// An empty tag or category does not filter, it returns nil when neither filters.
+func (t *terms) ids(tag, category string) map[uint64]struct{} {
+	if tag == "" && category == "" {
+		return nil
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	var ids map[uint64]struct{}
+
+	if category != "" {
+		ids = make(map[uint64]struct{})
+
+		children := t.children()
+		queue := []string{category}
+
+		for len(queue) > 0 {
+			slug := queue[0]
+			queue = queue[1:]
+
+			maps.Copy(ids, t.filed[slug])
+
+			for _, c := range children[slug] {
+				queue = append(queue, c.Slug)
+			}
+		}
+	}
+
+	if tag == "" {
+		return ids
+	}
+
+	if ids == nil {
+		// An unknown tag lists no post, rather than every post as a nil clone would.
+		ids = make(map[uint64]struct{}, len(t.tagged[tag]))
+		maps.Copy(ids, t.tagged[tag])
+
+		return ids
+	}
+
+	maps.DeleteFunc(ids, func(id uint64, _ struct{}) bool {
+		_, ok := t.tagged[tag][id]
+		return !ok
+	})
+
+	return ids
+}
+
+// cloud returns every tag with the number of public posts that have it, the most used first and
+// ties ordered by slug.
+func (t *terms) cloud() []blogbus.TagCount {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	tags := t.tagList()
+	out := make([]blogbus.TagCount, len(tags))
+
+	for i, tag := range tags {
+		out[i].Tag = tag
+
+		for id := range t.tagged[tag.Slug] {
+			if t.posts[id].public {
+				out[i].Posts++
+			}
+		}
+	}
+
+	slices.SortStableFunc(out, func(a, b blogbus.TagCount) int { return cmp.Compare(b.Posts, a.Posts) })
+
+	return out
+}
+
+// public reports whether bp is published and not in the trash.
+func public(bp blogbus.BlogPost) bool {
+	return bp.Status == blogbus.StatusPublished && !bp.Trashed()
+}
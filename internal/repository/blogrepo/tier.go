@@ -0,0 +1,158 @@
+package blogrepo
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/anazcodes/blogapp/internal/business/blogbus"
+	"github.com/anazcodes/blogapp/internal/repository/blogrepo/cache"
+)
+
+// tier is a cache.Cache that keeps the recently read posts of a slower backend in a bounded hot cache.
+//
+// The backend is authoritative. Reads are served from the hot cache and fall back to the backend on a
+// miss, populating the hot cache. Writes go to the backend first and then invalidate the hot copy, so
+// a failed write never leaves the hot cache ahead of the backend.
+type tier struct {
+	hot     cache.Cache
+	backend cache.Cache
+	// mu orders misses against writes, otherwise a miss could populate the hot cache with a post
+	// read from the backend just before a concurrent write invalidated it. Hits do not take it.
+	mu sync.Mutex
+}
+
+func newTier(backend cache.Cache, capacity int, policy cache.Policy) *tier {
+	return &tier{
+		hot:     cache.New(cache.Config{Capacity: capacity, Eviction: policy}),
+		backend: backend,
+	}
+}
+
+func (t *tier) AddBlogPost(ctx context.Context, abp blogbus.AddBlogPost) (uint64, error) {
+	// New posts are populated on their first read rather than evicting a hot post now.
+	return t.backend.AddBlogPost(ctx, abp)
+}
+
+func (t *tier) BlogPost(ctx context.Context, id uint64) (blogbus.BlogPost, error) {
+	if bp, err := t.hot.BlogPost(ctx, id); err == nil {
+		return bp, nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	bp, err := t.backend.BlogPost(ctx, id)
+	if err != nil {
+		return blogbus.BlogPost{}, err
+	}
+
+	// The post is served either way, a full hot cache that rejects it only costs a later miss.
+	_ = t.hot.PutBlogPost(ctx, bp)
+
+	return bp, nil
+}
+
+func (t *tier) BlogPosts(ctx context.Context) ([]blogbus.BlogPost, error) {
+	return t.backend.BlogPosts(ctx)
+}
+
+func (t *tier) ListBlogPosts(ctx context.Context, l cache.Listing) ([]blogbus.BlogPost, error) {
+	return t.backend.ListBlogPosts(ctx, l)
+}
+
+func (t *tier) DeleteBlogPost(ctx context.Context, id uint64) (uint64, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	id, err := t.backend.DeleteBlogPost(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+
+	t.invalidate(ctx, id)
+
+	return id, nil
+}
+
+func (t *tier) UpdateBlogPost(ctx context.Context, id uint64, ubp blogbus.UpdateBlogPost) (uint64, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	id, err := t.backend.UpdateBlogPost(ctx, id, ubp)
+	if err != nil {
+		return 0, err
+	}
+
+	t.invalidate(ctx, id)
+
+	return id, nil
+}
+
+func (t *tier) TransitionBlogPost(ctx context.Context, id uint64, tr blogbus.Transition) (blogbus.BlogPost, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	bp, err := t.backend.TransitionBlogPost(ctx, id, tr)
+	if err != nil {
+		return blogbus.BlogPost{}, err
+	}
+
+	t.invalidate(ctx, id)
+
+	return bp, nil
+}
+
+func (t *tier) TrashBlogPost(ctx context.Context, id uint64, at time.Time) (blogbus.BlogPost, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	bp, err := t.backend.TrashBlogPost(ctx, id, at)
+	if err != nil {
+		return blogbus.BlogPost{}, err
+	}
+
+	t.invalidate(ctx, id)
+
+	return bp, nil
+}
+
+func (t *tier) PutBlogPost(ctx context.Context, bp blogbus.BlogPost) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err := t.backend.PutBlogPost(ctx, bp); err != nil {
+		return err
+	}
+
+	t.invalidate(ctx, bp.ID)
+
+	return nil
+}
+
+func (t *tier) Snapshot(ctx context.Context) (blogbus.Snapshot, error) {
+	return t.backend.Snapshot(ctx)
+}
+
+func (t *tier) Restore(ctx context.Context, s blogbus.Snapshot) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err := t.backend.Restore(ctx, s); err != nil {
+		return err
+	}
+
+	// Every hot post may have changed, start over with an empty hot cache.
+	return t.hot.Restore(ctx, blogbus.Snapshot{})
+}
+
+// Stats returns the counters of the hot cache.
+func (t *tier) Stats() cache.Stats {
+	return t.hot.(cache.StatsReporter).Stats()
+}
+
+// invalidate drops the hot copy of a post, the caller must hold mu.
+func (t *tier) invalidate(ctx context.Context, id uint64) {
+	// A post that is not hot has nothing to invalidate.
+	_, _ = t.hot.DeleteBlogPost(ctx, id)
+}
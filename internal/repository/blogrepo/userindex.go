@@ -0,0 +1,191 @@
+package blogrepo
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anazcodes/blogapp/internal/business/authbus"
+)
+
+// accounts holds the users, their sessions and their API keys, and indexes the sessions of every user
+// so a password change ends them without a scan.
+type accounts struct {
+	mu       sync.RWMutex
+	users    map[string]authbus.User
+	sessions map[string]authbus.Session
+	byUser   map[string]map[string]struct{} // Sessions of every user.
+	keys     map[string]authbus.APIKey
+}
+
+func newAccounts() *accounts {
+	a := &accounts{}
+	a.reset(nil, nil, nil, time.Time{})
+
+	return a
+}
+
+// reset replaces every user with users, every session with sessions and every API key with keys, and
+// returns the IDs of the sessions left out as they expired by at or belong to no user.
+func (a *accounts) reset(users []authbus.User, sessions []authbus.Session, keys []authbus.APIKey, at time.Time) []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.users = make(map[string]authbus.User, len(users))
+	a.sessions = make(map[string]authbus.Session, len(sessions))
+	a.byUser = make(map[string]map[string]struct{})
+	a.keys = make(map[string]authbus.APIKey, len(keys))
+
+	for _, u := range users {
+		// Users stored before roles existed are readers, as the sqlite store migrates them.
+		if u.Role == "" {
+			u.Role = authbus.RoleReader
+		}
+
+		a.users[u.Username] = u
+	}
+
+	var dropped []string
+
+	for _, s := range sessions {
+		if _, ok := a.users[s.Username]; !ok || s.Expired(at) {
+			dropped = append(dropped, s.ID)
+			continue
+		}
+
+		a.add(s)
+	}
+
+	for _, k := range keys {
+		a.keys[k.ID] = k
+	}
+
+	return dropped
+}
+
+func (a *accounts) user(username string) (authbus.User, bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	u, ok := a.users[username]
+
+	return u, ok
+}
+
+// list returns every user, ordered by username.
+func (a *accounts) list() []authbus.User {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	return slices.SortedFunc(maps.Values(a.users), func(x, y authbus.User) int {
+		return strings.Compare(x.Username, y.Username)
+	})
+}
+
+func (a *accounts) putUser(u authbus.User) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.users[u.Username] = u
+}
+
+func (a *accounts) session(id string) (authbus.Session, bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	s, ok := a.sessions[id]
+
+	return s, ok
+}
+
+func (a *accounts) putSession(s authbus.Session) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.add(s)
+}
+
+// add adds s, the caller must hold mu.
+func (a *accounts) add(s authbus.Session) {
+	a.sessions[s.ID] = s
+
+	if a.byUser[s.Username] == nil {
+		a.byUser[s.Username] = make(map[string]struct{})
+	}
+
+	a.byUser[s.Username][s.ID] = struct{}{}
+}
+
+// sessionsOf returns the IDs of the sessions of username that keep selects, ordered by ID.
+func (a *accounts) sessionsOf(username string, keep func(s authbus.Session) bool) []string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	var ids []string
+
+	for _, id := range slices.Sorted(maps.Keys(a.byUser[username])) {
+		if keep(a.sessions[id]) {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
+
+// deleteSessions deletes the sessions with ids.
+func (a *accounts) deleteSessions(ids ...string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for _, id := range ids {
+		s, ok := a.sessions[id]
+		if !ok {
+			continue
+		}
+
+		delete(a.sessions, id)
+
+		if delete(a.byUser[s.Username], id); len(a.byUser[s.Username]) == 0 {
+			delete(a.byUser, s.Username)
+		}
+	}
+}
+
+func (a *accounts) key(id string) (authbus.APIKey, bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	k, ok := a.keys[id]
+
+	return k, ok
+}
+
+// keysOf returns the API keys of owner, or every key when owner is empty, ordered by creation then ID.
+func (a *accounts) keysOf(owner string) []authbus.APIKey {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	var keys []authbus.APIKey
+
+	for _, k := range a.keys {
+		if owner == "" || k.Owner == owner {
+			keys = append(keys, k)
+		}
+	}
+
+	slices.SortFunc(keys, func(x, y authbus.APIKey) int {
+		return cmp.Or(x.CreatedAt.Compare(y.CreatedAt), strings.Compare(x.ID, y.ID))
+	})
+
+	return keys
+}
+
+func (a *accounts) putKey(k authbus.APIKey) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.keys[k.ID] = k
+}
@@ -0,0 +1,191 @@
+package blogrepo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/anazcodes/blogapp/internal/business/authbus"
+	"github.com/anazcodes/blogapp/internal/repository/blogrepo/wal"
+)
+
+// UserStore persists the users, their sessions and their API keys. The sessions that expired while the
+// server was down are deleted from it on load.
+type UserStore interface {
+	Users(ctx context.Context) ([]authbus.User, error)
+	PutUser(ctx context.Context, u authbus.User) error
+	Sessions(ctx context.Context) ([]authbus.Session, error)
+	PutSession(ctx context.Context, s authbus.Session) error
+	DeleteSessions(ctx context.Context, ids []string) error
+	APIKeys(ctx context.Context) ([]authbus.APIKey, error)
+	PutAPIKey(ctx context.Context, k authbus.APIKey) error
+}
+
+// logUsers stores the users of a WAL repository in its log, next to its posts.
+type logUsers struct {
+	log *wal.Log
+}
+
+func (lu logUsers) Users(ctx context.Context) ([]authbus.User, error) {
+	return lu.log.Users()
+}
+
+func (lu logUsers) PutUser(ctx context.Context, u authbus.User) error {
+	return lu.log.PutUser(u)
+}
+
+func (lu logUsers) Sessions(ctx context.Context) ([]authbus.Session, error) {
+	return lu.log.Sessions()
+}
+
+func (lu logUsers) PutSession(ctx context.Context, s authbus.Session) error {
+	return lu.log.PutSession(s)
+}
+
+func (lu logUsers) DeleteSessions(ctx context.Context, ids []string) error {
+	return lu.log.DeleteSessions(ids)
+}
+
+func (lu logUsers) APIKeys(ctx context.Context) ([]authbus.APIKey, error) {
+	return lu.log.APIKeys()
+}
+
+func (lu logUsers) PutAPIKey(ctx context.Context, k authbus.APIKey) error {
+	return lu.log.PutAPIKey(k)
+}
+
+// persistUsers applies write to the user store, when the repository has one.
+func (r *repo) persistUsers(write func(s UserStore) error) error {
+	if r.userStore == nil {
+		return nil
+	}
+
+	return write(r.userStore)
+}
+
+func (r *repo) AddUser(ctx context.Context, u authbus.User) error {
+	r.userWrites.Lock()
+	defer r.userWrites.Unlock()
+
+	if _, ok := r.accounts.user(u.Username); ok {
+		return fmt.Errorf("query: %w", authbus.ErrUsernameTaken)
+	}
+
+	if err := r.persistUsers(func(s UserStore) error { return s.PutUser(ctx, u) }); err != nil {
+		return fmt.Errorf("query: %w", err)
+	}
+
+	r.accounts.putUser(u)
+
+	return nil
+}
+
+func (r *repo) User(ctx context.Context, username string) (authbus.User, error) {
+	u, ok := r.accounts.user(username)
+	if !ok {
+		return authbus.User{}, fmt.Errorf("query: %w", authbus.ErrUserNotFound)
+	}
+
+	return u, nil
+}
+
+func (r *repo) Users(ctx context.Context) ([]authbus.User, error) {
+	return r.accounts.list(), nil
+}
+
+func (r *repo) UpdateUser(ctx context.Context, username string, uu authbus.UserUpdate) (authbus.User, error) {
+	r.userWrites.Lock()
+	defer r.userWrites.Unlock()
+
+	u, ok := r.accounts.user(username)
+	if !ok {
+		return authbus.User{}, fmt.Errorf("query: %w", authbus.ErrUserNotFound)
+	}
+
+	u = uu.Apply(u)
+
+	if err := r.persistUsers(func(s UserStore) error { return s.PutUser(ctx, u) }); err != nil {
+		return authbus.User{}, fmt.Errorf("query: %w", err)
+	}
+
+	r.accounts.putUser(u)
+
+	return u, nil
+}
+
+// AddSession deletes the expired sessions of the user first, so the sessions of a user who never logs
+// out do not pile up.
+func (r *repo) AddSession(ctx context.Context, s authbus.Session) error {
+	r.userWrites.Lock()
+	defer r.userWrites.Unlock()
+
+	if _, ok := r.accounts.user(s.Username); !ok {
+		return fmt.Errorf("query: %w", authbus.ErrUserNotFound)
+	}
+
+	expired := r.accounts.sessionsOf(s.Username, func(old authbus.Session) bool { return old.Expired(s.CreatedAt) })
+	if len(expired) > 0 {
+		if err := r.deleteSessions(ctx, expired); err != nil {
+			return fmt.Errorf("query: %w", err)
+		}
+	}
+
+	if err := r.persistUsers(func(us UserStore) error { return us.PutSession(ctx, s) }); err != nil {
+		return fmt.Errorf("query: %w", err)
+	}
+
+	r.accounts.putSession(s)
+
+	return nil
+}
+
+func (r *repo) Session(ctx context.Context, id string) (authbus.Session, error) {
+	s, ok := r.accounts.session(id)
+	if !ok {
+		return authbus.Session{}, fmt.Errorf("query: %w", authbus.ErrSessionNotFound)
+	}
+
+	return s, nil
+}
+
+func (r *repo) DeleteSession(ctx context.Context, id string) error {
+	r.userWrites.Lock()
+	defer r.userWrites.Unlock()
+
+	if _, ok := r.accounts.session(id); !ok {
+		return nil
+	}
+
+	if err := r.deleteSessions(ctx, []string{id}); err != nil {
+		return fmt.Errorf("query: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repo) DeleteSessions(ctx context.Context, username, keep string) error {
+	r.userWrites.Lock()
+	defer r.userWrites.Unlock()
+
+	ids := r.accounts.sessionsOf(username, func(s authbus.Session) bool { return s.ID != keep })
+	if len(ids) == 0 {
+		return nil
+	}
+
+	if err := r.deleteSessions(ctx, ids); err != nil {
+		return fmt.Errorf("query: %w", err)
+	}
+
+	return nil
+}
+
+// deleteSessions deletes the sessions with ids from the store, then from memory. The caller must hold
+// userWrites.
+func (r *repo) deleteSessions(ctx context.Context, ids []string) error {
+	if err := r.persistUsers(func(s UserStore) error { return s.DeleteSessions(ctx, ids) }); err != nil {
+		return err
+	}
+
+	r.accounts.deleteSessions(ids...)
+
+	return nil
+}
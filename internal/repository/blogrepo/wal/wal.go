@@ -0,0 +1,823 @@
+// Package wal implements a write-ahead log of the mutations of blog posts, tags, categories, authors,
+// comments, reactions, users, sessions and API keys.
+//
+// The log is a file starting with a magic header followed by framed records. Each frame holds the
+// payload length, a CRC-32C checksum of the payload and the JSON encoded payload. A frame that is
+// short or fails its checksum marks a torn tail, it and everything after it is truncated on Open.
+package wal
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"log"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/anazcodes/blogapp/internal/business/authbus"
+	"github.com/anazcodes/blogapp/internal/business/blogbus"
+	"github.com/anazcodes/blogapp/internal/business/commentbus"
+)
+
+var (
+	ErrBadHeader = errors.New("wal: bad header")
+	ErrBroken    = errors.New("wal: broken")
+)
+
+// magic identifies a log file and its format version.
+var magic = []byte("BLOGWAL1")
+
+const (
+	frameSize  = 8       // Payload length and checksum.
+	maxPayload = 1 << 26 // Larger lengths can only come from a corrupt frame.
+)
+
+var table = crc32.MakeTable(crc32.Castagnoli)
+
+const (
+	opPut    = "put"
+	opDelete = "delete"
+	opSerial = "serial"
+
+	opPutTag         = "put_tag"
+	opDeleteTag      = "delete_tag"
+	opPutCategory    = "put_category"
+	opDeleteCategory = "delete_category"
+
+	opPutAuthor    = "put_author"
+	opDeleteAuthor = "delete_author"
+
+	opPutComment     = "put_comment"
+	opDeleteComments = "delete_comments"
+
+	opPutReaction         = "put_reaction"
+	opDeleteReaction      = "delete_reaction"
+	opDeletePostReactions = "delete_post_reactions"
+
+	opPutUser        = "put_user"
+	opPutSession     = "put_session"
+	opDeleteSessions = "delete_sessions"
+	opPutAPIKey      = "put_api_key"
+)
+
+// record is the payload of a single frame.
+type record struct {
+	Op     string            `json:"op"`
+	ID     uint64            `json:"id,omitempty"`
+	Serial uint64            `json:"serial,omitempty"`
+	Post   *blogbus.BlogPost `json:"post,omitempty"`
+	// Tag and Category are put, Slug names the tag or category deleted.
+	Tag      *blogbus.Tag      `json:"tag,omitempty"`
+	Category *blogbus.Category `json:"category,omitempty"`
+	Slug     string            `json:"slug,omitempty"`
+	// Author is put, ID names the author deleted.
+	Author *blogbus.Author `json:"author,omitempty"`
+	// Comment is put, IDs name the comments deleted.
+	Comment *commentbus.Comment `json:"comment,omitempty"`
+	IDs     []uint64            `json:"ids,omitempty"`
+	// Reaction is put, ID and Reader name the reaction deleted and IDs the posts whose reactions are
+	// deleted.
+	Reaction *blogbus.ReaderReaction `json:"reaction,omitempty"`
+	Reader   string                  `json:"reader,omitempty"`
+	// User and Session are put, Sessions name the sessions deleted.
+	User     *authbus.User    `json:"user,omitempty"`
+	Session  *authbus.Session `json:"session,omitempty"`
+	Sessions []string         `json:"sessions,omitempty"`
+	APIKey   *authbus.APIKey  `json:"api_key,omitempty"`
+}
+
+// SyncPolicy decides when appended records are flushed to stable storage.
+type SyncPolicy int
+
+const (
+	// SyncAlways fsyncs after every record, a mutation is durable once it returns.
+	SyncAlways SyncPolicy = iota
+	// SyncInterval fsyncs in the background, a crash loses at most one interval of mutations.
+	SyncInterval
+	// SyncNever leaves flushing to the operating system.
+	SyncNever
+)
+
+// ParseSyncPolicy parses always, interval or never into a SyncPolicy.
+func ParseSyncPolicy(s string) (SyncPolicy, error) {
+	switch s {
+	case "always":
+		return SyncAlways, nil
+	case "interval":
+		return SyncInterval, nil
+	case "never":
+		return SyncNever, nil
+	default:
+		return 0, fmt.Errorf("unknown sync policy %q", s)
+	}
+}
+
+// Options configures a Log.
+type Options struct {
+	Sync SyncPolicy
+	// SyncEvery is the fsync interval used by SyncInterval.
+	SyncEvery time.Duration
+}
+
+// Recovery is the state rebuilt by replaying a log.
+type Recovery struct {
+	Posts     []blogbus.BlogPost       // Live posts ordered by ID.
+	Serial    uint64                   // Highest ID ever issued.
+	Taxonomy  blogbus.Taxonomy         // Live tags and categories ordered by slug.
+	Authors   []blogbus.Author         // Live authors ordered by ID.
+	Comments  []commentbus.Comment     // Live comments ordered by ID.
+	Reactions []blogbus.ReaderReaction // Live reactions ordered by post ID and reader.
+	Users     []authbus.User           // Users ordered by username.
+	Sessions  []authbus.Session        // Live sessions ordered by ID.
+	APIKeys   []authbus.APIKey         // API keys, revoked ones too, ordered by ID.
+	Truncated int64                    // Bytes dropped from a torn tail.
+}
+
+// Log is an append-only cache.Journal safe for concurrent use.
+type Log struct {
+	mu    sync.Mutex
+	path  string
+	file  *os.File
+	opts  Options
+	size  int64 // Bytes of complete frames in file.
+	dirty int   // Records appended since the log was opened or compacted.
+	// broken is set when a compaction renamed its log into place but could not make the rename
+	// durable. A crash could bring the old log back without the records appended since, so every later
+	// write fails with it instead.
+	broken error
+	// recovered is what Open replayed, without the posts it returned, served to the storages loading
+	// their tags, authors, comments and users so the log is only read once.
+	recovered Recovery
+	done      chan struct{}
+	wg        sync.WaitGroup
+}
+
+// Open replays the log at path, creating it when it does not exist, and truncates any torn tail.
+func Open(path string, opts Options) (*Log, Recovery, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return nil, Recovery{}, fmt.Errorf("open: %w", err)
+	}
+
+	rec, size, err := replay(f)
+	if err != nil {
+		f.Close()
+		return nil, Recovery{}, fmt.Errorf("replay: %w", err)
+	}
+
+	if rec.Truncated > 0 {
+		log.Printf("wal: truncating %d bytes of torn tail from %s", rec.Truncated, path)
+	}
+
+	if size == 0 {
+		size = int64(len(magic))
+		if _, err := f.WriteAt(magic, 0); err != nil {
+			f.Close()
+			return nil, Recovery{}, fmt.Errorf("write header: %w", err)
+		}
+	}
+
+	if err := f.Truncate(size); err != nil {
+		f.Close()
+		return nil, Recovery{}, fmt.Errorf("truncate: %w", err)
+	}
+
+	if _, err := f.Seek(size, io.SeekStart); err != nil {
+		f.Close()
+		return nil, Recovery{}, fmt.Errorf("seek: %w", err)
+	}
+
+	recovered := rec
+	recovered.Posts = nil
+
+	l := &Log{
+		path:      path,
+		file:      f,
+		size:      size,
+		opts:      opts,
+		recovered: recovered,
+		done:      make(chan struct{}),
+	}
+
+	if opts.Sync == SyncInterval && opts.SyncEvery > 0 {
+		l.wg.Add(1)
+		go l.syncLoop()
+	}
+
+	return l, rec, nil
+}
+
+// Put records the full state of an added or updated post.
+func (l *Log) Put(bp blogbus.BlogPost) error {
+	return l.append(record{Op: opPut, Post: &bp})
+}
+
+// Delete records the removal of the post with the given ID.
+func (l *Log) Delete(id uint64) error {
+	return l.append(record{Op: opDelete, ID: id})
+}
+
+// PutTag records a tag that was added or renamed.
+func (l *Log) PutTag(t blogbus.Tag) error {
+	return l.append(record{Op: opPutTag, Tag: &t})
+}
+
+// DeleteTag records the removal of the tag with slug.
+func (l *Log) DeleteTag(slug string) error {
+	return l.append(record{Op: opDeleteTag, Slug: slug})
+}
+
+// PutCategory records a category that was added, renamed or moved.
+func (l *Log) PutCategory(c blogbus.Category) error {
+	return l.append(record{Op: opPutCategory, Category: &c})
+}
+
+// DeleteCategory records the removal of the category with slug.
+func (l *Log) DeleteCategory(slug string) error {
+	return l.append(record{Op: opDeleteCategory, Slug: slug})
+}
+
+// PutAuthor records an author that was added or edited.
+func (l *Log) PutAuthor(a blogbus.Author) error {
+	return l.append(record{Op: opPutAuthor, Author: &a})
+}
+
+// DeleteAuthor records the removal of the author with id.
+func (l *Log) DeleteAuthor(id uint64) error {
+	return l.append(record{Op: opDeleteAuthor, ID: id})
+}
+
+// Authors returns the live authors the log held when it was opened.
+func (l *Log) Authors() ([]blogbus.Author, error) {
+	return l.recovered.Authors, nil
+}
+
+// PutComment records a comment that was added or edited.
+func (l *Log) PutComment(c commentbus.Comment) error {
+	return l.append(record{Op: opPutComment, Comment: &c})
+}
+
+// DeleteComments records the removal of the comments with ids.
+func (l *Log) DeleteComments(ids []uint64) error {
+	return l.append(record{Op: opDeleteComments, IDs: ids})
+}
+
+// Comments returns the live comments the log held when it was opened.
+func (l *Log) Comments() ([]commentbus.Comment, error) {
+	return l.recovered.Comments, nil
+}
+
+// PutReaction records a reaction that was added or changed.
+func (l *Log) PutReaction(rr blogbus.ReaderReaction) error {
+	return l.append(record{Op: opPutReaction, Reaction: &rr})
+}
+
+// DeleteReaction records the removal of the reaction of reader to the post with postID.
+func (l *Log) DeleteReaction(postID uint64, reader string) error {
+	return l.append(record{Op: opDeleteReaction, ID: postID, Reader: reader})
+}
+
+// DeletePostReactions records the removal of every reaction to the posts with postIDs.
+func (l *Log) DeletePostReactions(postIDs []uint64) error {
+	return l.append(record{Op: opDeletePostReactions, IDs: postIDs})
+}
+
+// Reactions returns the live reactions the log held when it was opened.
+func (l *Log) Reactions() ([]blogbus.ReaderReaction, error) {
+	return l.recovered.Reactions, nil
+}
+
+// PutUser records a user that was added or changed.
+func (l *Log) PutUser(u authbus.User) error {
+	return l.append(record{Op: opPutUser, User: &u})
+}
+
+// Users returns the users the log held when it was opened.
+func (l *Log) Users() ([]authbus.User, error) {
+	return l.recovered.Users, nil
+}
+
+// PutSession records a session that was started.
+func (l *Log) PutSession(s authbus.Session) error {
+	return l.append(record{Op: opPutSession, Session: &s})
+}
+
+// DeleteSessions records the end of the sessions with ids.
+func (l *Log) DeleteSessions(ids []string) error {
+	return l.append(record{Op: opDeleteSessions, Sessions: ids})
+}
+
+// Sessions returns the live sessions the log held when it was opened.
+func (l *Log) Sessions() ([]authbus.Session, error) {
+	return l.recovered.Sessions, nil
+}
+
+// PutAPIKey records an API key that was created or changed.
+func (l *Log) PutAPIKey(k authbus.APIKey) error {
+	return l.append(record{Op: opPutAPIKey, APIKey: &k})
+}
+
+// APIKeys returns the API keys the log held when it was opened.
+func (l *Log) APIKeys() ([]authbus.APIKey, error) {
+	return l.recovered.APIKeys, nil
+}
+
+// Taxonomy returns the live tags and categories the log held when it was opened.
+func (l *Log) Taxonomy() (blogbus.Taxonomy, error) {
+	return l.recovered.Taxonomy, nil
+}
+
+// Dirty reports whether records were appended since the log was opened or last compacted.
+func (l *Log) Dirty() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.dirty > 0
+}
+
+// Sync flushes appended records to stable storage.
+func (l *Log) Sync() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.broken != nil {
+		return l.broken
+	}
+
+	return l.file.Sync()
+}
+
+// Restore atomically replaces the log with one holding only the snapshot. The users, sessions and API
+// keys are not part of a snapshot, they are kept as they are.
+func (l *Log) Restore(s blogbus.Snapshot) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.broken != nil {
+		return l.broken
+	}
+
+	rec, _, err := replay(io.NewSectionReader(l.file, 0, l.size))
+	if err != nil {
+		return fmt.Errorf("replay: %w", err)
+	}
+
+	return l.replace(Recovery{
+		Posts:     s.Posts,
+		Serial:    s.Serial,
+		Taxonomy:  s.Taxonomy,
+		Authors:   s.Authors,
+		Comments:  s.Comments,
+		Reactions: s.Reactions,
+		Users:     rec.Users,
+		Sessions:  rec.Sessions,
+		APIKeys:   rec.APIKeys,
+	})
+}
+
+// Compact atomically replaces the log with one holding only the live posts, tags, categories, authors,
+// comments, reactions, users, sessions and API keys and the ID serial.
+// Appends are blocked for the duration so no record can be lost.
+func (l *Log) Compact() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.broken != nil {
+		return l.broken
+	}
+
+	rec, _, err := replay(io.NewSectionReader(l.file, 0, l.size))
+	if err != nil {
+		return fmt.Errorf("replay: %w", err)
+	}
+
+	return l.replace(rec)
+}
+
+// replace swaps the log file for one holding rec, the caller must hold mu. The new log is opened
+// before it is renamed over the old one, so a failure up to the rename leaves the old log in use.
+func (l *Log) replace(rec Recovery) error {
+	tmp := l.path + ".tmp"
+	if err := write(tmp, rec); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("write: %w", err)
+	}
+
+	f, err := os.OpenFile(tmp, os.O_RDWR, 0o644)
+	if err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("open: %w", err)
+	}
+
+	size, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return fmt.Errorf("seek: %w", err)
+	}
+
+	if err := os.Rename(tmp, l.path); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return fmt.Errorf("rename: %w", err)
+	}
+
+	if err := l.file.Close(); err != nil {
+		log.Println("wal: closing compacted log: ", err)
+	}
+
+	l.file = f
+	l.size = size
+	l.dirty = 0
+
+	if err := syncDir(filepath.Dir(l.path)); err != nil {
+		l.broken = fmt.Errorf("%w: sync %s after compaction: %v", ErrBroken, filepath.Dir(l.path), err)
+		return l.broken
+	}
+
+	return nil
+}
+
+// syncDir flushes the entries of dir to stable storage, which makes a rename in it durable.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	return d.Sync()
+}
+
+// Close flushes and closes the log.
+func (l *Log) Close() error {
+	close(l.done)
+	l.wg.Wait()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := l.file.Sync(); err != nil {
+		return fmt.Errorf("sync: %w", err)
+	}
+
+	return l.file.Close()
+}
+
+func (l *Log) append(rec record) error {
+	frame, err := encode(rec)
+	if err != nil {
+		return fmt.Errorf("encode: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.broken != nil {
+		return l.broken
+	}
+
+	if _, err := l.file.Write(frame); err != nil {
+		// Cut the partial frame off so records appended later are not hidden behind it.
+		if terr := l.file.Truncate(l.size); terr == nil {
+			_, _ = l.file.Seek(l.size, io.SeekStart)
+		}
+		return fmt.Errorf("write: %w", err)
+	}
+
+	l.size += int64(len(frame))
+
+	if l.opts.Sync == SyncAlways {
+		if err := l.file.Sync(); err != nil {
+			return fmt.Errorf("sync: %w", err)
+		}
+	}
+
+	l.dirty++
+
+	return nil
+}
+
+func (l *Log) syncLoop() {
+	defer l.wg.Done()
+
+	ticker := time.NewTicker(l.opts.SyncEvery)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-l.done:
+			return
+		case <-ticker.C:
+			if err := l.Sync(); err != nil {
+				log.Println("wal: sync failed: ", err)
+			}
+		}
+	}
+}
+
+// encode frames rec as length, checksum and payload.
+func encode(rec record) ([]byte, error) {
+	payload, err := json.Marshal(rec)
+	if err != nil {
+		return nil, err
+	}
+
+	frame := make([]byte, frameSize, frameSize+len(payload))
+	binary.LittleEndian.PutUint32(frame[0:4], uint32(len(payload)))
+	binary.LittleEndian.PutUint32(frame[4:8], crc32.Checksum(payload, table))
+
+	return append(frame, payload...), nil
+}
+
+// replay folds the records in r into a Recovery.
+// It returns the size in bytes of the valid prefix of r, which is zero for an empty log.
+func replay(r io.Reader) (Recovery, int64, error) {
+	br := bufio.NewReader(r)
+
+	header := make([]byte, len(magic))
+	n, err := io.ReadFull(br, header)
+	if n == 0 && errors.Is(err, io.EOF) {
+		return Recovery{}, 0, nil
+	}
+	if err != nil || !bytes.Equal(header, magic) {
+		return Recovery{}, 0, ErrBadHeader
+	}
+
+	live := make(map[uint64]blogbus.BlogPost)
+	tags := make(map[string]blogbus.Tag)
+	categories := make(map[string]blogbus.Category)
+	authors := make(map[uint64]blogbus.Author)
+	comments := make(map[uint64]commentbus.Comment)
+	reactions := make(map[reactionKey]blogbus.ReaderReaction)
+	users := make(map[string]authbus.User)
+	sessions := make(map[string]authbus.Session)
+	apiKeys := make(map[string]authbus.APIKey)
+	var rec Recovery
+	size := int64(len(magic))
+
+	for {
+		payload, err := readFrame(br)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			// Everything from the first bad frame on is a torn tail.
+			rest, _ := io.Copy(io.Discard, br)
+			rec.Truncated = int64(len(payload)) + rest
+			break
+		}
+
+		var entry record
+		if err := json.Unmarshal(payload[frameSize:], &entry); err != nil {
+			return Recovery{}, 0, fmt.Errorf("offset %d: %w", size, err)
+		}
+
+		switch entry.Op {
+		case opPut:
+			if entry.Post == nil {
+				return Recovery{}, 0, fmt.Errorf("offset %d: put without post", size)
+			}
+			bp := *entry.Post
+			if bp.Status == "" {
+				// Written before posts had a lifecycle, when every post was public.
+				bp.Status = blogbus.StatusPublished
+				bp.PublishedAt = bp.CreatedAt
+			}
+			live[bp.ID] = bp
+			rec.Serial = max(rec.Serial, entry.Post.ID)
+		case opDelete:
+			delete(live, entry.ID)
+			rec.Serial = max(rec.Serial, entry.ID)
+		case opSerial:
+			rec.Serial = max(rec.Serial, entry.Serial)
+		case opPutTag:
+			if entry.Tag == nil {
+				return Recovery{}, 0, fmt.Errorf("offset %d: put_tag without tag", size)
+			}
+			tags[entry.Tag.Slug] = *entry.Tag
+		case opDeleteTag:
+			delete(tags, entry.Slug)
+		case opPutCategory:
+			if entry.Category == nil {
+				return Recovery{}, 0, fmt.Errorf("offset %d: put_category without category", size)
+			}
+			categories[entry.Category.Slug] = *entry.Category
+		case opDeleteCategory:
+			delete(categories, entry.Slug)
+		case opPutAuthor:
+			if entry.Author == nil {
+				return Recovery{}, 0, fmt.Errorf("offset %d: put_author without author", size)
+			}
+			authors[entry.Author.ID] = *entry.Author
+		case opDeleteAuthor:
+			delete(authors, entry.ID)
+		case opPutComment:
+			if entry.Comment == nil {
+				return Recovery{}, 0, fmt.Errorf("offset %d: put_comment without comment", size)
+			}
+			c := *entry.Comment
+			// A comment recorded before comments were moderated was public.
+			if c.Status == "" {
+				c.Status = commentbus.StatusApproved
+			}
+			comments[c.ID] = c
+		case opDeleteComments:
+			for _, id := range entry.IDs {
+				delete(comments, id)
+			}
+		case opPutReaction:
+			if entry.Reaction == nil {
+				return Recovery{}, 0, fmt.Errorf("offset %d: put_reaction without reaction", size)
+			}
+			reactions[reactionKey{entry.Reaction.PostID, entry.Reaction.Reader}] = *entry.Reaction
+		case opDeleteReaction:
+			delete(reactions, reactionKey{entry.ID, entry.Reader})
+		case opDeletePostReactions:
+			maps.DeleteFunc(reactions, func(k reactionKey, _ blogbus.ReaderReaction) bool {
+				return slices.Contains(entry.IDs, k.post)
+			})
+		case opPutUser:
+			if entry.User == nil {
+				return Recovery{}, 0, fmt.Errorf("offset %d: put_user without user", size)
+			}
+			users[entry.User.Username] = *entry.User
+		case opPutSession:
+			if entry.Session == nil {
+				return Recovery{}, 0, fmt.Errorf("offset %d: put_session without session", size)
+			}
+			sessions[entry.Session.ID] = *entry.Session
+		case opDeleteSessions:
+			for _, id := range entry.Sessions {
+				delete(sessions, id)
+			}
+		case opPutAPIKey:
+			if entry.APIKey == nil {
+				return Recovery{}, 0, fmt.Errorf("offset %d: put_api_key without api key", size)
+			}
+			apiKeys[entry.APIKey.ID] = *entry.APIKey
+		default:
+			return Recovery{}, 0, fmt.Errorf("offset %d: unknown op %q", size, entry.Op)
+		}
+
+		size += int64(len(payload))
+	}
+
+	rec.Posts = make([]blogbus.BlogPost, 0, len(live))
+	for _, bp := range live {
+		rec.Posts = append(rec.Posts, bp)
+	}
+
+	slices.SortFunc(rec.Posts, func(a, b blogbus.BlogPost) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	for _, slug := range slices.Sorted(maps.Keys(tags)) {
+		rec.Taxonomy.Tags = append(rec.Taxonomy.Tags, tags[slug])
+	}
+
+	for _, slug := range slices.Sorted(maps.Keys(categories)) {
+		rec.Taxonomy.Categories = append(rec.Taxonomy.Categories, categories[slug])
+	}
+
+	for _, id := range slices.Sorted(maps.Keys(authors)) {
+		rec.Authors = append(rec.Authors, authors[id])
+	}
+
+	for _, id := range slices.Sorted(maps.Keys(comments)) {
+		rec.Comments = append(rec.Comments, comments[id])
+	}
+
+	rec.Reactions = slices.SortedFunc(maps.Values(reactions), func(a, b blogbus.ReaderReaction) int {
+		return cmp.Or(cmp.Compare(a.PostID, b.PostID), cmp.Compare(a.Reader, b.Reader))
+	})
+
+	for _, username := range slices.Sorted(maps.Keys(users)) {
+		rec.Users = append(rec.Users, users[username])
+	}
+
+	for _, id := range slices.Sorted(maps.Keys(sessions)) {
+		rec.Sessions = append(rec.Sessions, sessions[id])
+	}
+
+	for _, id := range slices.Sorted(maps.Keys(apiKeys)) {
+		rec.APIKeys = append(rec.APIKeys, apiKeys[id])
+	}
+
+	return rec, size, nil
+}
+
+// reactionKey names the reaction of reader to post.
+type reactionKey struct {
+	post   uint64
+	reader string
+}
+
+var errTorn = errors.New("torn frame")
+
+// readFrame reads one frame and returns it whole, header included.
+// On a torn frame it returns the bytes read so far with errTorn.
+func readFrame(r io.Reader) ([]byte, error) {
+	frame := make([]byte, frameSize)
+
+	n, err := io.ReadFull(r, frame)
+	if n == 0 && errors.Is(err, io.EOF) {
+		return nil, io.EOF
+	}
+	if err != nil {
+		return frame[:n], errTorn
+	}
+
+	length := binary.LittleEndian.Uint32(frame[0:4])
+	sum := binary.LittleEndian.Uint32(frame[4:8])
+
+	if length > maxPayload {
+		return frame, errTorn
+	}
+
+	frame = append(frame, make([]byte, length)...)
+
+	n, err = io.ReadFull(r, frame[frameSize:])
+	if err != nil {
+		return frame[:frameSize+n], errTorn
+	}
+
+	if crc32.Checksum(frame[frameSize:], table) != sum {
+		return frame, errTorn
+	}
+
+	return frame, nil
+}
+
+// write creates a log at path holding the recovered serial followed by one put per post, tag,
+// category, author, comment, reaction, user, session and API key.
+func write(path string, rec Recovery) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+
+	if _, err := w.Write(magic); err != nil {
+		return err
+	}
+
+	records := make([]record, 0, len(rec.Posts)+len(rec.Taxonomy.Tags)+len(rec.Taxonomy.Categories)+len(rec.Authors)+len(rec.Comments)+len(rec.Reactions)+len(rec.Users)+len(rec.Sessions)+len(rec.APIKeys)+1)
+	records = append(records, record{Op: opSerial, Serial: rec.Serial})
+	for _, bp := range rec.Posts {
+		records = append(records, record{Op: opPut, Post: &bp})
+	}
+	for _, t := range rec.Taxonomy.Tags {
+		records = append(records, record{Op: opPutTag, Tag: &t})
+	}
+	for _, c := range rec.Taxonomy.Categories {
+		records = append(records, record{Op: opPutCategory, Category: &c})
+	}
+	for _, a := range rec.Authors {
+		records = append(records, record{Op: opPutAuthor, Author: &a})
+	}
+	for _, c := range rec.Comments {
+		records = append(records, record{Op: opPutComment, Comment: &c})
+	}
+	for _, rr := range rec.Reactions {
+		records = append(records, record{Op: opPutReaction, Reaction: &rr})
+	}
+	for _, u := range rec.Users {
+		records = append(records, record{Op: opPutUser, User: &u})
+	}
+	for _, s := range rec.Sessions {
+		records = append(records, record{Op: opPutSession, Session: &s})
+	}
+	for _, k := range rec.APIKeys {
+		records = append(records, record{Op: opPutAPIKey, APIKey: &k})
+	}
+
+	for _, r := range records {
+		frame, err := encode(r)
+		if err != nil {
+			return err
+		}
+
+		if _, err := w.Write(frame); err != nil {
+			return err
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	return f.Sync()
+}
@@ -0,0 +1,308 @@
+package wal_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/anazcodes/blogapp/internal/business/authbus"
+	"github.com/anazcodes/blogapp/internal/business/blogbus"
+	"github.com/anazcodes/blogapp/internal/business/commentbus"
+	"github.com/anazcodes/blogapp/internal/repository/blogrepo/cache"
+	"github.com/anazcodes/blogapp/internal/repository/blogrepo/wal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecovery(t *testing.T) {
+	input := blogbus.AddBlogPost{
+		Title:       "Title",
+		Description: "Description",
+		Body:        "Body",
+	}
+
+	path := filepath.Join(t.TempDir(), "blogposts.wal")
+
+	l, rec, err := wal.Open(path, wal.Options{Sync: wal.SyncAlways})
+	assert.Nil(t, err)
+	assert.Empty(t, rec.Posts)
+
+	c := cache.NewJournaledCache(10, rec.Serial, rec.Posts, l)
+
+	for range 3 {
+		_, err := c.AddBlogPost(t.Context(), input)
+		assert.Nil(t, err)
+	}
+
+	_, err = c.UpdateBlogPost(t.Context(), 2, blogbus.UpdateBlogPost{Body: "Updated Body"})
+	assert.Nil(t, err)
+
+	_, err = c.DeleteBlogPost(t.Context(), 3)
+	assert.Nil(t, err)
+
+	assert.Nil(t, l.Close())
+
+	l, rec, err = wal.Open(path, wal.Options{Sync: wal.SyncAlways})
+	assert.Nil(t, err)
+	defer l.Close()
+
+	assert.Equal(t, uint64(3), rec.Serial)
+	assert.Len(t, rec.Posts, 2)
+	assert.Equal(t, uint64(1), rec.Posts[0].ID)
+	assert.Equal(t, "Updated Body", rec.Posts[1].Body)
+	assert.Zero(t, rec.Truncated)
+
+	c = cache.NewJournaledCache(10, rec.Serial, rec.Posts, l)
+
+	id, err := c.AddBlogPost(t.Context(), input)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(4), id)
+}
+
+func TestCommentRecovery(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "blogposts.wal")
+
+	l, _, err := wal.Open(path, wal.Options{})
+	assert.Nil(t, err)
+
+	// The first comment is recorded as before comments were moderated, without a status.
+	assert.Nil(t, l.PutComment(commentbus.Comment{ID: 1, PostID: 1, Author: "Ann", Body: "Body"}))
+	assert.Nil(t, l.PutComment(commentbus.Comment{ID: 2, PostID: 1, Author: "Bob", Body: "Body", Status: commentbus.StatusPending}))
+	assert.Nil(t, l.PutComment(commentbus.Comment{ID: 3, PostID: 1, Author: "Eve", Body: "Body", Status: commentbus.StatusPending}))
+	assert.Nil(t, l.DeleteComments([]uint64{3}))
+	assert.Nil(t, l.Close())
+
+	l, rec, err := wal.Open(path, wal.Options{})
+	assert.Nil(t, err)
+	defer l.Close()
+
+	assert.Len(t, rec.Comments, 2)
+	assert.Equal(t, commentbus.StatusApproved, rec.Comments[0].Status)
+	assert.Equal(t, commentbus.StatusPending, rec.Comments[1].Status)
+}
+
+func TestReactionRecovery(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "blogposts.wal")
+
+	l, _, err := wal.Open(path, wal.Options{})
+	assert.Nil(t, err)
+
+	assert.Nil(t, l.PutReaction(blogbus.ReaderReaction{PostID: 1, Reader: "bob", Reaction: blogbus.ReactionLike}))
+	assert.Nil(t, l.PutReaction(blogbus.ReaderReaction{PostID: 1, Reader: "ann", Reaction: blogbus.ReactionLike}))
+	assert.Nil(t, l.PutReaction(blogbus.ReaderReaction{PostID: 1, Reader: "bob", Reaction: blogbus.ReactionWow}))
+	assert.Nil(t, l.PutReaction(blogbus.ReaderReaction{PostID: 2, Reader: "ann", Reaction: blogbus.ReactionSad}))
+	assert.Nil(t, l.PutReaction(blogbus.ReaderReaction{PostID: 3, Reader: "ann", Reaction: blogbus.ReactionSad}))
+	assert.Nil(t, l.DeleteReaction(1, "ann"))
+	assert.Nil(t, l.DeletePostReactions([]uint64{3}))
+	assert.Nil(t, l.Compact())
+	assert.Nil(t, l.Close())
+
+	l, rec, err := wal.Open(path, wal.Options{})
+	assert.Nil(t, err)
+	defer l.Close()
+
+	assert.Equal(t, []blogbus.ReaderReaction{
+		{PostID: 1, Reader: "bob", Reaction: blogbus.ReactionWow},
+		{PostID: 2, Reader: "ann", Reaction: blogbus.ReactionSad},
+	}, rec.Reactions)
+}
+
+func TestUserRecovery(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "blogposts.wal")
+
+	l, _, err := wal.Open(path, wal.Options{})
+	assert.Nil(t, err)
+
+	assert.Nil(t, l.PutUser(authbus.User{Username: "bob", PasswordHash: "old"}))
+	assert.Nil(t, l.PutUser(authbus.User{Username: "ann", PasswordHash: "hash"}))
+	assert.Nil(t, l.PutUser(authbus.User{Username: "bob", PasswordHash: "new"}))
+	assert.Nil(t, l.PutSession(authbus.Session{ID: "s1", Username: "ann"}))
+	assert.Nil(t, l.PutSession(authbus.Session{ID: "s2", Username: "bob"}))
+	assert.Nil(t, l.DeleteSessions([]string{"s1"}))
+	assert.Nil(t, l.PutAPIKey(authbus.APIKey{ID: "k1", Owner: "ann", Hash: "old"}))
+	assert.Nil(t, l.PutAPIKey(authbus.APIKey{ID: "k1", Owner: "ann", Hash: "new"}))
+
+	// A restore replaces the posts, the users, their sessions and their keys are kept.
+	assert.Nil(t, l.Restore(blogbus.Snapshot{Posts: []blogbus.BlogPost{{ID: 1, Title: "Title"}}, Serial: 1}))
+	assert.Nil(t, l.Close())
+
+	l, rec, err := wal.Open(path, wal.Options{})
+	assert.Nil(t, err)
+	defer l.Close()
+
+	assert.Equal(t, []authbus.User{
+		{Username: "ann", PasswordHash: "hash"},
+		{Username: "bob", PasswordHash: "new"},
+	}, rec.Users)
+	assert.Equal(t, []authbus.Session{{ID: "s2", Username: "bob"}}, rec.Sessions)
+	assert.Equal(t, []authbus.APIKey{{ID: "k1", Owner: "ann", Hash: "new"}}, rec.APIKeys)
+	assert.Len(t, rec.Posts, 1)
+
+	// The storages loading the users are served what was replayed, the log is not read again.
+	users, err := l.Users()
+	assert.Nil(t, err)
+	assert.Equal(t, rec.Users, users)
+
+	keys, err := l.APIKeys()
+	assert.Nil(t, err)
+	assert.Equal(t, rec.APIKeys, keys)
+}
+
+func TestTornTail(t *testing.T) {
+	testCases := []struct {
+		name string
+		tail []byte
+	}{
+		{
+			name: "Short Frame",
+			tail: []byte{0x10, 0x00},
+		},
+		{
+			name: "Short Payload",
+			tail: []byte{0x10, 0x00, 0x00, 0x00, 0x01, 0x02, 0x03, 0x04, '{'},
+		},
+		{
+			name: "Bad Checksum",
+			tail: []byte{0x02, 0x00, 0x00, 0x00, 0x01, 0x02, 0x03, 0x04, '{', '}'},
+		},
+		{
+			name: "Oversized Length",
+			tail: []byte{0xff, 0xff, 0xff, 0xff, 0x01, 0x02, 0x03, 0x04},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "blogposts.wal")
+
+			l, _, err := wal.Open(path, wal.Options{})
+			assert.Nil(t, err)
+			assert.Nil(t, l.Put(blogbus.BlogPost{ID: 1, Title: "Title"}))
+			assert.Nil(t, l.Close())
+
+			info, err := os.Stat(path)
+			assert.Nil(t, err)
+
+			f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o644)
+			assert.Nil(t, err)
+			_, err = f.Write(tc.tail)
+			assert.Nil(t, err)
+			assert.Nil(t, f.Close())
+
+			l, rec, err := wal.Open(path, wal.Options{})
+			assert.Nil(t, err)
+
+			assert.Equal(t, int64(len(tc.tail)), rec.Truncated)
+			assert.Len(t, rec.Posts, 1)
+
+			// Records appended after recovery must survive the next replay.
+			assert.Nil(t, l.Put(blogbus.BlogPost{ID: 2, Title: "Title"}))
+			assert.Nil(t, l.Close())
+
+			truncated, err := os.Stat(path)
+			assert.Nil(t, err)
+			assert.Greater(t, truncated.Size(), info.Size())
+
+			l, rec, err = wal.Open(path, wal.Options{})
+			assert.Nil(t, err)
+			defer l.Close()
+
+			assert.Len(t, rec.Posts, 2)
+			assert.Zero(t, rec.Truncated)
+		})
+	}
+}
+
+func TestCompact(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "blogposts.wal")
+
+	l, _, err := wal.Open(path, wal.Options{Sync: wal.SyncNever})
+	assert.Nil(t, err)
+
+	for id := range uint64(5) {
+		assert.Nil(t, l.Put(blogbus.BlogPost{ID: id + 1, Title: "Title"}))
+	}
+	for id := range uint64(5) {
+		assert.Nil(t, l.Delete(id+1))
+	}
+
+	assert.True(t, l.Dirty())
+	assert.Nil(t, l.Compact())
+	assert.False(t, l.Dirty())
+
+	// Appends go to the compacted log.
+	assert.Nil(t, l.Put(blogbus.BlogPost{ID: 6, Title: "Title"}))
+	assert.Nil(t, l.Close())
+
+	l, rec, err := wal.Open(path, wal.Options{})
+	assert.Nil(t, err)
+	defer l.Close()
+
+	assert.Len(t, rec.Posts, 1)
+	assert.Equal(t, uint64(6), rec.Serial)
+}
+
+func TestCompactFailed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "blogposts.wal")
+
+	l, _, err := wal.Open(path, wal.Options{Sync: wal.SyncNever})
+	assert.Nil(t, err)
+
+	assert.Nil(t, l.Put(blogbus.BlogPost{ID: 1, Title: "Title"}))
+
+	// A directory in the way of the new log fails the compaction before the rename.
+	assert.Nil(t, os.MkdirAll(filepath.Join(path+".tmp", "in-the-way"), 0o755))
+	assert.NotNil(t, l.Compact())
+
+	// The old log stays in use.
+	assert.Nil(t, l.Put(blogbus.BlogPost{ID: 2, Title: "Title"}))
+	assert.Nil(t, l.Close())
+
+	l, rec, err := wal.Open(path, wal.Options{})
+	assert.Nil(t, err)
+	defer l.Close()
+
+	assert.Len(t, rec.Posts, 2)
+}
+
+func TestParseSyncPolicy(t *testing.T) {
+	testCases := []struct {
+		name    string
+		input   string
+		output  wal.SyncPolicy
+		wantErr bool
+	}{
+		{name: "Always", input: "always", output: wal.SyncAlways},
+		{name: "Interval", input: "interval", output: wal.SyncInterval},
+		{name: "Never", input: "never", output: wal.SyncNever},
+		{name: "Unknown", input: "sometimes", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			output, err := wal.ParseSyncPolicy(tc.input)
+
+			assert.Equal(t, tc.wantErr, err != nil)
+			assert.Equal(t, tc.output, output)
+		})
+	}
+}
+
+func TestRestore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "blogposts.wal")
+
+	l, _, err := wal.Open(path, wal.Options{})
+	assert.Nil(t, err)
+
+	assert.Nil(t, l.Put(blogbus.BlogPost{ID: 1, Title: "Title"}))
+	assert.Nil(t, l.Restore(blogbus.Snapshot{Serial: 7, Posts: []blogbus.BlogPost{{ID: 3, Title: "Restored"}}}))
+	assert.Nil(t, l.Put(blogbus.BlogPost{ID: 8, Title: "Title"}))
+	assert.Nil(t, l.Close())
+
+	l, rec, err := wal.Open(path, wal.Options{})
+	assert.Nil(t, err)
+	defer l.Close()
+
+	assert.Equal(t, uint64(8), rec.Serial)
+	assert.Len(t, rec.Posts, 2)
+	assert.Equal(t, "Restored", rec.Posts[0].Title)
+}
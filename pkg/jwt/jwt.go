@@ -0,0 +1,216 @@
+// Package jwt implements JSON Web Tokens signed with HMAC-SHA256 (HS256) or Ed25519 (EdDSA).
+//
+// Tokens are signed with the active key of a KeySet and verified with whichever key of the set their
+// header names, so a key is rotated by adding the new key as active and keeping the old one until the
+// tokens it signed expire.
+package jwt
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrMalformed  = errors.New("malformed token")
+	ErrUnknownKey = errors.New("token signed with an unknown key")
+	ErrSignature  = errors.New("invalid token signature")
+	ErrExpired    = errors.New("token expired")
+	ErrNotYet     = errors.New("token used before it was issued")
+)
+
+// The algorithms tokens are signed with.
+const (
+	HS256 = "HS256"
+	EdDSA = "EdDSA"
+)
+
+// Claims are the claims of a token. The times are in seconds since the Unix epoch, as JWT has them.
+type Claims struct {
+	Subject   string `json:"sub"`
+	ID        string `json:"jti,omitempty"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// NewClaims returns the claims of a token for subject issued at at and valid for ttl.
+func NewClaims(subject string, at time.Time, ttl time.Duration) Claims {
+	return Claims{
+		Subject:   subject,
+		IssuedAt:  at.Unix(),
+		ExpiresAt: at.Add(ttl).Unix(),
+	}
+}
+
+// Valid returns ErrExpired once the token has expired at now, and ErrNotYet before it was issued.
+func (c Claims) Valid(now time.Time) error {
+	switch {
+	case now.Unix() >= c.ExpiresAt:
+		return ErrExpired
+	case now.Unix() < c.IssuedAt:
+		return ErrNotYet
+	}
+
+	return nil
+}
+
+type header struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ"`
+	Kid string `json:"kid"`
+}
+
+// sign returns claims signed with key as a compact token.
+func sign(key Key, claims Claims) (string, error) {
+	h, err := json.Marshal(header{Alg: key.Alg, Typ: "JWT", Kid: key.ID})
+	if err != nil {
+		return "", fmt.Errorf("marshal header: %w", err)
+	}
+
+	c, err := json.Marshal(claims)
+	if err != nil {
+		return "", fmt.Errorf("marshal claims: %w", err)
+	}
+
+	input := encode(h) + "." + encode(c)
+
+	sig, err := key.sign([]byte(input))
+	if err != nil {
+		return "", err
+	}
+
+	return input + "." + encode(sig), nil
+}
+
+// Verify returns the claims of token once its signature is verified with the key of ks its header names,
+// and the claims are valid at now.
+func (ks *KeySet) Verify(token string, now time.Time) (Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return Claims{}, ErrMalformed
+	}
+
+	var h header
+	if err := decodeJSON(parts[0], &h); err != nil {
+		return Claims{}, err
+	}
+
+	key, ok := ks.Key(h.Kid)
+	if !ok {
+		return Claims{}, fmt.Errorf("%w: %q", ErrUnknownKey, h.Kid)
+	}
+
+	// The key decides the algorithm, a token cannot pick a weaker one than its key is used with.
+	if h.Alg != key.Alg {
+		return Claims{}, fmt.Errorf("%w: key %q is not used with %q", ErrSignature, key.ID, h.Alg)
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return Claims{}, ErrMalformed
+	}
+
+	if !key.verify([]byte(parts[0]+"."+parts[1]), sig) {
+		return Claims{}, ErrSignature
+	}
+
+	var c Claims
+	if err := decodeJSON(parts[1], &c); err != nil {
+		return Claims{}, err
+	}
+
+	if err := c.Valid(now); err != nil {
+		return Claims{}, err
+	}
+
+	return c, nil
+}
+
+func encode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodeJSON decodes the base64url encoded JSON part into v.
+func decodeJSON(part string, v any) error {
+	b, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return ErrMalformed
+	}
+
+	if err := json.NewDecoder(bytes.NewReader(b)).Decode(v); err != nil {
+		return ErrMalformed
+	}
+
+	return nil
+}
+
+// Key is a key tokens are signed or verified with. An Ed25519 key holding only its public half
+// verifies tokens but cannot sign them.
+type Key struct {
+	ID      string
+	Alg     string
+	secret  []byte
+	private ed25519.PrivateKey
+	public  ed25519.PublicKey
+}
+
+// MinSecretLength is the length of the shortest HMAC secret, in bytes, as HS256 needs a secret at
+// least as long as its hash.
+const MinSecretLength = sha256.Size
+
+// NewHMACKey returns the HS256 key id with secret.
+func NewHMACKey(id string, secret []byte) (Key, error) {
+	if len(secret) < MinSecretLength {
+		return Key{}, fmt.Errorf("key %q: secret is shorter than %d bytes", id, MinSecretLength)
+	}
+
+	return Key{ID: id, Alg: HS256, secret: secret}, nil
+}
+
+// NewEd25519Key returns the EdDSA key id with private.
+func NewEd25519Key(id string, private ed25519.PrivateKey) Key {
+	return Key{ID: id, Alg: EdDSA, private: private, public: private.Public().(ed25519.PublicKey)}
+}
+
+// NewEd25519PublicKey returns the EdDSA key id that only verifies tokens, with public.
+func NewEd25519PublicKey(id string, public ed25519.PublicKey) Key {
+	return Key{ID: id, Alg: EdDSA, public: public}
+}
+
+// CanSign reports whether k holds what signing takes.
+func (k Key) CanSign() bool {
+	return k.Alg == HS256 || k.private != nil
+}
+
+func (k Key) sign(input []byte) ([]byte, error) {
+	switch {
+	case k.Alg == HS256:
+		mac := hmac.New(sha256.New, k.secret)
+		mac.Write(input)
+		return mac.Sum(nil), nil
+	case k.private != nil:
+		return ed25519.Sign(k.private, input), nil
+	default:
+		return nil, fmt.Errorf("key %q only verifies tokens", k.ID)
+	}
+}
+
+func (k Key) verify(input, sig []byte) bool {
+	switch k.Alg {
+	case HS256:
+		mac := hmac.New(sha256.New, k.secret)
+		mac.Write(input)
+		return hmac.Equal(sig, mac.Sum(nil))
+	case EdDSA:
+		return len(k.public) == ed25519.PublicKeySize && ed25519.Verify(k.public, input, sig)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,192 @@
+package jwt_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anazcodes/blogapp/pkg/jwt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerify(t *testing.T) {
+	hmacKey, err := jwt.NewHMACKey("h1", bytes.Repeat([]byte("s"), jwt.MinSecretLength))
+	assert.Nil(t, err)
+
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	assert.Nil(t, err)
+	edKey := jwt.NewEd25519Key("e1", private)
+
+	ks, err := jwt.NewKeySet("e1", edKey, hmacKey)
+	assert.Nil(t, err)
+
+	now := time.Now()
+	claims := jwt.NewClaims("ann", now, time.Hour)
+
+	signed, err := ks.Sign(claims)
+	assert.Nil(t, err)
+
+	tamper := func(token string) string {
+		parts := strings.Split(token, ".")
+		parts[1] = base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"root","iat":0,"exp":9999999999}`))
+		return strings.Join(parts, ".")
+	}
+
+	// A token of an HS256 header signed with the public key of an EdDSA key, as if it were an HMAC secret.
+	confused := func() string {
+		forger, err := jwt.NewHMACKey("e1", append(ed25519.PublicKey(nil), private.Public().(ed25519.PublicKey)...))
+		assert.Nil(t, err)
+		fks, err := jwt.NewKeySet("e1", forger)
+		assert.Nil(t, err)
+		token, err := fks.Sign(claims)
+		assert.Nil(t, err)
+		return token
+	}
+
+	unknown := func() string {
+		other, err := jwt.NewHMACKey("h2", bytes.Repeat([]byte("o"), jwt.MinSecretLength))
+		assert.Nil(t, err)
+		oks, err := jwt.NewKeySet("h2", other)
+		assert.Nil(t, err)
+		token, err := oks.Sign(claims)
+		assert.Nil(t, err)
+		return token
+	}
+
+	testCases := []struct {
+		name        string
+		token       string
+		at          time.Time
+		expectedErr error
+	}{
+		{
+			name:  "Valid",
+			token: signed,
+			at:    now,
+		},
+		{
+			name:        "Expired",
+			token:       signed,
+			at:          now.Add(time.Hour),
+			expectedErr: jwt.ErrExpired,
+		},
+		{
+			name:        "Not Yet Issued",
+			token:       signed,
+			at:          now.Add(-time.Minute),
+			expectedErr: jwt.ErrNotYet,
+		},
+		{
+			name:        "Tampered Claims",
+			token:       tamper(signed),
+			at:          now,
+			expectedErr: jwt.ErrSignature,
+		},
+		{
+			name:        "Algorithm Confusion",
+			token:       confused(),
+			at:          now,
+			expectedErr: jwt.ErrSignature,
+		},
+		{
+			name:        "Unknown Key",
+			token:       unknown(),
+			at:          now,
+			expectedErr: jwt.ErrUnknownKey,
+		},
+		{
+			name:        "Malformed",
+			token:       "not.a-token",
+			at:          now,
+			expectedErr: jwt.ErrMalformed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ks.Verify(tc.token, tc.at)
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+			if tc.expectedErr == nil {
+				assert.Equal(t, claims, got)
+			}
+		})
+	}
+}
+
+func TestRotation(t *testing.T) {
+	now := time.Now()
+
+	oldKey, err := jwt.GenerateKey("old", jwt.EdDSA)
+	assert.Nil(t, err)
+	newKey, err := jwt.GenerateKey("new", jwt.HS256)
+	assert.Nil(t, err)
+
+	active, keys, err := jwt.ParseKeySet([]byte(`{"active":"old","keys":[` + string(oldKey) + `]}`))
+	assert.Nil(t, err)
+	ks, err := jwt.NewKeySet(active, keys...)
+	assert.Nil(t, err)
+
+	before, err := ks.Sign(jwt.NewClaims("ann", now, time.Hour))
+	assert.Nil(t, err)
+
+	// The new key signs from now on, the old one still verifies the tokens it signed.
+	active, keys, err = jwt.ParseKeySet([]byte(`{"active":"new","keys":[` + string(newKey) + `,` + string(oldKey) + `]}`))
+	assert.Nil(t, err)
+	assert.Nil(t, ks.Replace(active, keys...))
+
+	after, err := ks.Sign(jwt.NewClaims("bob", now, time.Hour))
+	assert.Nil(t, err)
+
+	c, err := ks.Verify(before, now)
+	assert.Nil(t, err)
+	assert.Equal(t, "ann", c.Subject)
+
+	c, err = ks.Verify(after, now)
+	assert.Nil(t, err)
+	assert.Equal(t, "bob", c.Subject)
+
+	// Once the old key is retired its tokens no longer verify.
+	active, keys, err = jwt.ParseKeySet([]byte(`{"active":"new","keys":[` + string(newKey) + `]}`))
+	assert.Nil(t, err)
+	assert.Nil(t, ks.Replace(active, keys...))
+
+	_, err = ks.Verify(before, now)
+	assert.ErrorIs(t, err, jwt.ErrUnknownKey)
+}
+
+func TestReplace(t *testing.T) {
+	key, err := jwt.NewHMACKey("h1", bytes.Repeat([]byte("s"), jwt.MinSecretLength))
+	assert.Nil(t, err)
+
+	public, _, err := ed25519.GenerateKey(rand.Reader)
+	assert.Nil(t, err)
+	verifier := jwt.NewEd25519PublicKey("e1", public)
+
+	testCases := []struct {
+		name    string
+		active  string
+		keys    []jwt.Key
+		wantErr bool
+	}{
+		{name: "Valid", active: "h1", keys: []jwt.Key{key, verifier}},
+		{name: "Missing Active", active: "h2", keys: []jwt.Key{key}, wantErr: true},
+		{name: "Active Cannot Sign", active: "e1", keys: []jwt.Key{key, verifier}, wantErr: true},
+		{name: "Duplicate", active: "h1", keys: []jwt.Key{key, key}, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := jwt.NewKeySet(tc.active, tc.keys...)
+
+			assert.Equal(t, tc.wantErr, err != nil)
+		})
+	}
+
+	_, err = jwt.NewHMACKey("short", []byte("short"))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,181 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// KeySet is the keys tokens are verified with, one of which, the active key, signs them. It is safe for
+// concurrent use, and its keys are replaced at once when they are rotated.
+type KeySet struct {
+	mu     sync.RWMutex
+	keys   map[string]Key
+	active string
+}
+
+// NewKeySet returns the set of keys whose key with ID active signs tokens.
+func NewKeySet(active string, keys ...Key) (*KeySet, error) {
+	ks := &KeySet{}
+	if err := ks.Replace(active, keys...); err != nil {
+		return nil, err
+	}
+
+	return ks, nil
+}
+
+// Replace replaces the keys of ks with keys, whose key with ID active signs tokens from now on.
+func (ks *KeySet) Replace(active string, keys ...Key) error {
+	byID := make(map[string]Key, len(keys))
+
+	for _, k := range keys {
+		if k.ID == "" {
+			return fmt.Errorf("key without an ID")
+		}
+
+		if _, ok := byID[k.ID]; ok {
+			return fmt.Errorf("key %q is in the set twice", k.ID)
+		}
+
+		byID[k.ID] = k
+	}
+
+	if k, ok := byID[active]; !ok || !k.CanSign() {
+		return fmt.Errorf("active key %q is not a signing key of the set", active)
+	}
+
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	ks.keys = byID
+	ks.active = active
+
+	return nil
+}
+
+// Key returns the key with id.
+func (ks *KeySet) Key(id string) (Key, bool) {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	k, ok := ks.keys[id]
+
+	return k, ok
+}
+
+// Sign returns claims signed with the active key as a compact token.
+func (ks *KeySet) Sign(claims Claims) (string, error) {
+	ks.mu.RLock()
+	key := ks.keys[ks.active]
+	ks.mu.RUnlock()
+
+	return sign(key, claims)
+}
+
+// keySetFile is the JSON encoding of a key set. The key material is base64url encoded: the secret of
+// an HS256 key, and the seed of an EdDSA key that signs or the public key of one that only verifies.
+type keySetFile struct {
+	Active string    `json:"active"`
+	Keys   []keyFile `json:"keys"`
+}
+
+type keyFile struct {
+	ID     string `json:"kid"`
+	Alg    string `json:"alg"`
+	Secret string `json:"secret,omitempty"`
+	Seed   string `json:"seed,omitempty"`
+	Public string `json:"public,omitempty"`
+}
+
+// ParseKeySet decodes the keys of a key set and its active key from JSON such as
+//
+//	{"active": "2026-10", "keys": [
+//		{"kid": "2026-10", "alg": "EdDSA", "seed": "..."},
+//		{"kid": "2026-04", "alg": "EdDSA", "public": "..."}
+//	]}
+func ParseKeySet(data []byte) (active string, keys []Key, err error) {
+	var f keySetFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return "", nil, fmt.Errorf("unmarshal: %w", err)
+	}
+
+	for _, kf := range f.Keys {
+		k, err := kf.key()
+		if err != nil {
+			return "", nil, err
+		}
+
+		keys = append(keys, k)
+	}
+
+	return f.Active, keys, nil
+}
+
+func (kf keyFile) key() (Key, error) {
+	decode := func(field, s string) ([]byte, error) {
+		b, err := base64.RawURLEncoding.DecodeString(s)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %s: %w", kf.ID, field, err)
+		}
+
+		return b, nil
+	}
+
+	switch {
+	case kf.Alg == HS256:
+		secret, err := decode("secret", kf.Secret)
+		if err != nil {
+			return Key{}, err
+		}
+		return NewHMACKey(kf.ID, secret)
+	case kf.Alg == EdDSA && kf.Seed != "":
+		seed, err := decode("seed", kf.Seed)
+		if err != nil {
+			return Key{}, err
+		}
+		if len(seed) != ed25519.SeedSize {
+			return Key{}, fmt.Errorf("key %q: seed is not %d bytes", kf.ID, ed25519.SeedSize)
+		}
+		return NewEd25519Key(kf.ID, ed25519.NewKeyFromSeed(seed)), nil
+	case kf.Alg == EdDSA:
+		public, err := decode("public", kf.Public)
+		if err != nil {
+			return Key{}, err
+		}
+		if len(public) != ed25519.PublicKeySize {
+			return Key{}, fmt.Errorf("key %q: public key is not %d bytes", kf.ID, ed25519.PublicKeySize)
+		}
+		return NewEd25519PublicKey(kf.ID, public), nil
+	default:
+		return Key{}, fmt.Errorf("key %q: unknown algorithm %q", kf.ID, kf.Alg)
+	}
+}
+
+// GenerateKey returns a new random key id for alg, encoded as the JSON of a key of a key set.
+func GenerateKey(id, alg string) ([]byte, error) {
+	kf := keyFile{ID: id, Alg: alg}
+
+	switch alg {
+	case HS256:
+		secret := make([]byte, MinSecretLength)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, err
+		}
+		kf.Secret = encode(secret)
+	case EdDSA:
+		public, private, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		kf.Seed = encode(private.Seed())
+		// The public half is written too, for the servers that only verify the tokens.
+		kf.Public = encode(public)
+	default:
+		return nil, fmt.Errorf("unknown algorithm %q", alg)
+	}
+
+	return json.Marshal(kf)
+}
@@ -25,6 +25,12 @@ func Handle(c *fiber.Ctx, req interface{}, timeout time.Duration, respondWithErr
 
 	status := c.Response().StatusCode()
 
+	if page, ok := data.(Paginated); ok {
+		response := NewResponse(status, "Success", page.Items, nil)
+		response.Pagination = &Pagination{NextCursor: page.NextCursor, HasMore: page.HasMore}
+		return c.Status(status).JSON(response)
+	}
+
 	response := NewResponse(status, "Success", data, nil)
 	return c.Status(status).JSON(response)
 }
@@ -6,10 +6,25 @@ import (
 
 // Response represents a HTTP response.
 type Response struct {
-	Status  int    `json:"status"`
-	Message string `json:"message"`
-	Error   any    `json:"error,omitempty"`
-	Data    any    `json:"data,omitempty"`
+	Status     int         `json:"status"`
+	Message    string      `json:"message"`
+	Error      any         `json:"error,omitempty"`
+	Data       any         `json:"data,omitempty"`
+	Pagination *Pagination `json:"pagination,omitempty"`
+}
+
+// Pagination describes the position of a paginated response in its listing.
+type Pagination struct {
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+}
+
+// Paginated is returned by a Handle handler to respond with one page of a listing.
+// Items become the response data and the cursor fills the response pagination.
+type Paginated struct {
+	Items      any
+	NextCursor string
+	HasMore    bool
 }
 
 // NewResponse returns a new Response instance.